@@ -0,0 +1,326 @@
+// Package claim lets several agents take tasks from the same directory
+// without racing each other. A claim moves a pending, actionable task to
+// in-progress under an owner and records a lease in .taskmd/claims. Claims
+// are checked and written while holding a lock file, so of two concurrent
+// claims on the same task exactly one succeeds. A claim whose lease has
+// expired is stale and the task can be claimed again.
+package claim
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+	"github.com/driangle/taskmd/apps/cli/internal/next"
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
+)
+
+const (
+	claimsSubDir = ".taskmd/claims"
+	lockFileName = ".lock"
+
+	// DefaultLease is how long a claim lasts without being renewed.
+	DefaultLease = 30 * time.Minute
+)
+
+// Lock timing. A lock file older than lockStale was left by a crashed
+// process and is removed.
+var (
+	lockTimeout = 5 * time.Second
+	lockRetry   = 10 * time.Millisecond
+	lockStale   = 30 * time.Second
+)
+
+// ErrNotFound is returned when no task has the requested ID.
+var ErrNotFound = errors.New("task not found")
+
+// ErrClaimed is returned when another owner holds a live claim on the task.
+var ErrClaimed = errors.New("task already claimed")
+
+// ErrNotClaimable is returned when the task is not pending and actionable.
+var ErrNotClaimable = errors.New("task is not claimable")
+
+// Claim is the lease record for a claimed task.
+type Claim struct {
+	TaskID    string    `json:"task_id" yaml:"task_id"`
+	Owner     string    `json:"owner" yaml:"owner"`
+	FilePath  string    `json:"file_path" yaml:"file_path"`
+	ClaimedAt time.Time `json:"claimed_at" yaml:"claimed_at"`
+	ExpiresAt time.Time `json:"expires_at" yaml:"expires_at"`
+}
+
+// Expired reports whether the lease has run out at now.
+func (c *Claim) Expired(now time.Time) bool {
+	return !now.Before(c.ExpiresAt)
+}
+
+// Claimer claims tasks in one task directory.
+type Claimer struct {
+	// StateDir holds .taskmd/claims; see StateDir.
+	StateDir string
+	// Load scans the task directory and returns its tasks and the IDs of
+	// archived tasks, whose dependents count as met. It is called while the
+	// lock is held so the status check sees the latest files.
+	Load func() (tasks []*model.Task, archived []string, err error)
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+	// Timestamps selects the status timestamps a claim records. Nil records
+	// none.
+	Timestamps *taskfile.TimestampConfig
+}
+
+// StateDir returns the directory whose .taskmd/claims folder holds claims
+// for taskDir: its project directory (see taskfile.ProjectDir), or taskDir
+// when there is none. Every entry point resolves the same directory so CLI,
+// MCP and web claims see each other.
+func StateDir(taskDir string) string {
+	abs, err := filepath.Abs(taskDir)
+	if err != nil {
+		return taskDir
+	}
+	if root, ok := taskfile.ProjectDir(abs); ok {
+		return root
+	}
+	return abs
+}
+
+func (c *Claimer) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+// Claim sets the task to in-progress under owner and records a lease. It
+// fails with ErrClaimed if another owner holds a live claim, and with
+// ErrNotClaimable if the task is not pending and actionable. A task left
+// in-progress by an expired claim can be claimed again. Claiming a task the
+// owner already holds renews the lease.
+func (c *Claimer) Claim(taskID, owner string, lease time.Duration) (*Claim, error) {
+	if owner == "" {
+		return nil, fmt.Errorf("owner is required to claim a task")
+	}
+	if lease <= 0 {
+		lease = DefaultLease
+	}
+
+	var result *Claim
+	err := c.withLock(func() error {
+		tasks, archived, err := c.Load()
+		if err != nil {
+			return err
+		}
+		task := findTask(tasks, taskID)
+		if task == nil {
+			return fmt.Errorf("%w: %s", ErrNotFound, taskID)
+		}
+
+		existing, err := c.read(taskID)
+		if err != nil {
+			return err
+		}
+		now := c.now()
+		live := existing != nil && !existing.Expired(now)
+
+		if live && !strings.EqualFold(existing.Owner, owner) {
+			return fmt.Errorf("%w: %s is held by %s until %s", ErrClaimed, taskID, existing.Owner, existing.ExpiresAt.Format(time.RFC3339))
+		}
+		if live {
+			// Same owner: renew.
+			existing.ExpiresAt = now.Add(lease)
+			result = existing
+			return c.write(existing)
+		}
+		if err := checkClaimable(task, tasks, archived, existing); err != nil {
+			return err
+		}
+
+		status := string(model.StatusInProgress)
+		if err := taskfile.UpdateTaskFile(task.FilePath, taskfile.UpdateRequest{Status: &status, Owner: &owner, Timestamps: c.Timestamps}); err != nil {
+			return fmt.Errorf("failed to update task file: %w", err)
+		}
+
+		result = &Claim{TaskID: task.ID, Owner: owner, FilePath: task.FilePath, ClaimedAt: now, ExpiresAt: now.Add(lease)}
+		return c.write(result)
+	})
+	return result, err
+}
+
+// checkClaimable requires the task to be pending, or in-progress under an
+// expired claim, with all dependencies completed or archived.
+func checkClaimable(task *model.Task, tasks []*model.Task, archived []string, stale *Claim) error {
+	switch {
+	case task.Status == model.StatusPending:
+	case task.Status == model.StatusInProgress && stale != nil:
+	default:
+		return fmt.Errorf("%w: %s is %s", ErrNotClaimable, task.ID, task.Status)
+	}
+	taskMap := next.BuildTaskMap(tasks)
+	model.AddArchived(taskMap, archived)
+	if !next.IsActionable(task, taskMap) {
+		return fmt.Errorf("%w: %s has unmet dependencies or has not started yet", ErrNotClaimable, task.ID)
+	}
+	return nil
+}
+
+// Renew extends a live claim held by owner. This is the heartbeat that keeps
+// long-running work from expiring.
+func (c *Claimer) Renew(taskID, owner string, lease time.Duration) (*Claim, error) {
+	if lease <= 0 {
+		lease = DefaultLease
+	}
+	var result *Claim
+	err := c.withLock(func() error {
+		existing, err := c.held(taskID, owner)
+		if err != nil {
+			return err
+		}
+		existing.ExpiresAt = c.now().Add(lease)
+		result = existing
+		return c.write(existing)
+	})
+	return result, err
+}
+
+// Release removes owner's claim on the task. The task's status and owner
+// are left as they are.
+func (c *Claimer) Release(taskID, owner string) error {
+	return c.withLock(func() error {
+		if _, err := c.held(taskID, owner); err != nil {
+			return err
+		}
+		return os.Remove(c.claimPath(taskID))
+	})
+}
+
+// Get returns the claim record for a task, or nil if there is none.
+func (c *Claimer) Get(taskID string) (*Claim, error) {
+	return c.read(taskID)
+}
+
+// held returns the live claim on taskID if owner holds it.
+func (c *Claimer) held(taskID, owner string) (*Claim, error) {
+	existing, err := c.read(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil || existing.Expired(c.now()) {
+		return nil, fmt.Errorf("no active claim on %s", taskID)
+	}
+	if !strings.EqualFold(existing.Owner, owner) {
+		return nil, fmt.Errorf("%w: %s is held by %s", ErrClaimed, taskID, existing.Owner)
+	}
+	return existing, nil
+}
+
+func findTask(tasks []*model.Task, taskID string) *model.Task {
+	for _, t := range tasks {
+		if t.ID == taskID {
+			return t
+		}
+	}
+	return nil
+}
+
+func (c *Claimer) claimsDir() string {
+	return filepath.Join(c.StateDir, claimsSubDir)
+}
+
+func (c *Claimer) claimPath(taskID string) string {
+	return filepath.Join(c.claimsDir(), taskID+".yaml")
+}
+
+func (c *Claimer) read(taskID string) (*Claim, error) {
+	data, err := os.ReadFile(c.claimPath(taskID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read claim: %w", err)
+	}
+	var cl Claim
+	if err := yaml.Unmarshal(data, &cl); err != nil {
+		return nil, fmt.Errorf("failed to parse claim %s: %w", c.claimPath(taskID), err)
+	}
+	return &cl, nil
+}
+
+func (c *Claimer) write(cl *Claim) error {
+	data, err := yaml.Marshal(cl)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.claimPath(cl.TaskID), data, 0644); err != nil {
+		return fmt.Errorf("failed to write claim: %w", err)
+	}
+	return nil
+}
+
+// withLock runs fn while holding the claims lock file. The lock is created
+// with O_EXCL, which is atomic on every platform taskmd supports.
+func (c *Claimer) withLock(fn func() error) error {
+	if err := os.MkdirAll(c.claimsDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create claims directory: %w", err)
+	}
+	lockPath := filepath.Join(c.claimsDir(), lockFileName)
+
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			fmt.Fprintf(f, "%d\n", os.Getpid())
+			f.Close()
+			break
+		}
+		if !os.IsExist(err) {
+			return fmt.Errorf("failed to acquire claims lock: %w", err)
+		}
+		if isStale(lockPath) && breakStaleLock(lockPath) {
+			continue
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for claims lock %s", lockPath)
+		}
+		time.Sleep(lockRetry)
+	}
+	defer os.Remove(lockPath)
+
+	return fn()
+}
+
+// breakStaleLock removes the lock file at lockPath if it is still stale and
+// reports whether it did. Breakers take turns through a second lock file:
+// checking and removing is not atomic, so without it one process could
+// remove the fresh lock another has just taken after breaking the stale one.
+func breakStaleLock(lockPath string) bool {
+	breakPath := lockPath + ".break"
+	f, err := os.OpenFile(breakPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		// Another process is breaking the lock. Its break lock only outlives
+		// lockStale if that process crashed midway.
+		if isStale(breakPath) {
+			os.Remove(breakPath)
+		}
+		return false
+	}
+	f.Close()
+	defer os.Remove(breakPath)
+
+	if !isStale(lockPath) {
+		return false
+	}
+	return os.Remove(lockPath) == nil
+}
+
+// isStale reports whether the file at path was last written more than
+// lockStale ago.
+func isStale(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && time.Since(info.ModTime()) > lockStale
+}
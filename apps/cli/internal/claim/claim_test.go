@@ -0,0 +1,338 @@
+package claim
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+	"github.com/driangle/taskmd/apps/cli/internal/scanner"
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
+)
+
+func createClaimTestDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"001-setup.md": "---\nid: \"001\"\ntitle: \"Setup\"\nstatus: completed\n---\n",
+		"002-api.md":   "---\nid: \"002\"\ntitle: \"API\"\nstatus: pending\ndependencies: [\"001\"]\n---\n\n# API\n",
+		"003-ui.md":    "---\nid: \"003\"\ntitle: \"UI\"\nstatus: pending\ndependencies: [\"002\"]\n---\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func newTestClaimer(dir string, now *time.Time) *Claimer {
+	c := &Claimer{
+		StateDir: dir,
+		Load: func() ([]*model.Task, []string, error) {
+			result, err := scanner.NewScanner(dir, false, nil).Scan()
+			if err != nil {
+				return nil, nil, err
+			}
+			return result.Tasks, result.ArchivedIDs, nil
+		},
+	}
+	if now != nil {
+		c.Now = func() time.Time { return *now }
+	}
+	return c
+}
+
+func TestClaim(t *testing.T) {
+	dir := createClaimTestDir(t)
+	c := newTestClaimer(dir, nil)
+
+	cl, err := c.Claim("002", "agent-1", time.Hour)
+	if err != nil {
+		t.Fatalf("Claim failed: %v", err)
+	}
+	if cl.Owner != "agent-1" || cl.ExpiresAt.Sub(cl.ClaimedAt) != time.Hour {
+		t.Errorf("unexpected claim: %+v", cl)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(dir, "002-api.md"))
+	if !strings.Contains(string(content), "status: in-progress") || !strings.Contains(string(content), "owner: agent-1") {
+		t.Errorf("expected task file to be updated, got:\n%s", content)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".taskmd", "claims", "002.yaml")); err != nil {
+		t.Errorf("expected claim record: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".taskmd", "claims", lockFileName)); !os.IsNotExist(err) {
+		t.Errorf("expected lock file to be removed, got %v", err)
+	}
+}
+
+func TestClaim_SomeoneElseWon(t *testing.T) {
+	dir := createClaimTestDir(t)
+	c := newTestClaimer(dir, nil)
+
+	if _, err := c.Claim("002", "agent-1", time.Hour); err != nil {
+		t.Fatalf("first claim failed: %v", err)
+	}
+	_, err := c.Claim("002", "agent-2", time.Hour)
+	if !errors.Is(err, ErrClaimed) || !strings.Contains(err.Error(), "agent-1") {
+		t.Fatalf("expected ErrClaimed naming agent-1, got %v", err)
+	}
+}
+
+func TestClaim_NotClaimable(t *testing.T) {
+	dir := createClaimTestDir(t)
+	c := newTestClaimer(dir, nil)
+
+	for id, reason := range map[string]string{"001": "completed", "003": "unmet dependencies"} {
+		_, err := c.Claim(id, "agent-1", time.Hour)
+		if !errors.Is(err, ErrNotClaimable) || !strings.Contains(err.Error(), reason) {
+			t.Errorf("claim %s: expected ErrNotClaimable (%s), got %v", id, reason, err)
+		}
+	}
+
+	if _, err := c.Claim("999", "agent-1", time.Hour); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestClaim_Concurrent(t *testing.T) {
+	dir := createClaimTestDir(t)
+
+	const agents = 8
+	var wg sync.WaitGroup
+	errs := make([]error, agents)
+	for i := range agents {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = newTestClaimer(dir, nil).Claim("002", "agent-"+string(rune('a'+i)), time.Hour)
+		}(i)
+	}
+	wg.Wait()
+
+	won := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			won++
+		case !errors.Is(err, ErrClaimed):
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if won != 1 {
+		t.Errorf("expected exactly one winner, got %d", won)
+	}
+}
+
+func TestClaim_ConcurrentWithStaleLock(t *testing.T) {
+	dir := createClaimTestDir(t)
+	c := newTestClaimer(dir, nil)
+
+	// A lock left behind by a crashed process, which both claims find stale.
+	lockPath := filepath.Join(c.claimsDir(), lockFileName)
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(lockPath, []byte("99999\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(lockPath, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = newTestClaimer(dir, nil).Claim("002", "agent-"+string(rune('a'+i)), time.Hour)
+		}(i)
+	}
+	wg.Wait()
+
+	won := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			won++
+		case !errors.Is(err, ErrClaimed):
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if won != 1 {
+		t.Errorf("expected exactly one winner, got %d", won)
+	}
+}
+
+func TestWithLock_StaleLockHasOneHolder(t *testing.T) {
+	c := &Claimer{StateDir: t.TempDir()}
+	lockPath := filepath.Join(c.claimsDir(), lockFileName)
+
+	for range 20 {
+		if err := os.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(lockPath, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		old := time.Now().Add(-time.Hour)
+		if err := os.Chtimes(lockPath, old, old); err != nil {
+			t.Fatal(err)
+		}
+
+		var mu sync.Mutex
+		holders, maxHolders := 0, 0
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := c.withLock(func() error {
+					mu.Lock()
+					holders++
+					maxHolders = max(maxHolders, holders)
+					mu.Unlock()
+					time.Sleep(time.Millisecond)
+					mu.Lock()
+					holders--
+					mu.Unlock()
+					return nil
+				})
+				if err != nil {
+					t.Errorf("withLock failed: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if maxHolders != 1 {
+			t.Fatalf("expected one lock holder at a time, got %d", maxHolders)
+		}
+	}
+}
+
+func TestBreakStaleLock(t *testing.T) {
+	lockPath := filepath.Join(t.TempDir(), lockFileName)
+	old := time.Now().Add(-time.Hour)
+
+	// A lock taken since it was seen stale is left alone.
+	if err := os.WriteFile(lockPath, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if breakStaleLock(lockPath) {
+		t.Error("expected a fresh lock not to be broken")
+	}
+
+	// So is a stale lock another process is already breaking.
+	if err := os.Chtimes(lockPath, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(lockPath+".break", nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if breakStaleLock(lockPath) {
+		t.Error("expected the lock to be left to the other breaker")
+	}
+	if err := os.Remove(lockPath + ".break"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !breakStaleLock(lockPath) {
+		t.Error("expected the stale lock to be broken")
+	}
+	if _, err := os.Stat(lockPath); !os.IsNotExist(err) {
+		t.Errorf("expected the stale lock to be removed, got %v", err)
+	}
+}
+
+func TestClaim_StaleClaimExpires(t *testing.T) {
+	dir := createClaimTestDir(t)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	c := newTestClaimer(dir, &now)
+
+	if _, err := c.Claim("002", "agent-1", 10*time.Minute); err != nil {
+		t.Fatalf("first claim failed: %v", err)
+	}
+
+	now = now.Add(11 * time.Minute)
+	cl, err := c.Claim("002", "agent-2", 10*time.Minute)
+	if err != nil {
+		t.Fatalf("expected stale claim to be taken over, got %v", err)
+	}
+	if cl.Owner != "agent-2" {
+		t.Errorf("expected agent-2 to hold the claim, got %s", cl.Owner)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(dir, "002-api.md"))
+	if !strings.Contains(string(content), "owner: agent-2") {
+		t.Errorf("expected owner to change, got:\n%s", content)
+	}
+}
+
+func TestRenewAndRelease(t *testing.T) {
+	dir := createClaimTestDir(t)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	c := newTestClaimer(dir, &now)
+
+	if _, err := c.Claim("002", "agent-1", 10*time.Minute); err != nil {
+		t.Fatalf("claim failed: %v", err)
+	}
+
+	now = now.Add(8 * time.Minute)
+	if _, err := c.Renew("002", "agent-2", 10*time.Minute); !errors.Is(err, ErrClaimed) {
+		t.Errorf("expected another owner's renew to fail, got %v", err)
+	}
+	cl, err := c.Renew("002", "agent-1", 10*time.Minute)
+	if err != nil {
+		t.Fatalf("renew failed: %v", err)
+	}
+	if !cl.ExpiresAt.Equal(now.Add(10 * time.Minute)) {
+		t.Errorf("expected lease to be extended, got %v", cl.ExpiresAt)
+	}
+
+	if err := c.Release("002", "agent-1"); err != nil {
+		t.Fatalf("release failed: %v", err)
+	}
+	if got, _ := c.Get("002"); got != nil {
+		t.Errorf("expected claim to be removed, got %+v", got)
+	}
+}
+
+func TestStateDir(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, taskfile.ConfigFileName), []byte("dir: tasks\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tasks := filepath.Join(root, "tasks")
+	if err := os.MkdirAll(tasks, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := StateDir(tasks); got != root {
+		t.Errorf("StateDir = %s, want %s", got, root)
+	}
+}
+
+func TestStateDir_StopsAtRepoRoot(t *testing.T) {
+	outer := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outer, taskfile.ConfigFileName), []byte("dir: .\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tasks := filepath.Join(outer, "repo", "tasks")
+	for _, dir := range []string{filepath.Join(outer, "repo", ".git"), tasks} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := StateDir(tasks); got != tasks {
+		t.Errorf("StateDir = %s, want the task dir %s", got, tasks)
+	}
+}
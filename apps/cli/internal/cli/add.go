@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+	"github.com/driangle/taskmd/apps/cli/internal/nextid"
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
+)
+
+var (
+	addPriority  string
+	addEffort    string
+	addStatus    string
+	addOwner     string
+	addParent    string
+	addGroup     string
+	addTags      []string
+	addDependsOn []string
+	addBody      string
+	addEdit      bool
+	addDryRun    bool
+)
+
+// addEditorRunner opens a file in the user's editor.
+// Override in tests to avoid launching an interactive process.
+var addEditorRunner = openInEditor
+
+var addCmd = &cobra.Command{
+	Use:        "add <title>",
+	SuggestFor: []string{"new", "create"},
+	Short:      "Create a new task file",
+	Long: `Add creates a new task file with the next available ID.
+
+The ID is computed the same way as "taskmd next-id", and the file is named
+<id>-<slug>.md. When --group names an existing subdirectory of the task
+directory, the file is created there; otherwise it is created in the task
+directory itself.
+
+Dependencies and parent must reference existing task IDs.
+
+Examples:
+  taskmd add "Implement login page"
+  taskmd add "Add export command" --priority high --tag cli --depends-on 042
+  taskmd add "Write docs" --parent 040 --group cli --effort small
+  taskmd add "Investigate flaky test" --edit
+  taskmd add "Refactor scanner" --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAdd,
+}
+
+func init() {
+	rootCmd.AddCommand(addCmd)
+
+	addCmd.Flags().StringVar(&addPriority, "priority", "", "priority (low, medium, high, critical)")
+	addCmd.Flags().StringVar(&addEffort, "effort", "", "effort (small, medium, large)")
+	addCmd.Flags().StringVar(&addStatus, "status", "pending", "initial status (pending, in-progress, completed, blocked, cancelled)")
+	addCmd.Flags().StringVar(&addOwner, "owner", "", "owner/assignee of the task")
+	addCmd.Flags().StringVar(&addParent, "parent", "", "parent task ID")
+	addCmd.Flags().StringVar(&addGroup, "group", "", "task group (also selects a matching subdirectory)")
+	addCmd.Flags().StringArrayVar(&addTags, "tag", nil, "add a tag (repeatable)")
+	addCmd.Flags().StringArrayVar(&addDependsOn, "depends-on", nil, "add a dependency by task ID (repeatable)")
+	addCmd.Flags().StringVar(&addBody, "body", "", "markdown body (defaults to a title heading)")
+	addCmd.Flags().BoolVar(&addEdit, "edit", false, "open the new file in $EDITOR")
+	addCmd.Flags().BoolVar(&addDryRun, "dry-run", false, "print the new file without writing it")
+}
+
+func runAdd(_ *cobra.Command, args []string) error {
+	title := strings.TrimSpace(args[0])
+	if title == "" {
+		return fmt.Errorf("title must not be empty")
+	}
+
+	if err := validateAddEnums(); err != nil {
+		return err
+	}
+
+	flags := GetGlobalFlags()
+	scanDir := ResolveScanDir(nil)
+
+	taskScanner := newScanner(scanDir, flags)
+	result, err := taskScanner.Scan()
+	if err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+
+	debugLog("scan directory: %s", scanDir)
+	debugLog("found %d task(s)", len(result.Tasks))
+
+	if err := validateAddReferences(result.Tasks); err != nil {
+		return err
+	}
+
+	req := buildAddRequest(title, result.Tasks, result.ArchivedIDs)
+	targetDir := resolveAddDir(scanDir, addGroup)
+
+	if addDryRun {
+		r := getRenderer()
+		fmt.Printf("Would create %s:\n\n", filepath.Join(targetDir, taskfile.FileName(req.ID, req.Title)))
+		fmt.Print(taskfile.RenderNewTask(req))
+		fmt.Println("\n" + formatWarning("Dry run — no changes made.", r))
+		return nil
+	}
+
+	path, err := taskfile.CreateTaskFile(targetDir, req)
+	if err != nil {
+		return err
+	}
+
+	if addEdit {
+		if err := addEditorRunner(path); err != nil {
+			return fmt.Errorf("created %s but failed to open editor: %w", path, err)
+		}
+	}
+
+	if flags.Quiet {
+		fmt.Println(path)
+		return nil
+	}
+
+	r := getRenderer()
+	fmt.Printf("Created task %s (%s): %s\n", formatTaskID(req.ID, r), title, formatDim(path, r))
+	return nil
+}
+
+// buildAddRequest assembles the new task from flags, assigning the next free
+// ID. IDs of archived tasks count as taken.
+func buildAddRequest(title string, tasks []*model.Task, archivedIDs []string) taskfile.CreateRequest {
+	ids := make([]string, 0, len(tasks)+len(archivedIDs))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	ids = append(ids, archivedIDs...)
+
+	return taskfile.CreateRequest{
+		ID:           nextid.Calculate(ids).NextID,
+		Title:        title,
+		Status:       addStatus,
+		Priority:     addPriority,
+		Effort:       addEffort,
+		Owner:        addOwner,
+		Parent:       addParent,
+		Group:        addGroup,
+		Dependencies: addDependsOn,
+		Tags:         addTags,
+		Created:      time.Now(),
+		Body:         addBody,
+	}
+}
+
+func validateAddEnums() error {
+	if !contains(validStatusValues, addStatus) {
+		return invalidValueError("status", addStatus, validStatusValues)
+	}
+	if addPriority != "" && !contains(validPriorityValues, addPriority) {
+		return invalidValueError("priority", addPriority, validPriorityValues)
+	}
+	if addEffort != "" && !contains(validEffortValues, addEffort) {
+		return invalidValueError("effort", addEffort, validEffortValues)
+	}
+	return nil
+}
+
+// validateAddReferences ensures that --depends-on and --parent point at existing tasks.
+func validateAddReferences(tasks []*model.Task) error {
+	known := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		known[t.ID] = true
+	}
+
+	var missing []string
+	for _, dep := range addDependsOn {
+		if !known[dep] {
+			missing = append(missing, dep)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("unknown dependency task ID(s): %s", strings.Join(missing, ", "))
+	}
+
+	if addParent != "" && !known[addParent] {
+		return fmt.Errorf("unknown parent task ID: %s", addParent)
+	}
+	return nil
+}
+
+// resolveAddDir returns <scanDir>/<group> when that directory exists, otherwise scanDir.
+func resolveAddDir(scanDir, group string) string {
+	if group == "" {
+		return scanDir
+	}
+	groupDir := filepath.Join(scanDir, group)
+	if info, err := os.Stat(groupDir); err == nil && info.IsDir() {
+		return groupDir
+	}
+	return scanDir
+}
+
+// openInEditor opens path in $VISUAL or $EDITOR, attached to the terminal.
+func openInEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		return fmt.Errorf("no editor configured: set $EDITOR or $VISUAL")
+	}
+
+	parts := strings.Fields(editor)
+	cmd := exec.Command(parts[0], append(parts[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,247 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/driangle/taskmd/apps/cli/internal/parser"
+)
+
+func createAddTestFiles(t *testing.T) string {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, "cli"), 0755); err != nil {
+		t.Fatalf("failed to create group dir: %v", err)
+	}
+
+	files := map[string]string{
+		"040-epic.md": `---
+id: "040"
+title: "Epic"
+status: pending
+created: 2026-02-08
+---
+`,
+		"cli/042-dep.md": `---
+id: "042"
+title: "Dependency"
+status: pending
+created: 2026-02-08
+---
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create test file %s: %v", name, err)
+		}
+	}
+	return tmpDir
+}
+
+func resetAddFlags() {
+	addPriority = ""
+	addEffort = ""
+	addStatus = "pending"
+	addOwner = ""
+	addParent = ""
+	addGroup = ""
+	addTags = nil
+	addDependsOn = nil
+	addBody = ""
+	addEdit = false
+	addDryRun = false
+	addEditorRunner = openInEditor
+	taskDir = "."
+}
+
+func captureAddOutput(t *testing.T, args []string) (string, error) {
+	t.Helper()
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runAdd(addCmd, args)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	return buf.String(), err
+}
+
+func TestAdd_CreatesFileWithNextID(t *testing.T) {
+	tmpDir := createAddTestFiles(t)
+	resetAddFlags()
+	taskDir = tmpDir
+
+	output, err := captureAddOutput(t, []string{"Implement login page"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(output, "Created task 043") {
+		t.Errorf("expected confirmation, got: %s", output)
+	}
+
+	path := filepath.Join(tmpDir, "043-implement-login-page.md")
+	task, err := parser.ParseTaskFile(path)
+	if err != nil {
+		t.Fatalf("expected a valid task file: %v", err)
+	}
+	if task.ID != "043" || task.Title != "Implement login page" {
+		t.Errorf("unexpected id/title: %q %q", task.ID, task.Title)
+	}
+	if task.Status != "pending" {
+		t.Errorf("expected status pending, got %q", task.Status)
+	}
+	if task.Created.IsZero() {
+		t.Error("expected created date to be set")
+	}
+}
+
+func TestAdd_AllFields(t *testing.T) {
+	tmpDir := createAddTestFiles(t)
+	resetAddFlags()
+	taskDir = tmpDir
+	addPriority = "high"
+	addEffort = "small"
+	addOwner = "alice"
+	addTags = []string{"cli", "api"}
+	addDependsOn = []string{"042"}
+	addParent = "040"
+	addGroup = "cli"
+
+	if _, err := captureAddOutput(t, []string{"Add export command"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	path := filepath.Join(tmpDir, "cli", "043-add-export-command.md")
+	task, err := parser.ParseTaskFile(path)
+	if err != nil {
+		t.Fatalf("expected file in group directory: %v", err)
+	}
+	if task.Priority != "high" || task.Effort != "small" || task.Owner != "alice" {
+		t.Errorf("unexpected scalar fields: %+v", task)
+	}
+	if strings.Join(task.Tags, ",") != "cli,api" {
+		t.Errorf("unexpected tags: %v", task.Tags)
+	}
+	if strings.Join(task.Dependencies, ",") != "042" {
+		t.Errorf("unexpected dependencies: %v", task.Dependencies)
+	}
+	if task.Parent != "040" || task.Group != "cli" {
+		t.Errorf("unexpected parent/group: %q %q", task.Parent, task.Group)
+	}
+}
+
+func TestAdd_GroupWithoutDirectory(t *testing.T) {
+	tmpDir := createAddTestFiles(t)
+	resetAddFlags()
+	taskDir = tmpDir
+	addGroup = "web"
+
+	if _, err := captureAddOutput(t, []string{"Web task"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "043-web-task.md")); err != nil {
+		t.Errorf("expected file in task directory root: %v", err)
+	}
+}
+
+func TestAdd_UnknownDependency(t *testing.T) {
+	tmpDir := createAddTestFiles(t)
+	resetAddFlags()
+	taskDir = tmpDir
+	addDependsOn = []string{"999"}
+
+	_, err := captureAddOutput(t, []string{"Broken"})
+	if err == nil || !strings.Contains(err.Error(), "999") {
+		t.Fatalf("expected unknown dependency error, got %v", err)
+	}
+}
+
+func TestAdd_UnknownParent(t *testing.T) {
+	tmpDir := createAddTestFiles(t)
+	resetAddFlags()
+	taskDir = tmpDir
+	addParent = "999"
+
+	_, err := captureAddOutput(t, []string{"Orphan"})
+	if err == nil || !strings.Contains(err.Error(), "unknown parent") {
+		t.Fatalf("expected unknown parent error, got %v", err)
+	}
+}
+
+func TestAdd_InvalidPriority(t *testing.T) {
+	tmpDir := createAddTestFiles(t)
+	resetAddFlags()
+	taskDir = tmpDir
+	addPriority = "hgh"
+
+	_, err := captureAddOutput(t, []string{"Typo"})
+	if err == nil || !strings.Contains(err.Error(), `did you mean "high"`) {
+		t.Fatalf("expected suggestion error, got %v", err)
+	}
+}
+
+func TestAdd_DryRun(t *testing.T) {
+	tmpDir := createAddTestFiles(t)
+	resetAddFlags()
+	taskDir = tmpDir
+	addDryRun = true
+
+	output, err := captureAddOutput(t, []string{"Preview me"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(output, `id: "043"`) || !strings.Contains(output, "Dry run") {
+		t.Errorf("expected rendered preview, got: %s", output)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "043-preview-me.md")); !os.IsNotExist(err) {
+		t.Error("expected no file to be written in dry-run mode")
+	}
+}
+
+func TestAdd_EditOpensEditor(t *testing.T) {
+	tmpDir := createAddTestFiles(t)
+	resetAddFlags()
+	taskDir = tmpDir
+	addEdit = true
+
+	var opened string
+	addEditorRunner = func(path string) error {
+		opened = path
+		return nil
+	}
+	defer func() { addEditorRunner = openInEditor }()
+
+	if _, err := captureAddOutput(t, []string{"Edit me"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if filepath.Base(opened) != "043-edit-me.md" {
+		t.Errorf("expected editor to open new file, got %q", opened)
+	}
+}
+
+func TestAdd_EmptyDirectoryStartsAt001(t *testing.T) {
+	tmpDir := t.TempDir()
+	resetAddFlags()
+	taskDir = tmpDir
+
+	if _, err := captureAddOutput(t, []string{"First task"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "001-first-task.md")); err != nil {
+		t.Errorf("expected 001-first-task.md: %v", err)
+	}
+}
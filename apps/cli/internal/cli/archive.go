@@ -5,12 +5,14 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/driangle/taskmd/apps/cli/internal/model"
-	"github.com/driangle/taskmd/apps/cli/internal/scanner"
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
 )
 
 var (
@@ -19,6 +21,7 @@ var (
 	archiveAllCompleted bool
 	archiveAllCancelled bool
 	archiveTag          string
+	archiveOlderThan    string
 	archiveDryRun       bool
 	archiveYes          bool
 	archiveDelete       bool
@@ -32,10 +35,16 @@ var archiveCmd = &cobra.Command{
 main task list clean while preserving history. Use --delete to permanently
 remove tasks instead of archiving them.
 
-Tasks are selected by ID, status, or tag. Multiple filters use AND logic.
+Tasks are selected by ID, status, tag, or age. Multiple filters use AND logic.
+--older-than picks tasks finished longer ago than the given age (such as 30d,
+2w or 36h), judged by the completed timestamp or else the file's modification
+time. On its own it only considers completed and cancelled tasks.
+
+A task's worklog moves with it. Use 'taskmd unarchive' to restore a task.
 
 Examples:
   taskmd archive --all-completed
+  taskmd archive --older-than 30d -y
   taskmd archive --all-cancelled --dry-run
   taskmd archive --id 042 --id 043
   taskmd archive --status completed --tag backend
@@ -53,6 +62,7 @@ func init() {
 	archiveCmd.Flags().BoolVar(&archiveAllCompleted, "all-completed", false, "archive all completed tasks")
 	archiveCmd.Flags().BoolVar(&archiveAllCancelled, "all-cancelled", false, "archive all cancelled tasks")
 	archiveCmd.Flags().StringVar(&archiveTag, "tag", "", "archive tasks with this tag")
+	archiveCmd.Flags().StringVar(&archiveOlderThan, "older-than", "", "archive tasks finished longer ago than this age (e.g. 30d, 2w, 36h)")
 	archiveCmd.Flags().BoolVar(&archiveDryRun, "dry-run", false, "preview changes without making them")
 	archiveCmd.Flags().BoolVarP(&archiveYes, "yes", "y", false, "skip confirmation prompt")
 	archiveCmd.Flags().BoolVar(&archiveDelete, "delete", false, "permanently delete instead of archive")
@@ -67,13 +77,16 @@ func runArchive(_ *cobra.Command, _ []string) error {
 	flags := GetGlobalFlags()
 	scanDir := ResolveScanDir(nil)
 
-	taskScanner := scanner.NewScanner(scanDir, flags.Verbose, flags.IgnoreDirs)
+	taskScanner := newScanner(scanDir, flags)
 	result, err := taskScanner.Scan()
 	if err != nil {
 		return fmt.Errorf("scan failed: %w", err)
 	}
 
-	selected := filterArchiveTasks(result.Tasks)
+	selected, err := filterArchiveTasks(result.Tasks, time.Now())
+	if err != nil {
+		return err
+	}
 
 	if len(selected) == 0 {
 		return fmt.Errorf("no tasks match the given criteria")
@@ -109,22 +122,20 @@ func runArchive(_ *cobra.Command, _ []string) error {
 
 func validateArchiveFlags() error {
 	hasFilter := len(archiveIDs) > 0 || archiveStatus != "" ||
-		archiveAllCompleted || archiveAllCancelled || archiveTag != ""
+		archiveAllCompleted || archiveAllCancelled || archiveTag != "" || archiveOlderThan != ""
 
 	if !hasFilter {
-		return fmt.Errorf("specify tasks to archive: --id, --status, --all-completed, --all-cancelled, or --tag")
-	}
-
-	if archiveAllCompleted && archiveStatus != "" {
-		return fmt.Errorf("--all-completed and --status are mutually exclusive")
-	}
-	if archiveAllCancelled && archiveStatus != "" {
-		return fmt.Errorf("--all-cancelled and --status are mutually exclusive")
+		return fmt.Errorf("specify tasks to archive: --id, --status, --all-completed, --all-cancelled, --tag, or --older-than")
 	}
-	if archiveAllCompleted && archiveAllCancelled {
-		return fmt.Errorf("--all-completed and --all-cancelled are mutually exclusive")
+	if err := checkArchiveExclusiveFlags(); err != nil {
+		return err
 	}
 
+	if archiveOlderThan != "" {
+		if _, err := parseAge(archiveOlderThan); err != nil {
+			return err
+		}
+	}
 	if archiveStatus != "" {
 		valid := []string{"pending", "in-progress", "completed", "blocked", "cancelled"}
 		if !slices.Contains(valid, archiveStatus) {
@@ -135,36 +146,119 @@ func validateArchiveFlags() error {
 	return nil
 }
 
-func filterArchiveTasks(tasks []*model.Task) []*model.Task {
-	// Resolve effective status filter
-	effectiveStatus := archiveStatus
+// checkArchiveExclusiveFlags rejects status selectors that cannot be combined.
+func checkArchiveExclusiveFlags() error {
+	conflicts := []struct {
+		first, second string
+		both          bool
+	}{
+		{"--all-completed", "--status", archiveAllCompleted && archiveStatus != ""},
+		{"--all-cancelled", "--status", archiveAllCancelled && archiveStatus != ""},
+		{"--all-completed", "--all-cancelled", archiveAllCompleted && archiveAllCancelled},
+	}
+	for _, c := range conflicts {
+		if c.both {
+			return fmt.Errorf("%s and %s are mutually exclusive", c.first, c.second)
+		}
+	}
+	return nil
+}
+
+// archiveCriteria is the task selection given by the archive flags.
+type archiveCriteria struct {
+	ids    map[string]bool
+	status string
+	tag    string
+	cutoff time.Time // zero when --older-than is not set
+}
+
+func newArchiveCriteria(now time.Time) (archiveCriteria, error) {
+	c := archiveCriteria{
+		ids:    make(map[string]bool, len(archiveIDs)),
+		status: archiveStatus,
+		tag:    archiveTag,
+	}
 	if archiveAllCompleted {
-		effectiveStatus = string(model.StatusCompleted)
+		c.status = string(model.StatusCompleted)
 	} else if archiveAllCancelled {
-		effectiveStatus = string(model.StatusCancelled)
+		c.status = string(model.StatusCancelled)
 	}
-
-	// Build ID set for fast lookup
-	idSet := make(map[string]bool, len(archiveIDs))
 	for _, id := range archiveIDs {
-		idSet[id] = true
+		c.ids[id] = true
+	}
+	if archiveOlderThan != "" {
+		age, err := parseAge(archiveOlderThan)
+		if err != nil {
+			return c, err
+		}
+		c.cutoff = now.Add(-age)
+	}
+	return c, nil
+}
+
+func (c archiveCriteria) matches(task *model.Task) bool {
+	switch {
+	case task.Archived, len(c.ids) > 0 && !c.ids[task.ID]:
+		return false
+	case c.status != "" && string(task.Status) != c.status:
+		return false
+	case c.tag != "" && !slices.Contains(task.Tags, c.tag):
+		return false
+	}
+	return c.cutoff.IsZero() || finishedBefore(task, c.cutoff, c.status == "")
+}
+
+func filterArchiveTasks(tasks []*model.Task, now time.Time) ([]*model.Task, error) {
+	criteria, err := newArchiveCriteria(now)
+	if err != nil {
+		return nil, err
 	}
 
 	var selected []*model.Task
 	for _, task := range tasks {
-		if len(idSet) > 0 && !idSet[task.ID] {
-			continue
-		}
-		if effectiveStatus != "" && string(task.Status) != effectiveStatus {
-			continue
+		if criteria.matches(task) {
+			selected = append(selected, task)
 		}
-		if archiveTag != "" && !slices.Contains(task.Tags, archiveTag) {
-			continue
+	}
+	return selected, nil
+}
+
+// finishedBefore reports whether the task was finished before cutoff, going
+// by its completed timestamp or else its file's modification time. With
+// closedOnly, open tasks never qualify.
+func finishedBefore(task *model.Task, cutoff time.Time, closedOnly bool) bool {
+	if closedOnly && task.IsOpen() {
+		return false
+	}
+	finished := task.Completed
+	if finished.IsZero() {
+		info, err := os.Stat(task.FilePath)
+		if err != nil {
+			return false
 		}
-		selected = append(selected, task)
+		finished = info.ModTime()
 	}
+	return finished.Before(cutoff)
+}
 
-	return selected
+// parseAge parses an age such as 30d, 2w or 36h. Days and weeks are added
+// to the units time.ParseDuration accepts.
+func parseAge(s string) (time.Duration, error) {
+	invalid := fmt.Errorf("invalid age %q (expected a number of days, weeks or hours, e.g. 30d, 2w, 36h)", s)
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			count, err := strconv.Atoi(n)
+			if err != nil || count < 0 {
+				return 0, invalid
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, invalid
+	}
+	return d, nil
 }
 
 func printArchivePreview(tasks []*model.Task, action, absScanDir string) {
@@ -192,26 +286,10 @@ func executeDelete(tasks []*model.Task) error {
 
 func executeArchive(tasks []*model.Task, absScanDir string) error {
 	r := getRenderer()
-	archiveDir := filepath.Join(absScanDir, "archive")
 
 	for _, task := range tasks {
-		relPath, err := filepath.Rel(absScanDir, task.FilePath)
-		if err != nil {
-			return fmt.Errorf("failed to compute relative path for %s: %w", task.FilePath, err)
-		}
-
-		destPath := filepath.Join(archiveDir, relPath)
-
-		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
-			return fmt.Errorf("failed to create archive directory: %w", err)
-		}
-
-		if _, err := os.Stat(destPath); err == nil {
-			return fmt.Errorf("archive destination already exists: %s", destPath)
-		}
-
-		if err := os.Rename(task.FilePath, destPath); err != nil {
-			return fmt.Errorf("failed to move %s: %w", task.FilePath, err)
+		if _, err := taskfile.ArchiveTask(task, absScanDir); err != nil {
+			return err
 		}
 	}
 
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 const taskCompleted = `---
@@ -109,6 +110,7 @@ func resetArchiveFlags() {
 	archiveAllCompleted = false
 	archiveAllCancelled = false
 	archiveTag = ""
+	archiveOlderThan = ""
 	archiveDryRun = false
 	archiveYes = false
 	archiveDelete = false
@@ -604,3 +606,118 @@ func TestArchive_IDWithStatusFilter(t *testing.T) {
 		t.Errorf("expected 'no tasks match' error, got: %v", err)
 	}
 }
+
+func TestArchive_OlderThan(t *testing.T) {
+	tmpDir := createArchiveTestFiles(t)
+	resetArchiveFlags()
+	taskDir = tmpDir
+	archiveOlderThan = "30d"
+	archiveYes = true
+
+	old := time.Now().AddDate(0, 0, -40)
+	for _, name := range []string{"001-setup.md", "002-old.md", "003-new.md"} {
+		if err := os.Chtimes(filepath.Join(tmpDir, name), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	output, err := captureArchiveOutput(t)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The pending task is old too, but --older-than alone only takes closed tasks.
+	if !strings.Contains(output, "Archived 2 task(s)") {
+		t.Errorf("expected 2 tasks archived, got: %s", output)
+	}
+	for _, name := range []string{"001-setup.md", "002-old.md"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, "archive", name)); err != nil {
+			t.Errorf("expected %s in archive", name)
+		}
+	}
+	for _, name := range []string{"003-new.md", "004-api.md"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, name)); err != nil {
+			t.Errorf("expected %s to stay in place", name)
+		}
+	}
+}
+
+func TestArchive_OlderThanUsesCompletedTimestamp(t *testing.T) {
+	tmpDir := t.TempDir()
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	content := strings.Replace(taskCompleted, "status: completed", "status: completed\ncompleted: "+recent, 1)
+	path := filepath.Join(tmpDir, "001-setup.md")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().AddDate(0, 0, -40)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	resetArchiveFlags()
+	taskDir = tmpDir
+	archiveOlderThan = "1w"
+	archiveYes = true
+
+	_, err := captureArchiveOutput(t)
+	if err == nil || !strings.Contains(err.Error(), "no tasks match") {
+		t.Errorf("expected the recent completion to keep the task, got %v", err)
+	}
+}
+
+func TestArchive_MovesWorklog(t *testing.T) {
+	tmpDir := createArchiveTestFiles(t)
+	writeWorklog(t, tmpDir, "001")
+	resetArchiveFlags()
+	taskDir = tmpDir
+	archiveIDs = []string{"001"}
+	archiveYes = true
+
+	if _, err := captureArchiveOutput(t); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "archive", ".worklogs", "001.md")); err != nil {
+		t.Errorf("expected worklog to move into the archive: %v", err)
+	}
+}
+
+func TestArchive_InvalidOlderThan(t *testing.T) {
+	resetArchiveFlags()
+	archiveOlderThan = "a month"
+
+	_, err := captureArchiveOutput(t)
+	if err == nil || !strings.Contains(err.Error(), "invalid age") {
+		t.Errorf("expected an invalid age error, got %v", err)
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := map[string]time.Duration{
+		"30d": 30 * 24 * time.Hour,
+		"2w":  14 * 24 * time.Hour,
+		"36h": 36 * time.Hour,
+	}
+	for input, want := range tests {
+		got, err := parseAge(input)
+		if err != nil || got != want {
+			t.Errorf("parseAge(%q) = %v, %v; want %v", input, got, err, want)
+		}
+	}
+	for _, input := range []string{"", "d", "-3d", "soon"} {
+		if _, err := parseAge(input); err == nil {
+			t.Errorf("parseAge(%q) should fail", input)
+		}
+	}
+}
+
+func writeWorklog(t *testing.T, dir, id string) {
+	t.Helper()
+	path := filepath.Join(dir, ".worklogs", id+".md")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("## 2026-02-01T10:00:00Z\n\nDone.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
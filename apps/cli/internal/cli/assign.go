@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/driangle/taskmd/apps/cli/internal/next"
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
+)
+
+var (
+	assignBalance bool
+	assignOwners  []string
+	assignFilters []string
+	assignLimit   int
+	assignApply   bool
+	assignFormat  string
+)
+
+var assignCmd = &cobra.Command{
+	Use:   "assign",
+	Short: "Propose owners for unassigned tasks",
+	Long: `Assign distributes unassigned actionable tasks across owners.
+
+With --balance, tasks are taken in "taskmd next" order and each is given to
+the owner with the lightest current load. Load is the summed effort of an
+owner's in-progress tasks (small=1, medium=2, large=3, unset=2) plus the
+tasks assigned in this run.
+
+Candidate owners come from --owners, or default to everyone who already owns
+a task. By default assign only prints the proposal; pass --apply to write
+the chosen owners to each task's frontmatter.
+
+Examples:
+  taskmd assign --balance
+  taskmd assign --balance --owners alice,bob,carol --apply
+  taskmd assign --balance --filter tag=backend --limit 5 --apply
+  taskmd assign --balance --format json`,
+	Args: cobra.NoArgs,
+	RunE: runAssign,
+}
+
+func init() {
+	rootCmd.AddCommand(assignCmd)
+
+	assignCmd.Flags().BoolVar(&assignBalance, "balance", false, "assign owners by balancing in-progress load")
+	assignCmd.Flags().StringSliceVar(&assignOwners, "owners", nil, "candidate owners, comma-separated (default: all existing owners)")
+	assignCmd.Flags().StringArrayVar(&assignFilters, "filter", []string{}, "only assign tasks matching this filter expression")
+	assignCmd.Flags().IntVar(&assignLimit, "limit", 0, "maximum number of tasks to assign (0 = all)")
+	assignCmd.Flags().BoolVar(&assignApply, "apply", false, "write the proposed owners to the task files")
+	assignCmd.Flags().StringVar(&assignFormat, "format", "table", "output format (table, json, yaml)")
+}
+
+func runAssign(_ *cobra.Command, _ []string) error {
+	if !assignBalance {
+		return fmt.Errorf("nothing to do: use --balance to propose owners for unassigned tasks")
+	}
+	if err := ValidateFormat(assignFormat, []string{"table", "json", "yaml"}); err != nil {
+		return err
+	}
+
+	flags := GetGlobalFlags()
+	scanDir := ResolveScanDir(nil)
+
+	taskScanner := newScanner(scanDir, flags)
+	result, err := taskScanner.Scan()
+	if err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+
+	debugLog("scan directory: %s", scanDir)
+	debugLog("found %d task(s)", len(result.Tasks))
+
+	scoring := loadNextConfig()
+	assignments, err := next.Balance(result.Tasks, next.BalanceOptions{
+		Owners:  assignOwners,
+		Filters: assignFilters,
+		Limit:   assignLimit,
+		Scoring: &scoring,
+	})
+	if err != nil {
+		return err
+	}
+
+	if assignApply {
+		if err := writeAssignments(assignments); err != nil {
+			return err
+		}
+	}
+
+	relativeAssignmentPaths(assignments, scanDir)
+
+	switch assignFormat {
+	case "json":
+		return WriteJSON(os.Stdout, assignments)
+	case "yaml":
+		return WriteYAML(os.Stdout, assignments)
+	default:
+		outputAssignTable(assignments)
+		return nil
+	}
+}
+
+func writeAssignments(assignments []next.Assignment) error {
+	for _, a := range assignments {
+		owner := a.Owner
+		if err := taskfile.UpdateTaskFile(a.FilePath, taskfile.UpdateRequest{Owner: &owner}); err != nil {
+			return fmt.Errorf("failed to assign %s: %w", a.ID, err)
+		}
+	}
+	return nil
+}
+
+func relativeAssignmentPaths(assignments []next.Assignment, baseDir string) {
+	absBase, err := filepath.Abs(baseDir)
+	if err != nil {
+		return
+	}
+	for i := range assignments {
+		if rel, err := filepath.Rel(absBase, assignments[i].FilePath); err == nil {
+			assignments[i].FilePath = rel
+		}
+	}
+}
+
+func outputAssignTable(assignments []next.Assignment) {
+	r := getRenderer()
+
+	if len(assignments) == 0 {
+		fmt.Println("No unassigned actionable tasks found.")
+		return
+	}
+
+	fmt.Println(formatLabel("Proposed owners:", r))
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTitle\tEffort\tOwner\tLoad")
+	fmt.Fprintln(w, "--\t-----\t------\t-----\t----")
+	for _, a := range assignments {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n",
+			formatTaskID(a.ID, r),
+			a.Title,
+			formatEffort(a.Effort, r),
+			a.Owner,
+			a.Load,
+		)
+	}
+	w.Flush()
+
+	fmt.Println()
+	if assignApply {
+		fmt.Println(formatSuccess(fmt.Sprintf("Assigned %d task(s).", len(assignments)), r))
+	} else {
+		fmt.Println(formatWarning("Dry run — no changes made. Re-run with --apply to write owners.", r))
+	}
+}
@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/driangle/taskmd/apps/cli/internal/next"
+)
+
+func createAssignTestFiles(t *testing.T) string {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	tasks := map[string]string{
+		"001-api.md": `---
+id: "001"
+title: "Build API"
+status: in-progress
+priority: high
+effort: large
+owner: alice
+---
+`,
+		"002-docs.md": `---
+id: "002"
+title: "Write docs"
+status: in-progress
+priority: medium
+effort: small
+owner: bob
+---
+`,
+		"003-login.md": `---
+id: "003"
+title: "Add login"
+status: pending
+priority: critical
+effort: medium
+---
+
+# Add login
+`,
+		"004-logout.md": `---
+id: "004"
+title: "Add logout"
+status: pending
+priority: low
+effort: small
+---
+`,
+	}
+
+	for name, content := range tasks {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return tmpDir
+}
+
+func resetAssignFlags() {
+	assignBalance = false
+	assignOwners = nil
+	assignFilters = []string{}
+	assignLimit = 0
+	assignApply = false
+	assignFormat = "table"
+}
+
+func captureAssignOutput(t *testing.T) (string, error) {
+	t.Helper()
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runAssign(assignCmd, nil)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	return buf.String(), err
+}
+
+func TestAssign_RequiresBalance(t *testing.T) {
+	resetAssignFlags()
+	taskDir = createAssignTestFiles(t)
+
+	_, err := captureAssignOutput(t)
+	if err == nil || !strings.Contains(err.Error(), "--balance") {
+		t.Fatalf("expected error mentioning --balance, got %v", err)
+	}
+}
+
+func TestAssign_BalanceWritesOwners(t *testing.T) {
+	resetAssignFlags()
+	tmpDir := createAssignTestFiles(t)
+	taskDir = tmpDir
+	assignBalance = true
+	assignApply = true
+
+	output, err := captureAssignOutput(t)
+	if err != nil {
+		t.Fatalf("runAssign failed: %v", err)
+	}
+	if !strings.Contains(output, "Assigned 2 task(s).") {
+		t.Errorf("expected confirmation, got:\n%s", output)
+	}
+
+	// bob (load 1) takes 003 first, then alice and bob are tied at 3 and
+	// alice wins the tie by name order.
+	for file, owner := range map[string]string{"003-login.md": "bob", "004-logout.md": "alice"} {
+		content, _ := os.ReadFile(filepath.Join(tmpDir, file))
+		if !strings.Contains(string(content), "owner: "+owner) {
+			t.Errorf("expected %s to be assigned to %s, got:\n%s", file, owner, content)
+		}
+	}
+
+	content, _ := os.ReadFile(filepath.Join(tmpDir, "003-login.md"))
+	if !strings.Contains(string(content), "# Add login") {
+		t.Errorf("expected body to be preserved, got:\n%s", content)
+	}
+}
+
+func TestAssign_DefaultOnlyProposesJSON(t *testing.T) {
+	resetAssignFlags()
+	tmpDir := createAssignTestFiles(t)
+	taskDir = tmpDir
+	assignBalance = true
+	assignFormat = "json"
+	assignOwners = []string{"carol", "dave"}
+
+	output, err := captureAssignOutput(t)
+	if err != nil {
+		t.Fatalf("runAssign failed: %v", err)
+	}
+
+	var got []next.Assignment
+	if err := json.Unmarshal([]byte(output), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, output)
+	}
+	if len(got) != 2 || got[0].ID != "003" || got[0].Owner != "carol" || got[1].Owner != "dave" {
+		t.Errorf("unexpected proposal: %+v", got)
+	}
+	if got[0].FilePath != "003-login.md" {
+		t.Errorf("expected relative file path, got %s", got[0].FilePath)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(tmpDir, "003-login.md"))
+	if strings.Contains(string(content), "owner:") {
+		t.Errorf("assign without --apply should not write owners, got:\n%s", content)
+	}
+}
+
+func TestAssign_DefaultTableIsDryRun(t *testing.T) {
+	resetAssignFlags()
+	tmpDir := createAssignTestFiles(t)
+	taskDir = tmpDir
+	assignBalance = true
+
+	output, err := captureAssignOutput(t)
+	if err != nil {
+		t.Fatalf("runAssign failed: %v", err)
+	}
+	if !strings.Contains(output, "--apply") {
+		t.Errorf("expected a hint to re-run with --apply, got:\n%s", output)
+	}
+
+	for _, file := range []string{"003-login.md", "004-logout.md"} {
+		content, _ := os.ReadFile(filepath.Join(tmpDir, file))
+		if strings.Contains(string(content), "owner:") {
+			t.Errorf("expected %s to be unchanged, got:\n%s", file, content)
+		}
+	}
+}
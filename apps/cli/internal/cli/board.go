@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/driangle/taskmd/apps/cli/internal/board"
+	"github.com/driangle/taskmd/apps/cli/internal/model"
 	"github.com/driangle/taskmd/apps/cli/internal/scanner"
 )
 
@@ -16,6 +17,7 @@ var (
 	boardGroupBy string
 	boardFormat  string
 	boardOut     string
+	boardView    string
 )
 
 var boardCmd = &cobra.Command{
@@ -40,7 +42,11 @@ Examples:
   taskmd board tasks/
   taskmd board tasks/ --group-by priority
   taskmd board tasks/ --group-by tag --format json
-  taskmd board tasks/ --format txt --out board.txt`,
+  taskmd board tasks/ --format txt --out board.txt
+  taskmd board --view sprint
+
+With --view, the saved view's filters and group_by are applied; an explicit
+--group-by overrides the view.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runBoard,
 }
@@ -51,6 +57,7 @@ func init() {
 	boardCmd.Flags().StringVar(&boardGroupBy, "group-by", "status", "field to group by (status, priority, effort, group, tag)")
 	boardCmd.Flags().StringVar(&boardFormat, "format", "md", "output format (md, txt, json)")
 	boardCmd.Flags().StringVarP(&boardOut, "out", "o", "", "write output to file instead of stdout")
+	boardCmd.Flags().StringVar(&boardView, "view", "", "apply a saved view from .taskmd.yaml")
 }
 
 func runBoard(cmd *cobra.Command, args []string) error {
@@ -58,7 +65,7 @@ func runBoard(cmd *cobra.Command, args []string) error {
 
 	scanDir := ResolveScanDir(args)
 
-	taskScanner := scanner.NewScanner(scanDir, flags.Verbose, flags.IgnoreDirs)
+	taskScanner := newScanner(scanDir, flags)
 	result, err := taskScanner.Scan()
 	if err != nil {
 		return fmt.Errorf("scan failed: %w", err)
@@ -72,7 +79,12 @@ func runBoard(cmd *cobra.Command, args []string) error {
 		fmt.Fprintln(os.Stderr)
 	}
 
-	grouped, err := board.GroupTasks(result.Tasks, boardGroupBy)
+	tasks, groupBy, err := applyBoardView(cmd, result)
+	if err != nil {
+		return err
+	}
+
+	grouped, err := board.GroupTasks(tasks, groupBy)
 	if err != nil {
 		return err
 	}
@@ -91,9 +103,9 @@ func runBoard(cmd *cobra.Command, args []string) error {
 
 	switch boardFormat {
 	case "md":
-		return outputBoardMarkdown(grouped, outFile)
+		return outputBoardMarkdown(grouped, groupBy, outFile)
 	case "txt":
-		return outputBoardText(grouped, outFile)
+		return outputBoardText(grouped, groupBy, outFile)
 	case "json":
 		return outputBoardJSON(grouped, outFile)
 	default:
@@ -101,14 +113,41 @@ func runBoard(cmd *cobra.Command, args []string) error {
 	}
 }
 
-func outputBoardMarkdown(gr *board.GroupResult, w io.Writer) error {
+// applyBoardView filters the scanned tasks by the selected view and resolves
+// the group-by field.
+func applyBoardView(cmd *cobra.Command, result *scanner.ScanResult) ([]*model.Task, string, error) {
+	tasks := result.Tasks
+	if boardView == "" {
+		return tasks, boardGroupBy, nil
+	}
+
+	view, err := resolveView(boardView)
+	if err != nil {
+		return nil, "", err
+	}
+
+	groupBy := boardGroupBy
+	if view.GroupBy != "" && !cmd.Flags().Changed("group-by") {
+		groupBy = view.GroupBy
+	}
+
+	if len(view.Filters) > 0 {
+		tasks, err = applyFilters(tasks, view.Filters, result.ArchivedIDs)
+		if err != nil {
+			return nil, "", fmt.Errorf("filter error: %w", err)
+		}
+	}
+	return tasks, groupBy, nil
+}
+
+func outputBoardMarkdown(gr *board.GroupResult, groupBy string, w io.Writer) error {
 	r := getRenderer()
 	for i, key := range gr.Keys {
 		tasks := gr.Groups[key]
 		if i > 0 {
 			fmt.Fprintln(w)
 		}
-		coloredHeading := formatHeading(key, boardGroupBy, r)
+		coloredHeading := formatHeading(key, groupBy, r)
 		fmt.Fprintf(w, "## %s (%d)\n\n", coloredHeading, len(tasks))
 		for _, t := range tasks {
 			formattedID := formatTaskID(t.ID, r)
@@ -123,14 +162,14 @@ func outputBoardMarkdown(gr *board.GroupResult, w io.Writer) error {
 	return nil
 }
 
-func outputBoardText(gr *board.GroupResult, w io.Writer) error {
+func outputBoardText(gr *board.GroupResult, groupBy string, w io.Writer) error {
 	r := getRenderer()
 	for i, key := range gr.Keys {
 		tasks := gr.Groups[key]
 		if i > 0 {
 			fmt.Fprintln(w)
 		}
-		coloredHeading := formatHeading(key, boardGroupBy, r)
+		coloredHeading := formatHeading(key, groupBy, r)
 		countSuffix := fmt.Sprintf(" (%d)", len(tasks))
 		header := coloredHeading + countSuffix
 		// Use plain key length for the separator (not colored string length)
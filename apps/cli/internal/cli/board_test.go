@@ -122,6 +122,7 @@ func resetBoardFlags() {
 	boardGroupBy = "status"
 	boardFormat = "md"
 	boardOut = ""
+	boardView = ""
 	noColor = true
 }
 
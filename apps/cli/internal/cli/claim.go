@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/driangle/taskmd/apps/cli/internal/claim"
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+var (
+	claimOwner   string
+	claimLease   time.Duration
+	claimRenew   bool
+	claimRelease bool
+	claimFormat  string
+)
+
+var claimCmd = &cobra.Command{
+	Use:   "claim <task-id>",
+	Short: "Atomically take a pending task for an owner",
+	Long: `Claim sets a task to in-progress under --owner, but only if it is still
+pending and actionable. When several agents claim the same task at once,
+exactly one succeeds and the others get an error.
+
+Each claim is a lease recorded in .taskmd/claims next to .taskmd.yaml. Renew
+it with --renew while work continues; once the lease runs out the claim is
+stale and the task can be claimed by someone else. --release drops the claim
+without changing the task.
+
+Examples:
+  taskmd claim 042 --owner agent-1
+  taskmd claim 042 --owner agent-1 --lease 2h
+  taskmd claim 042 --owner agent-1 --renew
+  taskmd claim 042 --owner agent-1 --release`,
+	Args: cobra.ExactArgs(1),
+	RunE: runClaim,
+}
+
+func init() {
+	rootCmd.AddCommand(claimCmd)
+
+	claimCmd.Flags().StringVar(&claimOwner, "owner", "", "who is claiming the task (required)")
+	claimCmd.Flags().DurationVar(&claimLease, "lease", claim.DefaultLease, "how long the claim lasts without renewal")
+	claimCmd.Flags().BoolVar(&claimRenew, "renew", false, "extend an existing claim (heartbeat)")
+	claimCmd.Flags().BoolVar(&claimRelease, "release", false, "release an existing claim")
+	claimCmd.Flags().StringVar(&claimFormat, "format", "text", "output format (text, json, yaml)")
+
+	_ = claimCmd.MarkFlagRequired("owner")
+}
+
+func runClaim(_ *cobra.Command, args []string) error {
+	if claimRenew && claimRelease {
+		return fmt.Errorf("--renew and --release are mutually exclusive")
+	}
+	if err := ValidateFormat(claimFormat, []string{"text", "json", "yaml"}); err != nil {
+		return err
+	}
+
+	flags := GetGlobalFlags()
+	scanDir := ResolveScanDir(nil)
+	claimer := &claim.Claimer{
+		StateDir: claim.StateDir(scanDir),
+		Load: func() ([]*model.Task, []string, error) {
+			result, err := newScanner(scanDir, flags).Scan()
+			if err != nil {
+				return nil, nil, fmt.Errorf("scan failed: %w", err)
+			}
+			return result.Tasks, result.ArchivedIDs, nil
+		},
+		Timestamps: loadTimestampConfig(),
+	}
+
+	taskID := args[0]
+	r := getRenderer()
+
+	if claimRelease {
+		if err := claimer.Release(taskID, claimOwner); err != nil {
+			return err
+		}
+		fmt.Println(formatSuccess(fmt.Sprintf("Released claim on %s", taskID), r))
+		return nil
+	}
+
+	var cl *claim.Claim
+	var err error
+	if claimRenew {
+		cl, err = claimer.Renew(taskID, claimOwner, claimLease)
+	} else {
+		cl, err = claimer.Claim(taskID, claimOwner, claimLease)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch claimFormat {
+	case "json":
+		return WriteJSON(os.Stdout, cl)
+	case "yaml":
+		return WriteYAML(os.Stdout, cl)
+	default:
+		verb := "Claimed"
+		if claimRenew {
+			verb = "Renewed claim on"
+		}
+		fmt.Println(formatSuccess(fmt.Sprintf("%s %s for %s until %s", verb, cl.TaskID, cl.Owner, cl.ExpiresAt.Local().Format(time.RFC3339)), r))
+		return nil
+	}
+}
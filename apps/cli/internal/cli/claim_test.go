@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/claim"
+)
+
+func resetClaimFlags() {
+	claimOwner = ""
+	claimLease = claim.DefaultLease
+	claimRenew = false
+	claimRelease = false
+	claimFormat = "text"
+}
+
+func captureClaimOutput(t *testing.T, args []string) (string, error) {
+	t.Helper()
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runClaim(claimCmd, args)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	return buf.String(), err
+}
+
+func TestClaim_ClaimRenewRelease(t *testing.T) {
+	tmpDir := createSetTestFiles(t)
+	resetClaimFlags()
+	taskDir = tmpDir
+	claimOwner = "agent-1"
+	claimLease = time.Hour
+
+	output, err := captureClaimOutput(t, []string{"001"})
+	if err != nil {
+		t.Fatalf("claim failed: %v", err)
+	}
+	if !strings.Contains(output, "Claimed 001 for agent-1") {
+		t.Errorf("unexpected output: %s", output)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(tmpDir, "001-setup.md"))
+	if !strings.Contains(string(content), "status: in-progress") || !strings.Contains(string(content), "owner: agent-1") {
+		t.Errorf("expected task to be claimed, got:\n%s", content)
+	}
+
+	claimOwner = "agent-2"
+	if _, err := captureClaimOutput(t, []string{"001"}); err == nil || !strings.Contains(err.Error(), "held by agent-1") {
+		t.Errorf("expected claim by agent-2 to fail, got %v", err)
+	}
+
+	claimOwner = "agent-1"
+	claimRenew = true
+	if output, err := captureClaimOutput(t, []string{"001"}); err != nil || !strings.Contains(output, "Renewed claim on 001") {
+		t.Errorf("renew failed: %v %s", err, output)
+	}
+
+	claimRenew = false
+	claimRelease = true
+	if output, err := captureClaimOutput(t, []string{"001"}); err != nil || !strings.Contains(output, "Released claim on 001") {
+		t.Errorf("release failed: %v %s", err, output)
+	}
+}
+
+func TestClaim_RenewAndReleaseExclusive(t *testing.T) {
+	resetClaimFlags()
+	claimOwner = "agent-1"
+	claimRenew = true
+	claimRelease = true
+
+	if _, err := captureClaimOutput(t, []string{"001"}); err == nil {
+		t.Fatal("expected error for --renew with --release")
+	}
+}
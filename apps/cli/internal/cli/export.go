@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+var (
+	exportFormat  string
+	exportFilters []string
+	exportColumns string
+	exportOut     string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export tasks for spreadsheets, calendars and other tools",
+	Long: `Export writes tasks in formats other tools can import.
+
+Formats:
+  csv      one row per task with the columns chosen by --columns
+  ical     an iCalendar file with one VTODO entry per task
+  todotxt  one todo.txt line per task
+  github   a JSON array of GitHub "create issue" request bodies
+
+Filters use the same expression language as list (e.g. "status!=completed").
+Multiple --filter flags are combined with AND logic.
+
+Examples:
+  taskmd export --format csv --out tasks.csv
+  taskmd export --format csv --columns id,title,owner,tags
+  taskmd export --format ical --filter status!=completed -o tasks.ics
+  taskmd export --format todotxt > todo.txt
+  taskmd export --format github --filter tag=bug`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runExport,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringVar(&exportFormat, "format", "csv", "output format (csv, ical, todotxt, github)")
+	exportCmd.Flags().StringArrayVar(&exportFilters, "filter", []string{}, "filter expression (can specify multiple times for AND conditions, e.g., --filter status=pending)")
+	exportCmd.Flags().StringVar(&exportColumns, "columns", defaultExportColumns, "comma-separated list of columns for csv output")
+	exportCmd.Flags().StringVarP(&exportOut, "out", "o", "", "write output to file instead of stdout")
+}
+
+func runExport(_ *cobra.Command, args []string) error {
+	flags := GetGlobalFlags()
+
+	writer, err := newExportWriter(exportFormat, exportColumns)
+	if err != nil {
+		return err
+	}
+
+	scanDir := ResolveScanDir(args)
+
+	taskScanner := newScanner(scanDir, flags)
+	result, err := taskScanner.Scan()
+	if err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+
+	tasks := result.Tasks
+	makeFilePathsRelative(tasks, scanDir)
+
+	if len(exportFilters) > 0 {
+		tasks, err = applyFilters(tasks, exportFilters, result.ArchivedIDs)
+		if err != nil {
+			return fmt.Errorf("filter error: %w", err)
+		}
+	}
+
+	if err := model.SortTasks(tasks, "id"); err != nil {
+		return err
+	}
+
+	outFile := os.Stdout
+	if exportOut != "" {
+		f, err := os.Create(exportOut)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		outFile = f
+	}
+
+	return writer.Write(outFile, tasks)
+}
+
+// parseColumns splits a comma-separated column list and checks each name.
+func parseColumns(columnsStr string) ([]string, error) {
+	var columns []string
+	for _, col := range strings.Split(columnsStr, ",") {
+		col = strings.TrimSpace(col)
+		if col == "" {
+			continue
+		}
+		if !contains(exportColumnNames, col) {
+			return nil, invalidValueError("column", col, exportColumnNames)
+		}
+		columns = append(columns, col)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns given")
+	}
+	return columns, nil
+}
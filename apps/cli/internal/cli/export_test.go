@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+func resetExportFlags() {
+	exportFormat = "csv"
+	exportFilters = []string{}
+	exportColumns = defaultExportColumns
+	exportOut = ""
+}
+
+func exportSampleTasks() []*model.Task {
+	return []*model.Task{
+		{
+			ID:           "001",
+			Title:        "Set up CI, finally",
+			Status:       model.StatusCompleted,
+			Priority:     model.PriorityHigh,
+			Tags:         []string{"infra", "ci"},
+			Created:      time.Date(2026, 2, 8, 0, 0, 0, 0, time.UTC),
+			Owner:        "alice",
+			Body:         "\nRun tests on push.\n",
+			Dependencies: []string{},
+		},
+		{
+			ID:           "002",
+			Title:        "Write docs",
+			Status:       model.StatusPending,
+			Priority:     model.PriorityLow,
+			Group:        "Launch Plan",
+			Dependencies: []string{"001"},
+			Created:      time.Date(2026, 2, 9, 0, 0, 0, 0, time.UTC),
+			Start:        time.Date(2026, 2, 16, 0, 0, 0, 0, time.UTC),
+			Due:          time.Date(2026, 2, 20, 0, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func writeExport(t *testing.T, format, columns string) string {
+	t.Helper()
+	writer, err := newExportWriter(format, columns)
+	if err != nil {
+		t.Fatalf("newExportWriter failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := writer.Write(&buf, exportSampleTasks()); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	return buf.String()
+}
+
+func TestNewExportWriter_Invalid(t *testing.T) {
+	if _, err := newExportWriter("xml", defaultExportColumns); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+	_, err := newExportWriter("csv", "id,titel")
+	if err == nil || !strings.Contains(err.Error(), `did you mean "title"`) {
+		t.Errorf("expected column suggestion, got %v", err)
+	}
+}
+
+func TestExport_CSV(t *testing.T) {
+	out := writeExport(t, "csv", "id, title,tags,deps,created")
+
+	rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid csv: %v", err)
+	}
+	want := [][]string{
+		{"id", "title", "tags", "deps", "created"},
+		{"001", "Set up CI, finally", "infra,ci", "", "2026-02-08"},
+		{"002", "Write docs", "", "001", "2026-02-09"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, got %d:\n%s", len(want), len(rows), out)
+	}
+	for i := range want {
+		if strings.Join(rows[i], "|") != strings.Join(want[i], "|") {
+			t.Errorf("row %d = %v, want %v", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestExport_ICal(t *testing.T) {
+	oldNow := exportNow
+	exportNow = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+	defer func() { exportNow = oldNow }()
+
+	out := writeExport(t, "ical", "")
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"BEGIN:VTODO\r\nUID:001@taskmd\r\nDTSTAMP:20260301T120000Z\r\n",
+		`SUMMARY:Set up CI\, finally`,
+		"CREATED:20260208T000000Z\r\n",
+		"DTSTART;VALUE=DATE:20260216\r\nDUE;VALUE=DATE:20260220\r\n",
+		"STATUS:COMPLETED\r\n",
+		"PRIORITY:3\r\n",
+		"CATEGORIES:infra,ci\r\n",
+		"DESCRIPTION:Run tests on push.\r\n",
+		"STATUS:NEEDS-ACTION\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "BEGIN:VTODO") != 2 {
+		t.Errorf("expected 2 VTODO entries, got:\n%s", out)
+	}
+}
+
+func TestICalFold(t *testing.T) {
+	line := "DESCRIPTION:" + strings.Repeat("é", 60)
+	folded := icalFold(line)
+	for _, part := range strings.Split(folded, "\r\n") {
+		if len(part) > 75 {
+			t.Errorf("line exceeds 75 octets: %d", len(part))
+		}
+	}
+	if strings.ReplaceAll(folded, "\r\n ", "") != line {
+		t.Error("unfolding did not restore the original line")
+	}
+}
+
+func TestExport_TodoTxt(t *testing.T) {
+	out := writeExport(t, "todotxt", "")
+
+	want := "x (B) Set up CI, finally @infra @ci id:001 owner:alice\n" +
+		"(D) 2026-02-09 Write docs +Launch-Plan id:002 dep:001 due:2026-02-20 t:2026-02-16\n"
+	if out != want {
+		t.Errorf("unexpected todo.txt output:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestExport_GitHub(t *testing.T) {
+	out := writeExport(t, "github", "")
+
+	var issues []githubIssueRequest
+	if err := json.Unmarshal([]byte(out), &issues); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d", len(issues))
+	}
+	first := issues[0]
+	if first.Title != "Set up CI, finally" || first.Body != "Run tests on push." ||
+		strings.Join(first.Labels, ",") != "infra,ci" || strings.Join(first.Assignees, ",") != "alice" {
+		t.Errorf("unexpected issue: %+v", first)
+	}
+	if issues[1].Assignees != nil || issues[1].Labels != nil {
+		t.Errorf("expected no labels or assignees, got %+v", issues[1])
+	}
+}
+
+func TestExportCommand_FilterAndOut(t *testing.T) {
+	tmpDir := createTestTaskFiles(t)
+	resetExportFlags()
+	defer resetExportFlags()
+
+	exportFilters = []string{"status=pending"}
+	exportColumns = "id,status"
+	exportOut = filepath.Join(t.TempDir(), "tasks.csv")
+
+	if err := runExport(exportCmd, []string{tmpDir}); err != nil {
+		t.Fatalf("runExport failed: %v", err)
+	}
+
+	data, err := os.ReadFile(exportOut)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	want := "id,status\n003,pending\n004,pending\n005,pending\n"
+	if string(data) != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", data, want)
+	}
+}
@@ -0,0 +1,259 @@
+package cli
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+// defaultExportColumns is the csv column set used when --columns is not given.
+const defaultExportColumns = "id,title,status,priority,effort,owner,group,tags,deps,created"
+
+// exportColumnNames lists the columns getColumnValue understands.
+var exportColumnNames = []string{
+	"id", "title", "status", "priority", "effort", "group", "owner",
+	"parent", "file", "created", "due", "start", "scheduled", "started", "completed",
+	"deps", "tags",
+}
+
+// exportNow returns the timestamp recorded in exports. Override in tests.
+var exportNow = time.Now
+
+// exportWriter writes a list of tasks in one export format.
+type exportWriter interface {
+	Write(w io.Writer, tasks []*model.Task) error
+}
+
+// exportFormats lists the formats accepted by newExportWriter.
+var exportFormats = []string{"csv", "ical", "todotxt", "github"}
+
+func newExportWriter(format, columnsStr string) (exportWriter, error) {
+	switch format {
+	case "csv":
+		columns, err := parseColumns(columnsStr)
+		if err != nil {
+			return nil, err
+		}
+		return csvExportWriter{columns: columns}, nil
+	case "ical":
+		return icalExportWriter{}, nil
+	case "todotxt":
+		return todoTxtExportWriter{}, nil
+	case "github":
+		return githubExportWriter{}, nil
+	default:
+		return nil, ValidateFormat(format, exportFormats)
+	}
+}
+
+// csvExportWriter writes a header row followed by one row per task.
+type csvExportWriter struct {
+	columns []string
+}
+
+func (c csvExportWriter) Write(w io.Writer, tasks []*model.Task) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(c.columns); err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		row := make([]string, len(c.columns))
+		for i, col := range c.columns {
+			row[i] = getColumnValue(task, col)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// icalExportWriter writes an iCalendar (RFC 5545) file of VTODO entries.
+type icalExportWriter struct{}
+
+var icalStatus = map[model.Status]string{
+	model.StatusPending:    "NEEDS-ACTION",
+	model.StatusBlocked:    "NEEDS-ACTION",
+	model.StatusInProgress: "IN-PROCESS",
+	model.StatusCompleted:  "COMPLETED",
+	model.StatusCancelled:  "CANCELLED",
+}
+
+var icalPriority = map[model.Priority]string{
+	model.PriorityCritical: "1",
+	model.PriorityHigh:     "3",
+	model.PriorityMedium:   "5",
+	model.PriorityLow:      "9",
+}
+
+func (icalExportWriter) Write(w io.Writer, tasks []*model.Task) error {
+	stamp := exportNow().UTC().Format("20060102T150405Z")
+
+	lines := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//taskmd//taskmd export//EN"}
+	for _, task := range tasks {
+		lines = append(lines, icalTodo(task, stamp)...)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, line := range lines {
+		if _, err := io.WriteString(w, icalFold(line)+"\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func icalTodo(task *model.Task, stamp string) []string {
+	lines := []string{
+		"BEGIN:VTODO",
+		"UID:" + icalEscape(task.ID) + "@taskmd",
+		"DTSTAMP:" + stamp,
+		"SUMMARY:" + icalEscape(task.Title),
+	}
+	if !task.Created.IsZero() {
+		lines = append(lines, "CREATED:"+task.Created.UTC().Format("20060102T150405Z"))
+	}
+	if !task.Start.IsZero() {
+		lines = append(lines, "DTSTART;VALUE=DATE:"+task.Start.Format("20060102"))
+	}
+	if !task.Due.IsZero() {
+		lines = append(lines, "DUE;VALUE=DATE:"+task.Due.Format("20060102"))
+	}
+	if s, ok := icalStatus[task.Status]; ok {
+		lines = append(lines, "STATUS:"+s)
+	}
+	if p, ok := icalPriority[task.Priority]; ok {
+		lines = append(lines, "PRIORITY:"+p)
+	}
+	if len(task.Tags) > 0 {
+		tags := make([]string, len(task.Tags))
+		for i, tag := range task.Tags {
+			tags[i] = icalEscape(tag)
+		}
+		lines = append(lines, "CATEGORIES:"+strings.Join(tags, ","))
+	}
+	if body := strings.TrimSpace(task.Body); body != "" {
+		lines = append(lines, "DESCRIPTION:"+icalEscape(body))
+	}
+	if task.Parent != "" {
+		lines = append(lines, "RELATED-TO:"+icalEscape(task.Parent)+"@taskmd")
+	}
+	return append(lines, "END:VTODO")
+}
+
+// icalEscape escapes a TEXT value.
+func icalEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// icalFold splits a content line into lines of at most 75 octets, continuing
+// each with a leading space. Multi-byte characters are never split.
+func icalFold(line string) string {
+	const limit = 75
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
+
+// todoTxtExportWriter writes one todo.txt line per task. The group becomes a
+// +project, tags become @contexts, and id: and dep: keep the dependency graph.
+// Due and start dates use the common due: and t: (threshold) extensions.
+type todoTxtExportWriter struct{}
+
+var todoTxtPriority = map[model.Priority]string{
+	model.PriorityCritical: "A",
+	model.PriorityHigh:     "B",
+	model.PriorityMedium:   "C",
+	model.PriorityLow:      "D",
+}
+
+func (todoTxtExportWriter) Write(w io.Writer, tasks []*model.Task) error {
+	for _, task := range tasks {
+		if _, err := fmt.Fprintln(w, todoTxtLine(task)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func todoTxtLine(task *model.Task) string {
+	var parts []string
+	if task.Status == model.StatusCompleted {
+		parts = append(parts, "x")
+	}
+	if p, ok := todoTxtPriority[task.Priority]; ok {
+		parts = append(parts, "("+p+")")
+	}
+	// todo.txt only allows a creation date on a done task after its
+	// completion date, which taskmd does not record.
+	if !task.Created.IsZero() && task.Status != model.StatusCompleted {
+		parts = append(parts, task.Created.Format("2006-01-02"))
+	}
+	parts = append(parts, strings.Join(strings.Fields(task.Title), " "))
+	if task.Group != "" {
+		parts = append(parts, "+"+todoTxtWord(task.Group))
+	}
+	for _, tag := range task.Tags {
+		parts = append(parts, "@"+todoTxtWord(tag))
+	}
+	parts = append(parts, "id:"+task.ID)
+	if len(task.Dependencies) > 0 {
+		parts = append(parts, "dep:"+strings.Join(task.Dependencies, ","))
+	}
+	if task.Owner != "" {
+		parts = append(parts, "owner:"+todoTxtWord(task.Owner))
+	}
+	if !task.Due.IsZero() {
+		parts = append(parts, "due:"+formatDate(task.Due))
+	}
+	if !task.Start.IsZero() {
+		parts = append(parts, "t:"+formatDate(task.Start))
+	}
+	return strings.Join(parts, " ")
+}
+
+// todoTxtWord joins the words of s with hyphens so it stays one token.
+func todoTxtWord(s string) string {
+	return strings.Join(strings.Fields(s), "-")
+}
+
+// githubExportWriter writes a JSON array of bodies for GitHub's "create an
+// issue" endpoint (POST /repos/{owner}/{repo}/issues).
+type githubExportWriter struct{}
+
+type githubIssueRequest struct {
+	Title     string   `json:"title"`
+	Body      string   `json:"body,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+}
+
+func (githubExportWriter) Write(w io.Writer, tasks []*model.Task) error {
+	issues := make([]githubIssueRequest, 0, len(tasks))
+	for _, task := range tasks {
+		issue := githubIssueRequest{
+			Title:  task.Title,
+			Body:   strings.TrimSpace(task.Body),
+			Labels: task.Tags,
+		}
+		if task.Owner != "" {
+			issue.Assignees = []string{task.Owner}
+		}
+		issues = append(issues, issue)
+	}
+	return WriteJSON(w, issues)
+}
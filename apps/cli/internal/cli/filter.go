@@ -8,9 +8,10 @@ import (
 // filterCriteria represents a single filter condition (kept for test compat).
 type filterCriteria = filter.Criteria
 
-// applyFilters delegates to the shared filter package.
-func applyFilters(tasks []*model.Task, filterExprs []string) ([]*model.Task, error) {
-	return filter.Apply(tasks, filterExprs)
+// applyFilters delegates to the shared filter package. Dependencies on
+// archivedIDs count as met.
+func applyFilters(tasks []*model.Task, filterExprs []string, archivedIDs []string) ([]*model.Task, error) {
+	return filter.ApplyEnv(tasks, filterExprs, filter.NewEnv(tasks).WithArchived(archivedIDs))
 }
 
 // matchesAllFilters is kept for backward-compatible tests.
@@ -74,7 +74,7 @@ func TestApplyFilters(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := applyFilters(tasks, tt.filters)
+			result, err := applyFilters(tasks, tt.filters, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("applyFilters() error = %v, wantErr %v", err, tt.wantErr)
 				return
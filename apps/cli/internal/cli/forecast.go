@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/driangle/taskmd/apps/cli/internal/forecast"
+)
+
+var (
+	forecastParent      string
+	forecastFilters     []string
+	forecastWeeks       int
+	forecastSimulations int
+	forecastSeed        int64
+	forecastSource      string
+	forecastFormat      string
+)
+
+var forecastCmd = &cobra.Command{
+	Use:        "forecast [directory]",
+	SuggestFor: []string{"eta", "burndown", "estimate"},
+	Short:      "Forecast completion dates from past throughput",
+	Long: `Forecast estimates when the remaining work will be done.
+
+Weekly throughput is measured from completion history: the completed
+timestamp in frontmatter, commits that set a task's status to completed
+(git), or the last worklog entry of completed tasks. A Monte Carlo simulation then replays randomly sampled past weeks over
+the remaining tasks and reports the dates by which 50% (P50) and 85% (P85)
+of the simulated futures were finished.
+
+In each simulated week, the sampled number of tasks is finished. Every task
+counts as one, whatever its effort. A task can only be finished after its
+dependencies, but may follow them within the same week if capacity is left;
+when more tasks are ready than capacity allows, those on or near the
+critical path go first.
+
+The forecast covers every task by default, or --parent and all of its
+subtasks, narrowed by --filter. Open dependencies outside that scope are
+included, since they have to finish first.
+
+JSON output adds the weekly burndown and the full outcome distribution, for
+charting.
+
+Examples:
+  taskmd forecast
+  taskmd forecast --parent 040
+  taskmd forecast --filter tag=mvp --weeks 8
+  taskmd forecast --source worklog
+  taskmd forecast --format json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runForecast,
+}
+
+func init() {
+	rootCmd.AddCommand(forecastCmd)
+
+	forecastCmd.Flags().StringVar(&forecastParent, "parent", "", "forecast only this task and its subtasks")
+	forecastCmd.Flags().StringArrayVar(&forecastFilters, "filter", []string{}, "filter expression (can specify multiple times for AND conditions)")
+	forecastCmd.Flags().IntVar(&forecastWeeks, "weeks", forecast.DefaultWeeks, "weeks of history to measure throughput over")
+	forecastCmd.Flags().IntVar(&forecastSimulations, "simulations", forecast.DefaultSimulations, "number of Monte Carlo runs")
+	forecastCmd.Flags().Int64Var(&forecastSeed, "seed", 0, "random seed for reproducible results (0 = random)")
+	forecastCmd.Flags().StringVar(&forecastSource, "source", forecast.SourceAuto, "completion history source (auto, frontmatter, git, worklog)")
+	forecastCmd.Flags().StringVar(&forecastFormat, "format", "text", "output format (text, json, yaml)")
+}
+
+func runForecast(_ *cobra.Command, args []string) error {
+	if err := ValidateFormat(forecastFormat, []string{"text", "json", "yaml"}); err != nil {
+		return err
+	}
+
+	flags := GetGlobalFlags()
+	scanDir := ResolveScanDir(args)
+	result, err := newScanner(scanDir, flags).Scan()
+	if err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+
+	scope, err := forecast.Scope(result.Tasks, forecastParent, forecastFilters)
+	if err != nil {
+		return err
+	}
+	completions, err := forecast.CompletionDates(result.Tasks, scanDir, forecastSource)
+	if err != nil {
+		return err
+	}
+
+	res, err := forecast.Run(scope, completions, forecast.Options{
+		Weeks:       forecastWeeks,
+		Simulations: forecastSimulations,
+		Seed:        forecastSeed,
+		Effort:      loadEffortWeights(),
+	})
+	if err != nil {
+		return err
+	}
+
+	switch forecastFormat {
+	case "json":
+		return WriteJSON(os.Stdout, res)
+	case "yaml":
+		return WriteYAML(os.Stdout, res)
+	default:
+		printForecast(res)
+		return nil
+	}
+}
+
+func printForecast(res *forecast.Result) {
+	r := getRenderer()
+
+	scope := "all tasks"
+	if forecastParent != "" {
+		scope = "task " + formatTaskID(forecastParent, r) + " and its subtasks"
+	}
+	fmt.Printf("%s %d open (%s)\n", formatLabel("Remaining:", r), res.Remaining, scope)
+	fmt.Printf("%s %.1f tasks/week (%d completed in the last %d weeks)\n",
+		formatLabel("Throughput:", r), res.Throughput, res.Completed, res.Weeks)
+
+	if res.Remaining == 0 {
+		fmt.Println(formatSuccess("Nothing left to do.", r))
+		return
+	}
+	fmt.Println()
+	for _, p := range []forecast.Percentile{res.P50, res.P85} {
+		fmt.Printf("%s %s %s\n", formatLabel(fmt.Sprintf("P%d:", p.Percent), r), p.Date,
+			formatDim(fmt.Sprintf("(%s)", pluralWeeks(p.Weeks)), r))
+	}
+	fmt.Println(formatDim(fmt.Sprintf("Based on %d simulations.", res.Simulations), r))
+}
+
+func pluralWeeks(n int) string {
+	if n == 1 {
+		return "1 week"
+	}
+	return fmt.Sprintf("%d weeks", n)
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/forecast"
+)
+
+func resetForecastFlags() {
+	forecastParent = ""
+	forecastFilters = []string{}
+	forecastWeeks = forecast.DefaultWeeks
+	forecastSimulations = 500
+	forecastSeed = 1
+	forecastSource = forecast.SourceWorklog
+	forecastFormat = "text"
+}
+
+func captureForecastOutput(t *testing.T, args []string) (string, error) {
+	t.Helper()
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runForecast(forecastCmd, args)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	return buf.String(), err
+}
+
+// createForecastTestFiles writes two completed tasks with worklogs from the
+// last two weeks and three open ones, two of them under parent 010.
+func createForecastTestFiles(t *testing.T) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"001-done.md":  "---\nid: \"001\"\ntitle: Done one\nstatus: completed\n---\n",
+		"002-done.md":  "---\nid: \"002\"\ntitle: Done two\nstatus: completed\n---\n",
+		"010-epic.md":  "---\nid: \"010\"\ntitle: Epic\nstatus: pending\n---\n",
+		"011-child.md": "---\nid: \"011\"\ntitle: Child\nstatus: pending\nparent: \"010\"\n---\n",
+		"020-other.md": "---\nid: \"020\"\ntitle: Other\nstatus: pending\n---\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".worklogs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for i, id := range []string{"001", "002"} {
+		at := time.Now().UTC().AddDate(0, 0, -3-7*i).Format(time.RFC3339)
+		entry := "## " + at + "\n\nFinished.\n"
+		if err := os.WriteFile(filepath.Join(tmpDir, ".worklogs", id+".md"), []byte(entry), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tmpDir
+}
+
+func TestForecast_Text(t *testing.T) {
+	taskDir = createForecastTestFiles(t)
+	resetForecastFlags()
+	forecastParent = "010"
+
+	output, err := captureForecastOutput(t, nil)
+	if err != nil {
+		t.Fatalf("forecast failed: %v", err)
+	}
+
+	for _, want := range []string{"Remaining: 2 open", "and its subtasks", "2 completed in the last 12 weeks", "P50:", "P85:"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestForecast_JSON(t *testing.T) {
+	taskDir = createForecastTestFiles(t)
+	resetForecastFlags()
+	forecastFormat = "json"
+	forecastWeeks = 4
+
+	output, err := captureForecastOutput(t, nil)
+	if err != nil {
+		t.Fatalf("forecast failed: %v", err)
+	}
+
+	var res forecast.Result
+	if err := json.Unmarshal([]byte(output), &res); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, output)
+	}
+	if res.Remaining != 3 || res.Completed != 2 || len(res.Burndown) != 4 {
+		t.Errorf("unexpected result: %+v", res)
+	}
+	if res.P85.Weeks < res.P50.Weeks || res.P50.Date == "" {
+		t.Errorf("unexpected percentiles: %+v %+v", res.P50, res.P85)
+	}
+}
+
+func TestForecast_NoHistory(t *testing.T) {
+	taskDir = createForecastTestFiles(t)
+	resetForecastFlags()
+	os.RemoveAll(filepath.Join(taskDir, ".worklogs"))
+
+	_, err := captureForecastOutput(t, nil)
+	if err == nil || !strings.Contains(err.Error(), "no completed tasks") {
+		t.Errorf("expected a no-history error, got %v", err)
+	}
+}
+
+func TestForecast_UnknownParent(t *testing.T) {
+	taskDir = createForecastTestFiles(t)
+	resetForecastFlags()
+	forecastParent = "999"
+
+	_, err := captureForecastOutput(t, nil)
+	if err == nil || !strings.Contains(err.Error(), "parent task 999 not found") {
+		t.Errorf("expected an unknown parent error, got %v", err)
+	}
+}
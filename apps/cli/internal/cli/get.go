@@ -8,14 +8,16 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
 	"github.com/driangle/taskmd/apps/cli/internal/graph"
+	"github.com/driangle/taskmd/apps/cli/internal/history"
 	"github.com/driangle/taskmd/apps/cli/internal/model"
-	"github.com/driangle/taskmd/apps/cli/internal/scanner"
 	"github.com/driangle/taskmd/apps/cli/internal/taskcontext"
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
 	"github.com/driangle/taskmd/apps/cli/internal/worklog"
 )
 
@@ -88,7 +90,7 @@ func runGet(cmd *cobra.Command, args []string) error {
 
 	scanDir := ResolveScanDir(nil)
 
-	taskScanner := scanner.NewScanner(scanDir, flags.Verbose, flags.IgnoreDirs)
+	taskScanner := newScanner(scanDir, flags)
 	result, err := taskScanner.Scan()
 	if err != nil {
 		return fmt.Errorf("scan failed: %w", err)
@@ -120,7 +122,12 @@ func runGet(cmd *cobra.Command, args []string) error {
 
 	wlInfo := loadWorklogInfo(task, scanDir)
 
-	return outputGet(task, depInfo, ctxFiles, wlInfo, getFormat)
+	var hist []history.Entry
+	if getFormat == "json" || getFormat == "yaml" {
+		hist = loadTaskHistory(task, scanDir)
+	}
+
+	return outputGet(task, depInfo, ctxFiles, wlInfo, hist, getFormat)
 }
 
 // worklogInfo holds optional worklog metadata for display.
@@ -398,14 +405,14 @@ func buildDependencyInfo(task *model.Task, allTasks []*model.Task) dependencyInf
 }
 
 // outputGet routes to the appropriate formatter.
-func outputGet(task *model.Task, deps dependencyInfo, ctxFiles []taskcontext.FileEntry, wl *worklogInfo, format string) error {
+func outputGet(task *model.Task, deps dependencyInfo, ctxFiles []taskcontext.FileEntry, wl *worklogInfo, hist []history.Entry, format string) error {
 	switch format {
 	case "text":
 		return outputGetText(task, deps, ctxFiles, wl, os.Stdout)
 	case "json":
-		return outputGetJSON(task, deps, ctxFiles, wl, os.Stdout)
+		return outputGetJSON(task, deps, ctxFiles, wl, hist, os.Stdout)
 	case "yaml":
-		return outputGetYAML(task, deps, ctxFiles, wl, os.Stdout)
+		return outputGetYAML(task, deps, ctxFiles, wl, hist, os.Stdout)
 	default:
 		return fmt.Errorf("unsupported format: %s (supported: text, json, yaml)", format)
 	}
@@ -426,6 +433,12 @@ func outputGetText(task *model.Task, deps dependencyInfo, ctxFiles []taskcontext
 	if !task.Created.IsZero() {
 		fmt.Fprintf(w, "%s %s\n", formatLabel("Created:", r), task.Created.Format("2006-01-02"))
 	}
+	if !task.Started.IsZero() {
+		fmt.Fprintf(w, "%s %s\n", formatLabel("Started:", r), task.Started.Format("2006-01-02 15:04"))
+	}
+	if !task.Completed.IsZero() {
+		fmt.Fprintf(w, "%s %s\n", formatLabel("Completed:", r), task.Completed.Format("2006-01-02 15:04"))
+	}
 	fmt.Fprintf(w, "%s %s\n", formatLabel("File:", r), formatDim(task.FilePath, r))
 	printWorklogInfo(w, wl, r)
 	printDescription(w, task.Body)
@@ -527,12 +540,15 @@ type getOutput struct {
 	Tags         []string                `json:"tags" yaml:"tags"`
 	Parent       *depEntry               `json:"parent,omitempty" yaml:"parent,omitempty"`
 	Created      string                  `json:"created,omitempty" yaml:"created,omitempty"`
+	Started      string                  `json:"started,omitempty" yaml:"started,omitempty"`
+	Completed    string                  `json:"completed,omitempty" yaml:"completed,omitempty"`
 	FilePath     string                  `json:"file_path" yaml:"file_path"`
 	Content      string                  `json:"content" yaml:"content"`
 	Dependencies getDepsJSON             `json:"dependencies" yaml:"dependencies"`
 	Children     []depEntry              `json:"children,omitempty" yaml:"children,omitempty"`
 	ContextFiles []taskcontext.FileEntry `json:"context_files,omitempty" yaml:"context_files,omitempty"`
 	Worklog      *worklogInfo            `json:"worklog,omitempty" yaml:"worklog,omitempty"`
+	History      []history.Entry         `json:"history,omitempty" yaml:"history,omitempty"`
 }
 
 type getDepsJSON struct {
@@ -540,28 +556,31 @@ type getDepsJSON struct {
 	Blocks    []depEntry `json:"blocks" yaml:"blocks"`
 }
 
-func buildGetOutput(task *model.Task, deps dependencyInfo, ctxFiles []taskcontext.FileEntry, wl *worklogInfo) getOutput {
+func buildGetOutput(task *model.Task, deps dependencyInfo, ctxFiles []taskcontext.FileEntry, wl *worklogInfo, hist []history.Entry) getOutput {
 	created := ""
 	if !task.Created.IsZero() {
 		created = task.Created.Format("2006-01-02")
 	}
 	out := getOutput{
-		ID:       task.ID,
-		Title:    task.Title,
-		Status:   string(task.Status),
-		Priority: string(task.Priority),
-		Effort:   string(task.Effort),
-		Tags:     task.Tags,
-		Parent:   deps.Parent,
-		Created:  created,
-		FilePath: task.FilePath,
-		Content:  strings.TrimSpace(task.Body),
+		ID:        task.ID,
+		Title:     task.Title,
+		Status:    string(task.Status),
+		Priority:  string(task.Priority),
+		Effort:    string(task.Effort),
+		Tags:      task.Tags,
+		Parent:    deps.Parent,
+		Created:   created,
+		Started:   formatTimestamp(task.Started),
+		Completed: formatTimestamp(task.Completed),
+		FilePath:  task.FilePath,
+		Content:   strings.TrimSpace(task.Body),
 		Dependencies: getDepsJSON{
 			DependsOn: deps.DependsOn,
 			Blocks:    deps.Blocks,
 		},
 		Children: deps.Children,
 		Worklog:  wl,
+		History:  hist,
 	}
 	if len(ctxFiles) > 0 {
 		out.ContextFiles = ctxFiles
@@ -569,12 +588,21 @@ func buildGetOutput(task *model.Task, deps dependencyInfo, ctxFiles []taskcontex
 	return out
 }
 
-func outputGetJSON(task *model.Task, deps dependencyInfo, ctxFiles []taskcontext.FileEntry, wl *worklogInfo, w io.Writer) error {
-	return WriteJSON(w, buildGetOutput(task, deps, ctxFiles, wl))
+// formatTimestamp formats a started or completed timestamp, or "" when it
+// is unset.
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(taskfile.TimestampLayout)
+}
+
+func outputGetJSON(task *model.Task, deps dependencyInfo, ctxFiles []taskcontext.FileEntry, wl *worklogInfo, hist []history.Entry, w io.Writer) error {
+	return WriteJSON(w, buildGetOutput(task, deps, ctxFiles, wl, hist))
 }
 
-func outputGetYAML(task *model.Task, deps dependencyInfo, ctxFiles []taskcontext.FileEntry, wl *worklogInfo, w io.Writer) error {
-	return WriteYAML(w, buildGetOutput(task, deps, ctxFiles, wl))
+func outputGetYAML(task *model.Task, deps dependencyInfo, ctxFiles []taskcontext.FileEntry, wl *worklogInfo, hist []history.Entry, w io.Writer) error {
+	return WriteYAML(w, buildGetOutput(task, deps, ctxFiles, wl, hist))
 }
 
 // printGetContextFiles appends context file information to the text output.
@@ -6,10 +6,10 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/driangle/taskmd/apps/cli/internal/graph"
 	"github.com/driangle/taskmd/apps/cli/internal/model"
-	"github.com/driangle/taskmd/apps/cli/internal/scanner"
 )
 
 var (
@@ -35,8 +35,10 @@ Supported formats:
   - mermaid: Mermaid diagram syntax (default)
   - dot: Graphviz DOT format
   - ascii: ASCII art tree
-  - json: JSON graph structure
+  - json: JSON graph structure, with per-task slack and the effort-weighted
+    critical path
 
+Filters use the same expression language as list (e.g. "tag=cli or tag=web").
 Multiple --filter flags are combined with AND logic.
 
 Examples:
@@ -66,7 +68,7 @@ func init() {
 	graphCmd.Flags().StringSliceVar(&graphExcludeStatus, "exclude-status", []string{"completed"}, "exclude tasks with status (completed, pending, in-progress, blocked, cancelled)")
 	graphCmd.Flags().BoolVar(&graphAll, "all", false, "include all tasks (overrides --exclude-status)")
 	graphCmd.Flags().StringVarP(&graphOut, "out", "o", "", "write output to file instead of stdout")
-	graphCmd.Flags().StringArrayVar(&graphFilters, "filter", []string{}, "filter tasks (can specify multiple times for AND conditions, e.g., --filter priority=high --filter effort=small)")
+	graphCmd.Flags().StringArrayVar(&graphFilters, "filter", []string{}, "filter expression (can specify multiple times for AND conditions, e.g., --filter \"priority>=high\" --filter effort=small)")
 }
 
 //nolint:gocognit,gocyclo,funlen // TODO: refactor to reduce complexity
@@ -86,7 +88,7 @@ func runGraph(cmd *cobra.Command, args []string) error {
 	scanDir := ResolveScanDir(args)
 
 	// Create scanner and scan for tasks
-	taskScanner := scanner.NewScanner(scanDir, flags.Verbose, flags.IgnoreDirs)
+	taskScanner := newScanner(scanDir, flags)
 	result, err := taskScanner.Scan()
 	if err != nil {
 		return fmt.Errorf("scan failed: %w", err)
@@ -107,7 +109,7 @@ func runGraph(cmd *cobra.Command, args []string) error {
 
 	// Apply --filter flags
 	if len(graphFilters) > 0 {
-		tasks, err = applyFilters(tasks, graphFilters)
+		tasks, err = applyFilters(tasks, graphFilters, result.ArchivedIDs)
 		if err != nil {
 			return fmt.Errorf("filter error: %w", err)
 		}
@@ -151,8 +153,9 @@ func runGraph(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Build graph
-	g := graph.NewGraph(tasks)
+	// Build graph; dependencies on archived tasks resolve to placeholders
+	g := graph.NewGraph(tasks).WithArchived(result.ArchivedIDs)
+	g.Effort = loadEffortWeights()
 
 	// Filter graph based on flags
 	if graphRoot != "" {
@@ -277,3 +280,10 @@ func runGraph(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// loadEffortWeights reads the hours per effort level from the effort section
+// of .taskmd.yaml. Malformed entries are reported by `taskmd validate`.
+func loadEffortWeights() graph.EffortWeights {
+	w, _ := graph.ParseEffortWeights(viper.Get("effort"))
+	return w
+}
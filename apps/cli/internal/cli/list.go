@@ -4,15 +4,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
 	"github.com/driangle/taskmd/apps/cli/internal/model"
-	"github.com/driangle/taskmd/apps/cli/internal/scanner"
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
 )
 
 var (
@@ -20,6 +20,7 @@ var (
 	listFilters []string
 	listSort    string
 	listColumns string
+	listView    string
 )
 
 // listCmd represents the list command
@@ -34,16 +35,26 @@ with task frontmatter. You can specify a different directory to scan.
 
 Output formats: table (default), json, yaml
 
-Multiple --filter flags are combined with AND logic.
+Filters are expressions such as status!=completed, priority>=high,
+"tag in (cli, web)" or "not blocked", combined with and, or, not and
+parentheses. Multiple --filter flags are combined with AND logic.
+
+Use --view to apply a saved view from the views section of .taskmd.yaml.
+Explicit --sort and --columns override the view; --filter adds to its filters.
 
 Examples:
   taskmd list
   taskmd list ./tasks
   taskmd list --filter status=pending
   taskmd list --filter status=pending --filter priority=high
+  taskmd list --filter "status!=completed and (tag=cli or tag=web)"
+  taskmd list --filter "created>=2026-09-01 and owner=al*"
   taskmd list --sort priority
+  taskmd list --filter overdue --sort due --columns id,title,due,owner
+  taskmd list --filter "due<=today+7d and status!=completed" --sort due
   taskmd list --columns id,title,deps
-  taskmd list --format json`,
+  taskmd list --format json
+  taskmd list --view my-backlog`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runList,
 }
@@ -52,9 +63,10 @@ func init() {
 	rootCmd.AddCommand(listCmd)
 
 	listCmd.Flags().StringVar(&listFormat, "format", "table", "output format (table, json, yaml)")
-	listCmd.Flags().StringArrayVar(&listFilters, "filter", []string{}, "filter tasks (can specify multiple times for AND conditions, e.g., --filter status=pending --filter priority=high)")
-	listCmd.Flags().StringVar(&listSort, "sort", "", "sort by field (id, title, status, priority, effort, created)")
+	listCmd.Flags().StringArrayVar(&listFilters, "filter", []string{}, "filter expression (can specify multiple times for AND conditions, e.g., --filter \"priority>=high and not blocked\")")
+	listCmd.Flags().StringVar(&listSort, "sort", "", "sort by field (id, title, status, priority, effort, created, due)")
 	listCmd.Flags().StringVar(&listColumns, "columns", "id,title,status,priority,file", "comma-separated list of columns to display")
+	listCmd.Flags().StringVar(&listView, "view", "", "apply a saved view from .taskmd.yaml")
 }
 
 func runList(cmd *cobra.Command, args []string) error {
@@ -64,7 +76,7 @@ func runList(cmd *cobra.Command, args []string) error {
 	debugLog("scan directory: %s", scanDir)
 
 	// Create scanner and scan for tasks
-	taskScanner := scanner.NewScanner(scanDir, flags.Verbose, flags.IgnoreDirs)
+	taskScanner := newScanner(scanDir, flags)
 	result, err := taskScanner.Scan()
 	if err != nil {
 		return fmt.Errorf("scan failed: %w", err)
@@ -85,19 +97,24 @@ func runList(cmd *cobra.Command, args []string) error {
 		fmt.Fprintln(os.Stderr)
 	}
 
-	debugLog("format: %s, sort: %q, filters: %v", listFormat, listSort, listFilters)
+	opts, err := resolveListOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	debugLog("format: %s, sort: %q, filters: %v", listFormat, opts.sort, opts.filters)
 
 	// Apply filters (multiple filters are AND'ed together)
-	if len(listFilters) > 0 {
-		tasks, err = applyFilters(tasks, listFilters)
+	if len(opts.filters) > 0 {
+		tasks, err = applyFilters(tasks, opts.filters, result.ArchivedIDs)
 		if err != nil {
 			return fmt.Errorf("filter error: %w", err)
 		}
 	}
 
 	// Apply sorting
-	if listSort != "" {
-		if err := sortTasks(tasks, listSort); err != nil {
+	if opts.sort != "" {
+		if err := sortTasks(tasks, opts.sort); err != nil {
 			return fmt.Errorf("sort error: %w", err)
 		}
 	}
@@ -109,55 +126,48 @@ func runList(cmd *cobra.Command, args []string) error {
 	case "yaml":
 		return outputYAML(tasks)
 	case "table":
-		return outputTable(tasks, listColumns)
+		return outputTable(tasks, opts.columns)
 	default:
 		return ValidateFormat(listFormat, []string{"table", "json", "yaml"})
 	}
 }
 
+// listOptions holds the effective filters, sort and columns after applying --view.
+type listOptions struct {
+	filters []string
+	sort    string
+	columns string
+}
+
+// resolveListOptions merges the selected view with explicit flags.
+// View filters are AND'ed with --filter; explicit --sort and --columns win.
+func resolveListOptions(cmd *cobra.Command) (listOptions, error) {
+	opts := listOptions{filters: listFilters, sort: listSort, columns: listColumns}
+	if listView == "" {
+		return opts, nil
+	}
+
+	view, err := resolveView(listView)
+	if err != nil {
+		return opts, err
+	}
+
+	opts.filters = append(append([]string{}, view.Filters...), listFilters...)
+	if opts.sort == "" {
+		opts.sort = view.Sort
+	}
+	if cols := view.ColumnsString(); cols != "" && !cmd.Flags().Changed("columns") {
+		opts.columns = cols
+	}
+	return opts, nil
+}
+
 // sortTasks sorts tasks by the specified field
 func sortTasks(tasks []*model.Task, sortField string) error {
-	switch sortField {
-	case "id":
-		sort.Slice(tasks, func(i, j int) bool {
-			return tasks[i].ID < tasks[j].ID
-		})
-	case "title":
-		sort.Slice(tasks, func(i, j int) bool {
-			return tasks[i].Title < tasks[j].Title
-		})
-	case "status":
-		sort.Slice(tasks, func(i, j int) bool {
-			return tasks[i].Status < tasks[j].Status
-		})
-	case "priority":
-		priorityOrder := map[model.Priority]int{
-			model.PriorityCritical: 0,
-			model.PriorityHigh:     1,
-			model.PriorityMedium:   2,
-			model.PriorityLow:      3,
-		}
-		sort.Slice(tasks, func(i, j int) bool {
-			return priorityOrder[tasks[i].Priority] < priorityOrder[tasks[j].Priority]
-		})
-	case "effort":
-		effortOrder := map[model.Effort]int{
-			model.EffortSmall:  0,
-			model.EffortMedium: 1,
-			model.EffortLarge:  2,
-		}
-		sort.Slice(tasks, func(i, j int) bool {
-			return effortOrder[tasks[i].Effort] < effortOrder[tasks[j].Effort]
-		})
-	case "created":
-		sort.Slice(tasks, func(i, j int) bool {
-			return tasks[i].Created.Before(tasks[j].Created)
-		})
-	default:
+	if !contains(validSortFields, sortField) {
 		return invalidValueError("sort field", sortField, validSortFields)
 	}
-
-	return nil
+	return model.SortTasks(tasks, sortField)
 }
 
 // outputJSON outputs tasks as JSON
@@ -223,6 +233,11 @@ func colorizeColumn(task *model.Task, column string, r *lipgloss.Renderer) strin
 		return formatPriority(value, r)
 	case "effort":
 		return formatEffort(value, r)
+	case "due":
+		if task.IsOverdue(time.Now()) {
+			return formatError(value, r)
+		}
+		return value
 	default:
 		return value
 	}
@@ -249,10 +264,17 @@ func getColumnValue(task *model.Task, column string) string {
 	}
 	switch column {
 	case "created":
-		if task.Created.IsZero() {
-			return ""
-		}
-		return task.Created.Format("2006-01-02")
+		return formatDate(task.Created)
+	case "due":
+		return formatDate(task.Due)
+	case "start":
+		return formatDate(task.Start)
+	case "scheduled":
+		return formatDate(task.Scheduled)
+	case "started":
+		return formatDate(task.Started)
+	case "completed":
+		return formatDate(task.Completed)
 	case "deps":
 		return strings.Join(task.Dependencies, ",")
 	case "tags":
@@ -262,6 +284,14 @@ func getColumnValue(task *model.Task, column string) string {
 	}
 }
 
+// formatDate formats a date field as YYYY-MM-DD, or "" when it is unset.
+func formatDate(d time.Time) string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(taskfile.DateLayout)
+}
+
 // getScalarColumnValue returns simple string field values.
 func getScalarColumnValue(task *model.Task, column string) string {
 	switch column {
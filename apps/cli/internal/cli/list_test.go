@@ -96,6 +96,7 @@ func TestGetColumnValue(t *testing.T) {
 func resetListFlags() {
 	listFilters = []string{}
 	listSort = ""
+	listView = ""
 	listColumns = "id,title,status,priority,file"
 	noColor = true
 }
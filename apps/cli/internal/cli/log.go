@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/driangle/taskmd/apps/cli/internal/history"
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+var (
+	logFormat string
+	logFields []string
+)
+
+var logCmd = &cobra.Command{
+	Use:        "log <task-id>",
+	SuggestFor: []string{"history", "blame", "timeline"},
+	Short:      "Show the git history of a task's fields",
+	Long: `Log walks the git history of a task file, following renames, and prints a
+timeline of frontmatter changes: status transitions, owner and priority
+changes and so on, with the date, author and commit of each.
+
+Commits that only edited the task body are left out. Uncommitted changes are
+not shown.
+
+Examples:
+  taskmd log 042
+  taskmd log 042 --field status
+  taskmd log 042 --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLog,
+}
+
+func init() {
+	rootCmd.AddCommand(logCmd)
+
+	logCmd.Flags().StringVar(&logFormat, "format", "text", "output format (text, json, yaml)")
+	logCmd.Flags().StringArrayVar(&logFields, "field", []string{}, "only show changes to this field (repeatable)")
+}
+
+func runLog(_ *cobra.Command, args []string) error {
+	if err := ValidateFormat(logFormat, []string{"text", "json", "yaml"}); err != nil {
+		return err
+	}
+
+	flags := GetGlobalFlags()
+	scanDir := ResolveScanDir(nil)
+	result, err := newScanner(scanDir, flags).Scan()
+	if err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+
+	task := findExactMatch(args[0], result.Tasks)
+	if task == nil {
+		return fmt.Errorf("task not found: %s", args[0])
+	}
+
+	entries, err := history.Log(task.FilePath)
+	if err != nil {
+		return err
+	}
+	entries = filterHistoryFields(entries, logFields)
+
+	switch logFormat {
+	case "json":
+		return WriteJSON(os.Stdout, entries)
+	case "yaml":
+		return WriteYAML(os.Stdout, entries)
+	default:
+		printLog(os.Stdout, task, entries)
+		return nil
+	}
+}
+
+// filterHistoryFields keeps only changes to the given fields, dropping
+// entries left without changes. The entry that created the file is kept.
+func filterHistoryFields(entries []history.Entry, fields []string) []history.Entry {
+	if len(fields) == 0 {
+		return entries
+	}
+	out := make([]history.Entry, 0, len(entries))
+	for _, e := range entries {
+		var changes []history.Change
+		for _, c := range e.Changes {
+			if slices.Contains(fields, c.Field) {
+				changes = append(changes, c)
+			}
+		}
+		if len(changes) > 0 || e.Added {
+			e.Changes = changes
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func printLog(w io.Writer, task *model.Task, entries []history.Entry) {
+	r := getRenderer()
+	fmt.Fprintf(w, "%s %s %s\n", formatLabel("History:", r), formatTaskID(task.ID, r), task.Title)
+
+	if len(entries) == 0 {
+		fmt.Fprintln(w, formatDim("No committed history for this task.", r))
+		return
+	}
+
+	for _, e := range entries {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "%s  %s  %s  %s\n", e.Date.Local().Format("2006-01-02 15:04"),
+			formatDim(e.ShortCommit(), r), e.Author, formatDim(e.Subject, r))
+		if e.Added {
+			created := "created"
+			if c, ok := e.FieldChange("status"); ok {
+				created += " as " + formatStatus(c.To, r)
+			}
+			fmt.Fprintf(w, "  %s\n", created)
+			continue
+		}
+		for _, c := range e.Changes {
+			fmt.Fprintf(w, "  %s %s\n", formatLabel(c.Field+":", r), formatFieldChange(c))
+		}
+	}
+}
+
+func formatFieldChange(c history.Change) string {
+	switch {
+	case c.From == "":
+		return "set to " + c.To
+	case c.To == "":
+		return "removed (was " + c.From + ")"
+	default:
+		return c.From + " → " + c.To
+	}
+}
+
+// loadTaskHistory returns the git history of a task for get's structured
+// output, or nil when it is unavailable (no git, or not in a repository).
+func loadTaskHistory(task *model.Task, scanDir string) []history.Entry {
+	entries, err := history.Log(filepath.Join(scanDir, task.FilePath))
+	if err != nil {
+		return nil
+	}
+	return entries
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func resetLogFlags() {
+	logFormat = "text"
+	logFields = []string{}
+}
+
+func captureLogOutput(t *testing.T, args []string) (string, error) {
+	t.Helper()
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runLog(logCmd, args)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	return buf.String(), err
+}
+
+// createLogTestRepo commits task 001 as pending, then as in-progress under
+// owner bob.
+func createLogTestRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Bob", "GIT_AUTHOR_EMAIL=bob@example.com",
+			"GIT_COMMITTER_NAME=Bob", "GIT_COMMITTER_EMAIL=bob@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(frontmatter string) {
+		t.Helper()
+		content := "---\nid: \"001\"\ntitle: Setup\n" + frontmatter + "---\n# Setup\n"
+		if err := os.WriteFile(filepath.Join(dir, "001-setup.md"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("init", "-q")
+	write("status: pending\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "Add setup task")
+	write("status: in-progress\nowner: bob\n")
+	git("commit", "-q", "-am", "Start setup")
+	return dir
+}
+
+func TestLog_Text(t *testing.T) {
+	taskDir = createLogTestRepo(t)
+	resetLogFlags()
+
+	output, err := captureLogOutput(t, []string{"001"})
+	if err != nil {
+		t.Fatalf("log failed: %v", err)
+	}
+
+	for _, want := range []string{"History: 001 Setup", "Add setup task", "created as pending", "Start setup", "status: pending → in-progress", "owner: set to bob", "Bob"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestLog_FieldFilterJSON(t *testing.T) {
+	taskDir = createLogTestRepo(t)
+	resetLogFlags()
+	logFormat = "json"
+	logFields = []string{"owner"}
+
+	output, err := captureLogOutput(t, []string{"001"})
+	if err != nil {
+		t.Fatalf("log failed: %v", err)
+	}
+
+	var entries []struct {
+		Subject string `json:"subject"`
+		Changes []struct {
+			Field string `json:"field"`
+		} `json:"changes"`
+	}
+	if err := json.Unmarshal([]byte(output), &entries); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, output)
+	}
+	if len(entries) != 2 || len(entries[1].Changes) != 1 || entries[1].Changes[0].Field != "owner" {
+		t.Errorf("expected only the owner change, got %+v", entries)
+	}
+}
+
+func TestLog_TaskNotFound(t *testing.T) {
+	taskDir = createLogTestRepo(t)
+	resetLogFlags()
+
+	_, err := captureLogOutput(t, []string{"999"})
+	if err == nil || !strings.Contains(err.Error(), "task not found") {
+		t.Errorf("expected task not found, got %v", err)
+	}
+}
+
+func TestGet_JSONIncludesHistory(t *testing.T) {
+	tmpDir := createLogTestRepo(t)
+	resetGetFlags()
+	taskDir = tmpDir
+	getFormat = "json"
+
+	output := captureGetOutput(t, "001")
+
+	var result struct {
+		History []struct {
+			Subject string `json:"subject"`
+		} `json:"history"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, output)
+	}
+	if len(result.History) != 2 || result.History[1].Subject != "Start setup" {
+		t.Errorf("expected two history entries, got %+v", result.History)
+	}
+}
@@ -35,6 +35,10 @@ func init() {
 }
 
 func runMcp(_ *cobra.Command, _ []string) error {
-	server := taskmcp.NewServer(Version)
+	server := taskmcp.NewServer(Version, taskmcp.Config{
+		Views:      loadViews(),
+		Next:       loadNextConfig(),
+		Timestamps: *loadTimestampConfig(),
+	})
 	return server.Run(context.Background(), &gomcp.StdioTransport{})
 }
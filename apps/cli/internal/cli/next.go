@@ -7,9 +7,9 @@ import (
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/driangle/taskmd/apps/cli/internal/next"
-	"github.com/driangle/taskmd/apps/cli/internal/scanner"
 )
 
 // Recommendation is re-exported from the shared package.
@@ -21,6 +21,8 @@ var (
 	nextFilters   []string
 	nextQuickWins bool
 	nextCritical  bool
+	nextExplain   bool
+	nextFor       string
 )
 
 var nextCmd = &cobra.Command{
@@ -33,6 +35,13 @@ Tasks are scored based on priority, critical path position, downstream impact,
 and effort. Only actionable tasks (pending or in-progress with all dependencies
 completed) are shown.
 
+Scoring weights and per-tag or per-group boosts can be configured under the
+"next" key in .taskmd.yaml. Use --explain to see how every candidate's score
+was computed.
+
+With --for, tasks already in progress under another owner are skipped, tasks
+owned by that person are preferred, and tasks owned by someone else rank lower.
+
 Output formats: table (default), json, yaml
 
 Examples:
@@ -42,7 +51,9 @@ Examples:
   taskmd next --filter tag=cli
   taskmd next --filter priority=high --format json
   taskmd next --quick-wins
-  taskmd next --critical --limit 1`,
+  taskmd next --critical --limit 1
+  taskmd next --explain
+  taskmd next --for alice`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runNext,
 }
@@ -52,16 +63,26 @@ func init() {
 
 	nextCmd.Flags().StringVar(&nextFormat, "format", "table", "output format (table, json, yaml)")
 	nextCmd.Flags().IntVar(&nextLimit, "limit", 5, "maximum number of recommendations")
-	nextCmd.Flags().StringArrayVar(&nextFilters, "filter", []string{}, "filter tasks (e.g., --filter tag=cli)")
+	nextCmd.Flags().StringArrayVar(&nextFilters, "filter", []string{}, "filter expression (e.g., --filter \"tag=cli or tag=web\")")
 	nextCmd.Flags().BoolVar(&nextQuickWins, "quick-wins", false, "show only quick wins (effort: small)")
 	nextCmd.Flags().BoolVar(&nextCritical, "critical", false, "show only critical path tasks")
+	nextCmd.Flags().StringVar(&nextFor, "for", "", "recommend for this owner (skips others' in-progress tasks)")
+	nextCmd.Flags().BoolVar(&nextExplain, "explain", false, "show the score breakdown for every candidate (ignores --limit)")
+}
+
+// loadNextConfig reads scoring weights and boosts from the next section of
+// .taskmd.yaml. Malformed entries are reported by `taskmd validate`, not here.
+func loadNextConfig() next.Config {
+	cfg, _ := next.ParseConfig(viper.Get("next"))
+	cfg.Effort = loadEffortWeights()
+	return cfg
 }
 
 func runNext(cmd *cobra.Command, args []string) error {
 	flags := GetGlobalFlags()
 	scanDir := ResolveScanDir(args)
 
-	taskScanner := scanner.NewScanner(scanDir, flags.Verbose, flags.IgnoreDirs)
+	taskScanner := newScanner(scanDir, flags)
 	result, err := taskScanner.Scan()
 	if err != nil {
 		return fmt.Errorf("scan failed: %w", err)
@@ -70,11 +91,16 @@ func runNext(cmd *cobra.Command, args []string) error {
 	allTasks := result.Tasks
 	makeFilePathsRelative(allTasks, scanDir)
 
+	scoring := loadNextConfig()
 	recs, err := next.Recommend(allTasks, next.Options{
 		Limit:     nextLimit,
 		Filters:   nextFilters,
 		QuickWins: nextQuickWins,
 		Critical:  nextCritical,
+		For:       nextFor,
+		Scoring:   &scoring,
+		Explain:   nextExplain,
+		Archived:  result.ArchivedIDs,
 	})
 	if err != nil {
 		return err
@@ -86,6 +112,9 @@ func runNext(cmd *cobra.Command, args []string) error {
 	case "yaml":
 		return outputNextYAML(recs)
 	case "table":
+		if nextExplain {
+			return outputNextExplain(recs)
+		}
 		return outputNextTable(recs)
 	default:
 		return ValidateFormat(nextFormat, []string{"table", "json", "yaml"})
@@ -143,3 +172,29 @@ func outputNextTable(recs []Recommendation) error {
 
 	return nil
 }
+
+// outputNextExplain prints each candidate with the points every scoring
+// factor contributed.
+func outputNextExplain(recs []Recommendation) error {
+	r := getRenderer()
+
+	if len(recs) == 0 {
+		fmt.Println("No actionable tasks found.")
+		return nil
+	}
+
+	fmt.Println(formatLabel("Score breakdown:", r))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	defer w.Flush()
+
+	for _, rec := range recs {
+		fmt.Fprintf(w, "\n")
+		fmt.Fprintf(w, "%d. %s %s (score %d)\n", rec.Rank, formatTaskID(rec.ID, r), rec.Title, rec.Score)
+		for _, f := range rec.Breakdown {
+			fmt.Fprintf(w, "     %s\t%+d\n", f.Name, f.Points)
+		}
+	}
+
+	return nil
+}
@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
+	"github.com/spf13/viper"
+
 	"github.com/driangle/taskmd/apps/cli/internal/model"
 	"github.com/driangle/taskmd/apps/cli/internal/next"
 )
@@ -170,6 +173,8 @@ func resetNextFlags() {
 	nextFilters = []string{}
 	nextQuickWins = false
 	nextCritical = false
+	nextExplain = false
+	nextFor = ""
 }
 
 func TestNext_BasicRanking(t *testing.T) {
@@ -245,6 +250,43 @@ func TestNext_BlockedTasksExcluded(t *testing.T) {
 	}
 }
 
+func TestNext_ArchivedDependenciesAreMet(t *testing.T) {
+	tmpDir := createNextTestTaskFiles(t)
+	if err := os.MkdirAll(filepath.Join(tmpDir, "archive"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"001.md", "002.md"} {
+		if err := os.Rename(filepath.Join(tmpDir, name), filepath.Join(tmpDir, "archive", name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resetNextFlags()
+	nextFormat = "json"
+	nextLimit = 20
+	nextFilters = []string{"not blocked"}
+
+	output, err := captureNextOutput(t, []string{tmpDir})
+	if err != nil {
+		t.Fatalf("runNext failed: %v", err)
+	}
+
+	var recs []Recommendation
+	if err := json.Unmarshal([]byte(output), &recs); err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+	got := map[string]bool{}
+	for _, rec := range recs {
+		got[rec.ID] = true
+	}
+	// 003 and 008 depend on archived 001, 004 on archived 002.
+	for _, id := range []string{"003", "004", "008"} {
+		if !got[id] {
+			t.Errorf("expected %s to be recommended, got %v", id, got)
+		}
+	}
+}
+
 func TestNext_CancelledTasksExcluded(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -1315,8 +1357,8 @@ func TestNext_Critical_NoCriticalTasksAvailable(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	// Create a scenario where actionable tasks are NOT on critical path
-	// Critical path: 001 -> 003 (longer chain)
-	// Non-critical: 002 (shorter parallel path)
+	// Critical path: 003 -> 004 (most remaining effort, but 003 is blocked)
+	// Non-critical: 002 (small, parallel)
 	tasks := map[string]string{
 		"001.md": `---
 id: "001"
@@ -1339,7 +1381,7 @@ created: 2026-02-02
 		"003.md": `---
 id: "003"
 title: "Long path intermediate"
-status: completed
+status: blocked
 priority: high
 effort: large
 dependencies: ["001"]
@@ -1348,7 +1390,7 @@ created: 2026-02-03
 		"004.md": `---
 id: "004"
 title: "Long path final"
-status: completed
+status: pending
 priority: high
 effort: large
 dependencies: ["003"]
@@ -1372,13 +1414,63 @@ created: 2026-02-04
 		t.Fatalf("runNext failed: %v", err)
 	}
 
-	// Task 002 is actionable but not on critical path (001->003->004 is longer)
+	// Task 002 is actionable but not on critical path (003->004 has more work left)
 	// So filtering by --critical should show no results
 	if !strings.Contains(output, "No critical path tasks available") {
 		t.Errorf("Expected 'No critical path tasks available' message, got: %s", output)
 	}
 }
 
+func TestNext_Critical_WeightedByEffort(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// A chain of three small tasks (3 x 2h) vs. one large task (16h): the
+	// large task is the critical path. With small weighted at 8h the chain
+	// (24h) takes over.
+	tasks := map[string]string{
+		"001.md": "---\nid: \"001\"\ntitle: \"Chain start\"\nstatus: pending\neffort: small\n---",
+		"002.md": "---\nid: \"002\"\ntitle: \"Chain middle\"\nstatus: pending\neffort: small\ndependencies: [\"001\"]\n---",
+		"003.md": "---\nid: \"003\"\ntitle: \"Chain end\"\nstatus: pending\neffort: small\ndependencies: [\"002\"]\n---",
+		"004.md": "---\nid: \"004\"\ntitle: \"Big job\"\nstatus: pending\neffort: large\n---",
+	}
+	for filename, content := range tasks {
+		if err := os.WriteFile(filepath.Join(tmpDir, filename), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	criticalIDs := func() []string {
+		t.Helper()
+		resetNextFlags()
+		nextFormat = "json"
+		nextCritical = true
+		output, err := captureNextOutput(t, []string{tmpDir})
+		if err != nil {
+			t.Fatalf("runNext failed: %v", err)
+		}
+		var recs []Recommendation
+		if err := json.Unmarshal([]byte(output), &recs); err != nil {
+			t.Fatalf("Failed to parse JSON: %v", err)
+		}
+		var ids []string
+		for _, rec := range recs {
+			ids = append(ids, rec.ID)
+		}
+		return ids
+	}
+
+	if ids := criticalIDs(); !slices.Equal(ids, []string{"004"}) {
+		t.Errorf("expected only 004 on the critical path, got %v", ids)
+	}
+
+	viper.Set("effort", map[string]any{"small": 8})
+	t.Cleanup(func() { viper.Set("effort", nil) })
+
+	if ids := criticalIDs(); !slices.Equal(ids, []string{"001"}) {
+		t.Errorf("expected the actionable chain start 001 on the critical path, got %v", ids)
+	}
+}
+
 func TestNext_Critical_TableFormat(t *testing.T) {
 	tmpDir := createNextTestTaskFiles(t)
 
@@ -1438,3 +1530,153 @@ func TestNext_QuickWins_Ranking(t *testing.T) {
 		}
 	}
 }
+
+// setTestNextConfig installs a next config section in viper for the duration of the test.
+func setTestNextConfig(t *testing.T, cfg map[string]any) {
+	t.Helper()
+	viper.Set("next", cfg)
+	t.Cleanup(func() { viper.Set("next", nil) })
+}
+
+func TestNext_ConfiguredWeights(t *testing.T) {
+	tmpDir := createNextTestTaskFiles(t)
+	setTestNextConfig(t, map[string]any{
+		"weights": map[string]any{
+			"priority_critical": 0,
+			"priority_high":     0,
+			"per_downstream":    100,
+			"downstream_max":    1000,
+		},
+	})
+
+	resetNextFlags()
+	nextFormat = "json"
+
+	output, err := captureNextOutput(t, []string{tmpDir})
+	if err != nil {
+		t.Fatalf("runNext failed: %v", err)
+	}
+
+	var recs []Recommendation
+	if err := json.Unmarshal([]byte(output), &recs); err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+
+	// 007 unblocks 006 and 009, so it outranks the critical-priority 003
+	if len(recs) == 0 || recs[0].ID != "007" {
+		t.Fatalf("Expected 007 first when unblocking is weighted heavily, got %+v", recs)
+	}
+}
+
+func TestNext_TagAndGroupBoosts(t *testing.T) {
+	tmpDir := createNextTestTaskFiles(t)
+	setTestNextConfig(t, map[string]any{
+		"boosts": map[string]any{
+			"tags": map[string]any{"docs": 100},
+		},
+	})
+
+	resetNextFlags()
+	nextFormat = "json"
+
+	output, err := captureNextOutput(t, []string{tmpDir})
+	if err != nil {
+		t.Fatalf("runNext failed: %v", err)
+	}
+
+	var recs []Recommendation
+	if err := json.Unmarshal([]byte(output), &recs); err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+
+	if len(recs) == 0 || recs[0].ID != "005" {
+		t.Fatalf("Expected boosted docs task 005 first, got %+v", recs)
+	}
+	if !slices.Contains(recs[0].Reasons, "boosted tag docs") {
+		t.Errorf("Expected boost reason, got %v", recs[0].Reasons)
+	}
+}
+
+func TestNext_Explain(t *testing.T) {
+	tmpDir := createNextTestTaskFiles(t)
+
+	resetNextFlags()
+	nextLimit = 1
+	nextExplain = true
+
+	output, err := captureNextOutput(t, []string{tmpDir})
+	if err != nil {
+		t.Fatalf("runNext failed: %v", err)
+	}
+
+	if !strings.Contains(output, "Score breakdown:") {
+		t.Errorf("Expected breakdown header, got:\n%s", output)
+	}
+	// Every actionable candidate is explained, regardless of --limit
+	for _, id := range []string{"003", "004", "005", "007", "008"} {
+		if !strings.Contains(output, id) {
+			t.Errorf("Expected candidate %s in explain output, got:\n%s", id, output)
+		}
+	}
+	if !strings.Contains(output, "critical priority") || !strings.Contains(output, "+40") {
+		t.Errorf("Expected critical priority factor with +40, got:\n%s", output)
+	}
+}
+
+func TestNext_ExplainJSONIncludesBreakdown(t *testing.T) {
+	tmpDir := createNextTestTaskFiles(t)
+
+	resetNextFlags()
+	nextFormat = "json"
+	nextExplain = true
+
+	output, err := captureNextOutput(t, []string{tmpDir})
+	if err != nil {
+		t.Fatalf("runNext failed: %v", err)
+	}
+
+	var recs []Recommendation
+	if err := json.Unmarshal([]byte(output), &recs); err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+
+	for _, rec := range recs {
+		total := 0
+		for _, f := range rec.Breakdown {
+			total += f.Points
+		}
+		if total != rec.Score {
+			t.Errorf("Task %s: breakdown sums to %d, score is %d", rec.ID, total, rec.Score)
+		}
+	}
+}
+
+func TestNext_ForOwner(t *testing.T) {
+	tmpDir := createNextTestTaskFiles(t)
+	content, _ := os.ReadFile(filepath.Join(tmpDir, "008.md"))
+	os.WriteFile(filepath.Join(tmpDir, "008.md"), []byte(strings.Replace(string(content), "status: in-progress", "status: in-progress\nowner: bob", 1)), 0644)
+
+	resetNextFlags()
+	nextFormat = "json"
+	nextLimit = 10
+	nextFor = "alice"
+
+	output, err := captureNextOutput(t, []string{tmpDir})
+	if err != nil {
+		t.Fatalf("runNext failed: %v", err)
+	}
+
+	var recs []Recommendation
+	if err := json.Unmarshal([]byte(output), &recs); err != nil {
+		t.Fatalf("Failed to parse JSON: %v", err)
+	}
+
+	for _, rec := range recs {
+		if rec.ID == "008" {
+			t.Errorf("Expected 008 (in progress under bob) to be skipped for alice")
+		}
+	}
+	if len(recs) != 4 {
+		t.Errorf("Expected 4 recommendations, got %d", len(recs))
+	}
+}
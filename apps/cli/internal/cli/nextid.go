@@ -7,7 +7,6 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/driangle/taskmd/apps/cli/internal/nextid"
-	"github.com/driangle/taskmd/apps/cli/internal/scanner"
 )
 
 var nextIDFormat string
@@ -42,7 +41,7 @@ func runNextID(cmd *cobra.Command, args []string) error {
 
 	scanDir := ResolveScanDir(args)
 
-	taskScanner := scanner.NewScanner(scanDir, flags.Verbose, flags.IgnoreDirs)
+	taskScanner := newScanner(scanDir, flags)
 	result, err := taskScanner.Scan()
 	if err != nil {
 		return fmt.Errorf("scan failed: %w", err)
@@ -56,10 +55,12 @@ func runNextID(cmd *cobra.Command, args []string) error {
 		fmt.Fprintln(os.Stderr)
 	}
 
-	ids := make([]string, len(result.Tasks))
-	for i, task := range result.Tasks {
-		ids[i] = task.ID
+	// Archived IDs count as taken so they are never handed out again.
+	ids := make([]string, 0, len(result.Tasks)+len(result.ArchivedIDs))
+	for _, task := range result.Tasks {
+		ids = append(ids, task.ID)
 	}
+	ids = append(ids, result.ArchivedIDs...)
 
 	nextResult := nextid.Calculate(ids)
 
@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/driangle/taskmd/apps/cli/internal/renumber"
+)
+
+var (
+	renumberCompact bool
+	renumberDryRun  bool
+)
+
+var renumberCmd = &cobra.Command{
+	Use:     "renumber <old-id> <new-id>",
+	Aliases: []string{"mv-id"},
+	Short:   "Change a task ID and rewrite all references to it",
+	Long: `Renumber changes a task's ID and rewrites everything that points at it:
+
+  - the task file name (when it starts with the old ID)
+  - the id field in frontmatter
+  - dependencies and parent fields in all other tasks
+  - the .worklogs/<id>.md file
+  - local_id and file_path entries in .taskmd/sync-state/*.yaml
+
+With --compact, every numeric ID in the task directory is renumbered so that
+each ID prefix forms a gap-free sequence starting at 1, preserving order.
+
+All files are staged first and moved into place together; if any step fails
+the original files are restored. Use --dry-run to print a diff instead.
+
+Examples:
+  taskmd renumber 042 107
+  taskmd renumber 042 107 --dry-run
+  taskmd renumber --compact --dry-run
+  taskmd mv-id cli-042 cli-043`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if renumberCompact {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(2)(cmd, args)
+	},
+	RunE: runRenumber,
+}
+
+func init() {
+	rootCmd.AddCommand(renumberCmd)
+
+	renumberCmd.Flags().BoolVar(&renumberCompact, "compact", false, "renumber all tasks to remove gaps")
+	renumberCmd.Flags().BoolVar(&renumberDryRun, "dry-run", false, "show a diff without writing changes")
+}
+
+func runRenumber(_ *cobra.Command, args []string) error {
+	flags := GetGlobalFlags()
+	scanDir := ResolveScanDir(nil)
+
+	taskScanner := newScanner(scanDir, flags)
+	result, err := taskScanner.Scan()
+	if err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+
+	debugLog("scan directory: %s", scanDir)
+	debugLog("found %d task(s)", len(result.Tasks))
+
+	var mapping map[string]string
+	if renumberCompact {
+		mapping = renumber.CompactMapping(result.Tasks, result.ArchivedIDs)
+	} else {
+		if args[0] == args[1] {
+			return fmt.Errorf("old and new IDs are the same: %s", args[0])
+		}
+		mapping = map[string]string{args[0]: args[1]}
+	}
+
+	if len(mapping) == 0 {
+		fmt.Println("Nothing to renumber: IDs are already compact.")
+		return nil
+	}
+
+	plan, err := renumber.BuildPlan(result.Tasks, mapping, renumber.Options{ConfigDir: resolveProjectRoot(), Archived: result.ArchivedIDs})
+	if err != nil {
+		return err
+	}
+
+	absScanDir, err := filepath.Abs(scanDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve scan directory: %w", err)
+	}
+
+	if renumberDryRun {
+		printRenumberDiff(plan, absScanDir)
+		r := getRenderer()
+		fmt.Println("\n" + formatWarning("Dry run — no changes made.", r))
+		return nil
+	}
+
+	if err := renumber.Apply(plan); err != nil {
+		return err
+	}
+
+	printRenumberSummary(plan)
+	return nil
+}
+
+func sortedMappingKeys(mapping map[string]string) []string {
+	keys := make([]string, 0, len(mapping))
+	for k := range mapping {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func printRenumberSummary(plan *renumber.Plan) {
+	r := getRenderer()
+	for _, oldID := range sortedMappingKeys(plan.Mapping) {
+		fmt.Printf("  %s -> %s\n", formatTaskID(oldID, r), formatTaskID(plan.Mapping[oldID], r))
+	}
+	fmt.Println(formatSuccess(fmt.Sprintf("Renumbered %d task(s), %d file(s) changed.", len(plan.Mapping), len(plan.Changes)), r))
+}
+
+func printRenumberDiff(plan *renumber.Plan, absScanDir string) {
+	r := getRenderer()
+	rel := func(p string) string {
+		if abs, err := filepath.Abs(p); err == nil {
+			if rp, err := filepath.Rel(absScanDir, abs); err == nil {
+				return rp
+			}
+		}
+		return p
+	}
+
+	for _, c := range plan.Changes {
+		fmt.Printf("%s %s\n", formatDim("---", r), rel(c.OldPath))
+		fmt.Printf("%s %s\n", formatDim("+++", r), rel(c.NewPath))
+		for _, line := range c.Diff() {
+			if line[0] == '-' {
+				fmt.Println(formatError(line, r))
+			} else {
+				fmt.Println(formatSuccess(line, r))
+			}
+		}
+		fmt.Println()
+	}
+	fmt.Printf("%d task(s) would be renumbered, %d file(s) changed.\n", len(plan.Mapping), len(plan.Changes))
+}
@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func createRenumberTestFiles(t *testing.T) string {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"001-setup.md": `---
+id: "001"
+title: "Setup"
+status: completed
+---
+`,
+		"005-feature.md": `---
+id: "005"
+title: "Feature"
+status: pending
+dependencies: ["001"]
+---
+`,
+		"009-polish.md": `---
+id: "009"
+title: "Polish"
+status: pending
+dependencies: ["005"]
+---
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create test file %s: %v", name, err)
+		}
+	}
+	return tmpDir
+}
+
+func resetRenumberFlags() {
+	renumberCompact = false
+	renumberDryRun = false
+	taskDir = "."
+}
+
+func captureRenumberOutput(t *testing.T, args []string) (string, error) {
+	t.Helper()
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runRenumber(renumberCmd, args)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	return buf.String(), err
+}
+
+func TestRenumber_SingleTask(t *testing.T) {
+	tmpDir := createRenumberTestFiles(t)
+	resetRenumberFlags()
+	taskDir = tmpDir
+
+	output, err := captureRenumberOutput(t, []string{"005", "107"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "Renumbered 1 task(s)") {
+		t.Errorf("expected summary, got: %s", output)
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "107-feature.md")); err != nil {
+		t.Errorf("expected renamed file: %v", err)
+	}
+	polish, _ := os.ReadFile(filepath.Join(tmpDir, "009-polish.md"))
+	if !strings.Contains(string(polish), `dependencies: ["107"]`) {
+		t.Errorf("expected dependency rewritten, got:\n%s", polish)
+	}
+}
+
+func TestRenumber_DryRun(t *testing.T) {
+	tmpDir := createRenumberTestFiles(t)
+	resetRenumberFlags()
+	taskDir = tmpDir
+	renumberDryRun = true
+
+	output, err := captureRenumberOutput(t, []string{"005", "107"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{"005-feature.md", "107-feature.md", `-id: "005"`, `+id: "107"`, "Dry run"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "005-feature.md")); err != nil {
+		t.Error("dry run should not rename files")
+	}
+}
+
+func TestRenumber_Compact(t *testing.T) {
+	tmpDir := createRenumberTestFiles(t)
+	resetRenumberFlags()
+	taskDir = tmpDir
+	renumberCompact = true
+
+	if _, err := captureRenumberOutput(t, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"001-setup.md", "002-feature.md", "003-polish.md"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, name)); err != nil {
+			t.Errorf("expected %s to exist", name)
+		}
+	}
+	polish, _ := os.ReadFile(filepath.Join(tmpDir, "003-polish.md"))
+	if !strings.Contains(string(polish), `dependencies: ["002"]`) {
+		t.Errorf("expected dependency rewritten, got:\n%s", polish)
+	}
+
+	output, err := captureRenumberOutput(t, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "already compact") {
+		t.Errorf("expected no-op message, got: %s", output)
+	}
+}
+
+func TestRenumber_Errors(t *testing.T) {
+	tmpDir := createRenumberTestFiles(t)
+	resetRenumberFlags()
+	taskDir = tmpDir
+
+	if _, err := captureRenumberOutput(t, []string{"404", "405"}); err == nil || !strings.Contains(err.Error(), "task not found") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	if _, err := captureRenumberOutput(t, []string{"005", "009"}); err == nil || !strings.Contains(err.Error(), "already in use") {
+		t.Errorf("expected in-use error, got %v", err)
+	}
+}
+
+func TestRenumber_Args(t *testing.T) {
+	resetRenumberFlags()
+	if err := renumberCmd.Args(renumberCmd, []string{"005"}); err == nil {
+		t.Error("expected error for a single argument")
+	}
+
+	renumberCompact = true
+	defer resetRenumberFlags()
+	if err := renumberCmd.Args(renumberCmd, []string{"005", "006"}); err == nil {
+		t.Error("expected error when --compact is combined with IDs")
+	}
+	if err := renumberCmd.Args(renumberCmd, nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
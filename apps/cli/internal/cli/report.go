@@ -7,7 +7,6 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/driangle/taskmd/apps/cli/internal/board"
-	"github.com/driangle/taskmd/apps/cli/internal/scanner"
 )
 
 var (
@@ -21,7 +20,13 @@ var reportCmd = &cobra.Command{
 	Use:   "report [path]",
 	Short: "Generate a comprehensive project report",
 	Long: `Generate a comprehensive report combining summary statistics, task groupings,
-critical path analysis, blocked tasks, and optional dependency graphs.
+critical path analysis with per-task slack, blocked tasks, and optional
+dependency graphs.
+
+The critical path is weighted by remaining effort: each open task counts its
+estimate in hours, or the hours for its effort level (see the effort section
+of .taskmd.yaml). Slack is how long a task can slip without delaying the
+project.
 
 Supported formats:
   - md: Rich markdown report (default)
@@ -62,7 +67,7 @@ func runReport(cmd *cobra.Command, args []string) error {
 
 	scanDir := ResolveScanDir(args)
 
-	taskScanner := scanner.NewScanner(scanDir, flags.Verbose, flags.IgnoreDirs)
+	taskScanner := newScanner(scanDir, flags)
 	result, err := taskScanner.Scan()
 	if err != nil {
 		return fmt.Errorf("scan failed: %w", err)
@@ -76,10 +81,11 @@ func runReport(cmd *cobra.Command, args []string) error {
 		fmt.Fprintln(os.Stderr)
 	}
 
-	data, err := collectReportData(result.Tasks, reportGroupBy, reportIncludeGraph)
+	data, err := collectReportData(result.Tasks, result.ArchivedIDs, reportGroupBy, reportIncludeGraph, loadEffortWeights())
 	if err != nil {
 		return err
 	}
+	data.Metrics.Flow = loadFlow(result.Tasks, scanDir)
 
 	var outFile *os.File
 	if reportOut != "" {
@@ -112,6 +118,8 @@ type ReportTaskJSON struct {
 	Status       string   `json:"status"`
 	Priority     string   `json:"priority,omitempty"`
 	Dependencies []string `json:"dependencies,omitempty"`
+	Duration     *float64 `json:"duration,omitempty"` // remaining hours
+	Slack        *float64 `json:"slack,omitempty"`    // hours the task can slip
 }
 
 func taskToReportJSON(t reportTask) ReportTaskJSON {
@@ -128,19 +136,34 @@ func taskToReportJSON(t reportTask) ReportTaskJSON {
 	}
 }
 
+// scheduledTaskToReportJSON includes the task's remaining hours and slack.
+func scheduledTaskToReportJSON(t reportTask) ReportTaskJSON {
+	rj := taskToReportJSON(t)
+	rj.Duration = &t.Duration
+	rj.Slack = &t.Slack
+	return rj
+}
+
 func outputReportJSON(data *reportData, outFile *os.File) error {
 	type reportJSON struct {
-		Summary      any               `json:"summary"`
-		Groups       []board.JSONGroup `json:"groups"`
-		GroupBy      string            `json:"group_by"`
-		CriticalPath []ReportTaskJSON  `json:"critical_path"`
-		BlockedTasks []ReportTaskJSON  `json:"blocked_tasks"`
-		Graph        map[string]any    `json:"graph,omitempty"`
+		Summary           any               `json:"summary"`
+		Groups            []board.JSONGroup `json:"groups"`
+		GroupBy           string            `json:"group_by"`
+		CriticalPath      []ReportTaskJSON  `json:"critical_path"`
+		CriticalPathHours float64           `json:"critical_path_hours"`
+		Slack             []ReportTaskJSON  `json:"slack"`
+		BlockedTasks      []ReportTaskJSON  `json:"blocked_tasks"`
+		Graph             map[string]any    `json:"graph,omitempty"`
 	}
 
 	cpTasks := make([]ReportTaskJSON, len(data.CriticalPath))
 	for i, t := range data.CriticalPath {
-		cpTasks[i] = taskToReportJSON(t)
+		cpTasks[i] = scheduledTaskToReportJSON(t)
+	}
+
+	slackTasks := make([]ReportTaskJSON, len(data.SlackTasks))
+	for i, t := range data.SlackTasks {
+		slackTasks[i] = scheduledTaskToReportJSON(t)
 	}
 
 	blockedTasks := make([]ReportTaskJSON, len(data.BlockedTasks))
@@ -149,11 +172,13 @@ func outputReportJSON(data *reportData, outFile *os.File) error {
 	}
 
 	rj := reportJSON{
-		Summary:      data.Metrics,
-		Groups:       board.ToJSON(data.GroupedTasks),
-		GroupBy:      data.GroupBy,
-		CriticalPath: cpTasks,
-		BlockedTasks: blockedTasks,
+		Summary:           data.Metrics,
+		Groups:            board.ToJSON(data.GroupedTasks),
+		GroupBy:           data.GroupBy,
+		CriticalPath:      cpTasks,
+		CriticalPathHours: data.CriticalPathHours,
+		Slack:             slackTasks,
+		BlockedTasks:      blockedTasks,
 	}
 
 	if data.IncludeGraph {
@@ -16,6 +16,8 @@ type reportTask struct {
 	Status       string
 	Priority     string
 	Dependencies []string
+	Duration     float64 // remaining hours
+	Slack        float64 // hours the task can slip without delaying the project
 }
 
 // reportData is the format-agnostic intermediate representation of a report.
@@ -24,14 +26,22 @@ type reportData struct {
 	GroupedTasks *board.GroupResult
 	GroupBy      string
 	CriticalPath []reportTask
+	// CriticalPathHours is the remaining effort along the critical path.
+	CriticalPathHours float64
+	// SlackTasks are open tasks off the critical path, least slack first.
+	SlackTasks   []reportTask
 	BlockedTasks []reportTask
 	IncludeGraph bool
 	GraphMermaid string
 	GraphJSON    map[string]any
 }
 
-func collectReportData(tasks []*model.Task, groupBy string, includeGraph bool) (*reportData, error) {
-	m := metrics.Calculate(tasks)
+// collectReportData builds the report. Dependencies on archivedIDs count as
+// met.
+func collectReportData(
+	tasks []*model.Task, archivedIDs []string, groupBy string, includeGraph bool, effort graph.EffortWeights,
+) (*reportData, error) {
+	m := metrics.Calculate(tasks, effort)
 
 	grouped, err := board.GroupTasks(tasks, groupBy)
 	if err != nil {
@@ -39,21 +49,24 @@ func collectReportData(tasks []*model.Task, groupBy string, includeGraph bool) (
 	}
 
 	taskMap := buildTaskMap(tasks)
+	model.AddArchived(taskMap, archivedIDs)
 
-	blocked := findBlockedTasks(tasks, taskMap)
-	critical := findCriticalPathTasks(tasks, taskMap)
+	g := graph.NewGraph(tasks).WithArchived(archivedIDs)
+	g.Effort = effort
+	cp := g.CriticalPath(effort)
 
 	data := &reportData{
-		Metrics:      m,
-		GroupedTasks: grouped,
-		GroupBy:      groupBy,
-		CriticalPath: critical,
-		BlockedTasks: blocked,
-		IncludeGraph: includeGraph,
+		Metrics:           m,
+		GroupedTasks:      grouped,
+		GroupBy:           groupBy,
+		CriticalPath:      findCriticalPathTasks(cp, taskMap),
+		CriticalPathHours: cp.Length,
+		SlackTasks:        findSlackTasks(tasks, cp),
+		BlockedTasks:      findBlockedTasks(tasks, taskMap),
+		IncludeGraph:      includeGraph,
 	}
 
 	if includeGraph {
-		g := graph.NewGraph(tasks)
 		data.GraphMermaid = g.ToMermaid("")
 		data.GraphJSON = g.ToJSON()
 	}
@@ -80,32 +93,43 @@ func findBlockedTasks(tasks []*model.Task, taskMap map[string]*model.Task) []rep
 	return blocked
 }
 
-func findCriticalPathTasks(tasks []*model.Task, taskMap map[string]*model.Task) []reportTask {
-	cpIDs := calculateCriticalPathTasks(tasks, taskMap)
-	depthMap := calculateDepthMap(tasks, taskMap)
+func newReportTask(t *model.Task, timing graph.Timing) reportTask {
+	return reportTask{
+		ID:           t.ID,
+		Title:        t.Title,
+		Status:       string(t.Status),
+		Priority:     string(t.Priority),
+		Dependencies: t.Dependencies,
+		Duration:     timing.Duration,
+		Slack:        timing.Slack,
+	}
+}
+
+// findCriticalPathTasks lists the critical tasks in schedule order so the
+// chain reads from root to leaf.
+func findCriticalPathTasks(cp *graph.CriticalPath, taskMap map[string]*model.Task) []reportTask {
+	cpTasks := make([]reportTask, 0, len(cp.Tasks))
+	for _, id := range cp.Tasks {
+		cpTasks = append(cpTasks, newReportTask(taskMap[id], cp.Timings[id]))
+	}
+	return cpTasks
+}
 
-	var cpTasks []reportTask
+// findSlackTasks lists open tasks off the critical path, least slack first.
+func findSlackTasks(tasks []*model.Task, cp *graph.CriticalPath) []reportTask {
+	var slack []reportTask
 	for _, t := range tasks {
-		if cpIDs[t.ID] {
-			cpTasks = append(cpTasks, reportTask{
-				ID:           t.ID,
-				Title:        t.Title,
-				Status:       string(t.Status),
-				Priority:     string(t.Priority),
-				Dependencies: t.Dependencies,
-			})
+		timing, ok := cp.Timings[t.ID]
+		if !ok || timing.Critical || !t.IsOpen() {
+			continue
 		}
+		slack = append(slack, newReportTask(t, timing))
 	}
-
-	// Sort by depth ascending so the chain reads from root to leaf.
-	sort.Slice(cpTasks, func(i, j int) bool {
-		di := depthMap[cpTasks[i].ID]
-		dj := depthMap[cpTasks[j].ID]
-		if di != dj {
-			return di < dj
+	sort.Slice(slack, func(i, j int) bool {
+		if slack[i].Slack != slack[j].Slack {
+			return slack[i].Slack < slack[j].Slack
 		}
-		return cpTasks[i].ID < cpTasks[j].ID
+		return slack[i].ID < slack[j].ID
 	})
-
-	return cpTasks
+	return slack
 }
@@ -9,13 +9,15 @@ import (
 )
 
 type htmlReportData struct {
-	Metrics      *metrics.Metrics
-	Groups       []htmlGroupData
-	GroupByLabel string
-	CriticalPath []htmlTaskData
-	BlockedTasks []htmlBlockedTaskData
-	IncludeGraph bool
-	MermaidSrc   string
+	Metrics           *metrics.Metrics
+	Groups            []htmlGroupData
+	GroupByLabel      string
+	CriticalPath      []htmlTaskData
+	CriticalPathHours string
+	SlackTasks        []htmlTaskData
+	BlockedTasks      []htmlBlockedTaskData
+	IncludeGraph      bool
+	MermaidSrc        string
 }
 
 type htmlGroupData struct {
@@ -29,6 +31,8 @@ type htmlTaskData struct {
 	Title    string
 	Status   string
 	Priority string
+	Duration string
+	Slack    string
 }
 
 type htmlBlockedTaskData struct {
@@ -61,12 +65,12 @@ func toHTMLData(data *reportData) htmlReportData {
 
 	cpTasks := make([]htmlTaskData, len(data.CriticalPath))
 	for i, t := range data.CriticalPath {
-		cpTasks[i] = htmlTaskData{
-			ID:       t.ID,
-			Title:    t.Title,
-			Status:   t.Status,
-			Priority: t.Priority,
-		}
+		cpTasks[i] = scheduledHTMLTask(t)
+	}
+
+	slackTasks := make([]htmlTaskData, len(data.SlackTasks))
+	for i, t := range data.SlackTasks {
+		slackTasks[i] = scheduledHTMLTask(t)
 	}
 
 	blocked := make([]htmlBlockedTaskData, len(data.BlockedTasks))
@@ -79,18 +83,33 @@ func toHTMLData(data *reportData) htmlReportData {
 	}
 
 	return htmlReportData{
-		Metrics:      data.Metrics,
-		Groups:       groups,
-		GroupByLabel: capitalizeFirst(data.GroupBy),
-		CriticalPath: cpTasks,
-		BlockedTasks: blocked,
-		IncludeGraph: data.IncludeGraph,
-		MermaidSrc:   data.GraphMermaid,
+		Metrics:           data.Metrics,
+		Groups:            groups,
+		GroupByLabel:      capitalizeFirst(data.GroupBy),
+		CriticalPath:      cpTasks,
+		CriticalPathHours: formatHours(data.CriticalPathHours),
+		SlackTasks:        slackTasks,
+		BlockedTasks:      blocked,
+		IncludeGraph:      data.IncludeGraph,
+		MermaidSrc:        data.GraphMermaid,
+	}
+}
+
+func scheduledHTMLTask(t reportTask) htmlTaskData {
+	return htmlTaskData{
+		ID:       t.ID,
+		Title:    t.Title,
+		Status:   t.Status,
+		Priority: t.Priority,
+		Duration: formatHours(t.Duration),
+		Slack:    formatHours(t.Slack),
 	}
 }
 
 func outputReportHTML(data *reportData, w io.Writer) error {
 	tmpl, err := template.New("report").Funcs(template.FuncMap{
+		"stat":         formatStat,
+		"flowSections": flowSections,
 		"statusClass": func(s string) string {
 			switch model.Status(s) {
 			case model.StatusCompleted:
@@ -151,6 +170,7 @@ const reportHTMLTemplate = `<!DOCTYPE html>
   <tr><td>Total Tasks</td><td>{{.Metrics.TotalTasks}}</td></tr>
   <tr><td>Blocked Tasks</td><td>{{.Metrics.BlockedTasksCount}}</td></tr>
   <tr><td>Critical Path Length</td><td>{{.Metrics.CriticalPathLength}}</td></tr>
+  <tr><td>Critical Path Effort</td><td>{{.CriticalPathHours}}</td></tr>
   <tr><td>Avg Dependencies</td><td>{{printf "%.1f" .Metrics.AvgDependenciesPerTask}}</td></tr>
 </table>
 
@@ -164,11 +184,38 @@ const reportHTMLTemplate = `<!DOCTYPE html>
 
 <h2>Critical Path</h2>
 {{if .CriticalPath}}
+<p>Remaining effort: {{.CriticalPathHours}}</p>
 <ol>
-{{range .CriticalPath}}  <li><span class="task-id">[{{.ID}}]</span> {{.Title}} <span class="badge {{statusClass .Status}}">{{.Status}}</span></li>
+{{range .CriticalPath}}  <li><span class="task-id">[{{.ID}}]</span> {{.Title}} <span class="badge {{statusClass .Status}}">{{.Status}}</span> {{.Duration}}</li>
 {{end}}</ol>
 {{else}}
-<p>No dependency chains found.</p>
+<p>No remaining work.</p>
+{{end}}
+
+{{if .SlackTasks}}
+<h2>Slack</h2>
+<table>
+  <tr><th>Task</th><th>Effort</th><th>Slack</th></tr>
+{{range .SlackTasks}}  <tr><td><span class="task-id">[{{.ID}}]</span> {{.Title}}</td><td>{{.Duration}}</td><td>{{.Slack}}</td></tr>
+{{end}}</table>
+{{end}}
+
+{{with .Metrics.Flow}}
+<h2>Flow</h2>
+<p>{{.Completed}} completed tasks with a committed completion date. Times are in days.</p>
+<table>
+  <tr><th>Metric</th><th>P50</th><th>P85</th><th>P95</th><th>Tasks</th></tr>
+  <tr><td>Lead time</td><td>{{stat .LeadTime .LeadTime.P50}}</td><td>{{stat .LeadTime .LeadTime.P85}}</td><td>{{stat .LeadTime .LeadTime.P95}}</td><td>{{.LeadTime.Count}}</td></tr>
+  <tr><td>Cycle time</td><td>{{stat .CycleTime .CycleTime.P50}}</td><td>{{stat .CycleTime .CycleTime.P85}}</td><td>{{stat .CycleTime .CycleTime.P95}}</td><td>{{.CycleTime.Count}}</td></tr>
+</table>
+<p>Throughput per week, oldest first: {{range $i, $w := .Throughput}}{{if $i}}, {{end}}{{$w.Completed}}{{end}}</p>
+{{range flowSections .}}
+<h3>By {{.Label}}</h3>
+<table>
+  <tr><th>{{.Label}}</th><th>Done</th><th>Lead P50</th><th>Lead P85</th><th>Cycle P50</th><th>Cycle P85</th></tr>
+{{range .Groups}}  <tr><td>{{.Key}}</td><td>{{.Completed}}</td><td>{{stat .LeadTime .LeadTime.P50}}</td><td>{{stat .LeadTime .LeadTime.P85}}</td><td>{{stat .CycleTime .CycleTime.P50}}</td><td>{{stat .CycleTime .CycleTime.P85}}</td></tr>
+{{end}}</table>
+{{end}}
 {{end}}
 
 <h2>Blocked Tasks</h2>
@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 
 	"github.com/driangle/taskmd/apps/cli/internal/metrics"
@@ -13,9 +14,11 @@ func outputReportMarkdown(data *reportData, w io.Writer) error {
 	fmt.Fprintln(w, "# Project Report")
 	fmt.Fprintln(w)
 
-	writeMarkdownSummary(data.Metrics, w)
+	writeMarkdownSummary(data, w)
 	writeMarkdownGroups(data, w)
-	writeMarkdownCriticalPath(data.CriticalPath, w)
+	writeMarkdownCriticalPath(data, w)
+	writeMarkdownSlack(data.SlackTasks, w)
+	writeMarkdownFlow(data.Metrics.Flow, w)
 	writeMarkdownBlockedTasks(data, w)
 
 	if data.IncludeGraph {
@@ -25,7 +28,8 @@ func outputReportMarkdown(data *reportData, w io.Writer) error {
 	return nil
 }
 
-func writeMarkdownSummary(m *metrics.Metrics, w io.Writer) {
+func writeMarkdownSummary(data *reportData, w io.Writer) {
+	m := data.Metrics
 	fmt.Fprintln(w, "## Summary")
 	fmt.Fprintln(w)
 	fmt.Fprintf(w, "| Metric | Value |\n")
@@ -33,6 +37,7 @@ func writeMarkdownSummary(m *metrics.Metrics, w io.Writer) {
 	fmt.Fprintf(w, "| Total Tasks | %d |\n", m.TotalTasks)
 	fmt.Fprintf(w, "| Blocked Tasks | %d |\n", m.BlockedTasksCount)
 	fmt.Fprintf(w, "| Critical Path Length | %d |\n", m.CriticalPathLength)
+	fmt.Fprintf(w, "| Critical Path Effort | %s |\n", formatHours(m.CriticalPathHours))
 	fmt.Fprintf(w, "| Avg Dependencies | %.1f |\n", m.AvgDependenciesPerTask)
 	fmt.Fprintln(w)
 
@@ -102,20 +107,88 @@ func writeMarkdownGroups(data *reportData, w io.Writer) {
 	}
 }
 
-func writeMarkdownCriticalPath(cpTasks []reportTask, w io.Writer) {
+func writeMarkdownCriticalPath(data *reportData, w io.Writer) {
 	fmt.Fprintln(w, "## Critical Path")
 	fmt.Fprintln(w)
 
-	if len(cpTasks) == 0 {
-		fmt.Fprintln(w, "No dependency chains found.")
+	if len(data.CriticalPath) == 0 {
+		fmt.Fprintln(w, "No remaining work.")
 		fmt.Fprintln(w)
 		return
 	}
 
-	for i, t := range cpTasks {
-		fmt.Fprintf(w, "%d. [%s] %s (%s)\n", i+1, t.ID, t.Title, t.Status)
+	fmt.Fprintf(w, "Remaining effort: %s\n", formatHours(data.CriticalPathHours))
+	fmt.Fprintln(w)
+	for i, t := range data.CriticalPath {
+		fmt.Fprintf(w, "%d. [%s] %s (%s, %s)\n", i+1, t.ID, t.Title, t.Status, formatHours(t.Duration))
+	}
+	fmt.Fprintln(w)
+}
+
+func writeMarkdownSlack(slackTasks []reportTask, w io.Writer) {
+	if len(slackTasks) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "## Slack")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Open tasks off the critical path and how long each can slip without delaying the project.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Task | Effort | Slack |")
+	fmt.Fprintln(w, "|------|--------|-------|")
+	for _, t := range slackTasks {
+		fmt.Fprintf(w, "| [%s] %s | %s | %s |\n", t.ID, t.Title, formatHours(t.Duration), formatHours(t.Slack))
+	}
+	fmt.Fprintln(w)
+}
+
+func writeMarkdownFlow(f *metrics.Flow, w io.Writer) {
+	if f == nil {
+		return
+	}
+
+	fmt.Fprintln(w, "## Flow")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%d completed tasks with a committed completion date. Times are in days.\n", f.Completed)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "| Metric | P50 | P85 | P95 | Tasks |")
+	fmt.Fprintln(w, "|--------|-----|-----|-----|-------|")
+	for _, row := range []struct {
+		label string
+		d     metrics.DurationStats
+	}{{"Lead time", f.LeadTime}, {"Cycle time", f.CycleTime}} {
+		fmt.Fprintf(w, "| %s | %s | %s | %s | %d |\n", row.label,
+			formatStat(row.d, row.d.P50), formatStat(row.d, row.d.P85), formatStat(row.d, row.d.P95), row.d.Count)
+	}
+	fmt.Fprintln(w)
+
+	counts := make([]string, len(f.Throughput))
+	for i, wk := range f.Throughput {
+		counts[i] = strconv.Itoa(wk.Completed)
 	}
+	fmt.Fprintf(w, "Throughput per week, oldest first: %s\n", strings.Join(counts, ", "))
 	fmt.Fprintln(w)
+
+	for _, section := range flowSections(f) {
+		fmt.Fprintf(w, "### By %s\n", section.Label)
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "| %s | Done | Lead P50 | Lead P85 | Cycle P50 | Cycle P85 |\n", section.Label)
+		fmt.Fprintln(w, "|-----|------|----------|----------|-----------|-----------|")
+		for _, g := range section.Groups {
+			fmt.Fprintf(w, "| %s | %d | %s | %s | %s | %s |\n", g.Key, g.Completed,
+				formatStat(g.LeadTime, g.LeadTime.P50), formatStat(g.LeadTime, g.LeadTime.P85),
+				formatStat(g.CycleTime, g.CycleTime.P50), formatStat(g.CycleTime, g.CycleTime.P85))
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// formatStat renders one statistic of d, or "-" when d has no samples.
+func formatStat(d metrics.DurationStats, v float64) string {
+	if d.Count == 0 {
+		return "-"
+	}
+	return formatDays(v)
 }
 
 func writeMarkdownBlockedTasks(data *reportData, w io.Writer) {
@@ -171,6 +244,11 @@ func formatWaitingOn(deps []string, taskMap map[string]reportTask) string {
 	return strings.Join(parts, ", ")
 }
 
+// formatHours renders a number of hours without trailing zeros, e.g. "2.5h".
+func formatHours(h float64) string {
+	return strconv.FormatFloat(h, 'f', -1, 64) + "h"
+}
+
 func capitalizeFirst(s string) string {
 	if s == "" {
 		return s
@@ -171,8 +171,8 @@ func TestReportCommand_MarkdownSummaryMetrics(t *testing.T) {
 	if !strings.Contains(output, "| Total Tasks | 6 |") {
 		t.Error("Expected total tasks = 6 in summary table")
 	}
-	if !strings.Contains(output, "| Critical Path Length |") {
-		t.Error("Expected critical path length in summary")
+	if !strings.Contains(output, "| Critical Path Length | 4 |") {
+		t.Error("Expected critical path length of 4 tasks in summary")
 	}
 	if !strings.Contains(output, "| Avg Dependencies |") {
 		t.Error("Expected avg dependencies in summary")
@@ -671,18 +671,72 @@ func TestReportCommand_CriticalPathOrdering(t *testing.T) {
 
 	output := captureReportOutput(t, tmpDir)
 
-	// Critical path: 001 -> 002 -> 004 -> 005
+	// Critical path: 002 -> 004 -> 005 (001 is already completed)
 	// They should appear in depth order (root first)
-	idx001 := strings.Index(output, "1. [001]")
+	idx002 := strings.Index(output, "1. [002]")
 	idx005 := strings.LastIndex(output, "[005]")
 
-	if idx001 == -1 {
-		t.Error("Expected task 001 in critical path")
+	if idx002 == -1 {
+		t.Error("Expected task 002 in critical path")
 	}
 	if idx005 == -1 {
 		t.Error("Expected task 005 in critical path")
 	}
-	if idx001 != -1 && idx005 != -1 && idx001 > idx005 {
-		t.Error("Expected task 001 to appear before task 005 in critical path")
+	if idx002 != -1 && idx005 != -1 && idx002 > idx005 {
+		t.Error("Expected task 002 to appear before task 005 in critical path")
+	}
+}
+
+func TestReportCommand_MarkdownSlack(t *testing.T) {
+	tmpDir := createReportTestFiles(t)
+	resetReportFlags()
+
+	output := captureReportOutput(t, tmpDir)
+
+	// Remaining work on 002 -> 004 -> 005 is 16h + 8h + 16h.
+	if !strings.Contains(output, "| Critical Path Effort | 40h |") {
+		t.Error("Expected critical path effort of 40h in summary")
+	}
+	if !strings.Contains(output, "1. [002] Implement authentication (") {
+		t.Errorf("Expected open task 002 to start the critical path, got:\n%s", output)
+	}
+	if strings.Contains(output, "[001] Setup project (completed") {
+		t.Error("Expected completed task 001 to be left off the critical path")
+	}
+	// 003 (8h) and 006 (2h) run in parallel with the 40h path.
+	if !strings.Contains(output, "| [006] Write documentation | 2h | 38h |") {
+		t.Errorf("Expected slack row for 006, got:\n%s", output)
+	}
+	if !strings.Contains(output, "| [003] Build UI components | 8h | 32h |") {
+		t.Error("Expected slack row for 003")
+	}
+}
+
+func TestReportCommand_JSONSlack(t *testing.T) {
+	tmpDir := createReportTestFiles(t)
+	resetReportFlags()
+	reportFormat = "json"
+
+	output := captureReportOutput(t, tmpDir)
+
+	var result struct {
+		CriticalPath      []ReportTaskJSON `json:"critical_path"`
+		CriticalPathHours float64          `json:"critical_path_hours"`
+		Slack             []ReportTaskJSON `json:"slack"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+
+	if result.CriticalPathHours != 40 {
+		t.Errorf("Expected critical_path_hours 40, got %v", result.CriticalPathHours)
+	}
+	if len(result.Slack) != 2 || result.Slack[0].ID != "003" || *result.Slack[0].Slack != 32 {
+		t.Errorf("Expected 003 first in slack with 32h, got %+v", result.Slack)
+	}
+	for _, task := range result.CriticalPath {
+		if task.Slack == nil || *task.Slack != 0 {
+			t.Errorf("Expected zero slack for critical task %s", task.ID)
+		}
 	}
 }
@@ -6,6 +6,9 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+
+	"github.com/driangle/taskmd/apps/cli/internal/scanner"
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
 )
 
 var (
@@ -23,6 +26,9 @@ var (
 	debug   bool
 	noColor bool
 	taskDir string
+
+	includeArchived bool
+	noCache         bool
 )
 
 // rootCmd represents the base command
@@ -79,6 +85,8 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "enable debug output (prints to stderr)")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output")
 	rootCmd.PersistentFlags().StringVarP(&taskDir, "task-dir", "d", ".", "task directory to scan")
+	rootCmd.PersistentFlags().BoolVar(&includeArchived, "include-archived", false, "also scan tasks in archive/ directories")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "parse every task file instead of using the scan cache in .taskmd/cache")
 
 	// Deprecated alias: --dir still works but is hidden
 	rootCmd.PersistentFlags().StringVar(&taskDir, "dir", "", "task directory (deprecated: use --task-dir)")
@@ -92,6 +100,8 @@ func init() {
 	viper.BindPFlag("no-color", rootCmd.PersistentFlags().Lookup("no-color"))
 	viper.BindPFlag("task-dir", rootCmd.PersistentFlags().Lookup("task-dir"))
 	viper.BindPFlag("dir", rootCmd.PersistentFlags().Lookup("dir"))
+	viper.BindPFlag("include-archived", rootCmd.PersistentFlags().Lookup("include-archived"))
+	viper.BindPFlag("no-cache", rootCmd.PersistentFlags().Lookup("no-cache"))
 }
 
 // initConfig reads in config file and ENV variables if set
@@ -140,6 +150,9 @@ func GetGlobalFlags() GlobalFlags {
 		NoColor:    viper.GetBool("no-color") || noColor,
 		TaskDir:    dirVal,
 		IgnoreDirs: viper.GetStringSlice("ignore"),
+
+		IncludeArchived: viper.GetBool("include-archived") || includeArchived,
+		NoCache:         viper.GetBool("no-cache") || noCache,
 	}
 }
 
@@ -183,6 +196,24 @@ type GlobalFlags struct {
 	NoColor    bool
 	TaskDir    string
 	IgnoreDirs []string
+
+	IncludeArchived bool // scan archive/ directories too
+	NoCache         bool // parse every file instead of using the scan cache
+}
+
+// newScanner returns a scanner for dir that honors the global scan flags.
+func newScanner(dir string, flags GlobalFlags) *scanner.Scanner {
+	return scanner.NewScanner(dir, flags.Verbose, flags.IgnoreDirs).
+		IncludeArchived(flags.IncludeArchived).
+		UseCache(!flags.NoCache)
+}
+
+// loadTimestampConfig reads which status timestamps to record from the
+// timestamps section of .taskmd.yaml. Malformed entries are reported by
+// `taskmd validate`, not here.
+func loadTimestampConfig() *taskfile.TimestampConfig {
+	cfg, _ := taskfile.ParseTimestampConfig(viper.Get("timestamps"))
+	return &cfg
 }
 
 // ResolveScanDir returns the scan directory from positional arg or --task-dir flag.
@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -10,7 +11,6 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/driangle/taskmd/apps/cli/internal/model"
-	"github.com/driangle/taskmd/apps/cli/internal/scanner"
 	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
 	"github.com/driangle/taskmd/apps/cli/internal/verify"
 )
@@ -22,27 +22,49 @@ var (
 	setEffort     string
 	setOwner      string
 	setParent     string
+	setDue        string
+	setStart      string
+	setScheduled  string
 	setDone       bool
 	setDryRun     bool
 	setVerify     bool
 	setAddTags    []string
 	setRemoveTags []string
+
+	setAddDeps        []string
+	setRemoveDeps     []string
+	setAddTouches     []string
+	setRemoveTouches  []string
+	setAddContext     []string
+	setRemoveContext  []string
+	setAddVerifySteps []string
+	setRemoveVerify   []string
 )
 
 var setCmd = &cobra.Command{
 	Use:        "set",
 	SuggestFor: []string{"edit", "modify", "change"},
 	Short:      "Set a task's frontmatter fields",
-	Long: `Set modifies frontmatter fields (status, priority, effort, tags) of a task file.
+	Long: `Set modifies frontmatter fields (status, priority, effort, dates, tags,
+dependencies, touches, context, verify) of a task file.
+
+Dates (--due, --start, --scheduled) use YYYY-MM-DD; pass an empty string to clear one.
 
 The task is identified by --task-id (exact match only).
 
+Verify steps are added with a "type:value" shorthand: "bash:<command>" or
+"assert:<check>". --remove-verify removes steps whose run or check text matches.
+
 Examples:
   taskmd set --task-id cli-049 --status completed
   taskmd set --task-id cli-049 --priority high --effort large
   taskmd set --task-id cli-049 --done
+  taskmd set --task-id cli-049 --due 2026-03-01 --start 2026-02-20
   taskmd set --task-id cli-049 --add-tag backend --add-tag api
-  taskmd set --task-id cli-049 --remove-tag deprecated`,
+  taskmd set --task-id cli-049 --remove-tag deprecated
+  taskmd set --task-id cli-049 --add-dep cli-042 --remove-dep cli-040
+  taskmd set --task-id cli-049 --add-touches cli/graph --add-context docs/graph.md
+  taskmd set --task-id cli-049 --add-verify "bash:go test ./..."`,
 	Args: cobra.NoArgs,
 	RunE: runSet,
 }
@@ -68,11 +90,22 @@ func init() {
 		cmd.Flags().StringVar(&setEffort, "effort", "", "new effort (small, medium, large)")
 		cmd.Flags().StringVar(&setOwner, "owner", "", "owner/assignee of the task")
 		cmd.Flags().StringVar(&setParent, "parent", "", "parent task ID (use empty string to clear)")
+		cmd.Flags().StringVar(&setDue, "due", "", "due date, YYYY-MM-DD (use empty string to clear)")
+		cmd.Flags().StringVar(&setStart, "start", "", "start date, YYYY-MM-DD (use empty string to clear)")
+		cmd.Flags().StringVar(&setScheduled, "scheduled", "", "date the work is scheduled for, YYYY-MM-DD (use empty string to clear)")
 		cmd.Flags().BoolVar(&setDone, "done", false, "mark task as completed (alias for --status completed)")
 		cmd.Flags().BoolVar(&setDryRun, "dry-run", false, "preview changes without writing to disk")
 		cmd.Flags().BoolVar(&setVerify, "verify", false, "run verification checks before completing a task")
 		cmd.Flags().StringArrayVar(&setAddTags, "add-tag", nil, "add a tag (repeatable)")
 		cmd.Flags().StringArrayVar(&setRemoveTags, "remove-tag", nil, "remove a tag (repeatable)")
+		cmd.Flags().StringArrayVar(&setAddDeps, "add-dep", nil, "add a dependency by task ID (repeatable)")
+		cmd.Flags().StringArrayVar(&setRemoveDeps, "remove-dep", nil, "remove a dependency by task ID (repeatable)")
+		cmd.Flags().StringArrayVar(&setAddTouches, "add-touches", nil, "add a touches scope (repeatable)")
+		cmd.Flags().StringArrayVar(&setRemoveTouches, "remove-touches", nil, "remove a touches scope (repeatable)")
+		cmd.Flags().StringArrayVar(&setAddContext, "add-context", nil, "add a context path (repeatable)")
+		cmd.Flags().StringArrayVar(&setRemoveContext, "remove-context", nil, "remove a context path (repeatable)")
+		cmd.Flags().StringArrayVar(&setAddVerifySteps, "add-verify", nil, "add a verify step as bash:<command> or assert:<check> (repeatable)")
+		cmd.Flags().StringArrayVar(&setRemoveVerify, "remove-verify", nil, "remove verify steps whose run or check matches (repeatable)")
 
 		_ = cmd.MarkFlagRequired("task-id")
 	}
@@ -87,7 +120,7 @@ func runSet(cmd *cobra.Command, _ []string) error {
 	flags := GetGlobalFlags()
 	scanDir := ResolveScanDir(nil)
 
-	taskScanner := scanner.NewScanner(scanDir, flags.Verbose, flags.IgnoreDirs)
+	taskScanner := newScanner(scanDir, flags)
 	result, err := taskScanner.Scan()
 	if err != nil {
 		return fmt.Errorf("scan failed: %w", err)
@@ -114,6 +147,7 @@ func runSet(cmd *cobra.Command, _ []string) error {
 		return nil
 	}
 
+	req.Timestamps = loadTimestampConfig()
 	if err := taskfile.UpdateTaskFile(task.FilePath, req); err != nil {
 		return err
 	}
@@ -129,6 +163,32 @@ func setStringField(dst **string, value string) {
 	}
 }
 
+// applySetListFlags copies the add/remove list flags into the request.
+func applySetListFlags(req *taskfile.UpdateRequest) error {
+	if len(setAddTags) > 0 {
+		req.AddTags = setAddTags
+	}
+	if len(setRemoveTags) > 0 {
+		req.RemTags = setRemoveTags
+	}
+
+	req.AddDeps = setAddDeps
+	req.RemDeps = setRemoveDeps
+	req.AddTouches = setAddTouches
+	req.RemTouches = setRemoveTouches
+	req.AddContext = setAddContext
+	req.RemContext = setRemoveContext
+	req.RemVerify = setRemoveVerify
+	for _, spec := range setAddVerifySteps {
+		step, err := taskfile.ParseVerifyFlag(spec)
+		if err != nil {
+			return err
+		}
+		req.AddVerify = append(req.AddVerify, step)
+	}
+	return nil
+}
+
 func buildSetRequest(cmd *cobra.Command) (taskfile.UpdateRequest, error) {
 	if setDone && cmd.Flags().Changed("status") {
 		return taskfile.UpdateRequest{}, fmt.Errorf("--done and --status are mutually exclusive")
@@ -148,27 +208,38 @@ func buildSetRequest(cmd *cobra.Command) (taskfile.UpdateRequest, error) {
 	if cmd.Flags().Changed("parent") {
 		req.Parent = &setParent
 	}
+	applySetDateFlags(cmd, &req)
 
-	if len(setAddTags) > 0 {
-		req.AddTags = setAddTags
-	}
-	if len(setRemoveTags) > 0 {
-		req.RemTags = setRemoveTags
+	if err := applySetListFlags(&req); err != nil {
+		return taskfile.UpdateRequest{}, err
 	}
 
 	if err := validateSetEnums(req); err != nil {
 		return taskfile.UpdateRequest{}, err
 	}
 
-	hasScalar := req.Status != nil || req.Priority != nil || req.Effort != nil || req.Owner != nil || req.Parent != nil
-	hasTags := len(req.AddTags) > 0 || len(req.RemTags) > 0
-	if !hasScalar && !hasTags {
-		return taskfile.UpdateRequest{}, fmt.Errorf("nothing to update: provide --status, --priority, --effort, --owner, --parent, --done, --add-tag, or --remove-tag")
+	scalars := []*string{req.Status, req.Priority, req.Effort, req.Owner, req.Parent, req.Due, req.Start, req.Scheduled}
+	hasScalar := slices.ContainsFunc(scalars, func(v *string) bool { return v != nil })
+	if !hasScalar && !req.HasListEdits() {
+		return taskfile.UpdateRequest{}, fmt.Errorf("nothing to update: provide --status, --priority, --effort, --owner, --parent, --due, --start, --scheduled, --done, --add-tag, --remove-tag, --add-dep, --remove-dep, --add-touches, --remove-touches, --add-context, --remove-context, --add-verify, or --remove-verify")
 	}
 
 	return req, nil
 }
 
+// applySetDateFlags copies the date flags that were given into the request.
+func applySetDateFlags(cmd *cobra.Command, req *taskfile.UpdateRequest) {
+	if cmd.Flags().Changed("due") {
+		req.Due = &setDue
+	}
+	if cmd.Flags().Changed("start") {
+		req.Start = &setStart
+	}
+	if cmd.Flags().Changed("scheduled") {
+		req.Scheduled = &setScheduled
+	}
+}
+
 type changeEntry struct {
 	field    string
 	oldValue string
@@ -202,18 +273,46 @@ func buildChangeLog(task *model.Task, req taskfile.UpdateRequest) []changeEntry
 		changes = append(changes, changeEntry{field: "parent", oldValue: oldValues["parent"], newValue: *req.Parent})
 	}
 
-	if len(req.AddTags) > 0 || len(req.RemTags) > 0 {
-		newTags := taskfile.ComputeNewTags(task.Tags, req.AddTags, req.RemTags)
+	changes = appendDateChange(changes, "due", task.Due, req.Due)
+	changes = appendDateChange(changes, "start", task.Start, req.Start)
+	changes = appendDateChange(changes, "scheduled", task.Scheduled, req.Scheduled)
+
+	changes = appendListChange(changes, "tags", task.Tags, req.AddTags, req.RemTags)
+	changes = appendListChange(changes, "dependencies", task.Dependencies, req.AddDeps, req.RemDeps)
+	changes = appendListChange(changes, "touches", task.Touches, req.AddTouches, req.RemTouches)
+	changes = appendListChange(changes, "context", task.Context, req.AddContext, req.RemContext)
+
+	if len(req.AddVerify) > 0 || len(req.RemVerify) > 0 {
+		newSteps := taskfile.ComputeNewVerify(task.Verify, req.AddVerify, req.RemVerify)
 		changes = append(changes, changeEntry{
-			field:    "tags",
-			oldValue: "[" + strings.Join(task.Tags, ", ") + "]",
-			newValue: "[" + strings.Join(newTags, ", ") + "]",
+			field:    "verify",
+			oldValue: fmt.Sprintf("%d step(s)", len(task.Verify)),
+			newValue: fmt.Sprintf("%d step(s)", len(newSteps)),
 		})
 	}
 
 	return changes
 }
 
+func appendDateChange(changes []changeEntry, field string, current time.Time, value *string) []changeEntry {
+	if value == nil {
+		return changes
+	}
+	return append(changes, changeEntry{field: field, oldValue: formatDate(current), newValue: *value})
+}
+
+func appendListChange(changes []changeEntry, field string, current, add, remove []string) []changeEntry {
+	if len(add) == 0 && len(remove) == 0 {
+		return changes
+	}
+	updated := taskfile.ComputeNewList(current, add, remove)
+	return append(changes, changeEntry{
+		field:    field,
+		oldValue: "[" + strings.Join(current, ", ") + "]",
+		newValue: "[" + strings.Join(updated, ", ") + "]",
+	})
+}
+
 func printSetConfirmation(task *model.Task, changes []changeEntry) {
 	r := getRenderer()
 	fmt.Printf("Updated task %s (%s):\n", formatTaskID(task.ID, r), task.Title)
@@ -8,6 +8,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/spf13/viper"
+
 	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
 )
 
@@ -111,11 +113,22 @@ func resetSetFlags() {
 	setEffort = ""
 	setOwner = ""
 	setParent = ""
+	setDue = ""
+	setStart = ""
+	setScheduled = ""
 	setDone = false
 	setDryRun = false
 	setVerify = false
 	setAddTags = nil
 	setRemoveTags = nil
+	setAddDeps = nil
+	setRemoveDeps = nil
+	setAddTouches = nil
+	setRemoveTouches = nil
+	setAddContext = nil
+	setRemoveContext = nil
+	setAddVerifySteps = nil
+	setRemoveVerify = nil
 	taskDir = "."
 }
 
@@ -161,6 +174,48 @@ func TestSet_Status(t *testing.T) {
 	}
 }
 
+func TestSet_StatusRecordsTimestamps(t *testing.T) {
+	tmpDir := createSetTestFiles(t)
+	resetSetFlags()
+	taskDir = tmpDir
+	setTaskID = "001"
+	setStatus = "in-progress"
+
+	if _, err := captureSetOutput(t); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	setStatus = "completed"
+	if _, err := captureSetOutput(t); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(tmpDir, "001-setup.md"))
+	for _, field := range []string{"started: ", "completed: "} {
+		if !strings.Contains(string(content), field) {
+			t.Errorf("expected %s timestamp in file, got:\n%s", field, content)
+		}
+	}
+}
+
+func TestSet_TimestampsDisabledInConfig(t *testing.T) {
+	tmpDir := createSetTestFiles(t)
+	resetSetFlags()
+	taskDir = tmpDir
+	viper.Set("timestamps", false)
+	t.Cleanup(func() { viper.Set("timestamps", nil) })
+	setTaskID = "001"
+	setStatus = "completed"
+
+	if _, err := captureSetOutput(t); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(tmpDir, "001-setup.md"))
+	if strings.Contains(string(content), "completed: ") {
+		t.Errorf("expected no completed timestamp when disabled, got:\n%s", content)
+	}
+}
+
 func TestSet_Priority(t *testing.T) {
 	tmpDir := createSetTestFiles(t)
 	resetSetFlags()
@@ -979,6 +1034,53 @@ created: 2026-02-08
 	}
 }
 
+func TestSet_Dates(t *testing.T) {
+	tmpDir := createSetTestFiles(t)
+	resetSetFlags()
+	taskDir = tmpDir
+	setTaskID = "001"
+
+	for flag, value := range map[string]string{"due": "2026-03-01", "start": "2026-02-20"} {
+		setCmd.Flags().Set(flag, value)
+		defer func(flag string) {
+			setCmd.Flags().Set(flag, "")
+			setCmd.Flags().Lookup(flag).Changed = false
+		}(flag)
+	}
+
+	output, err := captureSetOutput(t)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(output, "due: (unset) -> 2026-03-01") || !strings.Contains(output, "start: (unset) -> 2026-02-20") {
+		t.Errorf("Expected date changes in output, got: %s", output)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(tmpDir, "001-setup.md"))
+	if !strings.Contains(string(content), "due: 2026-03-01") || !strings.Contains(string(content), "start: 2026-02-20") {
+		t.Errorf("Expected file to contain dates, got:\n%s", string(content))
+	}
+}
+
+func TestSet_InvalidDate(t *testing.T) {
+	tmpDir := createSetTestFiles(t)
+	resetSetFlags()
+	taskDir = tmpDir
+	setTaskID = "001"
+
+	setCmd.Flags().Set("due", "03/01/2026")
+	defer func() {
+		setCmd.Flags().Set("due", "")
+		setCmd.Flags().Lookup("due").Changed = false
+	}()
+
+	_, err := captureSetOutput(t)
+	if err == nil || !strings.Contains(err.Error(), "invalid due date") {
+		t.Fatalf("expected invalid due date error, got %v", err)
+	}
+}
+
 func createVerifySetTestFile(t *testing.T, id, verifyYAML string) string {
 	t.Helper()
 	tmpDir := t.TempDir()
@@ -1153,3 +1255,66 @@ func TestComputeNewTags(t *testing.T) {
 		})
 	}
 }
+
+func TestSet_AddRemoveDependencies(t *testing.T) {
+	tmpDir := createSetTestFiles(t)
+	resetSetFlags()
+	taskDir = tmpDir
+	setTaskID = "003"
+	setAddDeps = []string{"001"}
+	setRemoveDeps = []string{"002"}
+
+	output, err := captureSetOutput(t)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(output, "dependencies: [002] -> [001]") {
+		t.Errorf("Expected dependency change in output, got: %s", output)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(tmpDir, "003-ui.md"))
+	if !strings.Contains(string(content), `dependencies: ["001"]`) {
+		t.Errorf("Expected updated dependencies, got:\n%s", content)
+	}
+}
+
+func TestSet_AddTouchesContextAndVerify(t *testing.T) {
+	tmpDir := createSetTestFiles(t)
+	resetSetFlags()
+	taskDir = tmpDir
+	setTaskID = "001"
+	setAddTouches = []string{"cli/build"}
+	setAddContext = []string{"Makefile"}
+	setAddVerifySteps = []string{"bash:make build"}
+
+	output, err := captureSetOutput(t)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(output, "verify: 0 step(s) -> 1 step(s)") {
+		t.Errorf("Expected verify change in output, got: %s", output)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(tmpDir, "001-setup.md"))
+	fileStr := string(content)
+	for _, want := range []string{`touches: ["cli/build"]`, `context: ["Makefile"]`, "verify:\n  - type: bash\n    run: \"make build\""} {
+		if !strings.Contains(fileStr, want) {
+			t.Errorf("Expected %q in file, got:\n%s", want, fileStr)
+		}
+	}
+}
+
+func TestSet_InvalidVerifyFlag(t *testing.T) {
+	tmpDir := createSetTestFiles(t)
+	resetSetFlags()
+	taskDir = tmpDir
+	setTaskID = "001"
+	setAddVerifySteps = []string{"make build"}
+
+	_, err := captureSetOutput(t)
+	if err == nil || !strings.Contains(err.Error(), "invalid verify step") {
+		t.Fatalf("Expected invalid verify step error, got: %v", err)
+	}
+}
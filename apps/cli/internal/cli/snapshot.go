@@ -7,8 +7,8 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/driangle/taskmd/apps/cli/internal/graph"
 	"github.com/driangle/taskmd/apps/cli/internal/model"
-	"github.com/driangle/taskmd/apps/cli/internal/scanner"
 )
 
 var (
@@ -22,7 +22,7 @@ var (
 // snapshotCmd represents the snapshot command
 var snapshotCmd = &cobra.Command{
 	Use:        "snapshot",
-	SuggestFor: []string{"save", "backup", "export"},
+	SuggestFor: []string{"save", "backup"},
 	Short:      "Produce a frozen, machine-readable representation of tasks",
 	Long: `Snapshot produces a static, machine-readable representation of tasks
 for CI/CD pipelines and automation.
@@ -86,7 +86,7 @@ func runSnapshot(cmd *cobra.Command, args []string) error {
 	scanDir := ResolveScanDir(args)
 
 	// Create scanner and scan for tasks
-	taskScanner := scanner.NewScanner(scanDir, flags.Verbose, flags.IgnoreDirs)
+	taskScanner := newScanner(scanDir, flags)
 	result, err := taskScanner.Scan()
 	if err != nil {
 		return fmt.Errorf("scan failed: %w", err)
@@ -114,7 +114,7 @@ func runSnapshot(cmd *cobra.Command, args []string) error {
 	if snapshotDerived {
 		depthMap = calculateDepthMap(tasks, taskMap)
 		topoOrder = calculateTopologicalOrder(tasks, taskMap)
-		criticalPathTasks = calculateCriticalPathTasks(tasks, taskMap)
+		criticalPathTasks = graph.NewGraph(tasks).CriticalPath(loadEffortWeights()).Set()
 	}
 
 	// Convert tasks to snapshots
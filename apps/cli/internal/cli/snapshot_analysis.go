@@ -129,51 +129,3 @@ func calculateTopologicalOrder(tasks []*model.Task, taskMap map[string]*model.Ta
 
 	return order
 }
-
-// calculateCriticalPathTasks identifies tasks on the critical path
-func calculateCriticalPathTasks(tasks []*model.Task, taskMap map[string]*model.Task) map[string]bool {
-	criticalPath := make(map[string]bool)
-
-	// Calculate depth for each task
-	depthMap := calculateDepthMap(tasks, taskMap)
-
-	// Find maximum depth
-	maxDepth := 0
-	for _, depth := range depthMap {
-		if depth > maxDepth {
-			maxDepth = depth
-		}
-	}
-
-	// Mark tasks on critical path (those with max depth)
-	for taskID, depth := range depthMap {
-		if depth == maxDepth {
-			criticalPath[taskID] = true
-			// Mark all dependencies on the path
-			markCriticalPathDependencies(taskID, taskMap, depthMap, maxDepth, criticalPath)
-		}
-	}
-
-	return criticalPath
-}
-
-// markCriticalPathDependencies recursively marks dependencies on critical path
-func markCriticalPathDependencies(
-	taskID string,
-	taskMap map[string]*model.Task,
-	depthMap map[string]int,
-	targetDepth int,
-	criticalPath map[string]bool,
-) {
-	task, exists := taskMap[taskID]
-	if !exists {
-		return
-	}
-
-	for _, depID := range task.Dependencies {
-		if depthMap[depID] == targetDepth-1 {
-			criticalPath[depID] = true
-			markCriticalPathDependencies(depID, taskMap, depthMap, targetDepth-1, criticalPath)
-		}
-	}
-}
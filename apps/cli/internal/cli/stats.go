@@ -2,15 +2,19 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
+	"github.com/driangle/taskmd/apps/cli/internal/history"
 	"github.com/driangle/taskmd/apps/cli/internal/metrics"
 	"github.com/driangle/taskmd/apps/cli/internal/model"
-	"github.com/driangle/taskmd/apps/cli/internal/scanner"
 )
 
 // statsCmd represents the stats command
@@ -26,6 +30,11 @@ var statsCmd = &cobra.Command{
 - Critical path length (longest dependency chain)
 - Maximum dependency depth
 - Average dependencies per task
+- Flow metrics for completed tasks: lead time (created to completed), cycle
+  time (first in-progress to completed) and weekly throughput, overall and
+  by tag, group and owner. Transition dates come from the started and
+  completed frontmatter timestamps, or else the git history of the task
+  files; without either, flow metrics are omitted.
 
 By default, scans the current directory and all subdirectories for markdown files
 with task frontmatter. You can specify a different directory to scan.
@@ -53,7 +62,7 @@ func runStats(cmd *cobra.Command, args []string) error {
 	scanDir := ResolveScanDir(args)
 
 	// Create scanner and scan for tasks
-	taskScanner := scanner.NewScanner(scanDir, flags.Verbose, flags.IgnoreDirs)
+	taskScanner := newScanner(scanDir, flags)
 	result, err := taskScanner.Scan()
 	if err != nil {
 		return fmt.Errorf("scan failed: %w", err)
@@ -71,7 +80,8 @@ func runStats(cmd *cobra.Command, args []string) error {
 	}
 
 	// Calculate metrics
-	m := metrics.Calculate(tasks)
+	m := metrics.Calculate(tasks, loadEffortWeights())
+	m.Flow = loadFlow(tasks, scanDir)
 
 	// Output in requested format
 	switch statsFormat {
@@ -92,8 +102,6 @@ func outputStatsJSON(m *metrics.Metrics) error {
 }
 
 // outputStatsTable outputs metrics in a human-readable table format
-//
-//nolint:gocognit,funlen // TODO: refactor to reduce complexity
 func outputStatsTable(m *metrics.Metrics) error {
 	r := getRenderer()
 
@@ -108,68 +116,126 @@ func outputStatsTable(m *metrics.Metrics) error {
 	fmt.Fprintf(w, "Total Tasks:\t%d\n", m.TotalTasks)
 	fmt.Fprintf(w, "Blocked Tasks:\t%d\n", m.BlockedTasksCount)
 	fmt.Fprintf(w, "Critical Path Length:\t%d\n", m.CriticalPathLength)
+	fmt.Fprintf(w, "Critical Path Effort:\t%s\n", formatHours(m.CriticalPathHours))
 	fmt.Fprintf(w, "Max Dependency Depth:\t%d\n", m.MaxDependencyDepth)
 	fmt.Fprintf(w, "Avg Dependencies/Task:\t%.2f\n", m.AvgDependenciesPerTask)
 	fmt.Fprintln(w)
 
-	// Tasks by status
-	fmt.Fprintln(w, formatLabel("BY STATUS:", r))
-	if len(m.TasksByStatus) > 0 {
-		// Order: pending, in-progress, completed, blocked, cancelled
-		statusOrder := []model.Status{
-			model.StatusPending,
-			model.StatusInProgress,
-			model.StatusCompleted,
-			model.StatusBlocked,
-			model.StatusCancelled,
-		}
-		for _, status := range statusOrder {
-			if count, ok := m.TasksByStatus[status]; ok && count > 0 {
-				fmt.Fprintf(w, "  %s:\t%d\n", formatStatus(string(status), r), count)
-			}
-		}
-	} else {
-		fmt.Fprintln(w, "  (none)")
-	}
+	writeCountsTable(w, formatLabel("BY STATUS:", r), m.TasksByStatus, []model.Status{
+		model.StatusPending,
+		model.StatusInProgress,
+		model.StatusCompleted,
+		model.StatusBlocked,
+		model.StatusCancelled,
+	}, func(s string) string { return formatStatus(s, r) })
 	fmt.Fprintln(w)
 
-	// Tasks by priority
-	fmt.Fprintln(w, formatLabel("BY PRIORITY:", r))
-	if len(m.TasksByPriority) > 0 {
-		// Order: critical, high, medium, low
-		priorityOrder := []model.Priority{
-			model.PriorityCritical,
-			model.PriorityHigh,
-			model.PriorityMedium,
-			model.PriorityLow,
+	writeCountsTable(w, formatLabel("BY PRIORITY:", r), m.TasksByPriority, []model.Priority{
+		model.PriorityCritical,
+		model.PriorityHigh,
+		model.PriorityMedium,
+		model.PriorityLow,
+	}, func(s string) string { return formatPriority(s, r) })
+	fmt.Fprintln(w)
+
+	writeCountsTable(w, formatLabel("BY EFFORT:", r), m.TasksByEffort, []model.Effort{
+		model.EffortSmall,
+		model.EffortMedium,
+		model.EffortLarge,
+	}, func(s string) string { return formatEffort(s, r) })
+
+	if m.Flow != nil {
+		fmt.Fprintln(w)
+		writeFlowTable(w, m.Flow, r)
+	}
+
+	return nil
+}
+
+// writeCountsTable prints a labelled section with the non-zero counts in
+// the given order, or "(none)" when there are no counts.
+func writeCountsTable[K ~string](w io.Writer, label string, counts map[K]int, order []K, format func(string) string) {
+	fmt.Fprintln(w, label)
+	if len(counts) == 0 {
+		fmt.Fprintln(w, "  (none)")
+		return
+	}
+	for _, key := range order {
+		if count := counts[key]; count > 0 {
+			fmt.Fprintf(w, "  %s:\t%d\n", format(string(key)), count)
 		}
-		for _, priority := range priorityOrder {
-			if count, ok := m.TasksByPriority[priority]; ok && count > 0 {
-				fmt.Fprintf(w, "  %s:\t%d\n", formatPriority(string(priority), r), count)
+	}
+}
+
+// flowTableRows caps each per-key breakdown in the table; JSON has them all.
+const flowTableRows = 10
+
+// writeFlowTable prints lead time, cycle time and throughput in days.
+func writeFlowTable(w io.Writer, f *metrics.Flow, r *lipgloss.Renderer) {
+	fmt.Fprintln(w, formatLabel("FLOW (days):", r))
+	fmt.Fprintf(w, "  Completed:\t%d\n", f.Completed)
+	fmt.Fprintf(w, "  Lead Time:\t%s\n", formatDurationStats(f.LeadTime))
+	fmt.Fprintf(w, "  Cycle Time:\t%s\n", formatDurationStats(f.CycleTime))
+	counts := make([]string, len(f.Throughput))
+	for i, wk := range f.Throughput {
+		counts[i] = strconv.Itoa(wk.Completed)
+	}
+	fmt.Fprintf(w, "  Throughput/Week:\t%s %s\n", strings.Join(counts, " "),
+		formatDim(fmt.Sprintf("(last %d weeks, oldest first)", len(counts)), r))
+
+	for _, section := range flowSections(f) {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, formatLabel("FLOW BY "+strings.ToUpper(section.Label)+":", r))
+		for i, g := range section.Groups {
+			if i == flowTableRows {
+				fmt.Fprintln(w, formatDim(fmt.Sprintf("  ... %d more", len(section.Groups)-i), r))
+				break
 			}
+			fmt.Fprintf(w, "  %s:\t%d done\tlead %s\tcycle %s\n", g.Key, g.Completed,
+				formatMedianP85(g.LeadTime), formatMedianP85(g.CycleTime))
 		}
-	} else {
-		fmt.Fprintln(w, "  (none)")
 	}
-	fmt.Fprintln(w)
+}
 
-	// Tasks by effort
-	fmt.Fprintln(w, formatLabel("BY EFFORT:", r))
-	if len(m.TasksByEffort) > 0 {
-		// Order: small, medium, large
-		effortOrder := []model.Effort{
-			model.EffortSmall,
-			model.EffortMedium,
-			model.EffortLarge,
-		}
-		for _, effort := range effortOrder {
-			if count, ok := m.TasksByEffort[effort]; ok && count > 0 {
-				fmt.Fprintf(w, "  %s:\t%d\n", formatEffort(string(effort), r), count)
-			}
+// flowSection is a non-empty per-key breakdown of flow metrics.
+type flowSection struct {
+	Label  string
+	Groups []metrics.FlowGroup
+}
+
+func flowSections(f *metrics.Flow) []flowSection {
+	var sections []flowSection
+	for _, s := range []flowSection{{"Tag", f.ByTag}, {"Group", f.ByGroup}, {"Owner", f.ByOwner}} {
+		if len(s.Groups) > 0 {
+			sections = append(sections, s)
 		}
-	} else {
-		fmt.Fprintln(w, "  (none)")
 	}
+	return sections
+}
+
+// formatDurationStats renders percentiles like "p50 2.5  p85 6  p95 9  (n=12)".
+func formatDurationStats(d metrics.DurationStats) string {
+	if d.Count == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("p50 %s  p85 %s  p95 %s  (n=%d)", formatDays(d.P50), formatDays(d.P85), formatDays(d.P95), d.Count)
+}
 
-	return nil
+func formatMedianP85(d metrics.DurationStats) string {
+	if d.Count == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("p50 %s p85 %s", formatDays(d.P50), formatDays(d.P85))
+}
+
+func formatDays(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// loadFlow computes flow metrics from the started and completed timestamps
+// in frontmatter and the git history of scanDir. It is nil when no completion
+// date is known.
+func loadFlow(tasks []*model.Task, scanDir string) *metrics.Flow {
+	dates, _ := history.TaskDates(tasks, scanDir) // outside git, frontmatter only
+	return metrics.CalculateFlow(tasks, dates, time.Now())
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/driangle/taskmd/apps/cli/internal/model"
 	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
 )
 
@@ -17,7 +18,7 @@ var validPriorityValues = []string{"low", "medium", "high", "critical"}
 var validEffortValues = []string{"small", "medium", "large"}
 
 // validSortFields lists all valid sort field values for the list command.
-var validSortFields = []string{"id", "title", "status", "priority", "effort", "created"}
+var validSortFields = model.SortFields
 
 // suggestValue finds the closest match from valid options using Levenshtein distance.
 // Returns the closest match, or empty string if no reasonable match exists.
@@ -56,6 +57,9 @@ func validateSetEnums(req taskfile.UpdateRequest) error {
 	if req.Effort != nil && !contains(validEffortValues, *req.Effort) {
 		return invalidValueError("effort", *req.Effort, validEffortValues)
 	}
+	if errs := taskfile.ValidateUpdateRequest(taskfile.UpdateRequest{Due: req.Due, Start: req.Start, Scheduled: req.Scheduled}); len(errs) > 0 {
+		return fmt.Errorf("%s", errs[0])
+	}
 	return nil
 }
 
@@ -1,26 +1,47 @@
 package cli
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/driangle/taskmd/apps/cli/internal/sync"
+	_ "github.com/driangle/taskmd/apps/cli/internal/sync/file"   // register file sync source
 	_ "github.com/driangle/taskmd/apps/cli/internal/sync/github" // register github sync source
+	_ "github.com/driangle/taskmd/apps/cli/internal/sync/gitlab" // register gitlab sync source
+	_ "github.com/driangle/taskmd/apps/cli/internal/sync/jira"   // register jira sync source
+	_ "github.com/driangle/taskmd/apps/cli/internal/sync/linear" // register linear sync source
 )
 
 var (
 	syncDryRun   bool
 	syncSource   string
 	syncConflict string
+	syncPush     bool
 )
 
+// syncStdinReader is the reader used for interactive conflict prompts.
+// Override in tests to simulate user input.
+var syncStdinReader io.Reader = os.Stdin
+
 var syncCmd = &cobra.Command{
 	Use:   "sync",
 	Short: "Sync tasks from external sources",
-	Long: `Sync fetches tasks from configured external sources (GitHub Issues, Jira, etc.)
-and creates or updates local markdown task files.
+	Long: `Sync fetches tasks from configured external sources (GitHub Issues, GitLab, Jira, etc.)
+and creates or updates local markdown task files. The "file" source imports
+tasks from a local CSV, JSON or todo.txt file the same way.
+
+With --push, local edits to title, status, labels and assignee since the last
+sync are sent back to the source, using the field_map in reverse.
+
+Tasks changed on both sides are merged field by field against the values
+recorded at the last sync: a field edited on one side only takes that side's
+value, and only fields edited differently on both sides are conflicts. The
+--conflict strategy decides those fields; "interactive" asks for each one.
 
 Configuration is read from .taskmd.yaml in the current directory.
 
@@ -29,7 +50,9 @@ Examples:
   taskmd sync --dry-run
   taskmd sync --source github
   taskmd sync --conflict remote
-  taskmd sync --conflict local`,
+  taskmd sync --conflict local
+  taskmd sync --conflict interactive
+  taskmd sync --push`,
 	Args: cobra.NoArgs,
 	RunE: runSync,
 }
@@ -39,16 +62,17 @@ func init() {
 
 	syncCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "preview changes without writing files")
 	syncCmd.Flags().StringVar(&syncSource, "source", "", "sync only the named source")
-	syncCmd.Flags().StringVar(&syncConflict, "conflict", "skip", "conflict resolution strategy: skip, remote, local")
+	syncCmd.Flags().StringVar(&syncConflict, "conflict", "skip", "conflict resolution strategy: skip, remote, local, interactive")
+	syncCmd.Flags().BoolVar(&syncPush, "push", false, "push local edits back to sources that support it")
 }
 
 func runSync(_ *cobra.Command, _ []string) error {
 	flags := GetGlobalFlags()
 
 	switch syncConflict {
-	case sync.ConflictSkip, sync.ConflictRemote, sync.ConflictLocal:
+	case sync.ConflictSkip, sync.ConflictRemote, sync.ConflictLocal, sync.ConflictInteractive:
 	default:
-		return fmt.Errorf("invalid --conflict value %q: must be skip, remote, or local", syncConflict)
+		return fmt.Errorf("invalid --conflict value %q: must be skip, remote, local, or interactive", syncConflict)
 	}
 
 	cfg, err := sync.LoadConfig(".")
@@ -61,6 +85,14 @@ func runSync(_ *cobra.Command, _ []string) error {
 		Verbose:          flags.Verbose,
 		DryRun:           syncDryRun,
 		ConflictStrategy: syncConflict,
+		Push:             syncPush,
+		Timestamps:       loadTimestampConfig(),
+	}
+	if syncConflict == sync.ConflictInteractive {
+		reader := bufio.NewReader(syncStdinReader)
+		engine.Resolve = func(action sync.SyncAction, c sync.FieldConflict) string {
+			return promptConflict(reader, action, c)
+		}
 	}
 
 	sources := cfg.Sources
@@ -96,6 +128,24 @@ func filterSources(sources []sync.SourceConfig, name string) []sync.SourceConfig
 	return nil
 }
 
+// promptConflict asks which side should win a single field conflict.
+// Anything other than l or r leaves the field unresolved.
+func promptConflict(reader *bufio.Reader, action sync.SyncAction, c sync.FieldConflict) string {
+	fmt.Fprintf(os.Stderr, "Conflict in [%s] %s, field %q:\n", action.LocalID, action.Title, c.Field)
+	fmt.Fprintf(os.Stderr, "  base:   %s\n  local:  %s\n  remote: %s\n", c.Base, c.Local, c.Remote)
+	fmt.Fprint(os.Stderr, "Keep [l]ocal, take [r]emote, or [s]kip? ")
+
+	line, _ := reader.ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "l", "local":
+		return sync.ConflictLocal
+	case "r", "remote":
+		return sync.ConflictRemote
+	default:
+		return sync.ConflictSkip
+	}
+}
+
 func printSyncResult(_ string, result *sync.SyncResult, quietMode bool) {
 	if quietMode {
 		return
@@ -115,10 +165,25 @@ func printSyncResult(_ string, result *sync.SyncResult, quietMode bool) {
 		}
 	}
 
+	if len(result.Pushed) > 0 {
+		fmt.Printf("  Pushed %d task(s):\n", len(result.Pushed))
+		for _, a := range result.Pushed {
+			fmt.Printf("    ^ [%s] %s\n", a.LocalID, a.Title)
+		}
+	}
+
+	if len(result.Removed) > 0 {
+		fmt.Printf("  Removed %d task(s) (missing from source):\n", len(result.Removed))
+		for _, a := range result.Removed {
+			fmt.Printf("    - [%s] %s\n", a.LocalID, a.Title)
+		}
+	}
+
 	if len(result.Conflicts) > 0 {
-		fmt.Printf("  Conflicts %d task(s) (skipped, local changes detected):\n", len(result.Conflicts))
+		fmt.Printf("  Conflicts %d task(s) (skipped fields changed on both sides):\n", len(result.Conflicts))
 		for _, a := range result.Conflicts {
 			fmt.Printf("    ! [%s] %s\n", a.LocalID, a.Title)
+			printFieldConflicts(a.Conflicts)
 		}
 	}
 
@@ -133,7 +198,18 @@ func printSyncResult(_ string, result *sync.SyncResult, quietMode bool) {
 		}
 	}
 
-	total := len(result.Created) + len(result.Updated) + len(result.Skipped) + len(result.Conflicts)
-	fmt.Printf("  Done: %d total, %d created, %d updated, %d skipped, %d conflicts\n",
-		total, len(result.Created), len(result.Updated), len(result.Skipped), len(result.Conflicts))
+	total := len(result.Created) + len(result.Updated) + len(result.Pushed) + len(result.Removed) +
+		len(result.Skipped) + len(result.Conflicts)
+	fmt.Printf("  Done: %d total, %d created, %d updated, %d pushed, %d removed, %d skipped, %d conflicts\n",
+		total, len(result.Created), len(result.Updated), len(result.Pushed), len(result.Removed),
+		len(result.Skipped), len(result.Conflicts))
+}
+
+func printFieldConflicts(conflicts []sync.FieldConflict) {
+	for _, c := range conflicts {
+		if c.Resolution != sync.ConflictSkip {
+			continue
+		}
+		fmt.Printf("        %s: local %q, remote %q (was %q)\n", c.Field, c.Local, c.Remote, c.Base)
+	}
 }
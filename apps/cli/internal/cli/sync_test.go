@@ -219,3 +219,90 @@ func (m *cliMockSource) ValidateConfig(_ sync.SourceConfig) error { return nil }
 func (m *cliMockSource) FetchTasks(_ sync.SourceConfig) ([]sync.ExternalTask, error) {
 	return m.tasks, nil
 }
+
+func TestSyncCommand_PushUnsupportedSource(t *testing.T) {
+	sourceName := "test-cli-push"
+	defer sync.Unregister(sourceName)
+
+	sync.Register(&cliMockSource{name: sourceName})
+
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(origDir)
+
+	configContent := "sync:\n  sources:\n    - name: " + sourceName + "\n      output_dir: \"tasks\"\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, ".taskmd.yaml"), []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	syncDryRun = false
+	syncSource = ""
+	syncConflict = "skip"
+	syncPush = true
+	defer func() { syncPush = false }()
+
+	err := runSync(syncCmd, nil)
+	if err == nil {
+		t.Fatal("expected error for source without push support")
+	}
+	if !strings.Contains(err.Error(), "does not support pushing") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+}
+
+func TestSyncCommand_InteractiveConflict(t *testing.T) {
+	sourceName := "test-cli-interactive"
+	defer sync.Unregister(sourceName)
+
+	src := &cliMockSource{
+		name:  sourceName,
+		tasks: []sync.ExternalTask{{ExternalID: "CLI-1", Title: "Original", Status: "open"}},
+	}
+	sync.Register(src)
+
+	tmpDir := t.TempDir()
+	origDir, _ := os.Getwd()
+	if err := os.Chdir(tmpDir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(origDir)
+
+	configContent := "sync:\n  sources:\n    - name: " + sourceName + "\n      output_dir: \"tasks\"\n      field_map:\n        status:\n          open: pending\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, ".taskmd.yaml"), []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	syncDryRun = false
+	syncSource = ""
+	syncConflict = "interactive"
+	defer func() { syncConflict = "skip" }()
+
+	if err := runSync(syncCmd, nil); err != nil {
+		t.Fatalf("first sync error: %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(tmpDir, "tasks", "*.md"))
+	if len(files) != 1 {
+		t.Fatalf("expected 1 task file, got %d", len(files))
+	}
+	data, _ := os.ReadFile(files[0])
+	if err := os.WriteFile(files[0], []byte(strings.Replace(string(data), `"Original"`, `"Local title"`, 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	src.tasks[0].Title = "Remote title"
+
+	syncStdinReader = strings.NewReader("r\n")
+	defer func() { syncStdinReader = os.Stdin }()
+
+	if err := runSync(syncCmd, nil); err != nil {
+		t.Fatalf("second sync error: %v", err)
+	}
+
+	data, _ = os.ReadFile(files[0])
+	if !strings.Contains(string(data), `title: "Remote title"`) {
+		t.Errorf("expected remote title after choosing remote, got:\n%s", data)
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/driangle/taskmd/apps/cli/internal/model"
-	"github.com/driangle/taskmd/apps/cli/internal/scanner"
 )
 
 var (
@@ -35,6 +34,7 @@ with task frontmatter. You can specify a different directory to scan.
 
 Output formats: table (default), json, yaml
 
+Filters use the same expression language as list (e.g. "status!=completed").
 Multiple --filter flags are combined with AND logic.
 
 Examples:
@@ -59,7 +59,7 @@ func runTags(cmd *cobra.Command, args []string) error {
 
 	scanDir := ResolveScanDir(args)
 
-	taskScanner := scanner.NewScanner(scanDir, flags.Verbose, flags.IgnoreDirs)
+	taskScanner := newScanner(scanDir, flags)
 	result, err := taskScanner.Scan()
 	if err != nil {
 		return fmt.Errorf("scan failed: %w", err)
@@ -76,7 +76,7 @@ func runTags(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(tagsFilters) > 0 {
-		tasks, err = applyFilters(tasks, tagsFilters)
+		tasks, err = applyFilters(tasks, tagsFilters, result.ArchivedIDs)
 		if err != nil {
 			return fmt.Errorf("filter error: %w", err)
 		}
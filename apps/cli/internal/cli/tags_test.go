@@ -197,7 +197,7 @@ func TestAggregateTags_WithFiltering(t *testing.T) {
 	}
 
 	// Filter to pending only, then aggregate
-	filtered, err := applyFilters(tasks, []string{"status=pending"})
+	filtered, err := applyFilters(tasks, []string{"status=pending"}, nil)
 	if err != nil {
 		t.Fatalf("filter failed: %v", err)
 	}
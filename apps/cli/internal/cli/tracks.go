@@ -9,7 +9,6 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
-	"github.com/driangle/taskmd/apps/cli/internal/scanner"
 	"github.com/driangle/taskmd/apps/cli/internal/tracks"
 )
 
@@ -30,7 +29,9 @@ Tasks without a "touches" field are shown as "flexible" and can be assigned
 to any track.
 
 Scope definitions can be configured in .taskmd.yaml under the "scopes" key.
-Unknown scopes produce warnings when scopes are configured.
+Unknown scopes produce warnings when scopes are configured. Tasks within a
+track are ordered by the same score as "taskmd next", including any weights
+and boosts from the "next" key.
 
 Output formats: table (default), json, yaml
 
@@ -48,7 +49,7 @@ func init() {
 	rootCmd.AddCommand(tracksCmd)
 
 	tracksCmd.Flags().StringVar(&tracksFormat, "format", "table", "output format (table, json, yaml)")
-	tracksCmd.Flags().StringArrayVar(&tracksFilters, "filter", []string{}, "filter tasks (e.g., --filter tag=cli)")
+	tracksCmd.Flags().StringArrayVar(&tracksFilters, "filter", []string{}, "filter expression (e.g., --filter \"tag=cli or tag=web\")")
 	tracksCmd.Flags().IntVar(&tracksLimit, "limit", 0, "maximum number of tracks to show (0 = unlimited)")
 }
 
@@ -56,7 +57,7 @@ func runTracks(cmd *cobra.Command, args []string) error {
 	flags := GetGlobalFlags()
 	scanDir := ResolveScanDir(args)
 
-	taskScanner := scanner.NewScanner(scanDir, flags.Verbose, flags.IgnoreDirs)
+	taskScanner := newScanner(scanDir, flags)
 	scanResult, err := taskScanner.Scan()
 	if err != nil {
 		return fmt.Errorf("scan failed: %w", err)
@@ -66,10 +67,13 @@ func runTracks(cmd *cobra.Command, args []string) error {
 	makeFilePathsRelative(allTasks, scanDir)
 
 	knownScopes := loadScopesConfig()
+	scoring := loadNextConfig()
 
 	result, err := tracks.Assign(allTasks, tracks.Options{
 		Filters:     tracksFilters,
 		KnownScopes: knownScopes,
+		Scoring:     &scoring,
+		Archived:    scanResult.ArchivedIDs,
 	})
 	if err != nil {
 		return err
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
+)
+
+var unarchiveDryRun bool
+
+var unarchiveCmd = &cobra.Command{
+	Use:   "unarchive <task-id>...",
+	Short: "Restore archived tasks",
+	Long: `Unarchive moves task files out of the archive/ subdirectory back to where
+they were archived from, together with their worklogs.
+
+Examples:
+  taskmd unarchive 042
+  taskmd unarchive 042 043 --dry-run`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runUnarchive,
+}
+
+func init() {
+	rootCmd.AddCommand(unarchiveCmd)
+
+	unarchiveCmd.Flags().BoolVar(&unarchiveDryRun, "dry-run", false, "preview changes without making them")
+}
+
+func runUnarchive(_ *cobra.Command, args []string) error {
+	flags := GetGlobalFlags()
+	flags.IncludeArchived = true
+	scanDir := ResolveScanDir(nil)
+
+	result, err := newScanner(scanDir, flags).Scan()
+	if err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+
+	selected, err := findArchivedTasks(args, result.Tasks)
+	if err != nil {
+		return err
+	}
+
+	absScanDir, err := filepath.Abs(scanDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve scan directory: %w", err)
+	}
+
+	printArchivePreview(selected, "Restore", absScanDir)
+
+	r := getRenderer()
+	if unarchiveDryRun {
+		fmt.Println("\n" + formatWarning("Dry run — no changes made.", r))
+		return nil
+	}
+
+	for _, task := range selected {
+		if _, err := taskfile.UnarchiveTask(task); err != nil {
+			return err
+		}
+	}
+	fmt.Println(formatSuccess(fmt.Sprintf("Restored %d task(s).", len(selected)), r))
+	return nil
+}
+
+// findArchivedTasks resolves each ID to an archived task. It fails if an ID
+// is not archived, or if an active task already uses it.
+func findArchivedTasks(ids []string, tasks []*model.Task) ([]*model.Task, error) {
+	var selected []*model.Task
+	for _, id := range ids {
+		var archived, active *model.Task
+		for _, task := range tasks {
+			if task.ID != id {
+				continue
+			}
+			if task.Archived {
+				archived = task
+			} else {
+				active = task
+			}
+		}
+		switch {
+		case archived == nil && active != nil:
+			return nil, fmt.Errorf("task %s is not archived", id)
+		case archived == nil:
+			return nil, fmt.Errorf("archived task not found: %s", id)
+		case active != nil:
+			return nil, fmt.Errorf("cannot restore %s: an active task with that ID already exists (%s)", id, active.FilePath)
+		}
+		selected = append(selected, archived)
+	}
+	return selected, nil
+}
@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func captureUnarchiveOutput(t *testing.T, args []string) (string, error) {
+	t.Helper()
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runUnarchive(unarchiveCmd, args)
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	return buf.String(), err
+}
+
+// createArchivedTestFiles archives cli/004-api.md and its worklog.
+func createArchivedTestFiles(t *testing.T) string {
+	t.Helper()
+	tmpDir := createArchiveTestFilesWithSubdir(t)
+	writeWorklog(t, filepath.Join(tmpDir, "cli"), "004")
+
+	resetArchiveFlags()
+	taskDir = tmpDir
+	archiveIDs = []string{"004"}
+	archiveYes = true
+	if _, err := captureArchiveOutput(t); err != nil {
+		t.Fatalf("archive failed: %v", err)
+	}
+	unarchiveDryRun = false
+	return tmpDir
+}
+
+func TestUnarchive(t *testing.T) {
+	tmpDir := createArchivedTestFiles(t)
+
+	output, err := captureUnarchiveOutput(t, []string{"004"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "Restored 1 task(s)") {
+		t.Errorf("expected restore confirmation, got: %s", output)
+	}
+
+	for _, path := range []string{
+		filepath.Join(tmpDir, "cli", "004-api.md"),
+		filepath.Join(tmpDir, "cli", ".worklogs", "004.md"),
+	} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("expected %s to be restored: %v", path, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "archive", "cli", "004-api.md")); !os.IsNotExist(err) {
+		t.Error("expected the archived file to be moved")
+	}
+}
+
+func TestUnarchive_DryRun(t *testing.T) {
+	tmpDir := createArchivedTestFiles(t)
+	unarchiveDryRun = true
+	defer func() { unarchiveDryRun = false }()
+
+	output, err := captureUnarchiveOutput(t, []string{"004"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(output, "Dry run") {
+		t.Errorf("expected dry run notice, got: %s", output)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "archive", "cli", "004-api.md")); err != nil {
+		t.Error("expected the file to stay archived")
+	}
+}
+
+func TestUnarchive_Errors(t *testing.T) {
+	tmpDir := createArchivedTestFiles(t)
+
+	if _, err := captureUnarchiveOutput(t, []string{"001"}); err == nil || !strings.Contains(err.Error(), "not archived") {
+		t.Errorf("expected a not archived error, got %v", err)
+	}
+	if _, err := captureUnarchiveOutput(t, []string{"999"}); err == nil || !strings.Contains(err.Error(), "archived task not found") {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+
+	// An active task reusing the ID blocks the restore.
+	if err := os.WriteFile(filepath.Join(tmpDir, "004-new.md"), []byte(taskCompletedBackend), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := captureUnarchiveOutput(t, []string{"004"}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected an ID conflict error, got %v", err)
+	}
+}
+
+func TestUnarchive_NestedArchiveDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	// Sync's archive policy archives into <output dir>/archive/.
+	archived := filepath.Join(tmpDir, "github", "archive", "004-api.md")
+	if err := os.MkdirAll(filepath.Dir(archived), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(archived, []byte(taskCompletedBackend), 0644); err != nil {
+		t.Fatal(err)
+	}
+	resetArchiveFlags()
+	taskDir = tmpDir
+	unarchiveDryRun = false
+
+	if _, err := captureUnarchiveOutput(t, []string{"004"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "github", "004-api.md")); err != nil {
+		t.Errorf("expected the task to be restored next to the archive dir: %v", err)
+	}
+}
+
+func TestUnarchive_AfterAddKeepsArchivedID(t *testing.T) {
+	tmpDir := createArchiveTestFiles(t)
+	resetArchiveFlags()
+	taskDir = tmpDir
+	archiveIDs = []string{"004"}
+	archiveYes = true
+	if _, err := captureArchiveOutput(t); err != nil {
+		t.Fatalf("archive failed: %v", err)
+	}
+
+	// 004 is the highest ID but archived; add must not hand it out again.
+	resetAddFlags()
+	taskDir = tmpDir
+	output, err := captureAddOutput(t, []string{"Three"})
+	if err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+	if !strings.Contains(output, "Created task 005") {
+		t.Errorf("expected the new task to get 005, got: %s", output)
+	}
+
+	unarchiveDryRun = false
+	output, err = captureUnarchiveOutput(t, []string{"004"})
+	if err != nil {
+		t.Fatalf("unarchive failed: %v", err)
+	}
+	if !strings.Contains(output, "Restored 1 task(s)") {
+		t.Errorf("expected restore confirmation, got: %s", output)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "004-api.md")); err != nil {
+		t.Errorf("expected 004 to be restored: %v", err)
+	}
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/driangle/taskmd/apps/cli/internal/model"
-	"github.com/driangle/taskmd/apps/cli/internal/scanner"
 	"github.com/driangle/taskmd/apps/cli/internal/validator"
 )
 
@@ -69,7 +68,7 @@ func runValidate(cmd *cobra.Command, args []string) error {
 	scanDir := ResolveScanDir(args)
 
 	// Create scanner and scan for tasks
-	taskScanner := scanner.NewScanner(scanDir, flags.Verbose, flags.IgnoreDirs)
+	taskScanner := newScanner(scanDir, flags)
 	result, err := taskScanner.Scan()
 	if err != nil {
 		return fmt.Errorf("scan failed: %w", err)
@@ -88,8 +87,8 @@ func runValidate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Run validation
-	v := validator.NewValidator(validateStrict)
+	// Run validation; dependencies on archived tasks count as completed
+	v := validator.NewValidator(validateStrict).WithArchived(result.ArchivedIDs)
 	validationResult := v.Validate(tasks)
 	validateConfig(v, validationResult, tasks)
 
@@ -222,6 +221,10 @@ func loadConfigForValidation() *validator.ConfigData {
 	config := &validator.ConfigData{
 		TopKeys:    topKeys,
 		ConfigPath: configPath,
+		Views:      viper.Get("views"),
+		Next:       viper.Get("next"),
+		Effort:     viper.Get("effort"),
+		Timestamps: viper.Get("timestamps"),
 	}
 
 	raw := viper.Get("scopes")
@@ -0,0 +1,19 @@
+package cli
+
+import (
+	"github.com/spf13/viper"
+
+	"github.com/driangle/taskmd/apps/cli/internal/views"
+)
+
+// loadViews reads saved views from the views section of .taskmd.yaml.
+// Malformed entries are reported by `taskmd validate`, not here.
+func loadViews() views.Views {
+	v, _ := views.Parse(viper.Get("views"))
+	return v
+}
+
+// resolveView looks up a saved view by name.
+func resolveView(name string) (views.View, error) {
+	return loadViews().Get(name)
+}
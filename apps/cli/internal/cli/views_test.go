@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// setTestViews installs saved views in viper for the duration of the test.
+func setTestViews(t *testing.T, v map[string]any) {
+	t.Helper()
+	viper.Set("views", v)
+	t.Cleanup(func() { viper.Set("views", nil) })
+}
+
+func TestListCommand_View(t *testing.T) {
+	tmpDir := createBoardTestFiles(t)
+	resetListFlags()
+	setTestViews(t, map[string]any{
+		"open": map[string]any{
+			"filter":  "status!=completed",
+			"sort":    "priority",
+			"columns": []any{"id", "title"},
+		},
+	})
+	listView = "open"
+
+	output, err := captureListOutput(t, tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(output, "Setup project") {
+		t.Error("expected completed task to be filtered out by view")
+	}
+	if !strings.Contains(output, "Implement authentication") {
+		t.Error("expected pending task in output")
+	}
+	if strings.Contains(output, "status") {
+		t.Error("expected view columns to replace default columns")
+	}
+	authIdx := strings.Index(output, "Implement authentication")
+	uiIdx := strings.Index(output, "Build UI components")
+	if uiIdx >= 0 && authIdx > uiIdx {
+		t.Error("expected critical task before medium task with priority sort")
+	}
+}
+
+func TestListCommand_ViewCombinesWithFilter(t *testing.T) {
+	tmpDir := createBoardTestFiles(t)
+	resetListFlags()
+	setTestViews(t, map[string]any{
+		"open": map[string]any{"filter": "status!=completed"},
+	})
+	listView = "open"
+	listFilters = []string{"tag=frontend"}
+
+	output, err := captureListOutput(t, tmpDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(output, "Implement authentication") {
+		t.Error("expected --filter to narrow view results")
+	}
+	if !strings.Contains(output, "Build UI components") {
+		t.Error("expected frontend task in output")
+	}
+}
+
+func TestListCommand_UnknownView(t *testing.T) {
+	tmpDir := createBoardTestFiles(t)
+	resetListFlags()
+	setTestViews(t, map[string]any{"open": map[string]any{}})
+	listView = "missing"
+
+	_, err := captureListOutput(t, tmpDir)
+	if err == nil {
+		t.Fatal("expected error for unknown view")
+	}
+	if !strings.Contains(err.Error(), "unknown view") || !strings.Contains(err.Error(), "open") {
+		t.Errorf("expected error listing available views, got: %v", err)
+	}
+}
+
+func TestBoardCommand_View(t *testing.T) {
+	tmpDir := createBoardTestFiles(t)
+	resetBoardFlags()
+	setTestViews(t, map[string]any{
+		"by-priority": map[string]any{
+			"filter":   "status!=completed",
+			"group_by": "priority",
+		},
+	})
+	boardView = "by-priority"
+
+	output := captureBoardOutput(t, tmpDir)
+
+	if !strings.Contains(output, "## critical") {
+		t.Errorf("expected board grouped by priority, got:\n%s", output)
+	}
+	if strings.Contains(output, "Setup project") {
+		t.Error("expected completed task to be filtered out by view")
+	}
+}
@@ -86,6 +86,11 @@ func runWebStart(cmd *cobra.Command, _ []string) error {
 		Verbose:  flags.Verbose,
 		ReadOnly: webReadOnly,
 		Version:  FullVersion(),
+		Views:    loadViews(),
+		Next:     loadNextConfig(),
+
+		Timestamps:      *loadTimestampConfig(),
+		IncludeArchived: flags.IncludeArchived,
 	})
 
 	ctx, cancel := signal.NotifyContext(
@@ -0,0 +1,317 @@
+package filter
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+	"github.com/driangle/taskmd/apps/cli/internal/nextid"
+)
+
+// Env carries the context some predicates need beyond the task itself.
+type Env struct {
+	// Tasks indexes all known tasks by ID; used to resolve dependencies.
+	Tasks map[string]*model.Task
+}
+
+// WithArchived makes dependencies on the given archived task IDs resolve as
+// completed.
+func (e *Env) WithArchived(archivedIDs []string) *Env {
+	model.AddArchived(e.Tasks, archivedIDs)
+	return e
+}
+
+// NewEnv builds an Env indexing the given tasks by ID.
+func NewEnv(tasks []*model.Task) *Env {
+	index := make(map[string]*model.Task, len(tasks))
+	for _, t := range tasks {
+		index[t.ID] = t
+	}
+	return &Env{Tasks: index}
+}
+
+type fieldKind int
+
+const (
+	kindString  fieldKind = iota // exact match, glob with * ? [
+	kindText                     // case-insensitive substring, or glob
+	kindList                     // matches if any element matches
+	kindOrdered                  // string with a fixed ordering
+	kindDate                     // YYYY-MM-DD or today[+-N]d, compared by day
+	kindBool                     // computed true/false predicate
+	kindID                       // string, ordered numerically when possible
+)
+
+type fieldDef struct {
+	kind     fieldKind
+	value    func(*model.Task) string
+	list     func(*model.Task) []string
+	date     func(*model.Task) time.Time
+	pred     func(*model.Task, *Env) bool
+	order    []string // kindOrdered: values from lowest to highest
+	presence bool     // "true"/"false" test whether the field is set
+}
+
+var fields = map[string]fieldDef{
+	"id":        {kind: kindID, value: func(t *model.Task) string { return t.ID }},
+	"title":     {kind: kindText, value: func(t *model.Task) string { return t.Title }},
+	"status":    {kind: kindString, value: func(t *model.Task) string { return string(t.Status) }},
+	"group":     {kind: kindString, value: func(t *model.Task) string { return t.Group }},
+	"owner":     {kind: kindString, value: func(t *model.Task) string { return t.Owner }},
+	"parent":    {kind: kindString, value: func(t *model.Task) string { return t.Parent }, presence: true},
+	"priority":  {kind: kindOrdered, value: func(t *model.Task) string { return string(t.Priority) }, order: []string{"low", "medium", "high", "critical"}},
+	"effort":    {kind: kindOrdered, value: func(t *model.Task) string { return string(t.Effort) }, order: []string{"small", "medium", "large"}},
+	"tag":       {kind: kindList, list: func(t *model.Task) []string { return t.Tags }},
+	"touches":   {kind: kindList, list: func(t *model.Task) []string { return t.Touches }},
+	"context":   {kind: kindList, list: func(t *model.Task) []string { return t.Context }},
+	"depends":   {kind: kindList, list: func(t *model.Task) []string { return t.Dependencies }},
+	"created":   {kind: kindDate, date: func(t *model.Task) time.Time { return t.Created }},
+	"due":       {kind: kindDate, date: func(t *model.Task) time.Time { return t.Due }},
+	"start":     {kind: kindDate, date: func(t *model.Task) time.Time { return t.Start }},
+	"scheduled": {kind: kindDate, date: func(t *model.Task) time.Time { return t.Scheduled }},
+	"started":   {kind: kindDate, date: func(t *model.Task) time.Time { return t.Started }},
+	"completed": {kind: kindDate, date: func(t *model.Task) time.Time { return t.Completed }},
+	"blocked":   {kind: kindBool, pred: isBlocked},
+	"overdue":   {kind: kindBool, pred: func(t *model.Task, _ *Env) bool { return t.IsOverdue(now()) }},
+}
+
+var fieldAliases = map[string]string{
+	"tags":         "tag",
+	"dependencies": "depends",
+	"dep":          "depends",
+}
+
+// Fields returns the sorted names of all filterable fields.
+func Fields() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lookupField(name string) (fieldDef, bool) {
+	if alias, ok := fieldAliases[name]; ok {
+		name = alias
+	}
+	def, ok := fields[name]
+	return def, ok
+}
+
+func isBoolField(name string) bool {
+	def, ok := lookupField(name)
+	return ok && def.kind == kindBool
+}
+
+// isBlocked reports whether any dependency is missing or not yet completed.
+func isBlocked(task *model.Task, env *Env) bool {
+	for _, depID := range task.Dependencies {
+		dep, ok := env.Tasks[depID]
+		if !ok || dep.Status != model.StatusCompleted {
+			return true
+		}
+	}
+	return false
+}
+
+func isOrdering(op Op) bool {
+	return op == OpLt || op == OpLe || op == OpGt || op == OpGe
+}
+
+// compileComparison validates a comparison and returns its predicate.
+func compileComparison(c *Comparison) (func(*model.Task, *Env) bool, error) {
+	def, ok := lookupField(c.Field)
+	if !ok {
+		return nil, fmt.Errorf("unknown filter field %q (valid: %s)", c.Field, strings.Join(Fields(), ", "))
+	}
+
+	if isOrdering(c.Op) {
+		return compileOrdering(c, def)
+	}
+
+	if def.kind == kindBool {
+		return compileBool(c, def)
+	}
+
+	if def.presence && (c.Op == OpEq || c.Op == OpNe) && (c.Values[0] == "true" || c.Values[0] == "false") {
+		want := (c.Values[0] == "true") == (c.Op == OpEq)
+		return func(t *model.Task, _ *Env) bool { return (def.value(t) != "") == want }, nil
+	}
+
+	matchOne, err := valueMatcher(c, def)
+	if err != nil {
+		return nil, err
+	}
+
+	matchAny := func(t *model.Task) bool {
+		return slices.ContainsFunc(c.Values, func(v string) bool { return matchOne(t, v) })
+	}
+
+	switch c.Op {
+	case OpEq, OpIn:
+		return func(t *model.Task, _ *Env) bool { return matchAny(t) }, nil
+	default: // OpNe, OpNotIn
+		return func(t *model.Task, _ *Env) bool { return !matchAny(t) }, nil
+	}
+}
+
+// valueMatcher returns the equality test for a single value of the field.
+func valueMatcher(c *Comparison, def fieldDef) (func(t *model.Task, value string) bool, error) {
+	switch def.kind {
+	case kindList:
+		return func(t *model.Task, value string) bool {
+			return slices.ContainsFunc(def.list(t), func(v string) bool { return matchString(v, value) })
+		}, nil
+	case kindText:
+		return func(t *model.Task, value string) bool { return matchText(def.value(t), value) }, nil
+	case kindDate:
+		days := make(map[string]string, len(c.Values))
+		for _, v := range c.Values {
+			day, err := parseDate(v)
+			if err != nil {
+				return nil, invalidDateError(v, c.Field)
+			}
+			days[v] = day.Format(dateLayout)
+		}
+		return func(t *model.Task, value string) bool {
+			d := def.date(t)
+			return !d.IsZero() && d.Format(dateLayout) == days[value]
+		}, nil
+	default:
+		return func(t *model.Task, value string) bool { return matchString(def.value(t), value) }, nil
+	}
+}
+
+func compileBool(c *Comparison, def fieldDef) (func(*model.Task, *Env) bool, error) {
+	if c.Op != OpEq && c.Op != OpNe {
+		return nil, fmt.Errorf("operator %s is not supported for %s", c.Op, c.Field)
+	}
+	v := c.Values[0]
+	if v != "true" && v != "false" {
+		return nil, fmt.Errorf("invalid value %q for %s (expected true or false)", v, c.Field)
+	}
+	want := (v == "true") == (c.Op == OpEq)
+	return func(t *model.Task, env *Env) bool { return def.pred(t, env) == want }, nil
+}
+
+func compileOrdering(c *Comparison, def fieldDef) (func(*model.Task, *Env) bool, error) {
+	value := c.Values[0]
+
+	var cmp func(t *model.Task) (int, bool)
+	switch def.kind {
+	case kindOrdered:
+		want := slices.Index(def.order, value)
+		if want < 0 {
+			return nil, fmt.Errorf("invalid value %q for %s (valid: %s)", value, c.Field, strings.Join(def.order, ", "))
+		}
+		cmp = func(t *model.Task) (int, bool) {
+			got := slices.Index(def.order, def.value(t))
+			return got - want, got >= 0
+		}
+	case kindDate:
+		want, err := parseDate(value)
+		if err != nil {
+			return nil, invalidDateError(value, c.Field)
+		}
+		cmp = func(t *model.Task) (int, bool) {
+			d := def.date(t)
+			if d.IsZero() {
+				return 0, false
+			}
+			day := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+			return day.Compare(want), true
+		}
+	case kindID:
+		cmp = func(t *model.Task) (int, bool) { return compareIDs(def.value(t), value), true }
+	default:
+		return nil, fmt.Errorf("operator %s is not supported for %s", c.Op, c.Field)
+	}
+
+	return func(t *model.Task, _ *Env) bool {
+		n, ok := cmp(t)
+		if !ok {
+			return false
+		}
+		switch c.Op {
+		case OpLt:
+			return n < 0
+		case OpLe:
+			return n <= 0
+		case OpGt:
+			return n > 0
+		default:
+			return n >= 0
+		}
+	}, nil
+}
+
+const dateLayout = "2006-01-02"
+
+// now returns the current time for relative dates and overdue. Override in tests.
+var now = time.Now
+
+var relativeDate = regexp.MustCompile(`^today(?:([+-]\d+)d)?$`)
+
+// parseDate accepts YYYY-MM-DD, or "today" optionally offset by a number of
+// days such as today+7d or today-1d.
+func parseDate(s string) (time.Time, error) {
+	m := relativeDate.FindStringSubmatch(s)
+	if m == nil {
+		return time.Parse(dateLayout, s)
+	}
+	t := now()
+	today := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if m[1] == "" {
+		return today, nil
+	}
+	days, err := strconv.Atoi(m[1])
+	if err != nil {
+		return time.Time{}, err
+	}
+	return today.AddDate(0, 0, days), nil
+}
+
+func invalidDateError(value, field string) error {
+	return fmt.Errorf("invalid date %q for %s (expected YYYY-MM-DD or today, today+Nd, today-Nd)", value, field)
+}
+
+// compareIDs orders IDs numerically when they share a prefix, otherwise lexically.
+func compareIDs(a, b string) int {
+	pa, na, _, okA := nextid.Split(a)
+	pb, nb, _, okB := nextid.Split(b)
+	if okA && okB && pa == pb {
+		return na - nb
+	}
+	return strings.Compare(a, b)
+}
+
+func hasGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+// matchString compares exactly, or as a glob pattern if value contains wildcards.
+func matchString(actual, value string) bool {
+	if hasGlob(value) {
+		ok, err := path.Match(value, actual)
+		return err == nil && ok
+	}
+	return actual == value
+}
+
+// matchText is a case-insensitive substring match, or a glob over the whole text.
+func matchText(actual, value string) bool {
+	actual = strings.ToLower(actual)
+	value = strings.ToLower(value)
+	if hasGlob(value) {
+		ok, err := path.Match(value, actual)
+		return err == nil && ok
+	}
+	return strings.Contains(actual, value)
+}
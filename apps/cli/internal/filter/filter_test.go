@@ -1,7 +1,9 @@
 package filter
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/driangle/taskmd/apps/cli/internal/model"
 )
@@ -93,3 +95,215 @@ func TestApply_ParentFilter(t *testing.T) {
 		}
 	})
 }
+
+func exprTestTasks() []*model.Task {
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+	return []*model.Task{
+		{ID: "001", Title: "Setup repo", Status: model.StatusCompleted, Priority: model.PriorityLow, Effort: model.EffortSmall, Tags: []string{"infra"}, Owner: "alice", Created: day("2026-08-01")},
+		{ID: "002", Title: "Build CLI", Status: model.StatusPending, Priority: model.PriorityHigh, Effort: model.EffortLarge, Tags: []string{"cli"}, Owner: "alex", Dependencies: []string{"001"}, Created: day("2026-09-01")},
+		{ID: "003", Title: "Web dashboard", Status: model.StatusPending, Priority: model.PriorityCritical, Tags: []string{"web", "ui"}, Owner: "bob", Dependencies: []string{"002"}, Created: day("2026-09-15")},
+		{ID: "010", Title: "Docs", Status: model.StatusInProgress, Priority: model.PriorityMedium, Touches: []string{"docs/cli"}, Dependencies: []string{"999"}},
+	}
+}
+
+func TestApply_Expressions(t *testing.T) {
+	tasks := exprTestTasks()
+
+	tests := []struct {
+		name    string
+		filters []string
+		wantIDs []string
+	}{
+		{"not equal", []string{"status!=completed"}, []string{"002", "003", "010"}},
+		{"or with parens", []string{"status!=completed and (tag=cli or tag=web)"}, []string{"002", "003"}},
+		{"not", []string{"not tag=cli"}, []string{"001", "003", "010"}},
+		{"priority at least high", []string{"priority>=high"}, []string{"002", "003"}},
+		{"priority below high", []string{"priority<high"}, []string{"001", "010"}},
+		{"effort ordering skips unset", []string{"effort<=medium"}, []string{"001"}},
+		{"created after", []string{"created>2026-08-31"}, []string{"002", "003"}},
+		{"created on day", []string{"created=2026-09-15"}, []string{"003"}},
+		{"created skips unset", []string{"created<2026-12-31"}, []string{"001", "002", "003"}},
+		{"tag in", []string{"tag in (web, infra)"}, []string{"001", "003"}},
+		{"status not in", []string{"status not in (completed, in-progress)"}, []string{"002", "003"}},
+		{"owner glob", []string{"owner=al*"}, []string{"001", "002"}},
+		{"touches glob", []string{"touches=docs/*"}, []string{"010"}},
+		{"title glob", []string{"title=*cli"}, []string{"002"}},
+		{"title quoted substring", []string{`title="web dash"`}, []string{"003"}},
+		{"id numeric ordering", []string{"id>003"}, []string{"010"}},
+		{"depends", []string{"depends=001"}, []string{"002"}},
+		{"repeated filters AND", []string{"priority>=medium", "tag!=web"}, []string{"002", "010"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered, err := Apply(tasks, tt.filters)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, task := range filtered {
+				got = append(got, task.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("got %v, want %v", got, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestApply_BlockedChecksDependencyStatus(t *testing.T) {
+	tasks := exprTestTasks()
+
+	tests := []struct {
+		filter  string
+		wantIDs []string
+	}{
+		// 002 depends on completed 001, so it is not blocked.
+		{"blocked=true", []string{"003", "010"}},
+		{"blocked", []string{"003", "010"}},
+		{"blocked=false", []string{"001", "002"}},
+		{"not blocked", []string{"001", "002"}},
+		{"blocked!=true", []string{"001", "002"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			filtered, err := Apply(tasks, []string{tt.filter})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, task := range filtered {
+				got = append(got, task.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("got %v, want %v", got, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestApply_LegacyMultiWordValue(t *testing.T) {
+	tasks := []*model.Task{
+		{ID: "001", Title: "Add command for export"},
+		{ID: "002", Title: "Add tests"},
+	}
+	filtered, err := Apply(tasks, []string{"title=add command"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(filtered) != 1 || filtered[0].ID != "001" {
+		t.Errorf("expected only 001 to match, got %v", filtered)
+	}
+}
+
+func TestApply_LegacyFallback(t *testing.T) {
+	tasks := []*model.Task{
+		{ID: "001", Title: "Fix or cleanup the parser"},
+		{ID: "002", Title: "Fix login"},
+		{ID: "003", Title: "Cleanup"},
+	}
+
+	tests := []struct {
+		expr    string
+		wantIDs []string
+	}{
+		{"title=fix or cleanup", []string{"001"}},
+		{"title=fix and cleanup", nil},
+		{"foo=bar", nil},
+		{"priority=urgent", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			filtered, err := Apply(tasks, []string{tt.expr})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, task := range filtered {
+				got = append(got, task.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("got %v, want %v", got, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestApplyEnv_ArchivedDependencies(t *testing.T) {
+	tasks := []*model.Task{
+		{ID: "002", Title: "Two", Status: model.StatusPending, Dependencies: []string{"001"}},
+	}
+
+	blocked, err := Apply(tasks, []string{"blocked"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(blocked) != 1 {
+		t.Fatalf("expected a missing dependency to block, got %d tasks", len(blocked))
+	}
+
+	blocked, err = ApplyEnv(tasks, []string{"blocked"}, NewEnv(tasks).WithArchived([]string{"001"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(blocked) != 0 {
+		t.Errorf("expected an archived dependency to count as met, got %d blocked tasks", len(blocked))
+	}
+}
+
+func TestApply_DueDates(t *testing.T) {
+	oldNow := now
+	now = func() time.Time { return time.Date(2026, 10, 16, 15, 0, 0, 0, time.Local) }
+	defer func() { now = oldNow }()
+
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+	tasks := []*model.Task{
+		{ID: "001", Status: model.StatusPending, Due: day("2026-10-15")},
+		{ID: "002", Status: model.StatusCompleted, Due: day("2026-10-01")},
+		{ID: "003", Status: model.StatusInProgress, Due: day("2026-10-16"), Start: day("2026-10-10")},
+		{ID: "004", Status: model.StatusPending, Due: day("2026-10-22"), Scheduled: day("2026-10-20")},
+		{ID: "005", Status: model.StatusPending},
+	}
+
+	tests := []struct {
+		name    string
+		filters []string
+		wantIDs []string
+	}{
+		{"overdue", []string{"overdue"}, []string{"001"}},
+		{"not overdue", []string{"not overdue"}, []string{"002", "003", "004", "005"}},
+		{"due before date", []string{"due<2026-10-16"}, []string{"001", "002"}},
+		{"due today", []string{"due=today"}, []string{"003"}},
+		{"due within a week", []string{"due<=today+7d", "status!=completed"}, []string{"001", "003", "004"}},
+		{"due since yesterday", []string{"due>=today-1d"}, []string{"001", "003", "004"}},
+		{"started", []string{"start<=today"}, []string{"003"}},
+		{"scheduled", []string{"scheduled=2026-10-20"}, []string{"004"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered, err := Apply(tasks, tt.filters)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, task := range filtered {
+				got = append(got, task.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("got %v, want %v", got, tt.wantIDs)
+			}
+		})
+	}
+
+	if _, err := Apply(tasks, []string{"due<today+7"}); err == nil {
+		t.Error("expected error for relative date without unit")
+	}
+}
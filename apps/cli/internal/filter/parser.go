@@ -0,0 +1,314 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+// Op is a comparison operator.
+type Op string
+
+const (
+	OpEq    Op = "="
+	OpNe    Op = "!="
+	OpLt    Op = "<"
+	OpLe    Op = "<="
+	OpGt    Op = ">"
+	OpGe    Op = ">="
+	OpIn    Op = "in"
+	OpNotIn Op = "not in"
+)
+
+// Node is a node in a parsed filter expression.
+type Node interface {
+	// Match reports whether the task satisfies the expression.
+	Match(task *model.Task, env *Env) bool
+	String() string
+}
+
+// And matches when both sides match.
+type And struct{ Left, Right Node }
+
+// Or matches when either side matches.
+type Or struct{ Left, Right Node }
+
+// Not negates its operand.
+type Not struct{ Expr Node }
+
+// Comparison tests a single task field against one or more values.
+// Values holds a single value for all operators except in / not in.
+type Comparison struct {
+	Field  string
+	Op     Op
+	Values []string
+
+	match func(task *model.Task, env *Env) bool
+}
+
+func (n *And) Match(task *model.Task, env *Env) bool {
+	return n.Left.Match(task, env) && n.Right.Match(task, env)
+}
+
+func (n *Or) Match(task *model.Task, env *Env) bool {
+	return n.Left.Match(task, env) || n.Right.Match(task, env)
+}
+
+func (n *Not) Match(task *model.Task, env *Env) bool {
+	return !n.Expr.Match(task, env)
+}
+
+func (n *Comparison) Match(task *model.Task, env *Env) bool {
+	return n.match(task, env)
+}
+
+func (n *And) String() string { return "(" + n.Left.String() + " and " + n.Right.String() + ")" }
+func (n *Or) String() string  { return "(" + n.Left.String() + " or " + n.Right.String() + ")" }
+func (n *Not) String() string { return "not " + n.Expr.String() }
+
+func (n *Comparison) String() string {
+	if n.Op == OpIn || n.Op == OpNotIn {
+		return fmt.Sprintf("%s %s (%s)", n.Field, n.Op, strings.Join(n.Values, ", "))
+	}
+	return n.Field + string(n.Op) + n.Values[0]
+}
+
+// Parse parses a single filter expression into an AST.
+//
+// Grammar:
+//
+//	expr       = or
+//	or         = and { "or" and }
+//	and        = unary { "and" unary }
+//	unary      = "not" unary | "(" expr ")" | comparison
+//	comparison = field op value | field [ "not" ] "in" "(" value { "," value } ")" | field
+//	op         = "=" | "!=" | "<" | "<=" | ">" | ">="
+//
+// A bare field is shorthand for field=true and is only valid for boolean
+// fields such as blocked. Keywords are case-insensitive.
+//
+// For compatibility with the original field=value form, an expression that
+// does not parse, or names an unknown field, is read that way instead when
+// it starts with field=: everything after the first '=' is the value. So
+// "title=add command" and "title=fix or cleanup" match titles containing
+// those words, and "foo=bar" matches nothing rather than failing.
+func Parse(expr string) (Node, error) {
+	node, err := parseExpr(expr)
+	if err == nil {
+		return node, nil
+	}
+	if legacy, ok := parseLegacy(expr); ok {
+		return legacy, nil
+	}
+	return nil, fmt.Errorf("invalid filter format in %q: %w", expr, err)
+}
+
+func parseExpr(expr string) (Node, error) {
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	node, err := p.parseOr()
+	if err == nil && p.peek().kind != tokEOF {
+		err = p.unexpected()
+	}
+	return node, err
+}
+
+// parseLegacy reads expr as field=value, split at the first '='. The value
+// is matched as it would be in an expression; where that is not possible,
+// as for an unknown field, the comparison matches no task, as it used to.
+func parseLegacy(expr string) (Node, bool) {
+	field, value, ok := strings.Cut(expr, "=")
+	field = strings.ToLower(strings.TrimSpace(field))
+	value = strings.TrimSpace(value)
+	if !ok || value == "" || !isFieldName(field) {
+		return nil, false
+	}
+
+	node := &Comparison{Field: field, Op: OpEq, Values: []string{value}}
+	match, err := compileComparison(node)
+	if err != nil {
+		match = func(*model.Task, *Env) bool { return false }
+	}
+	node.match = match
+	return node, true
+}
+
+func isFieldName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) unexpected() error {
+	t := p.peek()
+	return fmt.Errorf("unexpected %s at position %d", t, t.pos)
+}
+
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Or{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("and") {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &And{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	t := p.peek()
+	switch {
+	case t.isKeyword("not"):
+		p.next()
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Expr: inner}, nil
+	case t.kind == tokLParen:
+		p.next()
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokRParen {
+			return nil, p.unexpected()
+		}
+		p.next()
+		return inner, nil
+	case t.kind == tokWord:
+		return p.parseComparison()
+	default:
+		return nil, p.unexpected()
+	}
+}
+
+func (p *parser) parseComparison() (Node, error) {
+	fieldTok := p.next()
+	field := strings.ToLower(fieldTok.text)
+
+	var op Op
+	var values []string
+
+	t := p.peek()
+	switch {
+	case t.kind == tokOp:
+		p.next()
+		op = Op(t.text)
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = []string{v}
+	case t.isKeyword("in"):
+		p.next()
+		op = OpIn
+	case t.isKeyword("not") && p.tokens[p.pos+1].isKeyword("in"):
+		p.next()
+		p.next()
+		op = OpNotIn
+	default:
+		// Bare field: boolean shorthand for field=true.
+		op = OpEq
+		values = []string{"true"}
+		if !isBoolField(field) {
+			return nil, fmt.Errorf("expected operator after %q at position %d", fieldTok.text, t.pos)
+		}
+	}
+
+	if op == OpIn || op == OpNotIn {
+		var err error
+		values, err = p.parseValueList()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	node := &Comparison{Field: field, Op: op, Values: values}
+	match, err := compileComparison(node)
+	if err != nil {
+		return nil, err
+	}
+	node.match = match
+	return node, nil
+}
+
+func (p *parser) parseValue() (string, error) {
+	t := p.peek()
+	if t.kind != tokWord && t.kind != tokString {
+		return "", fmt.Errorf("expected value at position %d, got %s", t.pos, t)
+	}
+	p.next()
+	return t.text, nil
+}
+
+func (p *parser) parseValueList() ([]string, error) {
+	if p.peek().kind != tokLParen {
+		return nil, fmt.Errorf("expected '(' after in at position %d", p.peek().pos)
+	}
+	p.next()
+	var values []string
+	for {
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+		if p.peek().kind == tokComma {
+			p.next()
+			continue
+		}
+		if p.peek().kind != tokRParen {
+			return nil, p.unexpected()
+		}
+		p.next()
+		return values, nil
+	}
+}
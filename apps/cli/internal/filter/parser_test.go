@@ -0,0 +1,85 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse_Structure(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"status=pending", "status=pending"},
+		{"status!=completed and priority>=high", "(status!=completed and priority>=high)"},
+		{"tag=cli or tag=web and owner=bob", "(tag=cli or (tag=web and owner=bob))"},
+		{"(tag=cli or tag=web) and owner=bob", "((tag=cli or tag=web) and owner=bob)"},
+		{"not blocked", "not blocked=true"},
+		{"NOT status IN (pending, 'in-progress')", "not status in (pending, in-progress)"},
+		{"tag not in (a,b)", "tag not in (a, b)"},
+		{`title="fix the bug"`, "title=fix the bug"},
+		{"created>=2026-09-01", "created>=2026-09-01"},
+		// Anything that does not parse falls back to the original
+		// field=value form, which keeps the rest as the value.
+		{"title=add command", "title=add command"},
+		{"title=fix login (urgent), again", "title=fix login (urgent), again"},
+		{"title=fix or cleanup", "title=fix or cleanup"},
+		{"status=pending)", "status=pending)"},
+		{"foo=bar", "foo=bar"},
+		{"title=add and status=pending", "(title=add and status=pending)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			node, err := Parse(tt.expr)
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("expected error, got %s", node)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := node.String(); got != tt.want {
+				t.Errorf("Parse(%q) = %s, want %s", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr string
+	}{
+		{"", "unexpected end of expression"},
+		{"status", "expected operator"},
+		{"status=", "expected value"},
+		{"(status=pending", "unexpected end of expression"},
+		{"(status=pending) or", "unexpected end of expression"},
+		{`"open`, "unterminated string"},
+		{"color>red", `unknown filter field "color"`},
+		{"blocked!=maybe", "expected true or false"},
+		{"priority>=urgent", `invalid value "urgent" for priority`},
+		{"status>pending", "operator > is not supported for status"},
+		{"created>=yesterday", `invalid date "yesterday"`},
+		{"tag in cli", "expected '('"},
+		{"status!pending", "use != or not"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if !strings.Contains(err.Error(), "invalid filter format") {
+				t.Errorf("expected error to mention invalid filter format, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,386 @@
+// Package forecast estimates when a set of tasks will be finished. Weekly
+// throughput is measured from completion history and replayed in a Monte
+// Carlo simulation over the remaining dependency graph, giving a range of
+// likely completion dates rather than a single guess.
+package forecast
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/filter"
+	"github.com/driangle/taskmd/apps/cli/internal/graph"
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+// Defaults for Options.
+const (
+	DefaultWeeks       = 12
+	DefaultSimulations = 10000
+
+	// maxSimWeeks stops a simulation that makes no progress, e.g. when most
+	// sampled weeks completed nothing.
+	maxSimWeeks = 520
+)
+
+const dateLayout = "2006-01-02"
+
+// ErrNoHistory is returned when no task was completed inside the history
+// window, so there is no throughput to forecast from.
+var ErrNoHistory = errors.New("no completed tasks in the history window")
+
+// Options controls a forecast.
+type Options struct {
+	Weeks       int                 // history window in weeks; defaults to DefaultWeeks
+	Simulations int                 // Monte Carlo runs; defaults to DefaultSimulations
+	Seed        int64               // random seed; 0 picks one from the clock
+	Now         time.Time           // defaults to time.Now
+	Effort      graph.EffortWeights // orders work within a simulated week; zero uses the defaults
+}
+
+// Percentile is the date by which the given share of simulations finished.
+type Percentile struct {
+	Percent int    `json:"percent" yaml:"percent"`
+	Weeks   int    `json:"weeks" yaml:"weeks"`
+	Date    string `json:"date" yaml:"date"`
+}
+
+// Outcome is how many simulations finished after a given number of weeks.
+type Outcome struct {
+	Weeks       int     `json:"weeks" yaml:"weeks"`
+	Date        string  `json:"date" yaml:"date"`
+	Probability float64 `json:"probability" yaml:"probability"`
+	Cumulative  float64 `json:"cumulative" yaml:"cumulative"`
+}
+
+// Week is one week of history, ending on End.
+type Week struct {
+	End       string `json:"end" yaml:"end"`
+	Completed int    `json:"completed" yaml:"completed"`
+	Remaining int    `json:"remaining" yaml:"remaining"` // open tasks in scope at the end of the week
+}
+
+// Result is a completion forecast.
+type Result struct {
+	Remaining    int        `json:"remaining" yaml:"remaining"`
+	Completed    int        `json:"completed" yaml:"completed"` // completions inside the history window
+	Weeks        int        `json:"weeks" yaml:"weeks"`
+	Throughput   float64    `json:"throughput" yaml:"throughput"` // mean tasks per week
+	Simulations  int        `json:"simulations" yaml:"simulations"`
+	P50          Percentile `json:"p50" yaml:"p50"`
+	P85          Percentile `json:"p85" yaml:"p85"`
+	Distribution []Outcome  `json:"distribution" yaml:"distribution"`
+	Burndown     []Week     `json:"burndown" yaml:"burndown"`
+}
+
+// Scope selects the tasks a forecast covers: all tasks, or parent and its
+// descendants, optionally narrowed by filters. Open dependencies of selected
+// tasks are pulled in, since they have to finish first.
+func Scope(tasks []*model.Task, parent string, filters []string) ([]*model.Task, error) {
+	selected := tasks
+	if parent != "" {
+		var err error
+		if selected, err = descendants(tasks, parent); err != nil {
+			return nil, err
+		}
+	}
+	if len(filters) > 0 {
+		var err error
+		// Dependency predicates such as blocked look at every task, not
+		// only the ones under parent.
+		if selected, err = filter.ApplyEnv(selected, filters, filter.NewEnv(tasks)); err != nil {
+			return nil, err
+		}
+	}
+	return withOpenDependencies(tasks, selected), nil
+}
+
+func descendants(tasks []*model.Task, parent string) ([]*model.Task, error) {
+	children := make(map[string][]*model.Task)
+	var root *model.Task
+	for _, t := range tasks {
+		if t.ID == parent {
+			root = t
+		}
+		if t.Parent != "" {
+			children[t.Parent] = append(children[t.Parent], t)
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("parent task %s not found", parent)
+	}
+
+	seen := map[string]bool{root.ID: true}
+	out := []*model.Task{root}
+	for i := 0; i < len(out); i++ {
+		for _, child := range children[out[i].ID] {
+			if !seen[child.ID] {
+				seen[child.ID] = true
+				out = append(out, child)
+			}
+		}
+	}
+	return out, nil
+}
+
+func withOpenDependencies(tasks, selected []*model.Task) []*model.Task {
+	taskMap := make(map[string]*model.Task, len(tasks))
+	for _, t := range tasks {
+		taskMap[t.ID] = t
+	}
+
+	seen := make(map[string]bool, len(selected))
+	out := make([]*model.Task, 0, len(selected))
+	for _, t := range selected {
+		seen[t.ID] = true
+		out = append(out, t)
+	}
+	for i := 0; i < len(out); i++ {
+		if !out[i].IsOpen() {
+			continue
+		}
+		for _, depID := range out[i].Dependencies {
+			dep, ok := taskMap[depID]
+			if ok && !seen[depID] && dep.IsOpen() {
+				seen[depID] = true
+				out = append(out, dep)
+			}
+		}
+	}
+	return out
+}
+
+// Run forecasts when the open tasks in scope will be finished. Throughput
+// comes from completions (task ID -> completion time) of any task inside the
+// history window, not just tasks in scope: it measures how fast the team
+// works, scope decides how much work is left.
+func Run(scope []*model.Task, completions map[string]time.Time, opts Options) (*Result, error) {
+	opts = withDefaults(opts)
+
+	samples := weeklyThroughput(completions, opts.Now, opts.Weeks)
+	total := 0
+	for _, n := range samples {
+		total += n
+	}
+
+	remaining := openTasks(scope)
+	res := &Result{
+		Remaining:   len(remaining),
+		Completed:   total,
+		Weeks:       opts.Weeks,
+		Throughput:  math.Round(float64(total)/float64(opts.Weeks)*100) / 100,
+		Simulations: opts.Simulations,
+		Burndown:    burndown(scope, completions, opts.Now, opts.Weeks),
+	}
+	if len(remaining) == 0 {
+		res.P50 = percentile([]int{0}, 50, opts.Now)
+		res.P85 = percentile([]int{0}, 85, opts.Now)
+		return res, nil
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("%w (last %d weeks)", ErrNoHistory, opts.Weeks)
+	}
+
+	sim := newSimulation(remaining, opts.Effort)
+	rng := rand.New(rand.NewSource(opts.Seed))
+	runs := make([]int, opts.Simulations)
+	for i := range runs {
+		runs[i] = sim.run(samples, rng)
+	}
+	sort.Ints(runs)
+
+	res.P50 = percentile(runs, 50, opts.Now)
+	res.P85 = percentile(runs, 85, opts.Now)
+	res.Distribution = distribution(runs, opts.Now)
+	return res, nil
+}
+
+func withDefaults(opts Options) Options {
+	if opts.Weeks <= 0 {
+		opts.Weeks = DefaultWeeks
+	}
+	if opts.Simulations <= 0 {
+		opts.Simulations = DefaultSimulations
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	if opts.Seed == 0 {
+		opts.Seed = time.Now().UnixNano()
+	}
+	if opts.Effort == (graph.EffortWeights{}) {
+		opts.Effort = graph.DefaultEffortWeights()
+	}
+	return opts
+}
+
+func openTasks(tasks []*model.Task) []*model.Task {
+	var open []*model.Task
+	for _, t := range tasks {
+		if t.IsOpen() {
+			open = append(open, t)
+		}
+	}
+	return open
+}
+
+// weeklyThroughput counts completions in each of the last weeks seven-day
+// periods ending at now, oldest first.
+func weeklyThroughput(completions map[string]time.Time, now time.Time, weeks int) []int {
+	counts := make([]int, weeks)
+	start := now.AddDate(0, 0, -7*weeks)
+	for _, at := range completions {
+		if !at.After(start) || at.After(now) {
+			continue
+		}
+		idx := int(at.Sub(start) / (7 * 24 * time.Hour))
+		counts[min(idx, weeks-1)]++
+	}
+	return counts
+}
+
+// burndown reports, for each history week, how many tasks in scope were
+// completed that week and how many were still open at its end. Tasks count
+// from their created date; completed tasks with no known date are treated as
+// done before the window.
+func burndown(scope []*model.Task, completions map[string]time.Time, now time.Time, weeks int) []Week {
+	out := make([]Week, weeks)
+	for i := range out {
+		end := now.AddDate(0, 0, -7*(weeks-1-i))
+		begin := end.AddDate(0, 0, -7)
+		w := Week{End: end.Format(dateLayout)}
+		for _, t := range scope {
+			if t.Status == model.StatusCancelled || (!t.Created.IsZero() && t.Created.After(end)) {
+				continue
+			}
+			at, known := completions[t.ID]
+			switch {
+			case t.Status != model.StatusCompleted:
+				w.Remaining++
+			case !known:
+			case at.After(end):
+				w.Remaining++
+			case at.After(begin):
+				w.Completed++
+			}
+		}
+		out[i] = w
+	}
+	return out
+}
+
+func percentile(sorted []int, percent int, now time.Time) Percentile {
+	idx := int(math.Ceil(float64(percent)/100*float64(len(sorted)))) - 1
+	weeks := sorted[max(idx, 0)]
+	return Percentile{Percent: percent, Weeks: weeks, Date: weeksFrom(now, weeks)}
+}
+
+func distribution(sorted []int, now time.Time) []Outcome {
+	var out []Outcome
+	n := float64(len(sorted))
+	for i := 0; i < len(sorted); {
+		j := i
+		for j < len(sorted) && sorted[j] == sorted[i] {
+			j++
+		}
+		out = append(out, Outcome{
+			Weeks:       sorted[i],
+			Date:        weeksFrom(now, sorted[i]),
+			Probability: math.Round(float64(j-i)/n*10000) / 10000,
+			Cumulative:  math.Round(float64(j)/n*10000) / 10000,
+		})
+		i = j
+	}
+	return out
+}
+
+func weeksFrom(now time.Time, weeks int) string {
+	return now.AddDate(0, 0, 7*weeks).Format(dateLayout)
+}
+
+// simulation replays sampled weekly throughput over the remaining tasks.
+// Every task counts as one unit of throughput, whatever its effort. A task
+// can be finished once its dependencies are finished, including earlier in
+// the same week, so a chain of small tasks is not stretched to one week per
+// link when capacity allows. When there is more available work than
+// capacity, tasks with the least slack go first.
+type simulation struct {
+	deps [][]int // indexes of each task's open dependencies
+}
+
+func newSimulation(remaining []*model.Task, effort graph.EffortWeights) *simulation {
+	cp := graph.NewGraph(remaining).CriticalPath(effort)
+	order := make([]*model.Task, len(remaining))
+	copy(order, remaining)
+	sort.SliceStable(order, func(i, j int) bool {
+		si, sj := cp.Timings[order[i].ID].Slack, cp.Timings[order[j].ID].Slack
+		if si != sj {
+			return si < sj
+		}
+		return order[i].ID < order[j].ID
+	})
+
+	index := make(map[string]int, len(order))
+	for i, t := range order {
+		index[t.ID] = i
+	}
+	s := &simulation{deps: make([][]int, len(order))}
+	for i, t := range order {
+		for _, depID := range t.Dependencies {
+			if j, ok := index[depID]; ok {
+				s.deps[i] = append(s.deps[i], j)
+			}
+		}
+	}
+	return s
+}
+
+// run returns the number of weeks one simulated future takes to finish
+// every task.
+func (s *simulation) run(samples []int, rng *rand.Rand) int {
+	doneWeek := make([]int, len(s.deps)) // 0 = not done yet
+	left := len(s.deps)
+	week := 0
+	for left > 0 && week < maxSimWeeks {
+		week++
+		capacity := samples[rng.Intn(len(samples))]
+		finished := s.finish(doneWeek, week, capacity, true)
+		if finished == 0 && capacity > 0 {
+			// Only tasks in a dependency cycle are left; ignore their order.
+			finished = s.finish(doneWeek, week, capacity, false)
+		}
+		left -= finished
+	}
+	return week
+}
+
+// finish marks up to capacity tasks done in week. Tasks unblocked by work
+// finished this week are picked up by the next pass over the list.
+func (s *simulation) finish(doneWeek []int, week, capacity int, respectDeps bool) int {
+	finished := 0
+	for progress := true; progress && finished < capacity; {
+		progress = false
+		for i := 0; i < len(s.deps) && finished < capacity; i++ {
+			if doneWeek[i] != 0 || (respectDeps && !s.ready(i, doneWeek)) {
+				continue
+			}
+			doneWeek[i] = week
+			finished++
+			progress = true
+		}
+	}
+	return finished
+}
+
+func (s *simulation) ready(i int, doneWeek []int) bool {
+	for _, dep := range s.deps[i] {
+		if doneWeek[dep] == 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,194 @@
+package forecast
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+var now = time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+func task(id string, status model.Status, deps ...string) *model.Task {
+	return &model.Task{ID: id, Title: "Task " + id, Status: status, Dependencies: deps}
+}
+
+// steadyHistory completes perWeek tasks in each of the last weeks weeks.
+func steadyHistory(perWeek, weeks int) map[string]time.Time {
+	completions := make(map[string]time.Time)
+	for w := 0; w < weeks; w++ {
+		for i := 0; i < perWeek; i++ {
+			id := string(rune('a'+w)) + string(rune('a'+i))
+			completions[id] = now.AddDate(0, 0, -7*w-3)
+		}
+	}
+	return completions
+}
+
+func TestRun_ConstantThroughput(t *testing.T) {
+	var scope []*model.Task
+	for _, id := range []string{"1", "2", "3", "4", "5", "6", "7"} {
+		scope = append(scope, task(id, model.StatusPending))
+	}
+
+	res, err := Run(scope, steadyHistory(2, 4), Options{Weeks: 4, Simulations: 200, Seed: 1, Now: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Remaining != 7 || res.Completed != 8 || res.Throughput != 2 {
+		t.Errorf("unexpected totals: %+v", res)
+	}
+	// 7 tasks at exactly 2 per week always take 4 weeks.
+	want := Percentile{Percent: 50, Weeks: 4, Date: "2026-03-30"}
+	if res.P50 != want {
+		t.Errorf("expected P50 %+v, got %+v", want, res.P50)
+	}
+	if res.P85.Weeks != 4 {
+		t.Errorf("expected P85 of 4 weeks, got %+v", res.P85)
+	}
+	if len(res.Distribution) != 1 || res.Distribution[0].Cumulative != 1 {
+		t.Errorf("expected a single certain outcome, got %+v", res.Distribution)
+	}
+}
+
+func TestRun_DependentsFinishInSameWeek(t *testing.T) {
+	// With plenty of capacity a chain of three finishes in one week.
+	scope := []*model.Task{
+		task("1", model.StatusPending),
+		task("2", model.StatusPending, "1"),
+		task("3", model.StatusPending, "2"),
+	}
+
+	res, err := Run(scope, steadyHistory(5, 2), Options{Weeks: 2, Simulations: 50, Seed: 1, Now: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.P50.Weeks != 1 {
+		t.Errorf("expected the chain to take 1 week, got %+v", res.P50)
+	}
+}
+
+func TestRun_VariableThroughput(t *testing.T) {
+	// Weeks of 0 and 4 completions: sometimes fast, sometimes slow.
+	completions := steadyHistory(4, 1)
+	var scope []*model.Task
+	for _, id := range []string{"1", "2", "3", "4", "5", "6", "7", "8"} {
+		scope = append(scope, task(id, model.StatusPending))
+	}
+
+	res, err := Run(scope, completions, Options{Weeks: 2, Simulations: 2000, Seed: 7, Now: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.P50.Weeks < 2 || res.P85.Weeks < res.P50.Weeks {
+		t.Errorf("expected 2 <= P50 <= P85, got %+v and %+v", res.P50, res.P85)
+	}
+	if len(res.Distribution) < 2 {
+		t.Errorf("expected a spread of outcomes, got %+v", res.Distribution)
+	}
+}
+
+func TestRun_NoHistory(t *testing.T) {
+	scope := []*model.Task{task("1", model.StatusPending)}
+
+	old := map[string]time.Time{"x": now.AddDate(-1, 0, 0)}
+	_, err := Run(scope, old, Options{Weeks: 4, Now: now})
+	if !errors.Is(err, ErrNoHistory) {
+		t.Errorf("expected ErrNoHistory, got %v", err)
+	}
+
+	res, err := Run([]*model.Task{task("1", model.StatusCompleted)}, old, Options{Weeks: 4, Now: now})
+	if err != nil || res.Remaining != 0 || res.P50.Weeks != 0 {
+		t.Errorf("expected a finished scope to need no history, got %+v %v", res, err)
+	}
+}
+
+func TestRun_Burndown(t *testing.T) {
+	done := task("1", model.StatusCompleted)
+	open := task("2", model.StatusPending)
+	late := task("3", model.StatusPending)
+	late.Created = now.AddDate(0, 0, -3)
+	completions := map[string]time.Time{"1": now.AddDate(0, 0, -10)}
+
+	res, err := Run([]*model.Task{done, open, late}, completions, Options{Weeks: 3, Simulations: 10, Seed: 1, Now: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Week{
+		{End: "2026-02-16", Completed: 0, Remaining: 2},
+		{End: "2026-02-23", Completed: 1, Remaining: 1},
+		{End: "2026-03-02", Completed: 0, Remaining: 2},
+	}
+	if !slices.Equal(res.Burndown, want) {
+		t.Errorf("expected burndown %+v, got %+v", want, res.Burndown)
+	}
+}
+
+func TestScope_ParentAndDependencies(t *testing.T) {
+	epic := task("10", model.StatusPending)
+	child := task("11", model.StatusPending, "02")
+	child.Parent = "10"
+	grandchild := task("12", model.StatusCompleted)
+	grandchild.Parent = "11"
+	tasks := []*model.Task{
+		task("01", model.StatusPending),
+		task("02", model.StatusPending, "03"),
+		task("03", model.StatusCompleted),
+		epic, child, grandchild,
+	}
+
+	scope, err := Scope(tasks, "10", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, s := range scope {
+		ids = append(ids, s.ID)
+	}
+	// 02 is an open dependency of 11; 03 is done so it is not pulled in.
+	if !slices.Equal(ids, []string{"10", "11", "12", "02"}) {
+		t.Errorf("unexpected scope: %v", ids)
+	}
+
+	if _, err := Scope(tasks, "99", nil); err == nil {
+		t.Error("expected an error for an unknown parent")
+	}
+}
+
+func TestScope_Filters(t *testing.T) {
+	a := task("1", model.StatusPending)
+	a.Tags = []string{"mvp"}
+	tasks := []*model.Task{a, task("2", model.StatusPending)}
+
+	scope, err := Scope(tasks, "", []string{"tag=mvp"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scope) != 1 || scope[0].ID != "1" {
+		t.Errorf("expected only task 1, got %v", scope)
+	}
+}
+
+func TestScope_FiltersSeeAllTasks(t *testing.T) {
+	epic := task("10", model.StatusPending)
+	child := task("11", model.StatusPending, "03")
+	child.Parent = "10"
+	tasks := []*model.Task{task("03", model.StatusCompleted), epic, child}
+
+	scope, err := Scope(tasks, "10", []string{"not blocked"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, s := range scope {
+		ids = append(ids, s.ID)
+	}
+	// 11 depends on 03, which is outside the subtree but completed.
+	if !slices.Equal(ids, []string{"10", "11"}) {
+		t.Errorf("unexpected scope: %v", ids)
+	}
+}
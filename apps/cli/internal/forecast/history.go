@@ -0,0 +1,93 @@
+package forecast
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/history"
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+	"github.com/driangle/taskmd/apps/cli/internal/worklog"
+)
+
+// Where completion dates come from.
+const (
+	SourceAuto        = "auto"        // frontmatter, then git, then worklogs, per task
+	SourceFrontmatter = "frontmatter" // the completed timestamp field
+	SourceGit         = "git"         // commits that set status: completed
+	SourceWorklog     = "worklog"     // the last worklog entry of each completed task
+)
+
+// Sources lists the accepted values for the source option.
+var Sources = []string{SourceAuto, SourceFrontmatter, SourceGit, SourceWorklog}
+
+// CompletionDates returns when each completed task was finished, keyed by
+// task ID. Tasks with no known date are left out. dir is the scanned task
+// directory; with SourceAuto a directory outside a git repository silently
+// falls back to worklogs.
+func CompletionDates(tasks []*model.Task, dir, source string) (map[string]time.Time, error) {
+	switch source {
+	case SourceFrontmatter:
+		return frontmatterCompletionDates(tasks), nil
+	case SourceGit:
+		return gitCompletionDates(tasks, dir)
+	case SourceWorklog:
+		return worklogCompletionDates(tasks), nil
+	case SourceAuto, "":
+	default:
+		return nil, fmt.Errorf("unknown history source %q (valid: %s)", source, strings.Join(Sources, ", "))
+	}
+
+	// Later sources win: worklogs, then git, then frontmatter.
+	dates := worklogCompletionDates(tasks)
+	gitDates, _ := gitCompletionDates(tasks, dir) // nil outside a git repository
+	maps.Copy(dates, gitDates)
+	maps.Copy(dates, frontmatterCompletionDates(tasks))
+	return dates, nil
+}
+
+// frontmatterCompletionDates returns the completed timestamp of each
+// completed task that has one.
+func frontmatterCompletionDates(tasks []*model.Task) map[string]time.Time {
+	dates := make(map[string]time.Time)
+	for _, task := range tasks {
+		if task.Status == model.StatusCompleted && !task.Completed.IsZero() {
+			dates[task.ID] = task.Completed
+		}
+	}
+	return dates
+}
+
+// gitCompletionDates returns when each completed task was last moved to
+// completed according to the git history of dir.
+func gitCompletionDates(tasks []*model.Task, dir string) (map[string]time.Time, error) {
+	all, err := history.TaskDates(tasks, dir)
+	if err != nil {
+		return nil, err
+	}
+	dates := make(map[string]time.Time)
+	for _, task := range tasks {
+		if d := all[task.ID]; task.Status == model.StatusCompleted && !d.Completed.IsZero() {
+			dates[task.ID] = d.Completed
+		}
+	}
+	return dates, nil
+}
+
+// worklogCompletionDates uses the last worklog entry of each completed task
+// as its completion date.
+func worklogCompletionDates(tasks []*model.Task) map[string]time.Time {
+	dates := make(map[string]time.Time)
+	for _, task := range tasks {
+		if task.Status != model.StatusCompleted {
+			continue
+		}
+		wl, err := worklog.ParseWorklog(worklog.WorklogPath(task.FilePath, task.ID))
+		if err != nil || len(wl.Entries) == 0 {
+			continue
+		}
+		dates[task.ID] = wl.Entries[len(wl.Entries)-1].Timestamp
+	}
+	return dates
+}
@@ -0,0 +1,137 @@
+package forecast
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+func TestCompletionDates_Worklog(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".worklogs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	wl := "## 2026-02-01T10:00:00Z\n\nStarted.\n\n## 2026-02-03T16:30:00Z\n\nDone.\n"
+	if err := os.WriteFile(filepath.Join(dir, ".worklogs", "001.md"), []byte(wl), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tasks := []*model.Task{
+		{ID: "001", Status: model.StatusCompleted, FilePath: filepath.Join(dir, "001-a.md")},
+		{ID: "002", Status: model.StatusCompleted, FilePath: filepath.Join(dir, "002-b.md")},
+	}
+
+	dates, err := CompletionDates(tasks, dir, SourceWorklog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2026, 2, 3, 16, 30, 0, 0, time.UTC)
+	if len(dates) != 1 || !dates["001"].Equal(want) {
+		t.Errorf("expected only 001 at %v, got %v", want, dates)
+	}
+
+	if _, err := CompletionDates(tasks, dir, "jira"); err == nil {
+		t.Error("expected an error for an unknown source")
+	}
+}
+
+func TestCompletionDates_Git(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(date string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+			"GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(status string) {
+		t.Helper()
+		content := "---\nid: \"001\"\ntitle: A\nstatus: " + status + "\n---\n"
+		if err := os.WriteFile(filepath.Join(dir, "001-a.md"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("2026-01-01T09:00:00Z", "init", "-q")
+	write("pending")
+	git("2026-01-01T09:00:00Z", "add", ".")
+	git("2026-01-01T09:00:00Z", "commit", "-q", "-m", "add")
+	write("completed")
+	git("2026-01-05T09:00:00Z", "commit", "-q", "-am", "done")
+	write("in-progress")
+	git("2026-01-06T09:00:00Z", "commit", "-q", "-am", "reopen")
+	write("completed")
+	git("2026-01-09T09:00:00Z", "commit", "-q", "-am", "done again")
+
+	tasks := []*model.Task{{ID: "001", Status: model.StatusCompleted, FilePath: filepath.Join(dir, "001-a.md")}}
+	dates, err := CompletionDates(tasks, dir, SourceGit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2026, 1, 9, 9, 0, 0, 0, time.UTC)
+	if !dates["001"].Equal(want) {
+		t.Errorf("expected the latest completion %v, got %v", want, dates["001"])
+	}
+}
+
+func TestCompletionDates_AutoOutsideGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+
+	if _, err := CompletionDates(nil, dir, SourceGit); err == nil {
+		t.Error("expected --source git to fail outside a repository")
+	}
+	if _, err := CompletionDates(nil, dir, SourceAuto); err != nil {
+		t.Errorf("expected auto to fall back to worklogs, got %v", err)
+	}
+}
+
+func TestCompletionDates_Frontmatter(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+	if err := os.MkdirAll(filepath.Join(dir, ".worklogs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	wl := "## 2026-02-01T10:00:00Z\n\nDone.\n"
+	for _, id := range []string{"001", "002"} {
+		if err := os.WriteFile(filepath.Join(dir, ".worklogs", id+".md"), []byte(wl), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	completed := time.Date(2026, 2, 5, 9, 0, 0, 0, time.UTC)
+	tasks := []*model.Task{
+		{ID: "001", Status: model.StatusCompleted, Completed: completed, FilePath: filepath.Join(dir, "001-a.md")},
+		{ID: "002", Status: model.StatusCompleted, FilePath: filepath.Join(dir, "002-b.md")},
+	}
+
+	dates, err := CompletionDates(tasks, dir, SourceFrontmatter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dates) != 1 || !dates["001"].Equal(completed) {
+		t.Errorf("expected only 001 at %v, got %v", completed, dates)
+	}
+
+	// Auto prefers the frontmatter timestamp and falls back to worklogs.
+	dates, err = CompletionDates(tasks, dir, SourceAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dates) != 2 || !dates["001"].Equal(completed) || dates["002"].Day() != 1 {
+		t.Errorf("expected frontmatter for 001 and worklog for 002, got %v", dates)
+	}
+}
@@ -0,0 +1,249 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+// slackEpsilon absorbs float rounding when comparing schedule times.
+const slackEpsilon = 1e-9
+
+// EffortWeights converts effort levels into hours for critical path analysis.
+type EffortWeights struct {
+	Small  float64 `json:"small" yaml:"small"`
+	Medium float64 `json:"medium" yaml:"medium"`
+	Large  float64 `json:"large" yaml:"large"`
+}
+
+// DefaultEffortWeights returns the built-in hours per effort level. Tasks
+// without an effort count as medium.
+func DefaultEffortWeights() EffortWeights {
+	return EffortWeights{Small: 2, Medium: 8, Large: 16}
+}
+
+// Duration returns the remaining hours for a task: zero once it is completed
+// or cancelled, otherwise its estimate if set, else the weight of its effort.
+func (w EffortWeights) Duration(task *model.Task) float64 {
+	if !task.IsOpen() {
+		return 0
+	}
+	if task.Estimate > 0 {
+		return task.Estimate
+	}
+	switch task.Effort {
+	case model.EffortSmall:
+		return w.Small
+	case model.EffortLarge:
+		return w.Large
+	default:
+		return w.Medium
+	}
+}
+
+// ParseEffortWeights converts the raw effort section (as decoded from YAML)
+// into EffortWeights. Levels that are not set keep their defaults. Problems
+// are returned as messages rather than errors.
+func ParseEffortWeights(raw any) (EffortWeights, []string) {
+	w := DefaultEffortWeights()
+	if raw == nil {
+		return w, nil
+	}
+	section, ok := raw.(map[string]any)
+	if !ok {
+		return w, []string{"effort must be a mapping of effort levels to hours"}
+	}
+
+	fields := map[string]*float64{
+		"small":  &w.Small,
+		"medium": &w.Medium,
+		"large":  &w.Large,
+	}
+	keys := make([]string, 0, len(section))
+	for k := range section {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var problems []string
+	for _, key := range keys {
+		field, ok := fields[key]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("effort has unknown level: '%s' (valid: large, medium, small)", key))
+			continue
+		}
+		hours, ok := asHours(section[key])
+		if !ok {
+			problems = append(problems, fmt.Sprintf("effort.%s must be a non-negative number of hours", key))
+			continue
+		}
+		*field = hours
+	}
+	return w, problems
+}
+
+func asHours(val any) (float64, bool) {
+	var f float64
+	switch v := val.(type) {
+	case int:
+		f = float64(v)
+	case int64:
+		f = float64(v)
+	case float64:
+		f = v
+	default:
+		return 0, false
+	}
+	if f < 0 || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+	return f, true
+}
+
+// Timing is a task's place in the schedule, in hours from now.
+type Timing struct {
+	Duration      float64 `json:"duration" yaml:"duration"`
+	EarliestStart float64 `json:"earliest_start" yaml:"earliest_start"`
+	LatestStart   float64 `json:"latest_start" yaml:"latest_start"`
+	Slack         float64 `json:"slack" yaml:"slack"`
+	Critical      bool    `json:"critical" yaml:"critical"`
+}
+
+// CriticalPath is the result of a weighted longest-path analysis.
+type CriticalPath struct {
+	// Length is the remaining hours along the critical path.
+	Length float64 `json:"length" yaml:"length"`
+	// Tasks lists critical task IDs in schedule order.
+	Tasks []string `json:"tasks" yaml:"tasks"`
+	// Timings holds every open task's schedule. Completed and cancelled
+	// tasks, and tasks caught in dependency cycles, are left out.
+	Timings map[string]Timing `json:"-" yaml:"-"`
+}
+
+// IsCritical reports whether a task lies on the critical path.
+func (cp *CriticalPath) IsCritical(taskID string) bool {
+	return cp.Timings[taskID].Critical
+}
+
+// Set returns the critical task IDs as a set.
+func (cp *CriticalPath) Set() map[string]bool {
+	set := make(map[string]bool, len(cp.Tasks))
+	for _, id := range cp.Tasks {
+		set[id] = true
+	}
+	return set
+}
+
+// CriticalPath runs a critical path analysis with each task weighted by its
+// remaining hours (see EffortWeights.Duration). Slack is how long a task can
+// slip without delaying the whole project; tasks with zero slack are
+// critical. Completed and cancelled tasks are already done, so they are left
+// out and only remaining work shapes the path. When no work remains there is
+// no critical path.
+func (g *Graph) CriticalPath(w EffortWeights) *CriticalPath {
+	order := g.openOrder()
+	cp := &CriticalPath{Timings: make(map[string]Timing, len(order))}
+
+	earliestFinish := make(map[string]float64, len(order))
+	for _, id := range order {
+		t := Timing{Duration: w.Duration(g.TaskMap[id])}
+		for _, depID := range g.RevAdjacency[id] {
+			if ef, ok := earliestFinish[depID]; ok && ef > t.EarliestStart {
+				t.EarliestStart = ef
+			}
+		}
+		earliestFinish[id] = t.EarliestStart + t.Duration
+		cp.Length = math.Max(cp.Length, earliestFinish[id])
+		cp.Timings[id] = t
+	}
+
+	for i := len(order) - 1; i >= 0; i-- {
+		id := order[i]
+		t := cp.Timings[id]
+		latestFinish := cp.Length
+		for _, depID := range g.Adjacency[id] {
+			if dt, ok := cp.Timings[depID]; ok && dt.LatestStart < latestFinish {
+				latestFinish = dt.LatestStart
+			}
+		}
+		t.LatestStart = latestFinish - t.Duration
+		t.Slack = t.LatestStart - t.EarliestStart
+		if t.Slack < slackEpsilon {
+			t.Slack = 0
+		}
+		t.Critical = cp.Length > 0 && t.Slack == 0
+		cp.Timings[id] = t
+		if t.Critical {
+			cp.Tasks = append(cp.Tasks, id)
+		}
+	}
+
+	position := make(map[string]int, len(order))
+	for i, id := range order {
+		position[id] = i
+	}
+	sort.Slice(cp.Tasks, func(i, j int) bool {
+		a, b := cp.Timings[cp.Tasks[i]], cp.Timings[cp.Tasks[j]]
+		if a.EarliestStart != b.EarliestStart {
+			return a.EarliestStart < b.EarliestStart
+		}
+		return position[cp.Tasks[i]] < position[cp.Tasks[j]]
+	})
+	return cp
+}
+
+// openOrder returns topoOrder without completed or cancelled tasks.
+func (g *Graph) openOrder() []string {
+	var order []string
+	for _, id := range g.topoOrder() {
+		if g.TaskMap[id].IsOpen() {
+			order = append(order, id)
+		}
+	}
+	return order
+}
+
+// topoOrder returns task IDs with every task after its dependencies, ties
+// broken by ID. Tasks in or behind a dependency cycle are omitted, and
+// dependencies on unknown tasks are ignored.
+func (g *Graph) topoOrder() []string {
+	inDegree := make(map[string]int, len(g.TaskMap))
+	for id := range g.TaskMap {
+		for _, depID := range g.RevAdjacency[id] {
+			if _, ok := g.TaskMap[depID]; ok {
+				inDegree[id]++
+			}
+		}
+	}
+
+	var ready []string
+	for id := range g.TaskMap {
+		if inDegree[id] == 0 {
+			ready = append(ready, id)
+		}
+	}
+	sort.Strings(ready)
+
+	order := make([]string, 0, len(g.TaskMap))
+	for len(ready) > 0 {
+		id := ready[0]
+		ready = ready[1:]
+		order = append(order, id)
+
+		var unblocked []string
+		for _, next := range g.Adjacency[id] {
+			if _, ok := g.TaskMap[next]; !ok {
+				continue
+			}
+			inDegree[next]--
+			if inDegree[next] == 0 {
+				unblocked = append(unblocked, next)
+			}
+		}
+		ready = append(ready, unblocked...)
+		sort.Strings(ready)
+	}
+	return order
+}
@@ -0,0 +1,202 @@
+package graph
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+func pendingTask(id string, effort model.Effort, deps ...string) *model.Task {
+	return &model.Task{
+		ID:           id,
+		Title:        "Task " + id,
+		Status:       model.StatusPending,
+		Effort:       effort,
+		Dependencies: deps,
+	}
+}
+
+func TestCriticalPath_EffortOutweighsChainLength(t *testing.T) {
+	// A1 -> A2 -> A3 are small (3 x 2h); B is large (16h).
+	tasks := []*model.Task{
+		pendingTask("A1", model.EffortSmall),
+		pendingTask("A2", model.EffortSmall, "A1"),
+		pendingTask("A3", model.EffortSmall, "A2"),
+		pendingTask("B", model.EffortLarge),
+	}
+
+	cp := NewGraph(tasks).CriticalPath(DefaultEffortWeights())
+
+	if cp.Length != 16 {
+		t.Errorf("expected length 16, got %v", cp.Length)
+	}
+	if !slices.Equal(cp.Tasks, []string{"B"}) {
+		t.Errorf("expected critical path [B], got %v", cp.Tasks)
+	}
+	for _, id := range []string{"A1", "A2", "A3"} {
+		if got := cp.Timings[id].Slack; got != 10 {
+			t.Errorf("expected %s slack 10, got %v", id, got)
+		}
+	}
+}
+
+func TestCriticalPath_TimingsAndOrder(t *testing.T) {
+	// A (8h) -> C (2h) and B (2h) -> C: A is on the path, B has 6h slack.
+	tasks := []*model.Task{
+		pendingTask("C", model.EffortSmall, "A", "B"),
+		pendingTask("B", model.EffortSmall),
+		pendingTask("A", model.EffortMedium),
+	}
+
+	cp := NewGraph(tasks).CriticalPath(DefaultEffortWeights())
+
+	if !slices.Equal(cp.Tasks, []string{"A", "C"}) {
+		t.Errorf("expected critical path [A C], got %v", cp.Tasks)
+	}
+	want := Timing{Duration: 2, EarliestStart: 8, LatestStart: 8, Critical: true}
+	if got := cp.Timings["C"]; got != want {
+		t.Errorf("expected C timing %+v, got %+v", want, got)
+	}
+	want = Timing{Duration: 2, EarliestStart: 0, LatestStart: 6, Slack: 6}
+	if got := cp.Timings["B"]; got != want {
+		t.Errorf("expected B timing %+v, got %+v", want, got)
+	}
+	if !cp.IsCritical("A") || cp.IsCritical("B") {
+		t.Error("expected A critical and B not")
+	}
+}
+
+func TestCriticalPath_EstimateOverridesEffort(t *testing.T) {
+	small := pendingTask("S", model.EffortSmall)
+	small.Estimate = 20
+	tasks := []*model.Task{small, pendingTask("L", model.EffortLarge)}
+
+	cp := NewGraph(tasks).CriticalPath(DefaultEffortWeights())
+
+	if !slices.Equal(cp.Tasks, []string{"S"}) || cp.Length != 20 {
+		t.Errorf("expected S alone on a 20h path, got %v (%vh)", cp.Tasks, cp.Length)
+	}
+}
+
+func TestCriticalPath_CompletedWorkTakesNoTime(t *testing.T) {
+	// The long chain is finished, so the only remaining work is critical.
+	done := func(id string, deps ...string) *model.Task {
+		task := pendingTask(id, model.EffortLarge, deps...)
+		task.Status = model.StatusCompleted
+		return task
+	}
+	tasks := []*model.Task{
+		done("D1"),
+		done("D2", "D1"),
+		pendingTask("P", model.EffortSmall, "D1"),
+	}
+
+	cp := NewGraph(tasks).CriticalPath(DefaultEffortWeights())
+
+	if cp.Length != 2 || !cp.IsCritical("P") {
+		t.Errorf("expected P on a 2h path, got %v (%vh)", cp.Tasks, cp.Length)
+	}
+	if !slices.Equal(cp.Tasks, []string{"P"}) {
+		t.Errorf("expected completed tasks off the path, got %v", cp.Tasks)
+	}
+	if _, ok := cp.Timings["D1"]; ok {
+		t.Error("expected completed D1 to have no timing")
+	}
+
+	allDone := NewGraph([]*model.Task{done("D1"), done("D2", "D1")}).CriticalPath(DefaultEffortWeights())
+	if len(allDone.Tasks) != 0 || allDone.Length != 0 {
+		t.Errorf("expected no critical path once all work is done, got %v", allDone.Tasks)
+	}
+}
+
+func TestCriticalPath_CustomWeights(t *testing.T) {
+	tasks := []*model.Task{
+		pendingTask("A1", model.EffortSmall),
+		pendingTask("A2", model.EffortSmall, "A1"),
+		pendingTask("B", model.EffortLarge),
+	}
+
+	cp := NewGraph(tasks).CriticalPath(EffortWeights{Small: 5, Medium: 6, Large: 8})
+
+	if !slices.Equal(cp.Tasks, []string{"A1", "A2"}) || cp.Length != 10 {
+		t.Errorf("expected [A1 A2] on a 10h path, got %v (%vh)", cp.Tasks, cp.Length)
+	}
+}
+
+func TestCriticalPath_SkipsCyclesAndMissingDeps(t *testing.T) {
+	tasks := []*model.Task{
+		pendingTask("X", model.EffortSmall, "Y"),
+		pendingTask("Y", model.EffortSmall, "X"),
+		pendingTask("Z", model.EffortMedium, "missing"),
+	}
+
+	cp := NewGraph(tasks).CriticalPath(DefaultEffortWeights())
+
+	if _, ok := cp.Timings["X"]; ok {
+		t.Error("expected tasks in a cycle to have no timing")
+	}
+	if !slices.Equal(cp.Tasks, []string{"Z"}) {
+		t.Errorf("expected [Z], got %v", cp.Tasks)
+	}
+}
+
+func TestToJSON_IncludesSlack(t *testing.T) {
+	tasks := []*model.Task{
+		pendingTask("A", model.EffortLarge),
+		pendingTask("B", model.EffortSmall),
+	}
+
+	out := NewGraph(tasks).ToJSON()
+
+	nodes := out["nodes"].([]map[string]any)
+	if nodes[0]["critical"] != true || nodes[0]["slack"] != 0.0 {
+		t.Errorf("expected A critical with no slack, got %v", nodes[0])
+	}
+	if nodes[1]["critical"] != false || nodes[1]["slack"] != 14.0 {
+		t.Errorf("expected B to have 14h slack, got %v", nodes[1])
+	}
+	cp, ok := out["critical_path"].(*CriticalPath)
+	if !ok || cp.Length != 16 {
+		t.Errorf("expected critical_path with length 16, got %v", out["critical_path"])
+	}
+}
+
+func TestToJSON_CompletedTasksNotCritical(t *testing.T) {
+	done := pendingTask("A", model.EffortLarge)
+	done.Status = model.StatusCompleted
+	tasks := []*model.Task{done, pendingTask("B", model.EffortSmall, "A")}
+
+	nodes := NewGraph(tasks).ToJSON()["nodes"].([]map[string]any)
+
+	if _, ok := nodes[0]["critical"]; ok {
+		t.Errorf("expected no schedule for completed A, got %v", nodes[0])
+	}
+	if nodes[1]["critical"] != true {
+		t.Errorf("expected B critical, got %v", nodes[1])
+	}
+}
+
+func TestParseEffortWeights(t *testing.T) {
+	w, problems := ParseEffortWeights(map[string]any{
+		"small":  1,
+		"large":  12.5,
+		"huge":   40,
+		"medium": "lots",
+	})
+
+	want := EffortWeights{Small: 1, Medium: 8, Large: 12.5}
+	if w != want {
+		t.Errorf("expected %+v, got %+v", want, w)
+	}
+	joined := strings.Join(problems, "\n")
+	if len(problems) != 2 || !strings.Contains(joined, "unknown level: 'huge'") ||
+		!strings.Contains(joined, "effort.medium must be a non-negative number of hours") {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+
+	if w, problems := ParseEffortWeights(nil); w != DefaultEffortWeights() || problems != nil {
+		t.Errorf("expected defaults for a missing section, got %+v %v", w, problems)
+	}
+}
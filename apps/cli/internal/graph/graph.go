@@ -2,6 +2,7 @@ package graph
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -59,6 +60,7 @@ type Graph struct {
 	TaskMap      map[string]*model.Task
 	Adjacency    map[string][]string // task ID -> list of dependent task IDs
 	RevAdjacency map[string][]string // task ID -> list of dependency task IDs
+	Effort       EffortWeights       // hours per effort level, used for slack in ToJSON
 }
 
 // NewGraph creates a new graph from a list of tasks
@@ -68,6 +70,7 @@ func NewGraph(tasks []*model.Task) *Graph {
 		TaskMap:      make(map[string]*model.Task),
 		Adjacency:    make(map[string][]string),
 		RevAdjacency: make(map[string][]string),
+		Effort:       DefaultEffortWeights(),
 	}
 
 	// Build task map
@@ -89,6 +92,23 @@ func NewGraph(tasks []*model.Task) *Graph {
 	return g
 }
 
+// WithArchived adds a completed placeholder node for each archived task ID
+// that a task depends on, so those dependencies resolve instead of dangling.
+func (g *Graph) WithArchived(archivedIDs []string) *Graph {
+	var referenced []string
+	for _, id := range archivedIDs {
+		if _, ok := g.TaskMap[id]; !ok && len(g.Adjacency[id]) > 0 {
+			referenced = append(referenced, id)
+		}
+	}
+	model.AddArchived(g.TaskMap, referenced)
+	g.Tasks = slices.Clip(g.Tasks)
+	for _, id := range referenced {
+		g.Tasks = append(g.Tasks, g.TaskMap[id])
+	}
+	return g
+}
+
 // GetDownstream returns all tasks that depend on the given task (transitively)
 func (g *Graph) GetDownstream(taskID string) map[string]bool {
 	visited := make(map[string]bool)
@@ -184,7 +204,9 @@ func (g *Graph) FilterTasks(taskIDs map[string]bool) *Graph {
 			filtered = append(filtered, task)
 		}
 	}
-	return NewGraph(filtered)
+	sub := NewGraph(filtered)
+	sub.Effort = g.Effort
+	return sub
 }
 
 // ToMermaid generates a Mermaid diagram
@@ -435,20 +457,11 @@ func (g *Graph) ToJSON() map[string]any {
 		return sortedTasks[i].ID < sortedTasks[j].ID
 	})
 
+	cp := g.CriticalPath(g.Effort)
+
 	// Build nodes
 	for _, task := range sortedTasks {
-		node := map[string]any{
-			"id":     task.ID,
-			"title":  task.Title,
-			"status": string(task.Status),
-		}
-		if task.Priority != "" {
-			node["priority"] = string(task.Priority)
-		}
-		if task.Group != "" {
-			node["group"] = task.Group
-		}
-		nodes = append(nodes, node)
+		nodes = append(nodes, jsonNode(task, cp))
 	}
 
 	// Build edges
@@ -474,8 +487,9 @@ func (g *Graph) ToJSON() map[string]any {
 	}
 
 	result := map[string]any{
-		"nodes": nodes,
-		"edges": edges,
+		"nodes":         nodes,
+		"edges":         edges,
+		"critical_path": cp,
 	}
 
 	if len(cyclesData) > 0 {
@@ -484,3 +498,24 @@ func (g *Graph) ToJSON() map[string]any {
 
 	return result
 }
+
+// jsonNode builds the JSON node for a task, with its schedule if it has one.
+func jsonNode(task *model.Task, cp *CriticalPath) map[string]any {
+	node := map[string]any{
+		"id":     task.ID,
+		"title":  task.Title,
+		"status": string(task.Status),
+	}
+	if task.Priority != "" {
+		node["priority"] = string(task.Priority)
+	}
+	if task.Group != "" {
+		node["group"] = task.Group
+	}
+	if timing, ok := cp.Timings[task.ID]; ok {
+		node["duration"] = timing.Duration
+		node["slack"] = timing.Slack
+		node["critical"] = timing.Critical
+	}
+	return node
+}
@@ -66,6 +66,24 @@ func TestNewGraph(t *testing.T) {
 	}
 }
 
+func TestGraph_WithArchived(t *testing.T) {
+	tasks := []*model.Task{
+		{ID: "T2", Title: "Task 2", Status: model.StatusPending, Dependencies: []string{"T1"}},
+	}
+	g := NewGraph(tasks).WithArchived([]string{"T1", "T9"})
+
+	placeholder, ok := g.TaskMap["T1"]
+	if !ok || placeholder.Status != model.StatusCompleted || !placeholder.Archived {
+		t.Fatalf("expected a completed archived placeholder for T1, got %+v", placeholder)
+	}
+	if _, ok := g.TaskMap["T9"]; ok {
+		t.Error("expected unreferenced archived tasks to be left out")
+	}
+	if len(g.Tasks) != 2 || len(tasks) != 1 {
+		t.Errorf("expected the placeholder in the graph only, got %d graph tasks and %d input tasks", len(g.Tasks), len(tasks))
+	}
+}
+
 func TestGetDownstream(t *testing.T) {
 	tasks := createTestTasks()
 	g := NewGraph(tasks)
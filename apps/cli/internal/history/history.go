@@ -0,0 +1,202 @@
+// Package history reads the git history of task files and turns it into a
+// timeline of frontmatter changes.
+package history
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
+)
+
+// ErrNoGit is returned when the git binary is not installed.
+var ErrNoGit = errors.New("git is not installed")
+
+// Change is one frontmatter field that changed in a commit. From is empty
+// when the field was added and To is empty when it was removed.
+type Change struct {
+	Field string `json:"field" yaml:"field"`
+	From  string `json:"from,omitempty" yaml:"from,omitempty"`
+	To    string `json:"to,omitempty" yaml:"to,omitempty"`
+}
+
+// Entry is a commit that changed a task's frontmatter.
+type Entry struct {
+	Commit  string    `json:"commit" yaml:"commit"`
+	Author  string    `json:"author" yaml:"author"`
+	Date    time.Time `json:"date" yaml:"date"`
+	Subject string    `json:"subject" yaml:"subject"`
+	Path    string    `json:"path" yaml:"path"`                       // file path at that commit, relative to the repository root
+	Added   bool      `json:"added,omitempty" yaml:"added,omitempty"` // the commit created the file
+	Changes []Change  `json:"changes" yaml:"changes"`
+}
+
+// ShortCommit returns the abbreviated commit hash.
+func (e Entry) ShortCommit() string {
+	if len(e.Commit) > 7 {
+		return e.Commit[:7]
+	}
+	return e.Commit
+}
+
+// FieldChange returns the change to field in this entry, if any.
+func (e Entry) FieldChange(field string) (Change, bool) {
+	for _, c := range e.Changes {
+		if c.Field == field {
+			return c, true
+		}
+	}
+	return Change{}, false
+}
+
+// Log returns the frontmatter history of the task file at filePath, oldest
+// first, following renames. Commits that only touched the body are left
+// out. A file that git does not track has no history and no error.
+func Log(filePath string) ([]Entry, error) {
+	abs, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Dir(abs)
+
+	out, err := runGit(dir, "log", "--follow", "--name-only", "--no-color",
+		"--format=%x00%H%x1f%an%x1f%aI%x1f%s", "--", filepath.Base(abs))
+	if err != nil {
+		return nil, err
+	}
+	commits := parseLog(out)
+
+	var entries []Entry
+	var prev map[string]any
+	for i := len(commits) - 1; i >= 0; i-- {
+		e := commits[i]
+		content, err := runGit(dir, "show", e.Commit+":"+e.Path)
+		if err != nil {
+			continue // the file was deleted in this commit
+		}
+		fields := frontmatter(content)
+		e.Added = prev == nil
+		e.Changes = diff(prev, fields)
+		prev = fields
+		if e.Added || len(e.Changes) > 0 {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// parseLog splits `git log --name-only` output into entries, newest first.
+func parseLog(out []byte) []Entry {
+	var entries []Entry
+	for _, record := range strings.Split(string(out), "\x00") {
+		lines := strings.Split(strings.TrimSpace(record), "\n")
+		header := strings.Split(lines[0], "\x1f")
+		if len(header) != 4 {
+			continue
+		}
+		e := Entry{Commit: header[0], Author: header[1], Subject: header[3]}
+		e.Date, _ = time.Parse(time.RFC3339, header[2])
+		for _, line := range lines[1:] {
+			if line = strings.TrimSpace(line); line != "" {
+				e.Path = line
+			}
+		}
+		if e.Path != "" {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// frontmatter decodes the YAML frontmatter of a task file. Files without
+// valid frontmatter yield an empty map, so every field shows as removed.
+func frontmatter(content []byte) map[string]any {
+	lines := strings.Split(string(content), "\n")
+	openIdx, closeIdx := taskfile.FindFrontmatterBounds(lines)
+	fields := make(map[string]any)
+	if openIdx != 0 {
+		return fields
+	}
+	if err := yaml.Unmarshal([]byte(strings.Join(lines[openIdx+1:closeIdx], "\n")), &fields); err != nil {
+		return make(map[string]any)
+	}
+	return fields
+}
+
+// diff lists fields whose values differ between two frontmatter versions,
+// status first and then alphabetically.
+func diff(before, after map[string]any) []Change {
+	keys := make(map[string]bool)
+	for k := range before {
+		keys[k] = true
+	}
+	for k := range after {
+		keys[k] = true
+	}
+
+	var changes []Change
+	for k := range keys {
+		from, to := formatValue(before[k]), formatValue(after[k])
+		if from != to {
+			changes = append(changes, Change{Field: k, From: from, To: to})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if (changes[i].Field == "status") != (changes[j].Field == "status") {
+			return changes[i].Field == "status"
+		}
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+// formatValue renders a frontmatter value for display: lists are joined with
+// commas and dates without a time of day are shown as YYYY-MM-DD.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		if v.Hour() == 0 && v.Minute() == 0 && v.Second() == 0 {
+			return v.Format("2006-01-02")
+		}
+		return v.Format(time.RFC3339)
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = formatValue(item)
+		}
+		return strings.Join(parts, ", ")
+	case map[string]any:
+		b, _ := yaml.Marshal(v)
+		return strings.TrimSpace(string(b))
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func runGit(dir string, args ...string) ([]byte, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, ErrNoGit
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return out, nil
+}
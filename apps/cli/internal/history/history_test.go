@@ -0,0 +1,134 @@
+package history
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// testRepo is a throwaway git repository for history tests.
+type testRepo struct {
+	t   *testing.T
+	dir string
+}
+
+func newTestRepo(t *testing.T) *testRepo {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	r := &testRepo{t: t, dir: t.TempDir()}
+	r.git("Alice", "2026-01-01T09:00:00Z", "init", "-q")
+	return r
+}
+
+func (r *testRepo) git(author, date string, args ...string) {
+	r.t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME="+author, "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME="+author, "GIT_COMMITTER_EMAIL=test@example.com",
+		"GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		r.t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func (r *testRepo) commit(author, date, name, content, msg string) {
+	r.t.Helper()
+	if err := os.WriteFile(filepath.Join(r.dir, name), []byte(content), 0644); err != nil {
+		r.t.Fatal(err)
+	}
+	r.git(author, date, "add", "-A")
+	r.git(author, date, "commit", "-q", "-m", msg)
+}
+
+func TestLog_Timeline(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("Alice", "2026-01-01T09:00:00Z", "042-task.md",
+		"---\nid: \"042\"\ntitle: Task\nstatus: pending\ntags: [api]\n---\nBody\n", "Add task 042")
+	r.commit("Bob", "2026-01-03T10:00:00Z", "042-task.md",
+		"---\nid: \"042\"\ntitle: Task\nstatus: in-progress\nowner: bob\ntags: [api]\n---\nBody\n", "Start 042")
+	r.commit("Bob", "2026-01-04T10:00:00Z", "042-task.md",
+		"---\nid: \"042\"\ntitle: Task\nstatus: in-progress\nowner: bob\ntags: [api]\n---\nMore body\n", "Notes")
+	r.git("Bob", "2026-01-05T10:00:00Z", "mv", "042-task.md", "042-renamed.md")
+	r.git("Bob", "2026-01-05T10:00:00Z", "commit", "-q", "-m", "Rename")
+	r.commit("Carol", "2026-01-06T15:30:00Z", "042-renamed.md",
+		"---\nid: \"042\"\ntitle: Task\nstatus: completed\ntags: [api, done]\n---\nMore body\n", "Finish 042")
+
+	entries, err := Log(filepath.Join(r.dir, "042-renamed.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var subjects []string
+	for _, e := range entries {
+		subjects = append(subjects, e.Subject)
+	}
+	if !slices.Equal(subjects, []string{"Add task 042", "Start 042", "Finish 042"}) {
+		t.Fatalf("unexpected timeline: %v", subjects)
+	}
+
+	if !entries[0].Added || entries[0].Path != "042-task.md" {
+		t.Errorf("expected the first entry to add 042-task.md, got %+v", entries[0])
+	}
+	start := entries[1]
+	if start.Author != "Bob" || !start.Date.Equal(time.Date(2026, 1, 3, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected author or date: %+v", start)
+	}
+	wantStart := []Change{
+		{Field: "status", From: "pending", To: "in-progress"},
+		{Field: "owner", To: "bob"},
+	}
+	if !slices.Equal(start.Changes, wantStart) {
+		t.Errorf("expected %+v, got %+v", wantStart, start.Changes)
+	}
+
+	finish := entries[2]
+	if finish.Path != "042-renamed.md" {
+		t.Errorf("expected the renamed path, got %s", finish.Path)
+	}
+	if c, ok := finish.FieldChange("owner"); !ok || c.From != "bob" || c.To != "" {
+		t.Errorf("expected owner removed, got %+v", c)
+	}
+	if c, ok := finish.FieldChange("tags"); !ok || c.From != "api" || c.To != "api, done" {
+		t.Errorf("expected tags change, got %+v", c)
+	}
+}
+
+func TestLog_Untracked(t *testing.T) {
+	r := newTestRepo(t)
+	r.commit("Alice", "2026-01-01T09:00:00Z", "README.md", "# Tasks\n", "Init")
+	path := filepath.Join(r.dir, "001-new.md")
+	if err := os.WriteFile(path, []byte("---\nid: \"001\"\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := Log(path)
+	if err != nil || len(entries) != 0 {
+		t.Errorf("expected no history and no error, got %v %v", entries, err)
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		in   any
+		want string
+	}{
+		{nil, ""},
+		{"pending", "pending"},
+		{42, "42"},
+		{[]any{"a", "b"}, "a, b"},
+		{time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), "2026-02-01"},
+		{time.Date(2026, 2, 1, 14, 5, 0, 0, time.UTC), "2026-02-01T14:05:00Z"},
+	}
+	for _, tt := range tests {
+		if got := formatValue(tt.in); got != tt.want {
+			t.Errorf("formatValue(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
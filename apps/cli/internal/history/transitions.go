@@ -0,0 +1,182 @@
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+// Transition is a commit that set a task's status.
+type Transition struct {
+	Status model.Status `json:"status" yaml:"status"`
+	At     time.Time    `json:"at" yaml:"at"`
+}
+
+// Dates are the moments in a task's life that flow metrics need. Zero
+// values mean unknown.
+type Dates struct {
+	Created   time.Time `json:"created,omitempty" yaml:"created,omitempty"`     // first commit of the file
+	Started   time.Time `json:"started,omitempty" yaml:"started,omitempty"`     // first move to in-progress
+	Completed time.Time `json:"completed,omitempty" yaml:"completed,omitempty"` // latest move to completed
+}
+
+// maxFrontmatterLine bounds where a status line is taken to be frontmatter,
+// so "status:" lines in example YAML further down a task body are ignored.
+const maxFrontmatterLine = 50
+
+// Transitions scans the git history under dir once and returns the status
+// transitions of each task, oldest first. The first transition is the
+// status the file was committed with. Renames are followed, so a task moved
+// by archive or renumber keeps the history from its old path.
+func Transitions(tasks []*model.Task, dir string) (map[string][]Transition, error) {
+	top, err := runGit(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := runGit(dir, "log", "--format=%x00%cI", "-p", "--unified=0", "--no-color", "-M", "--", ".")
+	if err != nil {
+		return nil, err
+	}
+
+	p := &transitionParser{
+		root:     strings.TrimSpace(string(top)),
+		idByPath: make(map[string]string, len(tasks)),
+		result:   make(map[string][]Transition),
+	}
+	for _, task := range tasks {
+		p.idByPath[canonicalPath(task.FilePath)] = task.ID
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		p.line(sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	// git log is newest first; reverse each task's transitions.
+	for id, ts := range p.result {
+		slices.Reverse(ts)
+		p.result[id] = ts
+	}
+	return p.result, nil
+}
+
+// transitionParser reads "git log -p" output newest first. Each rename maps
+// the old path to the task at the new one, so older commits to the old path
+// are attributed to the same task.
+type transitionParser struct {
+	root       string
+	idByPath   map[string]string
+	result     map[string][]Transition
+	commitTime time.Time
+	currentID  string
+	renameFrom string
+	lineNo     int // line number of the next added line in the new file
+}
+
+func (p *transitionParser) line(line string) {
+	switch {
+	case strings.HasPrefix(line, "\x00"):
+		p.commitTime, _ = time.Parse(time.RFC3339, strings.TrimPrefix(line, "\x00"))
+	case strings.HasPrefix(line, "rename from "):
+		p.renameFrom = strings.TrimPrefix(line, "rename from ")
+	case strings.HasPrefix(line, "rename to "):
+		p.followRename(strings.TrimPrefix(line, "rename to "))
+	case strings.HasPrefix(line, "+++ "):
+		p.currentID = ""
+		if path, ok := strings.CutPrefix(line, "+++ b/"); ok {
+			p.currentID = p.idByPath[p.canonical(path)]
+		}
+	case strings.HasPrefix(line, "@@ "):
+		p.lineNo = hunkStart(line)
+	case p.currentID != "" && strings.HasPrefix(line, "+"):
+		p.added(line)
+	}
+}
+
+func (p *transitionParser) followRename(to string) {
+	if id := p.idByPath[p.canonical(to)]; id != "" && p.renameFrom != "" {
+		p.idByPath[p.canonical(p.renameFrom)] = id
+	}
+	p.renameFrom = ""
+}
+
+// added records a transition for a "+status:" line in the frontmatter.
+func (p *transitionParser) added(line string) {
+	if value, ok := strings.CutPrefix(line, "+status:"); ok && p.lineNo <= maxFrontmatterLine && !p.commitTime.IsZero() {
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+		p.result[p.currentID] = append(p.result[p.currentID], Transition{Status: model.Status(value), At: p.commitTime})
+		p.currentID = "" // one transition per file per commit
+	}
+	p.lineNo++
+}
+
+func (p *transitionParser) canonical(path string) string {
+	return canonicalPath(filepath.Join(p.root, path))
+}
+
+// hunkStart returns the new-file start line of a "@@ -a,b +c,d @@" header.
+func hunkStart(header string) int {
+	fields := strings.Fields(header)
+	if len(fields) < 3 {
+		return 0
+	}
+	start, _, _ := strings.Cut(strings.TrimPrefix(fields[2], "+"), ",")
+	n, _ := strconv.Atoi(start)
+	return n
+}
+
+// TaskDates returns the created, started and completed times of each task
+// from the git history of dir. Tasks git knows nothing about are left out.
+func TaskDates(tasks []*model.Task, dir string) (map[string]Dates, error) {
+	transitions, err := Transitions(tasks, dir)
+	if err != nil {
+		return nil, err
+	}
+	dates := make(map[string]Dates, len(transitions))
+	for id, ts := range transitions {
+		dates[id] = DatesFrom(ts)
+	}
+	return dates, nil
+}
+
+// DatesFrom derives a task's created, started and completed times from its
+// transitions. Completed is only set when the last transition is to
+// completed, so reopened tasks do not count as done.
+func DatesFrom(ts []Transition) Dates {
+	var d Dates
+	if len(ts) == 0 {
+		return d
+	}
+	d.Created = ts[0].At
+	for _, t := range ts {
+		if t.Status == model.StatusInProgress && d.Started.IsZero() {
+			d.Started = t.At
+		}
+	}
+	if last := ts[len(ts)-1]; last.Status == model.StatusCompleted {
+		d.Completed = last.At
+	}
+	return d
+}
+
+// canonicalPath makes paths from the scanner and from git comparable.
+func canonicalPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	return path
+}
@@ -0,0 +1,94 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+func TestTransitions(t *testing.T) {
+	r := newTestRepo(t)
+	task := func(status, body string) string {
+		return "---\nid: \"001\"\ntitle: Task\nstatus: " + status + "\n---\n" + body
+	}
+	// Example YAML far down the body must not count as a transition.
+	example := strings.Repeat("text\n", 60) + "```yaml\nstatus: cancelled\n```\n"
+
+	r.commit("Alice", "2026-01-01T09:00:00Z", "001-task.md", task("pending", ""), "Add")
+	r.commit("Alice", "2026-01-02T09:00:00Z", "001-task.md", task("in-progress", ""), "Start")
+	r.commit("Alice", "2026-01-03T09:00:00Z", "001-task.md", task("in-progress", example), "Example")
+	r.commit("Alice", "2026-01-04T09:00:00Z", "001-task.md", task("completed", example), "Done")
+
+	tasks := []*model.Task{{ID: "001", FilePath: filepath.Join(r.dir, "001-task.md")}}
+	transitions, err := Transitions(tasks, r.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, tr := range transitions["001"] {
+		got = append(got, string(tr.Status)+"@"+tr.At.Format("01-02"))
+	}
+	want := "pending@01-01 in-progress@01-02 completed@01-04"
+	if strings.Join(got, " ") != want {
+		t.Errorf("expected %s, got %v", want, got)
+	}
+
+	d := DatesFrom(transitions["001"])
+	day := func(n int) time.Time { return time.Date(2026, 1, n, 9, 0, 0, 0, time.UTC) }
+	if !d.Created.Equal(day(1)) || !d.Started.Equal(day(2)) || !d.Completed.Equal(day(4)) {
+		t.Errorf("unexpected dates: %+v", d)
+	}
+}
+
+func TestTransitions_FollowsRenames(t *testing.T) {
+	r := newTestRepo(t)
+	task := func(id, status string) string {
+		return "---\nid: \"" + id + "\"\ntitle: Task\nstatus: " + status + "\n---\n# Task\n\nSome description.\n"
+	}
+
+	r.commit("Alice", "2026-01-01T09:00:00Z", "004-task.md", task("004", "pending"), "Add")
+	r.commit("Alice", "2026-01-02T09:00:00Z", "004-task.md", task("004", "in-progress"), "Start")
+	// Renumber, then archive with the completing edit in the same commit.
+	r.git("Alice", "2026-01-03T09:00:00Z", "mv", "004-task.md", "107-task.md")
+	r.commit("Alice", "2026-01-03T09:00:00Z", "107-task.md", task("107", "in-progress"), "Renumber")
+	if err := os.Mkdir(filepath.Join(r.dir, "archive"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	r.git("Alice", "2026-01-04T09:00:00Z", "mv", "107-task.md", "archive/107-task.md")
+	r.commit("Alice", "2026-01-04T09:00:00Z", "archive/107-task.md", task("107", "completed"), "Archive")
+
+	tasks := []*model.Task{{ID: "107", FilePath: filepath.Join(r.dir, "archive", "107-task.md")}}
+	transitions, err := Transitions(tasks, r.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, tr := range transitions["107"] {
+		got = append(got, string(tr.Status)+"@"+tr.At.Format("01-02"))
+	}
+	want := "pending@01-01 in-progress@01-02 completed@01-04"
+	if strings.Join(got, " ") != want {
+		t.Errorf("expected %s, got %v", want, got)
+	}
+}
+
+func TestDatesFrom_Reopened(t *testing.T) {
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := DatesFrom([]Transition{
+		{Status: model.StatusPending, At: at},
+		{Status: model.StatusCompleted, At: at.AddDate(0, 0, 1)},
+		{Status: model.StatusInProgress, At: at.AddDate(0, 0, 2)},
+	})
+	if !d.Completed.IsZero() {
+		t.Errorf("expected a reopened task to have no completion, got %v", d.Completed)
+	}
+	if !d.Started.Equal(at.AddDate(0, 0, 2)) {
+		t.Errorf("expected started on the reopen, got %v", d.Started)
+	}
+}
@@ -0,0 +1,97 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/driangle/taskmd/apps/cli/internal/claim"
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+// ClaimInput defines the input schema for the claim tool.
+type ClaimInput struct {
+	TaskDir string `json:"task_dir,omitempty" jsonschema:"task directory to scan, defaults to current directory"`
+	TaskID  string `json:"task_id" jsonschema:"required,task ID to claim"`
+	Owner   string `json:"owner" jsonschema:"required,who is claiming the task, e.g. an agent name"`
+	Lease   string `json:"lease,omitempty" jsonschema:"how long the claim lasts without renewal, as a Go duration such as 30m or 2h; defaults to 30m"`
+	Action  string `json:"action,omitempty" jsonschema:"claim (default), renew to extend your claim, or release to drop it"`
+}
+
+func registerClaimTool(server *gomcp.Server, cfg Config) {
+	gomcp.AddTool(server, &gomcp.Tool{
+		Name:        "claim",
+		Description: "Atomically claim a pending, actionable task: sets status in-progress and owner, and fails if another owner already holds it. Use after next to avoid two agents starting the same task; renew the claim while working",
+	}, withConfig(cfg, handleClaim))
+}
+
+func handleClaim(_ context.Context, _ *gomcp.CallToolRequest, input ClaimInput, cfg Config) (*gomcp.CallToolResult, any, error) {
+	if input.TaskID == "" {
+		return nil, nil, fmt.Errorf("task_id is required")
+	}
+	if input.Owner == "" {
+		return nil, nil, fmt.Errorf("owner is required")
+	}
+
+	lease, err := parseLease(input.Lease)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	taskDir := input.TaskDir
+	if taskDir == "" {
+		taskDir = "."
+	}
+	claimer := &claim.Claimer{
+		StateDir: claim.StateDir(taskDir),
+		Load: func() ([]*model.Task, []string, error) {
+			result, err := newScanner(taskDir).Scan()
+			if err != nil {
+				return nil, nil, fmt.Errorf("scan failed: %w", err)
+			}
+			return result.Tasks, result.ArchivedIDs, nil
+		},
+		Timestamps: &cfg.Timestamps,
+	}
+
+	var out any
+	switch input.Action {
+	case "", "claim":
+		out, err = claimer.Claim(input.TaskID, input.Owner, lease)
+	case "renew":
+		out, err = claimer.Renew(input.TaskID, input.Owner, lease)
+	case "release":
+		err = claimer.Release(input.TaskID, input.Owner)
+		out = map[string]string{"task_id": input.TaskID, "released": input.Owner}
+	default:
+		return nil, nil, fmt.Errorf("invalid action %q (valid: claim, renew, release)", input.Action)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := json.Marshal(out)
+	if err != nil {
+		return nil, nil, fmt.Errorf("json marshal failed: %w", err)
+	}
+
+	return &gomcp.CallToolResult{
+		Content: []gomcp.Content{&gomcp.TextContent{Text: string(data)}},
+	}, nil, nil
+}
+
+// parseLease parses a lease duration such as "2h", defaulting to
+// claim.DefaultLease when empty.
+func parseLease(s string) (time.Duration, error) {
+	if s == "" {
+		return claim.DefaultLease, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid lease %q: %w", s, err)
+	}
+	return d, nil
+}
@@ -0,0 +1,60 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/driangle/taskmd/apps/cli/internal/claim"
+)
+
+func callClaim(t *testing.T, session *gomcp.ClientSession, args map[string]any) (*gomcp.CallToolResult, error) {
+	t.Helper()
+	return session.CallTool(context.Background(), &gomcp.CallToolParams{
+		Name:      "claim",
+		Arguments: args,
+	})
+}
+
+func TestClaimTool_ClaimAndConflict(t *testing.T) {
+	tmpDir := createTestTaskFiles(t)
+	session := setupTestServer(t)
+
+	result, err := callClaim(t, session, map[string]any{"task_dir": tmpDir, "task_id": "002", "owner": "agent-1", "lease": "1h"})
+	if err != nil || result.IsError {
+		t.Fatalf("claim failed: %v %+v", err, result)
+	}
+
+	var cl claim.Claim
+	text := result.Content[0].(*gomcp.TextContent).Text
+	if err := json.Unmarshal([]byte(text), &cl); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if cl.TaskID != "002" || cl.Owner != "agent-1" {
+		t.Errorf("unexpected claim: %+v", cl)
+	}
+
+	content := readFileContent(t, filepath.Join(tmpDir, "002-auth.md"))
+	if !strings.Contains(content, "status: in-progress") || !strings.Contains(content, "owner: agent-1") {
+		t.Errorf("expected task to be claimed, got:\n%s", content)
+	}
+
+	result, err = callClaim(t, session, map[string]any{"task_dir": tmpDir, "task_id": "002", "owner": "agent-2"})
+	if err == nil && !result.IsError {
+		t.Fatal("expected second claim to fail")
+	}
+}
+
+func TestClaimTool_InvalidAction(t *testing.T) {
+	tmpDir := createTestTaskFiles(t)
+	session := setupTestServer(t)
+
+	result, err := callClaim(t, session, map[string]any{"task_dir": tmpDir, "task_id": "002", "owner": "agent-1", "action": "steal"})
+	if err == nil && !result.IsError {
+		t.Fatal("expected invalid action to fail")
+	}
+}
@@ -7,7 +7,6 @@ import (
 
 	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
 
-	"github.com/driangle/taskmd/apps/cli/internal/scanner"
 	"github.com/driangle/taskmd/apps/cli/internal/taskcontext"
 )
 
@@ -39,7 +38,7 @@ func handleContext(_ context.Context, _ *gomcp.CallToolRequest, input ContextInp
 		taskDir = "."
 	}
 
-	taskScanner := scanner.NewScanner(taskDir, false, nil)
+	taskScanner := newScanner(taskDir)
 	result, err := taskScanner.Scan()
 	if err != nil {
 		return nil, nil, fmt.Errorf("scan failed: %w", err)
@@ -4,12 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/driangle/taskmd/apps/cli/internal/graph"
 	"github.com/driangle/taskmd/apps/cli/internal/model"
-	"github.com/driangle/taskmd/apps/cli/internal/scanner"
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
 )
 
 // GetInput defines the input schema for the get tool.
@@ -30,6 +31,8 @@ type getOutput struct {
 	Touches      []string `json:"touches,omitempty"`
 	Parent       string   `json:"parent,omitempty"`
 	Created      string   `json:"created,omitempty"`
+	Started      string   `json:"started,omitempty"`
+	Completed    string   `json:"completed,omitempty"`
 	FilePath     string   `json:"file_path"`
 	Content      string   `json:"content"`
 	DependsOn    []depRef `json:"depends_on"`
@@ -60,7 +63,7 @@ func handleGet(_ context.Context, _ *gomcp.CallToolRequest, input GetInput) (*go
 		taskDir = "."
 	}
 
-	taskScanner := scanner.NewScanner(taskDir, false, nil)
+	taskScanner := newScanner(taskDir)
 	result, err := taskScanner.Scan()
 	if err != nil {
 		return nil, nil, fmt.Errorf("scan failed: %w", err)
@@ -92,6 +95,15 @@ func findTaskByID(id string, tasks []*model.Task) *model.Task {
 	return nil
 }
 
+// formatTimestamp formats a started or completed timestamp, or "" when it
+// is unset.
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(taskfile.TimestampLayout)
+}
+
 func buildGetOutput(task *model.Task, allTasks []*model.Task) getOutput {
 	taskMap := make(map[string]*model.Task, len(allTasks))
 	for _, t := range allTasks {
@@ -141,6 +153,8 @@ func buildGetOutput(task *model.Task, allTasks []*model.Task) getOutput {
 		Touches:      task.Touches,
 		Parent:       task.Parent,
 		Created:      created,
+		Started:      formatTimestamp(task.Started),
+		Completed:    formatTimestamp(task.Completed),
 		FilePath:     task.FilePath,
 		Content:      task.Body,
 		DependsOn:    dependsOn,
@@ -10,7 +10,6 @@ import (
 	"github.com/driangle/taskmd/apps/cli/internal/filter"
 	"github.com/driangle/taskmd/apps/cli/internal/graph"
 	"github.com/driangle/taskmd/apps/cli/internal/model"
-	"github.com/driangle/taskmd/apps/cli/internal/scanner"
 )
 
 // GraphInput defines the input schema for the graph tool.
@@ -18,32 +17,33 @@ type GraphInput struct {
 	TaskDir       string   `json:"task_dir,omitempty" jsonschema:"task directory to scan, defaults to current directory"`
 	RootTaskID    string   `json:"root_task_id,omitempty" jsonschema:"focus on a specific task and its dependencies/dependents"`
 	ExcludeStatus []string `json:"exclude_status,omitempty" jsonschema:"exclude tasks with these statuses"`
-	Filters       []string `json:"filters,omitempty" jsonschema:"filter expressions, e.g. status=pending, priority=high"`
+	Filters       []string `json:"filters,omitempty" jsonschema:"filter expressions combined with AND; each supports and/or/not, != < <= > >=, in (...) and globs, e.g. status=pending, tag in (cli, web)"`
 }
 
-func registerGraphTool(server *gomcp.Server) {
+func registerGraphTool(server *gomcp.Server, cfg Config) {
 	gomcp.AddTool(server, &gomcp.Tool{
 		Name:        "graph",
 		Description: "Get the task dependency graph as JSON with nodes, edges, and cycle detection",
-	}, handleGraph)
+	}, withConfig(cfg, handleGraph))
 }
 
-func handleGraph(_ context.Context, _ *gomcp.CallToolRequest, input GraphInput) (*gomcp.CallToolResult, any, error) {
+func handleGraph(_ context.Context, _ *gomcp.CallToolRequest, input GraphInput, cfg Config) (*gomcp.CallToolResult, any, error) {
 	taskDir := input.TaskDir
 	if taskDir == "" {
 		taskDir = "."
 	}
 
-	taskScanner := scanner.NewScanner(taskDir, false, nil)
+	taskScanner := newScanner(taskDir)
 	result, err := taskScanner.Scan()
 	if err != nil {
 		return nil, nil, fmt.Errorf("scan failed: %w", err)
 	}
+	archivedIDs := result.ArchivedIDs
 
 	tasks := result.Tasks
 
 	if len(input.Filters) > 0 {
-		tasks, err = filter.Apply(tasks, input.Filters)
+		tasks, err = filter.ApplyEnv(tasks, input.Filters, filter.NewEnv(tasks).WithArchived(archivedIDs))
 		if err != nil {
 			return nil, nil, fmt.Errorf("filter error: %w", err)
 		}
@@ -53,7 +53,7 @@ func handleGraph(_ context.Context, _ *gomcp.CallToolRequest, input GraphInput)
 		tasks = excludeByStatus(tasks, input.ExcludeStatus)
 	}
 
-	g := graph.NewGraph(tasks)
+	g := graph.NewGraph(tasks).WithArchived(archivedIDs)
 
 	if input.RootTaskID != "" {
 		if _, ok := g.TaskMap[input.RootTaskID]; !ok {
@@ -72,6 +72,7 @@ func handleGraph(_ context.Context, _ *gomcp.CallToolRequest, input GraphInput)
 		g = g.FilterTasks(combined)
 	}
 
+	g.Effort = cfg.Next.Effort
 	graphJSON := g.ToJSON()
 
 	data, err := json.Marshal(graphJSON)
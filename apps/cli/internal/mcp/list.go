@@ -4,36 +4,36 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"sort"
 
 	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/driangle/taskmd/apps/cli/internal/filter"
 	"github.com/driangle/taskmd/apps/cli/internal/model"
-	"github.com/driangle/taskmd/apps/cli/internal/scanner"
+	"github.com/driangle/taskmd/apps/cli/internal/views"
 )
 
 // ListInput defines the input schema for the list tool.
 type ListInput struct {
 	TaskDir string   `json:"task_dir,omitempty" jsonschema:"task directory to scan, defaults to current directory"`
-	Filters []string `json:"filters,omitempty" jsonschema:"filter expressions, e.g. status=pending, priority=high"`
+	Filters []string `json:"filters,omitempty" jsonschema:"filter expressions combined with AND; each supports and/or/not, != < <= > >=, in (...) and globs, e.g. status!=completed, priority>=high, not blocked"`
 	Sort    string   `json:"sort,omitempty" jsonschema:"sort field: id, title, status, priority, effort, created"`
+	View    string   `json:"view,omitempty" jsonschema:"name of a saved view from .taskmd.yaml; its filters are combined with filters and its sort is used unless sort is set"`
 }
 
-func registerListTool(server *gomcp.Server) {
+func registerListTool(server *gomcp.Server, cfg Config) {
 	gomcp.AddTool(server, &gomcp.Tool{
 		Name:        "list",
 		Description: "List and filter tasks in a taskmd project",
-	}, handleList)
+	}, withConfig(cfg, handleList))
 }
 
-func handleList(_ context.Context, _ *gomcp.CallToolRequest, input ListInput) (*gomcp.CallToolResult, any, error) {
+func handleList(_ context.Context, _ *gomcp.CallToolRequest, input ListInput, cfg Config) (*gomcp.CallToolResult, any, error) {
 	taskDir := input.TaskDir
 	if taskDir == "" {
 		taskDir = "."
 	}
 
-	taskScanner := scanner.NewScanner(taskDir, false, nil)
+	taskScanner := newScanner(taskDir)
 	result, err := taskScanner.Scan()
 	if err != nil {
 		return nil, nil, fmt.Errorf("scan failed: %w", err)
@@ -41,15 +41,20 @@ func handleList(_ context.Context, _ *gomcp.CallToolRequest, input ListInput) (*
 
 	tasks := result.Tasks
 
-	if len(input.Filters) > 0 {
-		tasks, err = filter.Apply(tasks, input.Filters)
+	filters, sortField, err := resolveListView(cfg.Views, input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(filters) > 0 {
+		tasks, err = filter.ApplyEnv(tasks, filters, filter.NewEnv(tasks).WithArchived(result.ArchivedIDs))
 		if err != nil {
 			return nil, nil, fmt.Errorf("filter error: %w", err)
 		}
 	}
 
-	if input.Sort != "" {
-		if err := sortTasks(tasks, input.Sort); err != nil {
+	if sortField != "" {
+		if err := sortTasks(tasks, sortField); err != nil {
 			return nil, nil, err
 		}
 	}
@@ -64,33 +69,24 @@ func handleList(_ context.Context, _ *gomcp.CallToolRequest, input ListInput) (*
 	}, nil, nil
 }
 
-func sortTasks(tasks []*model.Task, field string) error {
-	switch field {
-	case "id":
-		sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
-	case "title":
-		sort.Slice(tasks, func(i, j int) bool { return tasks[i].Title < tasks[j].Title })
-	case "status":
-		sort.Slice(tasks, func(i, j int) bool { return tasks[i].Status < tasks[j].Status })
-	case "priority":
-		order := map[model.Priority]int{
-			model.PriorityCritical: 0,
-			model.PriorityHigh:     1,
-			model.PriorityMedium:   2,
-			model.PriorityLow:      3,
-		}
-		sort.Slice(tasks, func(i, j int) bool { return order[tasks[i].Priority] < order[tasks[j].Priority] })
-	case "effort":
-		order := map[model.Effort]int{
-			model.EffortSmall:  0,
-			model.EffortMedium: 1,
-			model.EffortLarge:  2,
-		}
-		sort.Slice(tasks, func(i, j int) bool { return order[tasks[i].Effort] < order[tasks[j].Effort] })
-	case "created":
-		sort.Slice(tasks, func(i, j int) bool { return tasks[i].Created.Before(tasks[j].Created) })
-	default:
-		return fmt.Errorf("unsupported sort field: %s (supported: id, title, status, priority, effort, created)", field)
+// resolveListView merges the requested saved view with explicit filters and sort.
+func resolveListView(saved views.Views, input ListInput) ([]string, string, error) {
+	if input.View == "" {
+		return input.Filters, input.Sort, nil
+	}
+
+	view, err := saved.Get(input.View)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sortField := input.Sort
+	if sortField == "" {
+		sortField = view.Sort
 	}
-	return nil
+	return append(append([]string{}, view.Filters...), input.Filters...), sortField, nil
+}
+
+func sortTasks(tasks []*model.Task, field string) error {
+	return model.SortTasks(tasks, field)
 }
@@ -10,6 +10,9 @@ import (
 	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/driangle/taskmd/apps/cli/internal/model"
+	"github.com/driangle/taskmd/apps/cli/internal/next"
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
+	"github.com/driangle/taskmd/apps/cli/internal/views"
 )
 
 func createTestTaskFiles(t *testing.T) string {
@@ -84,10 +87,18 @@ created: 2026-01-04
 
 func setupTestServer(t *testing.T) *gomcp.ClientSession {
 	t.Helper()
+	return setupTestServerWithConfig(t, Config{
+		Next:       next.DefaultConfig(),
+		Timestamps: taskfile.DefaultTimestampConfig(),
+	})
+}
+
+func setupTestServerWithConfig(t *testing.T, cfg Config) *gomcp.ClientSession {
+	t.Helper()
 
 	ctx := context.Background()
 
-	server := NewServer("test")
+	server := NewServer("test", cfg)
 	client := gomcp.NewClient(&gomcp.Implementation{
 		Name:    "test-client",
 		Version: "1.0",
@@ -280,6 +291,43 @@ func TestListTool_InvalidSort(t *testing.T) {
 	}
 }
 
+func TestListTool_View(t *testing.T) {
+	tmpDir := createTestTaskFiles(t)
+	saved, problems := views.Parse(map[string]any{
+		"open-features": map[string]any{
+			"filter": "status!=completed and tag=feature",
+			"sort":   "priority",
+		},
+	})
+	if len(problems) > 0 {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+	session := setupTestServerWithConfig(t, Config{Views: saved})
+
+	tasks := callList(t, session, map[string]any{
+		"task_dir": tmpDir,
+		"view":     "open-features",
+	})
+
+	if len(tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(tasks))
+	}
+	if tasks[0].ID != "002" || tasks[1].ID != "003" {
+		t.Errorf("expected [002 003] sorted by priority, got [%s %s]", tasks[0].ID, tasks[1].ID)
+	}
+
+	result, err := session.CallTool(context.Background(), &gomcp.CallToolParams{
+		Name: "list",
+		Arguments: map[string]any{
+			"task_dir": tmpDir,
+			"view":     "missing",
+		},
+	})
+	if err == nil && !result.IsError {
+		t.Fatal("expected error for unknown view")
+	}
+}
+
 func TestListTool_Discoverable(t *testing.T) {
 	session := setupTestServer(t)
 
@@ -8,32 +8,33 @@ import (
 	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/driangle/taskmd/apps/cli/internal/next"
-	"github.com/driangle/taskmd/apps/cli/internal/scanner"
 )
 
 // NextInput defines the input schema for the next tool.
 type NextInput struct {
 	TaskDir   string   `json:"task_dir,omitempty" jsonschema:"task directory to scan, defaults to current directory"`
 	Limit     int      `json:"limit,omitempty" jsonschema:"max number of recommendations to return, defaults to 5"`
-	Filters   []string `json:"filters,omitempty" jsonschema:"filter expressions, e.g. priority=high, tag=mvp"`
+	Filters   []string `json:"filters,omitempty" jsonschema:"filter expressions combined with AND; each supports and/or/not, != < <= > >=, in (...) and globs, e.g. priority>=high, tag=mvp or tag=cli"`
 	QuickWins bool     `json:"quick_wins,omitempty" jsonschema:"only show small-effort tasks"`
 	Critical  bool     `json:"critical,omitempty" jsonschema:"only show tasks on the critical path"`
+	For       string   `json:"for,omitempty" jsonschema:"recommend for this owner: skips tasks in progress under someone else and prefers the owner's own and unowned tasks"`
+	Explain   bool     `json:"explain,omitempty" jsonschema:"return every candidate with its score breakdown, ignoring limit"`
 }
 
-func registerNextTool(server *gomcp.Server) {
+func registerNextTool(server *gomcp.Server, cfg Config) {
 	gomcp.AddTool(server, &gomcp.Tool{
 		Name:        "next",
-		Description: "Get ranked task recommendations based on priority, dependencies, and critical path analysis",
-	}, handleNext)
+		Description: "Get ranked task recommendations based on priority, dependencies, and critical path analysis, using the scoring weights from .taskmd.yaml",
+	}, withConfig(cfg, handleNext))
 }
 
-func handleNext(_ context.Context, _ *gomcp.CallToolRequest, input NextInput) (*gomcp.CallToolResult, any, error) {
+func handleNext(_ context.Context, _ *gomcp.CallToolRequest, input NextInput, cfg Config) (*gomcp.CallToolResult, any, error) {
 	taskDir := input.TaskDir
 	if taskDir == "" {
 		taskDir = "."
 	}
 
-	taskScanner := scanner.NewScanner(taskDir, false, nil)
+	taskScanner := newScanner(taskDir)
 	result, err := taskScanner.Scan()
 	if err != nil {
 		return nil, nil, fmt.Errorf("scan failed: %w", err)
@@ -44,6 +45,10 @@ func handleNext(_ context.Context, _ *gomcp.CallToolRequest, input NextInput) (*
 		Filters:   input.Filters,
 		QuickWins: input.QuickWins,
 		Critical:  input.Critical,
+		For:       input.For,
+		Scoring:   &cfg.Next,
+		Explain:   input.Explain,
+		Archived:  result.ArchivedIDs,
 	}
 
 	recs, err := next.Recommend(result.Tasks, opts)
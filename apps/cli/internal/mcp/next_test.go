@@ -64,6 +64,21 @@ func TestNextTool_HappyPath(t *testing.T) {
 	}
 }
 
+func TestNextTool_UsesConfiguredWeights(t *testing.T) {
+	tmpDir := createTestTaskFiles(t)
+	cfg := next.DefaultConfig()
+	cfg.Weights.PriorityMedium = 1000
+	session := setupTestServerWithConfig(t, Config{Next: cfg})
+
+	recs := callNext(t, session, map[string]any{
+		"task_dir": tmpDir,
+	})
+
+	if len(recs) == 0 || recs[0].ID != "003" {
+		t.Fatalf("expected medium-priority 003 first with the configured weights, got %+v", recs)
+	}
+}
+
 func TestNextTool_WithLimit(t *testing.T) {
 	tmpDir := createTestTaskFiles(t)
 	session := setupTestServer(t)
@@ -1,25 +1,56 @@
 package mcp
 
 import (
+	"context"
+
 	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/driangle/taskmd/apps/cli/internal/next"
+	"github.com/driangle/taskmd/apps/cli/internal/scanner"
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
+	"github.com/driangle/taskmd/apps/cli/internal/views"
 )
 
+// Config holds the project settings the tools use, read from .taskmd.yaml
+// by the caller.
+type Config struct {
+	Views      views.Views              // saved views for list
+	Next       next.Config              // scoring for next; Next.Effort also weights graph
+	Timestamps taskfile.TimestampConfig // status timestamps set and claim record
+}
+
 // NewServer creates an MCP server with all taskmd tools registered.
-func NewServer(version string) *gomcp.Server {
+func NewServer(version string, cfg Config) *gomcp.Server {
 	server := gomcp.NewServer(&gomcp.Implementation{
 		Name:    "taskmd",
 		Version: version,
 	}, nil)
 
-	registerListTool(server)
+	registerListTool(server, cfg)
 	registerGetTool(server)
-	registerNextTool(server)
+	registerNextTool(server, cfg)
 	registerSearchTool(server)
 	registerContextTool(server)
-	registerSetTool(server)
+	registerSetTool(server, cfg)
+	registerClaimTool(server, cfg)
 	registerValidateTool(server)
-	registerGraphTool(server)
+	registerGraphTool(server, cfg)
 	registerStatusTool(server)
 
 	return server
 }
+
+// withConfig binds cfg to a tool handler that needs the project settings.
+func withConfig[In any](
+	cfg Config, h func(context.Context, *gomcp.CallToolRequest, In, Config) (*gomcp.CallToolResult, any, error),
+) gomcp.ToolHandlerFor[In, any] {
+	return func(ctx context.Context, req *gomcp.CallToolRequest, input In) (*gomcp.CallToolResult, any, error) {
+		return h(ctx, req, input, cfg)
+	}
+}
+
+// newScanner returns a scanner for taskDir that uses the scan cache, as the
+// CLI does unless --no-cache is given.
+func newScanner(taskDir string) *scanner.Scanner {
+	return scanner.NewScanner(taskDir, false, nil).UseCache(true)
+}
@@ -8,7 +8,7 @@ import (
 
 	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
 
-	"github.com/driangle/taskmd/apps/cli/internal/scanner"
+	"github.com/driangle/taskmd/apps/cli/internal/model"
 	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
 )
 
@@ -23,16 +23,30 @@ type SetInput struct {
 	Tags     []string `json:"tags,omitempty" jsonschema:"replace all tags with this list"`
 	AddTags  []string `json:"add_tags,omitempty" jsonschema:"tags to add to existing tags"`
 	RemTags  []string `json:"rem_tags,omitempty" jsonschema:"tags to remove from existing tags"`
+
+	Dependencies []string `json:"dependencies,omitempty" jsonschema:"replace all dependencies with this list of task IDs"`
+	AddDeps      []string `json:"add_deps,omitempty" jsonschema:"task IDs to add to existing dependencies"`
+	RemDeps      []string `json:"rem_deps,omitempty" jsonschema:"task IDs to remove from existing dependencies"`
+	Touches      []string `json:"touches,omitempty" jsonschema:"replace all touches scopes with this list"`
+	AddTouches   []string `json:"add_touches,omitempty" jsonschema:"scopes to add to existing touches"`
+	RemTouches   []string `json:"rem_touches,omitempty" jsonschema:"scopes to remove from existing touches"`
+	Context      []string `json:"context,omitempty" jsonschema:"replace all context paths with this list"`
+	AddContext   []string `json:"add_context,omitempty" jsonschema:"paths to add to existing context"`
+	RemContext   []string `json:"rem_context,omitempty" jsonschema:"paths to remove from existing context"`
+
+	Verify    []model.VerifyStep `json:"verify,omitempty" jsonschema:"replace all verify steps with this list"`
+	AddVerify []model.VerifyStep `json:"add_verify,omitempty" jsonschema:"verify steps to append"`
+	RemVerify []string           `json:"rem_verify,omitempty" jsonschema:"remove verify steps whose run or check matches one of these values"`
 }
 
-func registerSetTool(server *gomcp.Server) {
+func registerSetTool(server *gomcp.Server, cfg Config) {
 	gomcp.AddTool(server, &gomcp.Tool{
 		Name:        "set",
-		Description: "Update fields on a task (status, priority, effort, owner, tags)",
-	}, handleSet)
+		Description: "Update fields on a task (status, priority, effort, owner, tags, dependencies, touches, context, verify)",
+	}, withConfig(cfg, handleSet))
 }
 
-func handleSet(_ context.Context, _ *gomcp.CallToolRequest, input SetInput) (*gomcp.CallToolResult, any, error) {
+func handleSet(_ context.Context, _ *gomcp.CallToolRequest, input SetInput, cfg Config) (*gomcp.CallToolResult, any, error) {
 	if input.TaskID == "" {
 		return nil, nil, fmt.Errorf("task_id is required")
 	}
@@ -52,7 +66,7 @@ func handleSet(_ context.Context, _ *gomcp.CallToolRequest, input SetInput) (*go
 		taskDir = "."
 	}
 
-	taskScanner := scanner.NewScanner(taskDir, false, nil)
+	taskScanner := newScanner(taskDir)
 	result, err := taskScanner.Scan()
 	if err != nil {
 		return nil, nil, fmt.Errorf("scan failed: %w", err)
@@ -63,6 +77,7 @@ func handleSet(_ context.Context, _ *gomcp.CallToolRequest, input SetInput) (*go
 		return nil, nil, fmt.Errorf("task not found: %s", input.TaskID)
 	}
 
+	req.Timestamps = &cfg.Timestamps
 	if err := taskfile.UpdateTaskFile(task.FilePath, req); err != nil {
 		return nil, nil, fmt.Errorf("update failed: %w", err)
 	}
@@ -97,17 +112,33 @@ func buildUpdateRequest(input SetInput) taskfile.UpdateRequest {
 	}
 	req.AddTags = input.AddTags
 	req.RemTags = input.RemTags
+	if input.Dependencies != nil {
+		req.Dependencies = &input.Dependencies
+	}
+	req.AddDeps = input.AddDeps
+	req.RemDeps = input.RemDeps
+	if input.Touches != nil {
+		req.Touches = &input.Touches
+	}
+	req.AddTouches = input.AddTouches
+	req.RemTouches = input.RemTouches
+	if input.Context != nil {
+		req.Context = &input.Context
+	}
+	req.AddContext = input.AddContext
+	req.RemContext = input.RemContext
+	if input.Verify != nil {
+		req.Verify = &input.Verify
+	}
+	req.AddVerify = input.AddVerify
+	req.RemVerify = input.RemVerify
 	return req
 }
 
 func isEmptyRequest(req taskfile.UpdateRequest) bool {
-	return req.Status == nil &&
-		req.Priority == nil &&
-		req.Effort == nil &&
-		req.Owner == nil &&
-		req.Tags == nil &&
-		len(req.AddTags) == 0 &&
-		len(req.RemTags) == 0
+	hasScalar := req.Status != nil || req.Priority != nil || req.Effort != nil || req.Owner != nil
+	hasReplace := req.Tags != nil || req.Dependencies != nil || req.Touches != nil || req.Context != nil || req.Verify != nil
+	return !hasScalar && !hasReplace && !req.HasListEdits()
 }
 
 type setOutput struct {
@@ -139,9 +170,33 @@ func buildSetOutput(input SetInput, filePath string) setOutput {
 	if len(input.RemTags) > 0 {
 		updated["rem_tags"] = strings.Join(input.RemTags, ", ")
 	}
+	addListOutput(updated, "dependencies", input.Dependencies, input.Dependencies != nil)
+	addListOutput(updated, "add_deps", input.AddDeps, false)
+	addListOutput(updated, "rem_deps", input.RemDeps, false)
+	addListOutput(updated, "touches", input.Touches, input.Touches != nil)
+	addListOutput(updated, "add_touches", input.AddTouches, false)
+	addListOutput(updated, "rem_touches", input.RemTouches, false)
+	addListOutput(updated, "context", input.Context, input.Context != nil)
+	addListOutput(updated, "add_context", input.AddContext, false)
+	addListOutput(updated, "rem_context", input.RemContext, false)
+	if input.Verify != nil {
+		updated["verify"] = fmt.Sprintf("%d step(s)", len(input.Verify))
+	}
+	if len(input.AddVerify) > 0 {
+		updated["add_verify"] = fmt.Sprintf("%d step(s)", len(input.AddVerify))
+	}
+	addListOutput(updated, "rem_verify", input.RemVerify, false)
 	return setOutput{
 		TaskID:   input.TaskID,
 		FilePath: filePath,
 		Updated:  updated,
 	}
 }
+
+// addListOutput records a list change in the output map. Replacements are
+// recorded even when empty so that clearing a list is visible.
+func addListOutput(updated map[string]string, key string, values []string, always bool) {
+	if always || len(values) > 0 {
+		updated[key] = strings.Join(values, ", ")
+	}
+}
@@ -334,3 +334,38 @@ func TestSetTool_Discoverable(t *testing.T) {
 		t.Fatal("set tool not found in tools list")
 	}
 }
+
+func TestSetTool_AddDepsAndVerify(t *testing.T) {
+	tmpDir := createTestTaskFiles(t)
+	session := setupTestServer(t)
+
+	out := callSet(t, session, map[string]any{
+		"task_dir":   tmpDir,
+		"task_id":    "003",
+		"add_deps":   []string{"001"},
+		"add_verify": []map[string]string{{"type": "bash", "run": "go test ./..."}},
+	})
+
+	if out.Updated["add_deps"] != "001" {
+		t.Errorf("expected add_deps 001, got %q", out.Updated["add_deps"])
+	}
+
+	content := readFileContent(t, filepath.Join(tmpDir, "003-ui.md"))
+	if !strings.Contains(content, `dependencies: ["001"]`) {
+		t.Errorf("expected dependency to be added, got:\n%s", content)
+	}
+	if !strings.Contains(content, `run: "go test ./..."`) {
+		t.Errorf("expected verify step to be added, got:\n%s", content)
+	}
+}
+
+func TestSetTool_InvalidVerifyStep(t *testing.T) {
+	tmpDir := createTestTaskFiles(t)
+	session := setupTestServer(t)
+
+	callSetExpectError(t, session, map[string]any{
+		"task_dir":   tmpDir,
+		"task_id":    "003",
+		"add_verify": []map[string]string{{"type": "bash"}},
+	})
+}
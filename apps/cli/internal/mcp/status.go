@@ -8,7 +8,6 @@ import (
 	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/driangle/taskmd/apps/cli/internal/model"
-	"github.com/driangle/taskmd/apps/cli/internal/scanner"
 )
 
 // StatusInput defines the input schema for the status tool.
@@ -28,6 +27,8 @@ type statusOutput struct {
 	Owner        string   `json:"owner,omitempty"`
 	Parent       string   `json:"parent,omitempty"`
 	Created      string   `json:"created,omitempty"`
+	Started      string   `json:"started,omitempty"`
+	Completed    string   `json:"completed,omitempty"`
 	Dependencies []string `json:"dependencies"`
 	Group        string   `json:"group,omitempty"`
 	FilePath     string   `json:"file_path"`
@@ -50,7 +51,7 @@ func handleStatus(_ context.Context, _ *gomcp.CallToolRequest, input StatusInput
 		taskDir = "."
 	}
 
-	taskScanner := scanner.NewScanner(taskDir, false, nil)
+	taskScanner := newScanner(taskDir)
 	result, err := taskScanner.Scan()
 	if err != nil {
 		return nil, nil, fmt.Errorf("scan failed: %w", err)
@@ -88,6 +89,8 @@ func buildStatusOutput(task *model.Task) statusOutput {
 		Owner:        task.Owner,
 		Parent:       task.Parent,
 		Created:      created,
+		Started:      formatTimestamp(task.Started),
+		Completed:    formatTimestamp(task.Completed),
 		Dependencies: task.Dependencies,
 		Group:        task.Group,
 		FilePath:     task.FilePath,
@@ -7,7 +7,6 @@ import (
 
 	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
 
-	"github.com/driangle/taskmd/apps/cli/internal/scanner"
 	"github.com/driangle/taskmd/apps/cli/internal/validator"
 )
 
@@ -45,13 +44,12 @@ func handleValidate(_ context.Context, _ *gomcp.CallToolRequest, input ValidateI
 		taskDir = "."
 	}
 
-	taskScanner := scanner.NewScanner(taskDir, false, nil)
+	taskScanner := newScanner(taskDir)
 	result, err := taskScanner.Scan()
 	if err != nil {
 		return nil, nil, fmt.Errorf("scan failed: %w", err)
 	}
-
-	v := validator.NewValidator(input.Strict)
+	v := validator.NewValidator(input.Strict).WithArchived(result.ArchivedIDs)
 	vr := v.Validate(result.Tasks)
 
 	out := buildValidateOutput(vr)
@@ -0,0 +1,199 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/history"
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+// FlowWeeks is how many weeks of throughput flow metrics report.
+const FlowWeeks = 12
+
+// DurationStats summarizes a set of durations, in days.
+type DurationStats struct {
+	Count int     `json:"count"`
+	Mean  float64 `json:"mean"`
+	P50   float64 `json:"p50"`
+	P85   float64 `json:"p85"`
+	P95   float64 `json:"p95"`
+}
+
+// FlowGroup holds flow metrics for completed tasks sharing a tag, group or
+// owner.
+type FlowGroup struct {
+	Key       string        `json:"key"`
+	Completed int           `json:"completed"`
+	LeadTime  DurationStats `json:"lead_time"`
+	CycleTime DurationStats `json:"cycle_time"`
+}
+
+// WeekCount is the number of tasks completed in the week ending on End.
+type WeekCount struct {
+	End       string `json:"end"`
+	Completed int    `json:"completed"`
+}
+
+// Flow holds how long completed tasks took and how many finish per week.
+type Flow struct {
+	Completed  int           `json:"completed"`  // completed tasks with a known completion date
+	LeadTime   DurationStats `json:"lead_time"`  // created -> completed
+	CycleTime  DurationStats `json:"cycle_time"` // first in-progress -> completed
+	Throughput []WeekCount   `json:"throughput"` // completions per week, oldest first
+	ByTag      []FlowGroup   `json:"by_tag,omitempty"`
+	ByGroup    []FlowGroup   `json:"by_group,omitempty"`
+	ByOwner    []FlowGroup   `json:"by_owner,omitempty"`
+}
+
+// flowSample is one completed task's timings, in days. Cycle is negative
+// when the task was never seen in progress.
+type flowSample struct {
+	task      *model.Task
+	completed time.Time
+	lead      float64
+	cycle     float64
+}
+
+// CalculateFlow computes lead time, cycle time and weekly throughput for
+// completed tasks. The started and completed timestamps in frontmatter take
+// precedence over the dates recorded for each task ID. Lead time starts at
+// the task's created date, falling back to when it was first committed. It
+// returns nil when no completed task has a known completion date.
+func CalculateFlow(tasks []*model.Task, dates map[string]history.Dates, now time.Time) *Flow {
+	var samples []flowSample
+	for _, task := range tasks {
+		if task.Status != model.StatusCompleted {
+			continue
+		}
+		d := dates[task.ID]
+		if !task.Started.IsZero() {
+			d.Started = task.Started
+		}
+		if !task.Completed.IsZero() {
+			d.Completed = task.Completed
+		}
+		if d.Completed.IsZero() {
+			continue
+		}
+		s := flowSample{task: task, completed: d.Completed, lead: -1, cycle: -1}
+		created := task.Created
+		if created.IsZero() {
+			created = d.Created
+		}
+		if !created.IsZero() {
+			s.lead = days(d.Completed.Sub(created))
+		}
+		if !d.Started.IsZero() {
+			s.cycle = days(d.Completed.Sub(d.Started))
+		}
+		samples = append(samples, s)
+	}
+	if len(samples) == 0 {
+		return nil
+	}
+
+	f := summarizeFlow(samples)
+	f.Throughput = weeklyCompletions(samples, now)
+	f.ByTag = groupFlow(samples, func(t *model.Task) []string { return t.Tags })
+	f.ByGroup = groupFlow(samples, func(t *model.Task) []string { return nonEmpty(t.Group) })
+	f.ByOwner = groupFlow(samples, func(t *model.Task) []string { return nonEmpty(t.Owner) })
+	return f
+}
+
+func summarizeFlow(samples []flowSample) *Flow {
+	var lead, cycle []float64
+	for _, s := range samples {
+		if s.lead >= 0 {
+			lead = append(lead, s.lead)
+		}
+		if s.cycle >= 0 {
+			cycle = append(cycle, s.cycle)
+		}
+	}
+	return &Flow{
+		Completed: len(samples),
+		LeadTime:  durationStats(lead),
+		CycleTime: durationStats(cycle),
+	}
+}
+
+func groupFlow(samples []flowSample, keys func(*model.Task) []string) []FlowGroup {
+	byKey := make(map[string][]flowSample)
+	for _, s := range samples {
+		for _, k := range keys(s.task) {
+			byKey[k] = append(byKey[k], s)
+		}
+	}
+
+	groups := make([]FlowGroup, 0, len(byKey))
+	for k, group := range byKey {
+		f := summarizeFlow(group)
+		groups = append(groups, FlowGroup{Key: k, Completed: f.Completed, LeadTime: f.LeadTime, CycleTime: f.CycleTime})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Completed != groups[j].Completed {
+			return groups[i].Completed > groups[j].Completed
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
+
+// weeklyCompletions counts completions in each of the last FlowWeeks
+// seven-day periods ending at now.
+func weeklyCompletions(samples []flowSample, now time.Time) []WeekCount {
+	weeks := make([]WeekCount, FlowWeeks)
+	for i := range weeks {
+		weeks[i].End = now.AddDate(0, 0, -7*(FlowWeeks-1-i)).Format("2006-01-02")
+	}
+	start := now.AddDate(0, 0, -7*FlowWeeks)
+	for _, s := range samples {
+		if !s.completed.After(start) || s.completed.After(now) {
+			continue
+		}
+		idx := int(s.completed.Sub(start) / (7 * 24 * time.Hour))
+		weeks[min(idx, FlowWeeks-1)].Completed++
+	}
+	return weeks
+}
+
+func durationStats(values []float64) DurationStats {
+	if len(values) == 0 {
+		return DurationStats{}
+	}
+	sort.Float64s(values)
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return DurationStats{
+		Count: len(values),
+		Mean:  round1(sum / float64(len(values))),
+		P50:   percentile(values, 50),
+		P85:   percentile(values, 85),
+		P95:   percentile(values, 95),
+	}
+}
+
+// percentile returns the nearest-rank percentile of sorted values.
+func percentile(sorted []float64, p int) float64 {
+	idx := int(math.Ceil(float64(p)/100*float64(len(sorted)))) - 1
+	return round1(sorted[max(idx, 0)])
+}
+
+func days(d time.Duration) float64 {
+	return max(d.Hours()/24, 0)
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
+
+func nonEmpty(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return []string{s}
+}
@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/history"
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+func TestCalculateFlow(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return now.AddDate(0, 0, d) }
+
+	tasks := []*model.Task{
+		{ID: "1", Status: model.StatusCompleted, Created: day(-20), Tags: []string{"api"}, Owner: "alice"},
+		{ID: "2", Status: model.StatusCompleted, Created: day(-10), Tags: []string{"api", "ui"}, Group: "web"},
+		{ID: "3", Status: model.StatusCompleted, Tags: []string{"ui"}},
+		{ID: "4", Status: model.StatusCompleted}, // no dates
+		{ID: "5", Status: model.StatusInProgress},
+	}
+	dates := map[string]history.Dates{
+		"1": {Started: day(-6), Completed: day(-2)},
+		"2": {Completed: day(-9)},
+		"3": {Created: day(-5), Started: day(-4), Completed: day(-1)},
+		"5": {Started: day(-1)},
+	}
+
+	f := CalculateFlow(tasks, dates, now)
+	if f == nil {
+		t.Fatal("expected flow metrics")
+	}
+
+	if f.Completed != 3 {
+		t.Errorf("expected 3 completed tasks with dates, got %d", f.Completed)
+	}
+	// Lead times: 18, 1 and 4 days (task 3 falls back to its first commit).
+	want := DurationStats{Count: 3, Mean: 7.7, P50: 4, P85: 18, P95: 18}
+	if f.LeadTime != want {
+		t.Errorf("expected lead time %+v, got %+v", want, f.LeadTime)
+	}
+	// Task 2 was never seen in progress, so only two cycle times: 4 and 3.
+	want = DurationStats{Count: 2, Mean: 3.5, P50: 3, P85: 4, P95: 4}
+	if f.CycleTime != want {
+		t.Errorf("expected cycle time %+v, got %+v", want, f.CycleTime)
+	}
+
+	if len(f.Throughput) != FlowWeeks || f.Throughput[FlowWeeks-1].Completed != 2 || f.Throughput[FlowWeeks-2].Completed != 1 {
+		t.Errorf("unexpected throughput: %+v", f.Throughput)
+	}
+	if f.Throughput[FlowWeeks-1].End != "2026-03-02" {
+		t.Errorf("expected the last week to end today, got %s", f.Throughput[FlowWeeks-1].End)
+	}
+
+	if len(f.ByTag) != 2 || f.ByTag[0].Key != "api" || f.ByTag[0].Completed != 2 || f.ByTag[1].Key != "ui" {
+		t.Errorf("unexpected tag breakdown: %+v", f.ByTag)
+	}
+	if len(f.ByGroup) != 1 || f.ByGroup[0].Key != "web" || f.ByGroup[0].CycleTime.Count != 0 {
+		t.Errorf("unexpected group breakdown: %+v", f.ByGroup)
+	}
+	if len(f.ByOwner) != 1 || f.ByOwner[0].Key != "alice" || f.ByOwner[0].CycleTime.P50 != 4 {
+		t.Errorf("unexpected owner breakdown: %+v", f.ByOwner)
+	}
+}
+
+func TestCalculateFlow_NoCompletions(t *testing.T) {
+	tasks := []*model.Task{{ID: "1", Status: model.StatusCompleted}}
+	if f := CalculateFlow(tasks, nil, time.Now()); f != nil {
+		t.Errorf("expected nil flow without completion dates, got %+v", f)
+	}
+}
+
+func TestCalculateFlow_FrontmatterTimestamps(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	tasks := []*model.Task{{
+		ID:        "1",
+		Status:    model.StatusCompleted,
+		Created:   now.AddDate(0, 0, -10),
+		Started:   now.AddDate(0, 0, -4),
+		Completed: now.AddDate(0, 0, -1),
+	}}
+	// Git saw the task start earlier; frontmatter wins.
+	dates := map[string]history.Dates{"1": {Started: now.AddDate(0, 0, -8), Completed: now.AddDate(0, 0, -2)}}
+
+	f := CalculateFlow(tasks, dates, now)
+	if f == nil {
+		t.Fatal("expected flow metrics")
+	}
+	if f.LeadTime.P50 != 9 || f.CycleTime.P50 != 3 {
+		t.Errorf("expected lead 9 and cycle 3 days from frontmatter, got %+v %+v", f.LeadTime, f.CycleTime)
+	}
+	if CalculateFlow(tasks, nil, now) == nil {
+		t.Error("expected flow metrics from frontmatter alone")
+	}
+}
@@ -3,6 +3,7 @@ package metrics
 import (
 	"sort"
 
+	"github.com/driangle/taskmd/apps/cli/internal/graph"
 	"github.com/driangle/taskmd/apps/cli/internal/model"
 )
 
@@ -20,13 +21,18 @@ type Metrics struct {
 	TasksByEffort          map[model.Effort]int   `json:"tasks_by_effort"`
 	BlockedTasksCount      int                    `json:"blocked_tasks_count"`
 	CriticalPathLength     int                    `json:"critical_path_length"`
+	CriticalPathHours      float64                `json:"critical_path_hours"`
 	MaxDependencyDepth     int                    `json:"max_dependency_depth"`
 	AvgDependenciesPerTask float64                `json:"avg_dependencies_per_task"`
 	TagsByCount            []TagInfo              `json:"tags_by_count"`
+	Flow                   *Flow                  `json:"flow,omitempty"`
 }
 
-// Calculate computes metrics for a set of tasks
-func Calculate(tasks []*model.Task) *Metrics {
+// Calculate computes metrics for a set of tasks. CriticalPathLength counts
+// the tasks in the longest dependency chain; CriticalPathHours is the
+// remaining work along the effort-weighted critical path (see
+// graph.CriticalPath).
+func Calculate(tasks []*model.Task, effort graph.EffortWeights) *Metrics {
 	m := &Metrics{
 		TasksByStatus:   make(map[model.Status]int),
 		TasksByPriority: make(map[model.Priority]int),
@@ -71,6 +77,7 @@ func Calculate(tasks []*model.Task) *Metrics {
 
 	// Calculate critical path and max depth
 	m.CriticalPathLength = calculateCriticalPath(tasks, taskMap)
+	m.CriticalPathHours = graph.NewGraph(tasks).CriticalPath(effort).Length
 	m.MaxDependencyDepth = calculateMaxDepth(tasks, taskMap)
 
 	return m
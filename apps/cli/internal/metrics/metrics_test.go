@@ -3,12 +3,13 @@ package metrics
 import (
 	"testing"
 
+	"github.com/driangle/taskmd/apps/cli/internal/graph"
 	"github.com/driangle/taskmd/apps/cli/internal/model"
 )
 
 func TestCalculate_EmptyTaskList(t *testing.T) {
 	tasks := []*model.Task{}
-	m := Calculate(tasks)
+	m := Calculate(tasks, graph.DefaultEffortWeights())
 
 	if m.TotalTasks != 0 {
 		t.Errorf("expected TotalTasks=0, got %d", m.TotalTasks)
@@ -19,6 +20,9 @@ func TestCalculate_EmptyTaskList(t *testing.T) {
 	if m.CriticalPathLength != 0 {
 		t.Errorf("expected CriticalPathLength=0, got %d", m.CriticalPathLength)
 	}
+	if m.CriticalPathHours != 0 {
+		t.Errorf("expected CriticalPathHours=0, got %g", m.CriticalPathHours)
+	}
 	if m.AvgDependenciesPerTask != 0 {
 		t.Errorf("expected AvgDependenciesPerTask=0, got %f", m.AvgDependenciesPerTask)
 	}
@@ -36,7 +40,7 @@ func TestCalculate_SingleTask(t *testing.T) {
 		},
 	}
 
-	m := Calculate(tasks)
+	m := Calculate(tasks, graph.DefaultEffortWeights())
 
 	if m.TotalTasks != 1 {
 		t.Errorf("expected TotalTasks=1, got %d", m.TotalTasks)
@@ -56,6 +60,9 @@ func TestCalculate_SingleTask(t *testing.T) {
 	if m.CriticalPathLength != 1 {
 		t.Errorf("expected CriticalPathLength=1, got %d", m.CriticalPathLength)
 	}
+	if m.CriticalPathHours != 8 {
+		t.Errorf("expected CriticalPathHours=8, got %g", m.CriticalPathHours)
+	}
 	if m.AvgDependenciesPerTask != 0 {
 		t.Errorf("expected AvgDependenciesPerTask=0, got %f", m.AvgDependenciesPerTask)
 	}
@@ -90,7 +97,7 @@ func TestCalculate_LinearDependencyChain(t *testing.T) {
 		},
 	}
 
-	m := Calculate(tasks)
+	m := Calculate(tasks, graph.DefaultEffortWeights())
 
 	if m.TotalTasks != 3 {
 		t.Errorf("expected TotalTasks=3, got %d", m.TotalTasks)
@@ -101,6 +108,10 @@ func TestCalculate_LinearDependencyChain(t *testing.T) {
 	if m.CriticalPathLength != 3 {
 		t.Errorf("expected CriticalPathLength=3, got %d", m.CriticalPathLength)
 	}
+	// Completed task 1 takes no time: 8h (medium) + 16h (large)
+	if m.CriticalPathHours != 24 {
+		t.Errorf("expected CriticalPathHours=24, got %g", m.CriticalPathHours)
+	}
 	if m.MaxDependencyDepth != 3 {
 		t.Errorf("expected MaxDependencyDepth=3, got %d", m.MaxDependencyDepth)
 	}
@@ -150,7 +161,7 @@ func TestCalculate_MultipleDependencies(t *testing.T) {
 		},
 	}
 
-	m := Calculate(tasks)
+	m := Calculate(tasks, graph.DefaultEffortWeights())
 
 	if m.TotalTasks != 4 {
 		t.Errorf("expected TotalTasks=4, got %d", m.TotalTasks)
@@ -161,6 +172,10 @@ func TestCalculate_MultipleDependencies(t *testing.T) {
 	if m.CriticalPathLength != 3 {
 		t.Errorf("expected CriticalPathLength=3, got %d", m.CriticalPathLength)
 	}
+	// Three medium tasks
+	if m.CriticalPathHours != 24 {
+		t.Errorf("expected CriticalPathHours=24, got %g", m.CriticalPathHours)
+	}
 
 	expectedAvg := 1.0 // (0 + 1 + 1 + 2) / 4
 	if m.AvgDependenciesPerTask != expectedAvg {
@@ -198,11 +213,14 @@ func TestCalculate_DiamondDependency(t *testing.T) {
 		},
 	}
 
-	m := Calculate(tasks)
+	m := Calculate(tasks, graph.DefaultEffortWeights())
 
 	if m.CriticalPathLength != 3 {
 		t.Errorf("expected CriticalPathLength=3 for diamond, got %d", m.CriticalPathLength)
 	}
+	if m.CriticalPathHours != 24 {
+		t.Errorf("expected CriticalPathHours=24 for diamond, got %g", m.CriticalPathHours)
+	}
 }
 
 func TestCalculate_ComplexGraph(t *testing.T) {
@@ -216,7 +234,7 @@ func TestCalculate_ComplexGraph(t *testing.T) {
 		{ID: "6", Dependencies: []string{"4", "5"}},
 	}
 
-	m := Calculate(tasks)
+	m := Calculate(tasks, graph.DefaultEffortWeights())
 
 	if m.TotalTasks != 6 {
 		t.Errorf("expected TotalTasks=6, got %d", m.TotalTasks)
@@ -228,6 +246,10 @@ func TestCalculate_ComplexGraph(t *testing.T) {
 	if m.CriticalPathLength != 4 {
 		t.Errorf("expected CriticalPathLength=4, got %d", m.CriticalPathLength)
 	}
+	// Four medium tasks
+	if m.CriticalPathHours != 32 {
+		t.Errorf("expected CriticalPathHours=32, got %g", m.CriticalPathHours)
+	}
 }
 
 func TestCalculate_MissingDependency(t *testing.T) {
@@ -243,7 +265,7 @@ func TestCalculate_MissingDependency(t *testing.T) {
 		},
 	}
 
-	m := Calculate(tasks)
+	m := Calculate(tasks, graph.DefaultEffortWeights())
 
 	// Should not panic, just ignore missing dependency
 	if m.TotalTasks != 2 {
@@ -288,7 +310,7 @@ func TestCalculate_CancelledStatus(t *testing.T) {
 		},
 	}
 
-	m := Calculate(tasks)
+	m := Calculate(tasks, graph.DefaultEffortWeights())
 
 	if m.TotalTasks != 6 {
 		t.Errorf("expected TotalTasks=6, got %d", m.TotalTasks)
@@ -320,7 +342,7 @@ func TestCalculate_TagsAggregation(t *testing.T) {
 		{ID: "4", Tags: []string{"docs"}},
 	}
 
-	m := Calculate(tasks)
+	m := Calculate(tasks, graph.DefaultEffortWeights())
 
 	if len(m.TagsByCount) != 4 {
 		t.Fatalf("expected 4 tags, got %d", len(m.TagsByCount))
@@ -351,7 +373,7 @@ func TestCalculate_NoTags(t *testing.T) {
 		{ID: "2", Tags: []string{}},
 	}
 
-	m := Calculate(tasks)
+	m := Calculate(tasks, graph.DefaultEffortWeights())
 
 	if len(m.TagsByCount) != 0 {
 		t.Errorf("expected 0 tags, got %d", len(m.TagsByCount))
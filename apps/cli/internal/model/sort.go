@@ -0,0 +1,57 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SortFields lists the fields accepted by SortTasks.
+var SortFields = []string{"id", "title", "status", "priority", "effort", "created", "due"}
+
+var priorityRank = map[Priority]int{
+	PriorityCritical: 0,
+	PriorityHigh:     1,
+	PriorityMedium:   2,
+	PriorityLow:      3,
+}
+
+var effortRank = map[Effort]int{
+	EffortSmall:  0,
+	EffortMedium: 1,
+	EffortLarge:  2,
+}
+
+// SortTasks sorts tasks in place by the given field.
+// Priority sorts most important first; effort sorts smallest first; due sorts
+// the earliest deadline first, with undated tasks last.
+func SortTasks(tasks []*Task, field string) error {
+	var less func(a, b *Task) bool
+	switch field {
+	case "id":
+		less = func(a, b *Task) bool { return a.ID < b.ID }
+	case "title":
+		less = func(a, b *Task) bool { return a.Title < b.Title }
+	case "status":
+		less = func(a, b *Task) bool { return a.Status < b.Status }
+	case "priority":
+		less = func(a, b *Task) bool { return priorityRank[a.Priority] < priorityRank[b.Priority] }
+	case "effort":
+		less = func(a, b *Task) bool { return effortRank[a.Effort] < effortRank[b.Effort] }
+	case "created":
+		less = func(a, b *Task) bool { return a.Created.Before(b.Created) }
+	case "due":
+		less = dueBefore
+	default:
+		return fmt.Errorf("unsupported sort field: %s (supported: %s)", field, strings.Join(SortFields, ", "))
+	}
+	sort.Slice(tasks, func(i, j int) bool { return less(tasks[i], tasks[j]) })
+	return nil
+}
+
+func dueBefore(a, b *Task) bool {
+	if a.Due.IsZero() || b.Due.IsZero() {
+		return !a.Due.IsZero() && b.Due.IsZero()
+	}
+	return a.Due.Before(b.Due)
+}
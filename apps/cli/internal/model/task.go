@@ -74,6 +74,7 @@ type Task struct {
 	Status       Status       `yaml:"status" json:"status"`
 	Priority     Priority     `yaml:"priority" json:"priority,omitempty"`
 	Effort       Effort       `yaml:"effort" json:"effort,omitempty"`
+	Estimate     float64      `yaml:"estimate,omitempty" json:"estimate,omitempty"` // hours
 	Dependencies []string     `yaml:"dependencies" json:"dependencies"`
 	Tags         []string     `yaml:"tags" json:"tags"`
 	Touches      []string     `yaml:"touches" json:"touches,omitempty"`
@@ -82,12 +83,20 @@ type Task struct {
 	Owner        string       `yaml:"owner" json:"owner,omitempty"`
 	Parent       string       `yaml:"parent,omitempty" json:"parent,omitempty"`
 	Created      time.Time    `yaml:"created" json:"created"`
+	Due          time.Time    `yaml:"due,omitempty" json:"due,omitzero"`
+	Start        time.Time    `yaml:"start,omitempty" json:"start,omitzero"`
+	Scheduled    time.Time    `yaml:"scheduled,omitempty" json:"scheduled,omitzero"`
+	Started      time.Time    `yaml:"started,omitempty" json:"started,omitzero"`     // set when first moved to in-progress
+	Completed    time.Time    `yaml:"completed,omitempty" json:"completed,omitzero"` // set when completed
 	Verify       []VerifyStep `yaml:"verify,omitempty" json:"verify,omitempty"`
 
 	// Content fields
 	Body     string `json:"-"`
 	FilePath string `json:"file_path"`
 
+	// Archived is set by the scanner for tasks found in an archive directory.
+	Archived bool `json:"archived,omitempty" yaml:"-"`
+
 	// Worklog metadata (populated on demand, not from frontmatter)
 	WorklogEntries int        `json:"worklog_entries,omitempty" yaml:"-"`
 	WorklogUpdated *time.Time `json:"worklog_updated,omitempty" yaml:"-"`
@@ -98,6 +107,36 @@ func (t *Task) IsValid() bool {
 	return t.ID != "" && t.Title != ""
 }
 
+// IsOpen reports whether the task still has work left: it is neither
+// completed nor cancelled.
+func (t *Task) IsOpen() bool {
+	return t.Status != StatusCompleted && t.Status != StatusCancelled
+}
+
+// IsOverdue reports whether an open task's due date is before today's date.
+func (t *Task) IsOverdue(today time.Time) bool {
+	return t.IsOpen() && !t.Due.IsZero() && DaysUntil(t.Due, today) < 0
+}
+
+// DaysUntil returns the number of calendar days from today to date, ignoring
+// the time of day. It is negative for past dates.
+func DaysUntil(date, today time.Time) int {
+	d := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	t := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	return int(d.Sub(t).Hours() / 24)
+}
+
+// AddArchived adds a completed placeholder to taskMap for each archived task
+// ID it does not already hold, so that dependencies on archived tasks count
+// as met.
+func AddArchived(taskMap map[string]*Task, archivedIDs []string) {
+	for _, id := range archivedIDs {
+		if _, ok := taskMap[id]; !ok {
+			taskMap[id] = &Task{ID: id, Title: "(archived)", Status: StatusCompleted, Archived: true}
+		}
+	}
+}
+
 // GetGroup returns the group, prioritizing frontmatter over derived value
 func (t *Task) GetGroup() string {
 	return t.Group
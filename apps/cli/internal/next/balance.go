@@ -0,0 +1,151 @@
+package next
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+// effortLoad is how much an in-progress or assigned task adds to its owner's
+// workload. Tasks without an effort count as medium.
+var effortLoad = map[model.Effort]int{
+	model.EffortSmall:  1,
+	model.EffortMedium: 2,
+	model.EffortLarge:  3,
+}
+
+const defaultEffortLoad = 2
+
+// Assignment is a proposed owner for an unassigned task.
+type Assignment struct {
+	ID       string `json:"id" yaml:"id"`
+	Title    string `json:"title" yaml:"title"`
+	FilePath string `json:"file_path" yaml:"file_path"`
+	Effort   string `json:"effort,omitempty" yaml:"effort,omitempty"`
+	Score    int    `json:"score" yaml:"score"`
+	Owner    string `json:"owner" yaml:"owner"`
+	Load     int    `json:"load" yaml:"load"` // owner's load after this assignment
+}
+
+// BalanceOptions controls owner assignment.
+type BalanceOptions struct {
+	Owners  []string // candidate owners; defaults to every owner found on tasks
+	Filters []string
+	Limit   int     // maximum tasks to assign; 0 means all
+	Scoring *Config // nil uses DefaultConfig
+}
+
+// Balance proposes owners for unassigned actionable tasks. Tasks are taken in
+// recommendation order and each goes to the candidate with the lowest load,
+// where load is the summed effort of the owner's in-progress tasks plus the
+// tasks assigned so far. Ties go to the first candidate in name order.
+func Balance(tasks []*model.Task, opts BalanceOptions) ([]Assignment, error) {
+	owners := opts.Owners
+	if len(owners) == 0 {
+		owners = collectOwners(tasks)
+	}
+	if len(owners) == 0 {
+		return nil, fmt.Errorf("no owners to balance across: no task has an owner yet, so list candidates explicitly")
+	}
+	owners = sortedUnique(owners)
+
+	recs, err := Recommend(tasks, Options{
+		Limit:   len(tasks),
+		Filters: opts.Filters,
+		Scoring: opts.Scoring,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	load := currentLoad(tasks, owners)
+	taskMap := BuildTaskMap(tasks)
+
+	var assignments []Assignment
+	for _, rec := range recs {
+		if opts.Limit > 0 && len(assignments) >= opts.Limit {
+			break
+		}
+		task := taskMap[rec.ID]
+		if task.Owner != "" {
+			continue
+		}
+
+		owner := leastLoaded(owners, load)
+		load[owner] += taskLoad(task)
+		assignments = append(assignments, Assignment{
+			ID:       task.ID,
+			Title:    task.Title,
+			FilePath: task.FilePath,
+			Effort:   string(task.Effort),
+			Score:    rec.Score,
+			Owner:    owner,
+			Load:     load[owner],
+		})
+	}
+	return assignments, nil
+}
+
+func taskLoad(task *model.Task) int {
+	if n, ok := effortLoad[task.Effort]; ok {
+		return n
+	}
+	return defaultEffortLoad
+}
+
+// currentLoad sums the effort of each candidate's in-progress tasks. Owners
+// are matched case-insensitively.
+func currentLoad(tasks []*model.Task, owners []string) map[string]int {
+	load := make(map[string]int, len(owners))
+	canonical := make(map[string]string, len(owners))
+	for _, o := range owners {
+		load[o] = 0
+		canonical[strings.ToLower(o)] = o
+	}
+	for _, task := range tasks {
+		if task.Status != model.StatusInProgress {
+			continue
+		}
+		if owner, ok := canonical[strings.ToLower(task.Owner)]; ok {
+			load[owner] += taskLoad(task)
+		}
+	}
+	return load
+}
+
+func leastLoaded(owners []string, load map[string]int) string {
+	best := owners[0]
+	for _, o := range owners[1:] {
+		if load[o] < load[best] {
+			best = o
+		}
+	}
+	return best
+}
+
+func collectOwners(tasks []*model.Task) []string {
+	var owners []string
+	for _, task := range tasks {
+		if task.Owner != "" {
+			owners = append(owners, task.Owner)
+		}
+	}
+	return owners
+}
+
+func sortedUnique(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var out []string
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[strings.ToLower(v)] {
+			continue
+		}
+		seen[strings.ToLower(v)] = true
+		out = append(out, v)
+	}
+	sort.Strings(out)
+	return out
+}
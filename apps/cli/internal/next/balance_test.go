@@ -0,0 +1,85 @@
+package next
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+func TestBalance(t *testing.T) {
+	tasks := []*model.Task{
+		{ID: "001", Status: model.StatusInProgress, Owner: "alice", Effort: model.EffortLarge},
+		{ID: "002", Status: model.StatusInProgress, Owner: "Bob", Effort: model.EffortSmall},
+		{ID: "003", Status: model.StatusPending, Priority: model.PriorityCritical, Effort: model.EffortMedium},
+		{ID: "004", Status: model.StatusPending, Priority: model.PriorityHigh, Effort: model.EffortSmall},
+		{ID: "005", Status: model.StatusPending, Priority: model.PriorityLow},
+		{ID: "006", Status: model.StatusPending, Priority: model.PriorityCritical, Owner: "carol"},
+	}
+
+	got, err := Balance(tasks, BalanceOptions{Owners: []string{"bob", "alice"}})
+	if err != nil {
+		t.Fatalf("Balance failed: %v", err)
+	}
+
+	// bob starts at 1 and alice at 3; 006 is already owned and is skipped
+	want := []string{"003=bob:3", "004=alice:4", "005=bob:5"}
+	var gotPairs []string
+	for _, a := range got {
+		gotPairs = append(gotPairs, fmt.Sprintf("%s=%s:%d", a.ID, a.Owner, a.Load))
+	}
+	if strings.Join(gotPairs, ",") != strings.Join(want, ",") {
+		t.Errorf("assignments = %v, want %v", gotPairs, want)
+	}
+}
+
+func TestBalance_DefaultOwnersAndLimit(t *testing.T) {
+	tasks := []*model.Task{
+		{ID: "001", Status: model.StatusCompleted, Owner: "alice"},
+		{ID: "002", Status: model.StatusPending},
+		{ID: "003", Status: model.StatusPending},
+	}
+
+	got, err := Balance(tasks, BalanceOptions{Limit: 1})
+	if err != nil {
+		t.Fatalf("Balance failed: %v", err)
+	}
+	if len(got) != 1 || got[0].Owner != "alice" {
+		t.Errorf("expected one task assigned to alice, got %+v", got)
+	}
+}
+
+func TestBalance_NoOwners(t *testing.T) {
+	tasks := []*model.Task{{ID: "001", Status: model.StatusPending}}
+
+	if _, err := Balance(tasks, BalanceOptions{}); err == nil {
+		t.Fatal("expected an error when there are no candidate owners")
+	}
+}
+
+func TestRecommend_For(t *testing.T) {
+	tasks := []*model.Task{
+		{ID: "001", Status: model.StatusInProgress, Owner: "bob", Priority: model.PriorityCritical},
+		{ID: "002", Status: model.StatusPending, Owner: "bob", Priority: model.PriorityHigh},
+		{ID: "003", Status: model.StatusPending, Priority: model.PriorityMedium},
+		{ID: "004", Status: model.StatusPending, Owner: "Alice", Priority: model.PriorityMedium},
+	}
+
+	recs, err := Recommend(tasks, Options{For: "alice"})
+	if err != nil {
+		t.Fatalf("Recommend failed: %v", err)
+	}
+
+	var ids []string
+	for _, rec := range recs {
+		ids = append(ids, rec.ID)
+	}
+	// 001 is skipped; 004 gains the owned-by-you bonus; 002 drops below 003
+	if got := strings.Join(ids, ","); got != "004,003,002" {
+		t.Errorf("recommendation order = %s, want 004,003,002", got)
+	}
+	if recs[0].Reasons[len(recs[0].Reasons)-1] != "assigned to you" {
+		t.Errorf("expected 'assigned to you' reason, got %v", recs[0].Reasons)
+	}
+}
@@ -0,0 +1,209 @@
+package next
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/driangle/taskmd/apps/cli/internal/graph"
+)
+
+// Weights holds the points awarded for each scoring factor.
+type Weights struct {
+	PriorityCritical int `json:"priority_critical" yaml:"priority_critical"`
+	PriorityHigh     int `json:"priority_high" yaml:"priority_high"`
+	PriorityMedium   int `json:"priority_medium" yaml:"priority_medium"`
+	PriorityLow      int `json:"priority_low" yaml:"priority_low"`
+	CriticalPath     int `json:"critical_path" yaml:"critical_path"`
+	PerDownstream    int `json:"per_downstream" yaml:"per_downstream"`
+	DownstreamMax    int `json:"downstream_max" yaml:"downstream_max"`
+	EffortSmall      int `json:"effort_small" yaml:"effort_small"`
+	EffortMedium     int `json:"effort_medium" yaml:"effort_medium"`
+	Overdue          int `json:"overdue" yaml:"overdue"`
+	DueSoon          int `json:"due_soon" yaml:"due_soon"`
+	DueThisWeek      int `json:"due_this_week" yaml:"due_this_week"`
+	Scheduled        int `json:"scheduled" yaml:"scheduled"`
+	OwnedByYou       int `json:"owned_by_you" yaml:"owned_by_you"`
+	OwnedByOther     int `json:"owned_by_other" yaml:"owned_by_other"`
+}
+
+// DefaultWeights returns the built-in scoring weights.
+func DefaultWeights() Weights {
+	return Weights{
+		PriorityCritical: ScorePriorityCritical,
+		PriorityHigh:     ScorePriorityHigh,
+		PriorityMedium:   ScorePriorityMedium,
+		PriorityLow:      ScorePriorityLow,
+		CriticalPath:     ScoreCriticalPath,
+		PerDownstream:    ScorePerDownstream,
+		DownstreamMax:    ScoreDownstreamMax,
+		EffortSmall:      ScoreEffortSmall,
+		EffortMedium:     ScoreEffortMedium,
+		Overdue:          ScoreOverdue,
+		DueSoon:          ScoreDueSoon,
+		DueThisWeek:      ScoreDueThisWeek,
+		Scheduled:        ScoreScheduled,
+		OwnedByYou:       ScoreOwnedByYou,
+		OwnedByOther:     ScoreOwnedByOther,
+	}
+}
+
+// fields maps config keys to the weights they set.
+func (w *Weights) fields() map[string]*int {
+	return map[string]*int{
+		"priority_critical": &w.PriorityCritical,
+		"priority_high":     &w.PriorityHigh,
+		"priority_medium":   &w.PriorityMedium,
+		"priority_low":      &w.PriorityLow,
+		"critical_path":     &w.CriticalPath,
+		"per_downstream":    &w.PerDownstream,
+		"downstream_max":    &w.DownstreamMax,
+		"effort_small":      &w.EffortSmall,
+		"effort_medium":     &w.EffortMedium,
+		"overdue":           &w.Overdue,
+		"due_soon":          &w.DueSoon,
+		"due_this_week":     &w.DueThisWeek,
+		"scheduled":         &w.Scheduled,
+		"owned_by_you":      &w.OwnedByYou,
+		"owned_by_other":    &w.OwnedByOther,
+	}
+}
+
+// Config holds the scoring settings from the next section of .taskmd.yaml.
+// Boost keys are lower-cased; tags and groups are matched case-insensitively.
+// Effort comes from the top-level effort section and weights the critical
+// path.
+type Config struct {
+	Weights     Weights             `json:"weights" yaml:"weights"`
+	TagBoosts   map[string]int      `json:"tag_boosts,omitempty" yaml:"tag_boosts,omitempty"`
+	GroupBoosts map[string]int      `json:"group_boosts,omitempty" yaml:"group_boosts,omitempty"`
+	Effort      graph.EffortWeights `json:"effort" yaml:"effort"`
+}
+
+// DefaultConfig returns the built-in weights with no boosts.
+func DefaultConfig() Config {
+	return Config{Weights: DefaultWeights(), Effort: graph.DefaultEffortWeights()}
+}
+
+// KnownWeights lists the keys accepted inside next.weights.
+var KnownWeights = sortedKeys(new(Weights).fields())
+
+// ParseConfig converts the raw next section (as decoded from YAML) into a
+// Config. Weights that are not set keep their defaults. Problems such as
+// unknown keys or non-integer values are returned as messages rather than
+// errors so callers can decide how strict to be.
+func ParseConfig(raw any) (Config, []string) {
+	cfg := DefaultConfig()
+	if raw == nil {
+		return cfg, nil
+	}
+	section, ok := raw.(map[string]any)
+	if !ok {
+		return cfg, []string{"next must be a mapping with weights and boosts"}
+	}
+
+	var problems []string
+	for _, key := range sortedKeys(section) {
+		switch key {
+		case "weights":
+			problems = append(problems, parseWeights(&cfg.Weights, section[key])...)
+		case "boosts":
+			problems = append(problems, parseBoosts(&cfg, section[key])...)
+		default:
+			problems = append(problems, fmt.Sprintf("next has unknown field: '%s' (valid: boosts, weights)", key))
+		}
+	}
+	return cfg, problems
+}
+
+func parseWeights(w *Weights, raw any) []string {
+	entries, ok := raw.(map[string]any)
+	if !ok {
+		return []string{"next.weights must be a mapping of factor names to points"}
+	}
+
+	fields := w.fields()
+	var problems []string
+	for _, key := range sortedKeys(entries) {
+		field, ok := fields[key]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("next.weights has unknown weight: '%s' (valid: %s)", key, strings.Join(KnownWeights, ", ")))
+			continue
+		}
+		n, err := asInt(entries[key])
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("next.weights.%s %v", key, err))
+			continue
+		}
+		*field = n
+	}
+	return problems
+}
+
+func parseBoosts(cfg *Config, raw any) []string {
+	entries, ok := raw.(map[string]any)
+	if !ok {
+		return []string{"next.boosts must be a mapping with tags and groups"}
+	}
+
+	var problems []string
+	for _, key := range sortedKeys(entries) {
+		var target *map[string]int
+		switch key {
+		case "tags":
+			target = &cfg.TagBoosts
+		case "groups":
+			target = &cfg.GroupBoosts
+		default:
+			problems = append(problems, fmt.Sprintf("next.boosts has unknown field: '%s' (valid: groups, tags)", key))
+			continue
+		}
+		boosts, boostProblems := parseBoostMap("next.boosts."+key, entries[key])
+		*target = boosts
+		problems = append(problems, boostProblems...)
+	}
+	return problems
+}
+
+func parseBoostMap(label string, raw any) (map[string]int, []string) {
+	entries, ok := raw.(map[string]any)
+	if !ok {
+		return nil, []string{label + " must be a mapping of names to points"}
+	}
+
+	boosts := make(map[string]int, len(entries))
+	var problems []string
+	for _, name := range sortedKeys(entries) {
+		n, err := asInt(entries[name])
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s.%s %v", label, name, err))
+			continue
+		}
+		boosts[strings.ToLower(name)] = n
+	}
+	return boosts, problems
+}
+
+func asInt(val any) (int, error) {
+	switch v := val.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v == math.Trunc(v) {
+			return int(v), nil
+		}
+	}
+	return 0, fmt.Errorf("must be an integer")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,68 @@
+package next
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+func TestParseConfig(t *testing.T) {
+	raw := map[string]any{
+		"weights": map[string]any{
+			"priority_critical": 10,
+			"per_downstream":    12.0,
+			"critical_path":     "lots",
+			"urgency":           1,
+		},
+		"boosts": map[string]any{
+			"tags":   map[string]any{"Security": 20},
+			"groups": map[string]any{"legacy": -10},
+		},
+	}
+
+	cfg, problems := ParseConfig(raw)
+
+	if cfg.Weights.PriorityCritical != 10 || cfg.Weights.PerDownstream != 12 {
+		t.Errorf("expected overridden weights, got %+v", cfg.Weights)
+	}
+	if cfg.Weights.CriticalPath != ScoreCriticalPath || cfg.Weights.PriorityHigh != ScorePriorityHigh {
+		t.Errorf("expected unset and invalid weights to keep defaults, got %+v", cfg.Weights)
+	}
+	if cfg.TagBoosts["security"] != 20 || cfg.GroupBoosts["legacy"] != -10 {
+		t.Errorf("unexpected boosts: tags=%v groups=%v", cfg.TagBoosts, cfg.GroupBoosts)
+	}
+
+	joined := strings.Join(problems, "\n")
+	if len(problems) != 2 ||
+		!strings.Contains(joined, "next.weights.critical_path must be an integer") ||
+		!strings.Contains(joined, "unknown weight: 'urgency'") {
+		t.Errorf("unexpected problems:\n%s", joined)
+	}
+}
+
+func TestParseConfig_Nil(t *testing.T) {
+	cfg, problems := ParseConfig(nil)
+	if len(problems) != 0 {
+		t.Errorf("expected no problems, got %v", problems)
+	}
+	if cfg.Weights != DefaultWeights() {
+		t.Errorf("expected default weights, got %+v", cfg.Weights)
+	}
+}
+
+func TestConfigScore_Boosts(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.TagBoosts = map[string]int{"security": 20}
+	cfg.GroupBoosts = map[string]int{"legacy": -10}
+
+	task := &model.Task{ID: "001", Priority: model.PriorityLow, Tags: []string{"Security"}, Group: "Legacy"}
+	score, reasons := cfg.Score(task, nil, nil)
+
+	if want := ScorePriorityLow + 20 - 10; score != want {
+		t.Errorf("score = %d, want %d", score, want)
+	}
+	if strings.Join(reasons, ",") != "boosted tag Security" {
+		t.Errorf("expected only the positive boost as a reason, got %v", reasons)
+	}
+}
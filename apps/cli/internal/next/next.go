@@ -3,13 +3,15 @@ package next
 import (
 	"fmt"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/driangle/taskmd/apps/cli/internal/filter"
 	"github.com/driangle/taskmd/apps/cli/internal/graph"
 	"github.com/driangle/taskmd/apps/cli/internal/model"
 )
 
-// Scoring constants
+// Default scoring weights; see Weights to override them.
 const (
 	ScorePriorityCritical = 40
 	ScorePriorityHigh     = 30
@@ -20,6 +22,18 @@ const (
 	ScoreDownstreamMax    = 15
 	ScoreEffortSmall      = 5
 	ScoreEffortMedium     = 2
+	ScoreOverdue          = 25
+	ScoreDueSoon          = 15 // due within DueSoonDays
+	ScoreDueThisWeek      = 8  // due within DueThisWeekDays
+	ScoreScheduled        = 10 // scheduled for today or earlier
+	ScoreOwnedByYou       = 10 // owner matches Options.For
+	ScoreOwnedByOther     = -20
+)
+
+// Deadline windows, in days from today.
+const (
+	DueSoonDays     = 2
+	DueThisWeekDays = 7
 )
 
 // Recommendation represents a scored task recommendation.
@@ -33,22 +47,34 @@ type Recommendation struct {
 	Effort          string   `json:"effort,omitempty" yaml:"effort,omitempty"`
 	Score           int      `json:"score" yaml:"score"`
 	Reasons         []string `json:"reasons" yaml:"reasons"`
+	Breakdown       []Factor `json:"breakdown,omitempty" yaml:"breakdown,omitempty"`
 	DownstreamCount int      `json:"downstream_count" yaml:"downstream_count"`
 	OnCriticalPath  bool     `json:"on_critical_path" yaml:"on_critical_path"`
 }
 
+// Factor is one contribution to a task's score.
+type Factor struct {
+	Name   string `json:"name" yaml:"name"`
+	Points int    `json:"points" yaml:"points"`
+}
+
 // Options controls recommendation behaviour.
 type Options struct {
 	Limit     int
 	Filters   []string
 	QuickWins bool
 	Critical  bool
+	For       string   // recommend for this owner: skip others' in-progress work, prefer own and unowned tasks
+	Scoring   *Config  // nil uses DefaultConfig
+	Explain   bool     // return every candidate with its score breakdown, ignoring Limit
+	Archived  []string // IDs of archived tasks; dependencies on them count as met
 }
 
 type scoredTask struct {
-	task    *model.Task
-	score   int
-	reasons []string
+	task      *model.Task
+	score     int
+	reasons   []string
+	breakdown []Factor
 }
 
 // Recommend scores and ranks actionable tasks, returning the top recommendations.
@@ -57,8 +83,14 @@ func Recommend(tasks []*model.Task, opts Options) ([]Recommendation, error) {
 		opts.Limit = 5
 	}
 
+	scoring := DefaultConfig()
+	if opts.Scoring != nil {
+		scoring = *opts.Scoring
+	}
+
 	taskMap := BuildTaskMap(tasks)
-	criticalPath := CalculateCriticalPathTasks(tasks, taskMap)
+	model.AddArchived(taskMap, opts.Archived)
+	criticalPath := CalculateCriticalPathTasks(tasks, scoring.Effort)
 	downstreamCounts := computeDownstreamCounts(tasks)
 
 	actionable, err := filterActionable(tasks, opts, taskMap, criticalPath)
@@ -66,10 +98,13 @@ func Recommend(tasks []*model.Task, opts Options) ([]Recommendation, error) {
 		return nil, err
 	}
 
-	scored := scoreAndSort(actionable, criticalPath, downstreamCounts)
+	scored := scoreAndSort(actionable, criticalPath, downstreamCounts, scoring, opts.For)
 
 	limit := min(opts.Limit, len(scored))
-	return buildRecommendations(scored[:limit], criticalPath, downstreamCounts), nil
+	if opts.Explain {
+		limit = len(scored)
+	}
+	return buildRecommendations(scored[:limit], criticalPath, downstreamCounts, opts.Explain), nil
 }
 
 func computeDownstreamCounts(tasks []*model.Task) map[string]int {
@@ -90,7 +125,7 @@ func filterActionable(
 	candidates := tasks
 	if len(opts.Filters) > 0 {
 		var err error
-		candidates, err = filter.Apply(candidates, opts.Filters)
+		candidates, err = filter.ApplyEnv(candidates, opts.Filters, filter.NewEnv(tasks).WithArchived(opts.Archived))
 		if err != nil {
 			return nil, fmt.Errorf("filter error: %w", err)
 		}
@@ -98,7 +133,7 @@ func filterActionable(
 
 	var actionable []*model.Task
 	for _, task := range candidates {
-		if IsActionable(task, taskMap) {
+		if IsActionable(task, taskMap) && !isTakenByOther(task, opts.For) {
 			actionable = append(actionable, task)
 		}
 	}
@@ -110,11 +145,13 @@ func scoreAndSort(
 	tasks []*model.Task,
 	criticalPath map[string]bool,
 	downstreamCounts map[string]int,
+	scoring Config,
+	forOwner string,
 ) []scoredTask {
 	scored := make([]scoredTask, len(tasks))
 	for i, task := range tasks {
-		s, r := ScoreTask(task, criticalPath, downstreamCounts)
-		scored[i] = scoredTask{task: task, score: s, reasons: r}
+		sheet := scoring.score(task, criticalPath, downstreamCounts, time.Now(), forOwner)
+		scored[i] = scoredTask{task: task, score: sheet.total, reasons: sheet.reasons, breakdown: sheet.factors}
 	}
 
 	sort.SliceStable(scored, func(i, j int) bool {
@@ -131,9 +168,14 @@ func buildRecommendations(
 	scored []scoredTask,
 	criticalPath map[string]bool,
 	downstreamCounts map[string]int,
+	explain bool,
 ) []Recommendation {
 	recs := make([]Recommendation, len(scored))
 	for i, st := range scored {
+		var breakdown []Factor
+		if explain {
+			breakdown = st.breakdown
+		}
 		recs[i] = Recommendation{
 			Rank:            i + 1,
 			ID:              st.task.ID,
@@ -144,6 +186,7 @@ func buildRecommendations(
 			Effort:          string(st.task.Effort),
 			Score:           st.score,
 			Reasons:         st.reasons,
+			Breakdown:       breakdown,
 			DownstreamCount: downstreamCounts[st.task.ID],
 			OnCriticalPath:  criticalPath[st.task.ID],
 		}
@@ -151,6 +194,13 @@ func buildRecommendations(
 	return recs
 }
 
+// isTakenByOther reports whether the task is already in progress under an
+// owner other than forOwner. It is always false when forOwner is empty.
+func isTakenByOther(task *model.Task, forOwner string) bool {
+	return forOwner != "" && task.Status == model.StatusInProgress &&
+		task.Owner != "" && !strings.EqualFold(task.Owner, forOwner)
+}
+
 // BuildTaskMap creates a map of task ID to task.
 func BuildTaskMap(tasks []*model.Task) map[string]*model.Task {
 	taskMap := make(map[string]*model.Task, len(tasks))
@@ -171,143 +221,187 @@ func HasUnmetDependencies(task *model.Task, taskMap map[string]*model.Task) bool
 	return false
 }
 
-// IsActionable returns true if the task is pending/in-progress with all deps completed.
+// IsActionable returns true if the task is pending/in-progress with all deps
+// completed and its start date, if any, reached.
 func IsActionable(task *model.Task, taskMap map[string]*model.Task) bool {
 	if task.Status != model.StatusPending && task.Status != model.StatusInProgress {
 		return false
 	}
+	if !task.Start.IsZero() && model.DaysUntil(task.Start, time.Now()) > 0 {
+		return false
+	}
 	return !HasUnmetDependencies(task, taskMap)
 }
 
-// ScoreTask computes a score and reason list for an actionable task.
+// ScoreTask computes a score and reason list for an actionable task using the
+// default weights.
 func ScoreTask(
 	task *model.Task,
 	criticalPath map[string]bool,
 	downstreamCounts map[string]int,
 ) (int, []string) {
-	score := 0
-	reasons := make([]string, 0)
+	return DefaultConfig().Score(task, criticalPath, downstreamCounts)
+}
+
+// Score computes a score and reason list for an actionable task using the
+// configured weights and boosts.
+func (c Config) Score(
+	task *model.Task,
+	criticalPath map[string]bool,
+	downstreamCounts map[string]int,
+) (int, []string) {
+	sheet := c.score(task, criticalPath, downstreamCounts, time.Now(), "")
+	return sheet.total, sheet.reasons
+}
+
+// scoreSheet accumulates a task's score, the reasons shown to the user and
+// the per-factor breakdown used by --explain.
+type scoreSheet struct {
+	total   int
+	reasons []string
+	factors []Factor
+}
+
+// add records points for a factor. Zero-point factors are left out of the
+// breakdown; an empty reason adds nothing to the reason list.
+func (s *scoreSheet) add(factor string, points int, reason string) {
+	if points != 0 {
+		s.total += points
+		s.factors = append(s.factors, Factor{Name: factor, Points: points})
+	}
+	if reason != "" {
+		s.reasons = append(s.reasons, reason)
+	}
+}
+
+func (c Config) score(
+	task *model.Task,
+	criticalPath map[string]bool,
+	downstreamCounts map[string]int,
+	today time.Time,
+	forOwner string,
+) *scoreSheet {
+	w := c.Weights
+	sheet := &scoreSheet{reasons: make([]string, 0)}
 
 	switch task.Priority {
 	case model.PriorityCritical:
-		score += ScorePriorityCritical
-		reasons = append(reasons, "critical priority")
+		sheet.add("critical priority", w.PriorityCritical, "critical priority")
 	case model.PriorityHigh:
-		score += ScorePriorityHigh
-		reasons = append(reasons, "high priority")
+		sheet.add("high priority", w.PriorityHigh, "high priority")
 	case model.PriorityMedium:
-		score += ScorePriorityMedium
+		sheet.add("medium priority", w.PriorityMedium, "")
 	default:
-		score += ScorePriorityLow
+		sheet.add("low priority", w.PriorityLow, "")
 	}
 
 	if criticalPath[task.ID] {
-		score += ScoreCriticalPath
-		reasons = append(reasons, "on critical path")
+		sheet.add("on critical path", w.CriticalPath, "on critical path")
 	}
 
-	dc := downstreamCounts[task.ID]
-	bonus := min(dc*ScorePerDownstream, ScoreDownstreamMax)
-	score += bonus
-	if dc > 0 {
+	if dc := downstreamCounts[task.ID]; dc > 0 {
 		noun := "tasks"
 		if dc == 1 {
 			noun = "task"
 		}
-		reasons = append(reasons, fmt.Sprintf("unblocks %d %s", dc, noun))
+		label := fmt.Sprintf("unblocks %d %s", dc, noun)
+		sheet.add(label, min(dc*w.PerDownstream, w.DownstreamMax), label)
 	}
 
 	switch task.Effort {
 	case model.EffortSmall:
-		score += ScoreEffortSmall
-		reasons = append(reasons, "quick win")
+		sheet.add("small effort", w.EffortSmall, "quick win")
 	case model.EffortMedium:
-		score += ScoreEffortMedium
+		sheet.add("medium effort", w.EffortMedium, "")
 	}
 
-	return score, reasons
+	c.scoreSchedule(sheet, task, today)
+	c.scoreBoosts(sheet, task)
+	c.scoreOwner(sheet, task, forOwner)
+	return sheet
 }
 
-// CalculateCriticalPathTasks identifies tasks on the critical path.
-func CalculateCriticalPathTasks(tasks []*model.Task, taskMap map[string]*model.Task) map[string]bool {
-	criticalPath := make(map[string]bool)
-	depthMap := calculateDepthMap(tasks, taskMap)
-
-	maxDepth := 0
-	for _, depth := range depthMap {
-		if depth > maxDepth {
-			maxDepth = depth
+// scoreSchedule scores how close a task is to its due date, and whether it
+// is scheduled for today or earlier.
+func (c Config) scoreSchedule(sheet *scoreSheet, task *model.Task, today time.Time) {
+	w := c.Weights
+
+	if !task.Due.IsZero() {
+		days := model.DaysUntil(task.Due, today)
+		switch {
+		case days < 0:
+			label := fmt.Sprintf("overdue by %s", pluralDays(-days))
+			sheet.add(label, w.Overdue, label)
+		case days <= DueSoonDays:
+			sheet.add(dueReason(days), w.DueSoon, dueReason(days))
+		case days <= DueThisWeekDays:
+			sheet.add(dueReason(days), w.DueThisWeek, dueReason(days))
 		}
 	}
 
-	for taskID, depth := range depthMap {
-		if depth == maxDepth {
-			criticalPath[taskID] = true
-			markCriticalPathDependencies(taskID, taskMap, depthMap, maxDepth, criticalPath)
-		}
+	if !task.Scheduled.IsZero() && model.DaysUntil(task.Scheduled, today) <= 0 {
+		sheet.add("scheduled", w.Scheduled, "scheduled")
 	}
-
-	return criticalPath
 }
 
-func calculateDepthMap(tasks []*model.Task, taskMap map[string]*model.Task) map[string]int {
-	memo := make(map[string]int)
-
-	var getDepth func(taskID string, visited map[string]bool) int
-	getDepth = func(taskID string, visited map[string]bool) int {
-		if depth, ok := memo[taskID]; ok {
-			return depth
+// scoreBoosts adds the configured per-tag and per-group boosts. Only positive
+// boosts are listed as reasons.
+func (c Config) scoreBoosts(sheet *scoreSheet, task *model.Task) {
+	for _, tag := range task.Tags {
+		if points, ok := c.TagBoosts[strings.ToLower(tag)]; ok {
+			sheet.add("tag "+tag, points, boostReason("tag "+tag, points))
 		}
-		if visited[taskID] {
-			return 0
-		}
-
-		task, exists := taskMap[taskID]
-		if !exists {
-			return 0
-		}
-
-		visited[taskID] = true
-		defer delete(visited, taskID)
-
-		maxDepth := 0
-		for _, depID := range task.Dependencies {
-			depth := getDepth(depID, visited)
-			if depth > maxDepth {
-				maxDepth = depth
-			}
+	}
+	if task.Group != "" {
+		if points, ok := c.GroupBoosts[strings.ToLower(task.Group)]; ok {
+			sheet.add("group "+task.Group, points, boostReason("group "+task.Group, points))
 		}
-
-		result := maxDepth + 1
-		memo[taskID] = result
-		return result
 	}
+}
 
-	for _, task := range tasks {
-		getDepth(task.ID, make(map[string]bool))
+// scoreOwner prefers tasks owned by forOwner over tasks owned by someone
+// else. Unowned tasks are left as they are.
+func (c Config) scoreOwner(sheet *scoreSheet, task *model.Task, forOwner string) {
+	if forOwner == "" || task.Owner == "" {
+		return
+	}
+	if strings.EqualFold(task.Owner, forOwner) {
+		sheet.add("assigned to you", c.Weights.OwnedByYou, "assigned to you")
+		return
 	}
+	sheet.add("assigned to "+task.Owner, c.Weights.OwnedByOther, "")
+}
 
-	return memo
+func boostReason(label string, points int) string {
+	if points <= 0 {
+		return ""
+	}
+	return "boosted " + label
 }
 
-func markCriticalPathDependencies(
-	taskID string,
-	taskMap map[string]*model.Task,
-	depthMap map[string]int,
-	targetDepth int,
-	criticalPath map[string]bool,
-) {
-	task, exists := taskMap[taskID]
-	if !exists {
-		return
+func dueReason(days int) string {
+	switch days {
+	case 0:
+		return "due today"
+	case 1:
+		return "due tomorrow"
+	default:
+		return fmt.Sprintf("due in %s", pluralDays(days))
 	}
+}
 
-	for _, depID := range task.Dependencies {
-		if depthMap[depID] == targetDepth-1 {
-			criticalPath[depID] = true
-			markCriticalPathDependencies(depID, taskMap, depthMap, targetDepth-1, criticalPath)
-		}
+func pluralDays(n int) string {
+	if n == 1 {
+		return "1 day"
 	}
+	return fmt.Sprintf("%d days", n)
+}
+
+// CalculateCriticalPathTasks identifies tasks on the effort-weighted critical
+// path: the chain of remaining work that determines when everything is done.
+// See graph.Graph.CriticalPath.
+func CalculateCriticalPathTasks(tasks []*model.Task, effort graph.EffortWeights) map[string]bool {
+	return graph.NewGraph(tasks).CriticalPath(effort).Set()
 }
 
 func applySpecialFilters(
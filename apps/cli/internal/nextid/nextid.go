@@ -123,3 +123,18 @@ func detectPrefix(parsed []parsedID) string {
 func formatID(prefix string, number int, padding int) string {
 	return fmt.Sprintf("%s%0*d", prefix, padding, number)
 }
+
+// Split breaks an ID into its prefix, numeric suffix and the digit width of
+// that suffix. Returns false if the ID does not end in digits.
+func Split(id string) (prefix string, number int, width int, ok bool) {
+	p, ok := parseID(id)
+	if !ok {
+		return "", 0, 0, false
+	}
+	return p.prefix, p.number, len(p.numStr), true
+}
+
+// Format assembles an ID from a prefix and a number zero-padded to padding digits.
+func Format(prefix string, number int, padding int) string {
+	return formatID(prefix, number, padding)
+}
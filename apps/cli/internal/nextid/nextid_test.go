@@ -222,3 +222,35 @@ func TestCalculate(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitAndFormat(t *testing.T) {
+	tests := []struct {
+		id     string
+		wantOK bool
+		prefix string
+		number int
+		width  int
+	}{
+		{"042", true, "", 42, 3},
+		{"cli-0107", true, "cli-", 107, 4},
+		{"abc", false, "", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			prefix, number, width, ok := Split(tt.id)
+			if ok != tt.wantOK {
+				t.Fatalf("Split(%q) ok = %v, want %v", tt.id, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if prefix != tt.prefix || number != tt.number || width != tt.width {
+				t.Errorf("Split(%q) = (%q, %d, %d), want (%q, %d, %d)", tt.id, prefix, number, width, tt.prefix, tt.number, tt.width)
+			}
+			if got := Format(prefix, number, width); got != tt.id {
+				t.Errorf("Format round-trip = %q, want %q", got, tt.id)
+			}
+		})
+	}
+}
@@ -108,6 +108,36 @@ Body content here.
 	}
 }
 
+func TestParseTaskContent_ScheduleDates(t *testing.T) {
+	content := []byte(`---
+id: "003"
+title: "Dated Task"
+created: 2026-02-08
+start: 2026-02-10
+scheduled: 2026-02-12
+due: 2026-02-20
+---
+`)
+
+	task, err := ParseTaskContent("test.md", content)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+
+	for name, tc := range map[string]struct {
+		got  time.Time
+		want time.Time
+	}{
+		"start":     {task.Start, time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)},
+		"scheduled": {task.Scheduled, time.Date(2026, 2, 12, 0, 0, 0, 0, time.UTC)},
+		"due":       {task.Due, time.Date(2026, 2, 20, 0, 0, 0, 0, time.UTC)},
+	} {
+		if !tc.got.Equal(tc.want) {
+			t.Errorf("expected %s date %v, got %v", name, tc.want, tc.got)
+		}
+	}
+}
+
 func TestParseTaskContent_EmptyFile(t *testing.T) {
 	content := []byte("")
 
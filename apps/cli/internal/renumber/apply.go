@@ -0,0 +1,113 @@
+package renumber
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// staged tracks the temporary files used while applying a single change.
+type staged struct {
+	change Change
+	temp   string // new content, written next to NewPath
+	backup string // original file, moved aside before commit
+	placed bool   // temp has been renamed onto NewPath
+}
+
+// Apply writes all changes in a plan. New content is staged in temporary
+// files first, originals are moved aside, and only then are the staged files
+// renamed into place. Any failure restores the original files.
+func Apply(plan *Plan) error {
+	steps := make([]*staged, 0, len(plan.Changes))
+
+	for _, c := range plan.Changes {
+		temp, err := writeTemp(c.NewPath, c.Content)
+		if err != nil {
+			cleanup(steps)
+			return err
+		}
+		steps = append(steps, &staged{change: c, temp: temp})
+	}
+
+	for _, s := range steps {
+		backup, err := moveAside(s.change.OldPath)
+		if err != nil {
+			rollback(steps)
+			return err
+		}
+		s.backup = backup
+	}
+
+	for _, s := range steps {
+		if err := os.Rename(s.temp, s.change.NewPath); err != nil {
+			rollback(steps)
+			return fmt.Errorf("failed to write %s: %w", s.change.NewPath, err)
+		}
+		s.placed = true
+	}
+
+	for _, s := range steps {
+		os.Remove(s.backup) //nolint:errcheck
+	}
+	return nil
+}
+
+func writeTemp(dest string, content []byte) (string, error) {
+	dir := filepath.Dir(dest)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	f, err := os.CreateTemp(dir, ".renumber-*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("failed to stage %s: %w", dest, err)
+	}
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		os.Remove(f.Name()) //nolint:errcheck
+		return "", fmt.Errorf("failed to stage %s: %w", dest, err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name()) //nolint:errcheck
+		return "", fmt.Errorf("failed to stage %s: %w", dest, err)
+	}
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		os.Remove(f.Name()) //nolint:errcheck
+		return "", fmt.Errorf("failed to stage %s: %w", dest, err)
+	}
+	return f.Name(), nil
+}
+
+func moveAside(path string) (string, error) {
+	backup := path + ".renumber-bak"
+	if err := os.Rename(path, backup); err != nil {
+		return "", fmt.Errorf("failed to move %s aside: %w", path, err)
+	}
+	return backup, nil
+}
+
+// cleanup removes staged temp files that were never placed.
+func cleanup(steps []*staged) {
+	for _, s := range steps {
+		if !s.placed {
+			os.Remove(s.temp) //nolint:errcheck
+		}
+	}
+}
+
+// rollback undoes placed files and restores backups, in reverse order.
+func rollback(steps []*staged) {
+	for i := len(steps) - 1; i >= 0; i-- {
+		s := steps[i]
+		if s.placed {
+			os.Remove(s.change.NewPath) //nolint:errcheck
+			s.placed = false
+		}
+	}
+	for i := len(steps) - 1; i >= 0; i-- {
+		s := steps[i]
+		if s.backup != "" {
+			os.Rename(s.backup, s.change.OldPath) //nolint:errcheck
+		}
+	}
+	cleanup(steps)
+}
@@ -0,0 +1,413 @@
+// Package renumber changes task IDs and rewrites every reference to them:
+// task file names, id/dependencies/parent frontmatter, worklog files and
+// sync state entries.
+package renumber
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+	"github.com/driangle/taskmd/apps/cli/internal/nextid"
+	"github.com/driangle/taskmd/apps/cli/internal/sync"
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
+	"github.com/driangle/taskmd/apps/cli/internal/worklog"
+)
+
+// Change is a single file rewrite or move. OldPath and NewPath are equal
+// for in-place rewrites. Content is the full new file content.
+type Change struct {
+	Kind       string // "task", "worklog" or "sync-state"
+	OldPath    string
+	NewPath    string
+	OldContent []byte
+	Content    []byte
+}
+
+// Moved reports whether the change renames the file.
+func (c Change) Moved() bool {
+	return c.OldPath != c.NewPath
+}
+
+// Plan is the full set of changes needed to apply an ID mapping.
+type Plan struct {
+	Mapping map[string]string
+	Changes []Change
+}
+
+// Options controls where worklogs and sync state are looked up.
+type Options struct {
+	// ConfigDir is the directory containing .taskmd/sync-state. Empty skips sync state.
+	ConfigDir string
+	// Archived holds the IDs of archived tasks. Unless the task is in the
+	// scanned tasks itself, its ID stays taken and is never a renumber target.
+	Archived []string
+}
+
+// CompactMapping returns a mapping that renumbers every numeric ID so each
+// prefix forms a gap-free sequence starting at 1. Relative order is preserved
+// and IDs that are already in place are omitted. Numbers held by archived
+// tasks are skipped, so archived IDs are never reused.
+func CompactMapping(tasks []*model.Task, archived []string) map[string]string {
+	type entry struct {
+		id     string
+		number int
+	}
+	byPrefix := make(map[string][]entry)
+	widths := make(map[string]int)
+	for _, t := range tasks {
+		prefix, number, width, ok := nextid.Split(t.ID)
+		if !ok {
+			continue
+		}
+		byPrefix[prefix] = append(byPrefix[prefix], entry{id: t.ID, number: number})
+		widths[prefix] = max(widths[prefix], width, 3)
+	}
+
+	held := make(map[string]bool)
+	for id := range reservedIDs(tasks, archived) {
+		if prefix, number, _, ok := nextid.Split(id); ok {
+			held[nextid.Format(prefix, number, 0)] = true
+		}
+	}
+
+	mapping := make(map[string]string)
+	for prefix, entries := range byPrefix {
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].number < entries[j].number })
+		seq := 1
+		for _, e := range entries {
+			for held[nextid.Format(prefix, seq, 0)] {
+				seq++
+			}
+			newID := nextid.Format(prefix, seq, widths[prefix])
+			seq++
+			if newID != e.id {
+				mapping[e.id] = newID
+			}
+		}
+	}
+	return mapping
+}
+
+// reservedIDs returns the archived IDs that do not belong to one of tasks,
+// i.e. those of archived tasks outside the scan.
+func reservedIDs(tasks []*model.Task, archived []string) map[string]bool {
+	reserved := make(map[string]bool, len(archived))
+	for _, id := range archived {
+		reserved[id] = true
+	}
+	for _, t := range tasks {
+		delete(reserved, t.ID)
+	}
+	return reserved
+}
+
+// BuildPlan validates the mapping against the scanned tasks and computes all
+// file changes. Task file paths must be absolute or relative to the working directory.
+func BuildPlan(tasks []*model.Task, mapping map[string]string, opts Options) (*Plan, error) {
+	if err := validateMapping(tasks, mapping, reservedIDs(tasks, opts.Archived)); err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{Mapping: mapping}
+
+	for _, t := range tasks {
+		change, err := planTaskChange(t, mapping)
+		if err != nil {
+			return nil, err
+		}
+		if change != nil {
+			plan.Changes = append(plan.Changes, *change)
+		}
+	}
+
+	for _, t := range tasks {
+		newID, ok := mapping[t.ID]
+		if !ok {
+			continue
+		}
+		change, err := planWorklogChange(t, newID)
+		if err != nil {
+			return nil, err
+		}
+		if change != nil {
+			plan.Changes = append(plan.Changes, *change)
+		}
+	}
+
+	if opts.ConfigDir != "" {
+		changes, err := planSyncStateChanges(tasks, mapping, plan.Changes, opts.ConfigDir)
+		if err != nil {
+			return nil, err
+		}
+		plan.Changes = append(plan.Changes, changes...)
+	}
+
+	if err := checkDestinations(plan.Changes); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+func validateMapping(tasks []*model.Task, mapping map[string]string, reserved map[string]bool) error {
+	counts := make(map[string]int, len(tasks))
+	for _, t := range tasks {
+		counts[t.ID]++
+	}
+
+	targets := make(map[string]string, len(mapping))
+	for oldID, newID := range mapping {
+		if newID == "" {
+			return fmt.Errorf("new ID for %s must not be empty", oldID)
+		}
+		switch counts[oldID] {
+		case 0:
+			return fmt.Errorf("task not found: %s", oldID)
+		case 1:
+		default:
+			return fmt.Errorf("task ID %s is used by %d tasks; resolve the duplicate first", oldID, counts[oldID])
+		}
+		if prev, dup := targets[newID]; dup {
+			return fmt.Errorf("tasks %s and %s would both be renumbered to %s", prev, oldID, newID)
+		}
+		targets[newID] = oldID
+		if reserved[newID] {
+			return fmt.Errorf("task ID %s is used by an archived task", newID)
+		}
+		if _, freed := mapping[newID]; counts[newID] > 0 && !freed {
+			return fmt.Errorf("task ID %s is already in use", newID)
+		}
+	}
+	return nil
+}
+
+func remap(id string, mapping map[string]string) string {
+	if newID, ok := mapping[id]; ok {
+		return newID
+	}
+	return id
+}
+
+func planTaskChange(t *model.Task, mapping map[string]string) (*Change, error) {
+	var req taskfile.UpdateRequest
+	changed := false
+
+	newID, renamed := mapping[t.ID]
+	if renamed {
+		req.ID = &newID
+		changed = true
+	}
+
+	newDeps := make([]string, len(t.Dependencies))
+	for i, d := range t.Dependencies {
+		newDeps[i] = remap(d, mapping)
+	}
+	if !slices.Equal(newDeps, t.Dependencies) {
+		req.Dependencies = &newDeps
+		changed = true
+	}
+
+	if t.Parent != "" {
+		if newParent := remap(t.Parent, mapping); newParent != t.Parent {
+			req.Parent = &newParent
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil, nil
+	}
+
+	old, err := os.ReadFile(t.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read task file: %w", err)
+	}
+	content, err := taskfile.ApplyUpdate(old, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rewrite %s: %w", t.FilePath, err)
+	}
+
+	newPath := t.FilePath
+	if renamed {
+		newPath = renamedTaskPath(t.FilePath, t.ID, newID)
+	}
+
+	return &Change{Kind: "task", OldPath: t.FilePath, NewPath: newPath, OldContent: old, Content: content}, nil
+}
+
+// renamedTaskPath swaps a leading "<oldID>-" or "<oldID>." in the filename for the new ID.
+// Files that don't follow the ID naming convention keep their name.
+func renamedTaskPath(path, oldID, newID string) string {
+	dir, base := filepath.Split(path)
+	if strings.HasPrefix(base, oldID+"-") || strings.HasPrefix(base, oldID+".") {
+		return filepath.Join(dir, newID+base[len(oldID):])
+	}
+	return path
+}
+
+func planWorklogChange(t *model.Task, newID string) (*Change, error) {
+	oldPath := worklog.WorklogPath(t.FilePath, t.ID)
+	if !worklog.Exists(oldPath) {
+		return nil, nil
+	}
+	content, err := os.ReadFile(oldPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read worklog: %w", err)
+	}
+	return &Change{
+		Kind:       "worklog",
+		OldPath:    oldPath,
+		NewPath:    worklog.WorklogPath(t.FilePath, newID),
+		OldContent: content,
+		Content:    content,
+	}, nil
+}
+
+func planSyncStateChanges(tasks []*model.Task, mapping map[string]string, taskChanges []Change, configDir string) ([]Change, error) {
+	sources, err := sync.StateSources(configDir)
+	if err != nil {
+		return nil, err
+	}
+
+	byPath := make(map[string]Change, len(taskChanges))
+	for _, c := range taskChanges {
+		if c.Kind == "task" {
+			byPath[absPath(c.OldPath)] = c
+		}
+	}
+	byID := make(map[string]*model.Task, len(tasks))
+	for _, t := range tasks {
+		byID[t.ID] = t
+	}
+
+	var changes []Change
+	for _, source := range sources {
+		state, err := sync.LoadState(configDir, source)
+		if err != nil {
+			return nil, err
+		}
+		if !updateSyncState(state, mapping, byID, byPath, configDir) {
+			continue
+		}
+
+		path := sync.StateFilePath(configDir, source)
+		old, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sync state: %w", err)
+		}
+		content, err := sync.MarshalState(state)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, Change{Kind: "sync-state", OldPath: path, NewPath: path, OldContent: old, Content: content})
+	}
+	return changes, nil
+}
+
+// updateSyncState rewrites LocalID and FilePath for renumbered tasks, and
+// the dependency and parent IDs recorded in each task's synced fields. A
+// local hash that matched the old file is refreshed so the rewrite is not
+// reported as a local edit on the next sync. Returns true if anything changed.
+func updateSyncState(state *sync.SyncState, mapping map[string]string, byID map[string]*model.Task, byPath map[string]Change, configDir string) bool {
+	changed := false
+	for extID, ts := range state.Tasks {
+		fieldsChanged := remapFields(&ts, mapping)
+		fileChanged := updateFileState(&ts, mapping, byID, byPath, configDir)
+		if fieldsChanged || fileChanged {
+			state.Tasks[extID] = ts
+			changed = true
+		}
+	}
+	return changed
+}
+
+// remapFields rewrites the task IDs in the merge base's dependencies and
+// parent. Without this the next sync would see the renumbered IDs in the
+// task file as a local change to those fields.
+func remapFields(ts *sync.TaskState, mapping map[string]string) bool {
+	if ts.Fields == nil {
+		return false
+	}
+	fields := *ts.Fields
+	changed := false
+
+	if len(fields.Dependencies) > 0 {
+		deps := make([]string, len(fields.Dependencies))
+		for i, d := range fields.Dependencies {
+			deps[i] = remap(d, mapping)
+		}
+		if !slices.Equal(deps, fields.Dependencies) {
+			fields.Dependencies = deps
+			changed = true
+		}
+	}
+	if newParent := remap(fields.Parent, mapping); newParent != fields.Parent {
+		fields.Parent = newParent
+		changed = true
+	}
+
+	if changed {
+		ts.Fields = &fields
+	}
+	return changed
+}
+
+// updateFileState follows a rewritten task file: it refreshes an unchanged
+// local hash and, for a renumbered task, updates LocalID and FilePath.
+func updateFileState(ts *sync.TaskState, mapping map[string]string, byID map[string]*model.Task, byPath map[string]Change, configDir string) bool {
+	statePath := ts.FilePath
+	if !filepath.IsAbs(statePath) {
+		statePath = filepath.Join(configDir, statePath)
+	}
+	c, ok := byPath[absPath(statePath)]
+	if !ok {
+		return false
+	}
+	if ts.LocalHash == sync.HashContent(c.OldContent) {
+		ts.LocalHash = sync.HashContent(c.Content)
+	}
+
+	newID, renamed := mapping[ts.LocalID]
+	if task := byID[ts.LocalID]; renamed && task != nil && absPath(task.FilePath) == absPath(statePath) {
+		ts.LocalID = newID
+		ts.FilePath = filepath.Join(filepath.Dir(ts.FilePath), filepath.Base(c.NewPath))
+	}
+	return true
+}
+
+func absPath(p string) string {
+	if abs, err := filepath.Abs(p); err == nil {
+		return abs
+	}
+	return p
+}
+
+// checkDestinations ensures no change would overwrite a file that isn't itself being moved away.
+func checkDestinations(changes []Change) error {
+	vacated := make(map[string]bool)
+	for _, c := range changes {
+		if c.Moved() {
+			vacated[absPath(c.OldPath)] = true
+		}
+	}
+	seen := make(map[string]bool)
+	for _, c := range changes {
+		dest := absPath(c.NewPath)
+		if seen[dest] {
+			return fmt.Errorf("multiple changes target %s", c.NewPath)
+		}
+		seen[dest] = true
+		if !c.Moved() || vacated[dest] {
+			continue
+		}
+		if _, err := os.Stat(dest); err == nil {
+			return fmt.Errorf("destination already exists: %s", c.NewPath)
+		}
+	}
+	return nil
+}
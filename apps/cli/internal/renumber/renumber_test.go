@@ -0,0 +1,381 @@
+package renumber
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+	"github.com/driangle/taskmd/apps/cli/internal/scanner"
+	"github.com/driangle/taskmd/apps/cli/internal/sync"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+	return string(data)
+}
+
+func scanTasks(t *testing.T, dir string) []*model.Task {
+	t.Helper()
+	result, err := scanner.NewScanner(dir, false, nil).Scan()
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	return result.Tasks
+}
+
+func setupTasks(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "001-setup.md"), `---
+id: "001"
+title: "Setup"
+status: completed
+---
+# Setup
+`)
+	writeFile(t, filepath.Join(dir, "004-feature.md"), `---
+id: "004"
+title: "Feature"
+status: pending
+dependencies: ["001"]
+---
+# Feature
+`)
+	writeFile(t, filepath.Join(dir, "sub", "009-child.md"), `---
+id: "009"
+title: "Child"
+status: pending
+parent: "004"
+dependencies:
+  - "001"
+  - "004"
+---
+# Child
+`)
+	return dir
+}
+
+func TestBuildPlan_ValidatesMapping(t *testing.T) {
+	dir := setupTasks(t)
+	tasks := scanTasks(t, dir)
+
+	tests := []struct {
+		name    string
+		mapping map[string]string
+		wantErr string
+	}{
+		{"unknown task", map[string]string{"999": "100"}, "task not found"},
+		{"target in use", map[string]string{"001": "004"}, "already in use"},
+		{"duplicate target", map[string]string{"001": "100", "004": "100"}, "would both be renumbered"},
+		{"empty target", map[string]string{"001": ""}, "must not be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := BuildPlan(tasks, tt.mapping, Options{})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestBuildPlan_SwapIsAllowed(t *testing.T) {
+	dir := setupTasks(t)
+	tasks := scanTasks(t, dir)
+
+	plan, err := BuildPlan(tasks, map[string]string{"001": "004", "004": "001"}, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := Apply(plan); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	if !strings.Contains(readFile(t, filepath.Join(dir, "004-setup.md")), `id: "004"`) {
+		t.Error("expected setup task to become 004")
+	}
+	if !strings.Contains(readFile(t, filepath.Join(dir, "001-feature.md")), `dependencies: ["004"]`) {
+		t.Error("expected feature task to depend on 004")
+	}
+}
+
+func TestApply_RewritesReferences(t *testing.T) {
+	dir := setupTasks(t)
+	tasks := scanTasks(t, dir)
+
+	plan, err := BuildPlan(tasks, map[string]string{"004": "107"}, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := Apply(plan); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "004-feature.md")); !os.IsNotExist(err) {
+		t.Error("expected old file to be gone")
+	}
+	feature := readFile(t, filepath.Join(dir, "107-feature.md"))
+	if !strings.Contains(feature, `id: "107"`) {
+		t.Errorf("expected new id in frontmatter, got:\n%s", feature)
+	}
+
+	child := readFile(t, filepath.Join(dir, "sub", "009-child.md"))
+	if !strings.Contains(child, "parent: 107") {
+		t.Errorf("expected parent rewritten, got:\n%s", child)
+	}
+	if !strings.Contains(child, "  - \"001\"\n  - \"107\"\n") {
+		t.Errorf("expected multiline dependencies rewritten in place, got:\n%s", child)
+	}
+
+	for _, name := range []string{"", "sub"} {
+		entries, _ := os.ReadDir(filepath.Join(dir, name))
+		for _, e := range entries {
+			if strings.Contains(e.Name(), ".renumber") {
+				t.Errorf("leftover staging file: %s", e.Name())
+			}
+		}
+	}
+}
+
+func TestBuildPlan_KeepsNonConventionalFileName(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "feature.md"), "---\nid: \"004\"\ntitle: \"Feature\"\n---\n")
+	tasks := scanTasks(t, dir)
+
+	plan, err := BuildPlan(tasks, map[string]string{"004": "005"}, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plan.Changes) != 1 || plan.Changes[0].Moved() {
+		t.Fatalf("expected a single in-place change, got %+v", plan.Changes)
+	}
+}
+
+func TestApply_MovesWorklog(t *testing.T) {
+	dir := setupTasks(t)
+	writeFile(t, filepath.Join(dir, ".worklogs", "004.md"), "## 2026-01-01T00:00:00Z\n\nStarted.\n")
+	tasks := scanTasks(t, dir)
+
+	plan, err := BuildPlan(tasks, map[string]string{"004": "107"}, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := Apply(plan); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, ".worklogs", "004.md")); !os.IsNotExist(err) {
+		t.Error("expected old worklog to be gone")
+	}
+	if got := readFile(t, filepath.Join(dir, ".worklogs", "107.md")); !strings.Contains(got, "Started.") {
+		t.Errorf("expected worklog content preserved, got %q", got)
+	}
+}
+
+func TestApply_UpdatesSyncState(t *testing.T) {
+	dir := setupTasks(t)
+	oldPath := filepath.Join(dir, "004-feature.md")
+	oldHash, err := sync.HashLocalFile(oldPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := &sync.SyncState{
+		Source: "github",
+		Tasks: map[string]sync.TaskState{
+			"42": {ExternalID: "42", LocalID: "004", FilePath: "004-feature.md", ExternalHash: "ext", LocalHash: oldHash},
+			"43": {ExternalID: "43", LocalID: "001", FilePath: "001-setup.md", ExternalHash: "ext", LocalHash: "stale"},
+		},
+	}
+	if err := sync.SaveState(dir, "github", state); err != nil {
+		t.Fatal(err)
+	}
+
+	tasks := scanTasks(t, dir)
+	plan, err := BuildPlan(tasks, map[string]string{"004": "107", "001": "100"}, Options{ConfigDir: dir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := Apply(plan); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	loaded, err := sync.LoadState(dir, "github")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := loaded.Tasks["42"]
+	if ts.LocalID != "107" || ts.FilePath != "107-feature.md" {
+		t.Errorf("expected 107/107-feature.md, got %s/%s", ts.LocalID, ts.FilePath)
+	}
+	newHash, _ := sync.HashLocalFile(filepath.Join(dir, "107-feature.md"))
+	if ts.LocalHash != newHash {
+		t.Error("expected unchanged local hash to be refreshed")
+	}
+
+	if loaded.Tasks["43"].LocalHash != "stale" {
+		t.Error("expected stale local hash to be left alone so local edits are still detected")
+	}
+}
+
+func TestApply_RemapsSyncStateFields(t *testing.T) {
+	dir := setupTasks(t)
+	childPath := filepath.Join("sub", "009-child.md")
+	oldHash, err := sync.HashLocalFile(filepath.Join(dir, childPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := &sync.SyncState{
+		Source: "github",
+		Tasks: map[string]sync.TaskState{
+			"44": {
+				ExternalID: "44", LocalID: "009", FilePath: childPath, ExternalHash: "ext", LocalHash: oldHash,
+				Fields: &sync.FieldValues{Title: "Child", Status: "pending", Dependencies: []string{"001", "004"}, Parent: "004"},
+			},
+		},
+	}
+	if err := sync.SaveState(dir, "github", state); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := BuildPlan(scanTasks(t, dir), map[string]string{"004": "107"}, Options{ConfigDir: dir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := Apply(plan); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+
+	loaded, err := sync.LoadState(dir, "github")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := loaded.Tasks["44"]
+	if ts.LocalID != "009" || ts.FilePath != childPath {
+		t.Errorf("expected 009/%s to be kept, got %s/%s", childPath, ts.LocalID, ts.FilePath)
+	}
+	if ts.Fields == nil || !slices.Equal(ts.Fields.Dependencies, []string{"001", "107"}) || ts.Fields.Parent != "107" {
+		t.Errorf("expected remapped dependencies and parent, got %+v", ts.Fields)
+	}
+	newHash, _ := sync.HashLocalFile(filepath.Join(dir, childPath))
+	if ts.LocalHash != newHash {
+		t.Error("expected local hash of the rewritten dependent to be refreshed")
+	}
+}
+
+func TestCompactMapping(t *testing.T) {
+	tasks := []*model.Task{
+		{ID: "002"},
+		{ID: "007"},
+		{ID: "003"},
+		{ID: "cli-10"},
+		{ID: "readme"},
+	}
+
+	got := CompactMapping(tasks, nil)
+	want := map[string]string{
+		"002":    "001",
+		"003":    "002",
+		"007":    "003",
+		"cli-10": "cli-001",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("mapping[%s] = %q, want %q", k, got[k], v)
+		}
+	}
+}
+
+func TestCompactMapping_AlreadyCompact(t *testing.T) {
+	tasks := []*model.Task{{ID: "001"}, {ID: "002"}}
+	if got := CompactMapping(tasks, nil); len(got) != 0 {
+		t.Errorf("expected empty mapping, got %v", got)
+	}
+}
+
+func TestCompactMapping_SkipsArchivedIDs(t *testing.T) {
+	tasks := []*model.Task{{ID: "003"}, {ID: "005"}}
+	got := CompactMapping(tasks, []string{"001", "3"})
+	if got["003"] != "002" || got["005"] != "004" {
+		t.Errorf("expected 003->002 and 005->004 around archived 001 and 3, got %v", got)
+	}
+}
+
+func TestBuildPlan_RejectsArchivedID(t *testing.T) {
+	dir := setupTasks(t)
+	_, err := BuildPlan(scanTasks(t, dir), map[string]string{"004": "002"}, Options{Archived: []string{"002"}})
+	if err == nil || !strings.Contains(err.Error(), "archived task") {
+		t.Fatalf("expected archived ID error, got %v", err)
+	}
+}
+
+func TestBuildPlan_DestinationExists(t *testing.T) {
+	dir := setupTasks(t)
+	writeFile(t, filepath.Join(dir, ".worklogs", "004.md"), "log\n")
+	writeFile(t, filepath.Join(dir, ".worklogs", "107.md"), "orphan\n")
+	tasks := scanTasks(t, dir)
+
+	_, err := BuildPlan(tasks, map[string]string{"004": "107"}, Options{})
+	if err == nil || !strings.Contains(err.Error(), "destination already exists") {
+		t.Fatalf("expected destination error, got %v", err)
+	}
+}
+
+func TestApply_RollsBackOnFailure(t *testing.T) {
+	dir := setupTasks(t)
+	tasks := scanTasks(t, dir)
+
+	plan, err := BuildPlan(tasks, map[string]string{"004": "107"}, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	original := readFile(t, filepath.Join(dir, "004-feature.md"))
+	// Make one original vanish between planning and applying.
+	os.Remove(filepath.Join(dir, "sub", "009-child.md"))
+
+	if err := Apply(plan); err == nil {
+		t.Fatal("expected apply to fail")
+	}
+
+	if got := readFile(t, filepath.Join(dir, "004-feature.md")); got != original {
+		t.Error("expected original task file to be restored")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "107-feature.md")); !os.IsNotExist(err) {
+		t.Error("expected renamed file to be rolled back")
+	}
+}
+
+func TestChangeDiff(t *testing.T) {
+	c := Change{
+		OldContent: []byte("a\nb\nc\n"),
+		Content:    []byte("a\nB\nc\n"),
+	}
+	got := c.Diff()
+	if len(got) != 2 || got[0] != "-b" || got[1] != "+B" {
+		t.Errorf("unexpected diff: %v", got)
+	}
+}
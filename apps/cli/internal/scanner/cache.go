@@ -0,0 +1,256 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+	"github.com/driangle/taskmd/apps/cli/internal/parser"
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
+)
+
+const (
+	cacheSubDir   = ".taskmd/cache"
+	cacheFileName = "scan.json"
+
+	// cacheVersion is bumped whenever the entry format changes. Changes to
+	// the task model or the binary are picked up by cacheKey on their own.
+	cacheVersion = 1
+
+	// racyWindow is how long after a file's mtime its stat data is trusted.
+	// A file hashed within this window may change again without its mtime
+	// moving on coarse-grained filesystems, so it is re-hashed instead.
+	racyWindow = time.Second
+)
+
+// cacheEntry records the parse result of one file, keyed by its absolute
+// path. A file matches when its mtime and size are unchanged, or failing
+// that, when its content hash is.
+type cacheEntry struct {
+	ModTime time.Time   `json:"mod_time"`
+	Size    int64       `json:"size"`
+	Hash    string      `json:"hash"`
+	Checked time.Time   `json:"checked"`
+	Task    *model.Task `json:"task,omitempty"`
+	Body    string      `json:"body,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+type cacheFile struct {
+	Key     string                 `json:"key"`
+	Entries map[string]*cacheEntry `json:"entries"`
+}
+
+// cacheKey identifies the code that wrote a cache: the entry format version,
+// the shape of model.Task and the build (module version and VCS revision).
+// A cache with a different key is discarded, so parser or model changes never
+// serve stale results, even without a manual cacheVersion bump.
+var cacheKey = sync.OnceValue(func() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "v%d\n", cacheVersion)
+	writeTypeSignature(h, reflect.TypeFor[model.Task](), map[reflect.Type]bool{})
+	if info, ok := debug.ReadBuildInfo(); ok {
+		fmt.Fprintf(h, "build %s %s\n", info.Main.Path, info.Main.Version)
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" || setting.Key == "vcs.modified" {
+				fmt.Fprintf(h, "%s=%s\n", setting.Key, setting.Value)
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+})
+
+// writeTypeSignature writes the fields of t, and of the model structs it
+// embeds, with their types and tags.
+func writeTypeSignature(w io.Writer, t reflect.Type, seen map[reflect.Type]bool) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || seen[t] || t.PkgPath() != reflect.TypeFor[model.Task]().PkgPath() {
+		return
+	}
+	seen[t] = true
+	fmt.Fprintf(w, "%s{\n", t.Name())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		fmt.Fprintf(w, "%s %s %q\n", f.Name, f.Type, f.Tag)
+		writeTypeSignature(w, f.Type, seen)
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// parseCache is an on-disk cache of parsed task files, so that unchanged
+// files skip YAML parsing. It is safe for concurrent use.
+type parseCache struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	dirty   bool
+}
+
+// cacheDir returns the .taskmd/cache directory for rootDir, under its
+// project directory (see taskfile.ProjectDir). ok is false outside a
+// project: the cache is then skipped, so scanning an unconfigured directory
+// never creates .taskmd in it.
+func cacheDir(rootDir string) (dir string, ok bool) {
+	root, ok := taskfile.ProjectDir(rootDir)
+	if !ok {
+		return "", false
+	}
+	return filepath.Join(root, cacheSubDir), true
+}
+
+// loadCache reads the cache in dir. A missing, unreadable or outdated cache
+// yields an empty one.
+func loadCache(dir string) *parseCache {
+	c := &parseCache{
+		path:    filepath.Join(dir, cacheFileName),
+		entries: make(map[string]*cacheEntry),
+	}
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return c
+	}
+	var file cacheFile
+	if err := json.Unmarshal(data, &file); err != nil || file.Key != cacheKey() || file.Entries == nil {
+		return c
+	}
+	c.entries = file.Entries
+	return c
+}
+
+// parse returns the task in path, reusing the cached result when the file
+// is unchanged and parsing it otherwise.
+func (c *parseCache) parse(path string) (*model.Task, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return parser.ParseTaskFile(path)
+	}
+
+	c.mu.Lock()
+	entry := c.entries[path]
+	c.mu.Unlock()
+
+	if entry != nil && entry.statMatches(info) {
+		return entry.result(path)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return parser.ParseTaskFile(path)
+	}
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+
+	if entry == nil || entry.Hash != hash {
+		task, parseErr := parser.ParseTaskContent(path, content)
+		entry = newCacheEntry(task, parseErr)
+	}
+	updated := *entry
+	updated.ModTime = info.ModTime()
+	updated.Size = info.Size()
+	updated.Hash = hash
+	updated.Checked = time.Now()
+
+	c.mu.Lock()
+	c.entries[path] = &updated
+	c.dirty = true
+	c.mu.Unlock()
+
+	return updated.result(path)
+}
+
+func newCacheEntry(task *model.Task, err error) *cacheEntry {
+	if err != nil {
+		return &cacheEntry{Error: err.Error()}
+	}
+	return &cacheEntry{Task: task, Body: task.Body}
+}
+
+// statMatches reports whether info describes the file this entry was made
+// from, without reading it. Files modified shortly before they were hashed
+// never match, since a later write may have kept the same mtime.
+func (e *cacheEntry) statMatches(info fs.FileInfo) bool {
+	return e.Size == info.Size() &&
+		e.ModTime.Equal(info.ModTime()) &&
+		e.ModTime.Add(racyWindow).Before(e.Checked)
+}
+
+// result returns a fresh copy of the cached task, or the cached parse error.
+func (e *cacheEntry) result(path string) (*model.Task, error) {
+	if e.Task == nil {
+		return nil, errors.New(e.Error)
+	}
+	task := *e.Task
+	task.FilePath = path
+	task.Body = e.Body
+	return &task, nil
+}
+
+// save prunes entries for files that no longer exist and writes the cache
+// if anything changed. seen holds the paths found by the current scan; other
+// entries are kept, since they may belong to a scan of another directory.
+func (c *parseCache) save(seen map[string]bool) error {
+	for path := range c.entries {
+		if seen[path] {
+			continue
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			delete(c.entries, path)
+			c.dirty = true
+		}
+	}
+	if !c.dirty {
+		return nil
+	}
+
+	data, err := json.Marshal(cacheFile{Key: cacheKey(), Entries: c.entries})
+	if err != nil {
+		return fmt.Errorf("failed to encode scan cache: %w", err)
+	}
+	dir := filepath.Dir(c.path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	// Keep the cache out of version control without touching the project's
+	// own .gitignore.
+	ignore := filepath.Join(dir, ".gitignore")
+	if _, err := os.Stat(ignore); os.IsNotExist(err) {
+		if err := os.WriteFile(ignore, []byte("*\n"), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", ignore, err)
+		}
+	}
+
+	// Write to a temporary file and rename it so concurrent scans never read
+	// a partial cache.
+	tmp, err := os.CreateTemp(dir, cacheFileName+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write scan cache: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write scan cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write scan cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write scan cache: %w", err)
+	}
+	return nil
+}
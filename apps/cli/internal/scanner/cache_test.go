@@ -0,0 +1,182 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
+)
+
+// newProject returns a temporary project directory, marked by .taskmd.yaml
+// so that scans in it use the cache.
+func newProject(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, taskfile.ConfigFileName), []byte("dir: .\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func loadProjectCache(t *testing.T, rootDir string) *parseCache {
+	t.Helper()
+	dir, ok := cacheDir(rootDir)
+	if !ok {
+		t.Fatalf("expected a cache directory for %s", rootDir)
+	}
+	return loadCache(dir)
+}
+
+// rewriteCachedTitle replaces the title stored in the cache for path, so a
+// scan that returns it proves the file was not parsed again.
+func rewriteCachedTitle(t *testing.T, rootDir, path, title string) {
+	t.Helper()
+	c := loadProjectCache(t, rootDir)
+	entry := c.entries[path]
+	if entry == nil || entry.Task == nil {
+		t.Fatalf("expected a cache entry for %s", path)
+	}
+	entry.Task.Title = title
+	c.dirty = true
+	if err := c.save(map[string]bool{path: true}); err != nil {
+		t.Fatalf("failed to save cache: %v", err)
+	}
+}
+
+func scanTitle(t *testing.T, dir string) string {
+	t.Helper()
+	result, err := NewScanner(dir, false, nil).UseCache(true).Scan()
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(result.Tasks) != 1 {
+		t.Fatalf("Expected 1 task, got %d", len(result.Tasks))
+	}
+	return result.Tasks[0].Title
+}
+
+func TestScanner_Cache(t *testing.T) {
+	tmpDir := newProject(t)
+	createTaskFile(t, tmpDir, "001")
+	path := filepath.Join(tmpDir, "001.md")
+
+	// Age the file so its stat data is trusted.
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := scanTitle(t, tmpDir); got != "Task 001" {
+		t.Fatalf("title = %q, want %q", got, "Task 001")
+	}
+	for _, name := range []string{cacheFileName, ".gitignore"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, cacheSubDir, name)); err != nil {
+			t.Errorf("expected %s in the cache directory: %v", name, err)
+		}
+	}
+
+	// Unchanged files come from the cache.
+	rewriteCachedTitle(t, tmpDir, path, "Cached")
+	if got := scanTitle(t, tmpDir); got != "Cached" {
+		t.Errorf("title = %q, want the cached title", got)
+	}
+
+	// A new mtime with the same content still matches by hash.
+	older := old.Add(-time.Hour)
+	if err := os.Chtimes(path, older, older); err != nil {
+		t.Fatal(err)
+	}
+	if got := scanTitle(t, tmpDir); got != "Cached" {
+		t.Errorf("title = %q, want the cached title after a touch", got)
+	}
+
+	// Changed content is parsed again.
+	content := "---\nid: \"001\"\ntitle: \"Renamed\"\n---\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := scanTitle(t, tmpDir); got != "Renamed" {
+		t.Errorf("title = %q, want %q", got, "Renamed")
+	}
+}
+
+func TestScanner_CacheDropsDeletedFiles(t *testing.T) {
+	tmpDir := newProject(t)
+	createTaskFile(t, tmpDir, "001")
+	createTaskFile(t, tmpDir, "002")
+
+	if _, err := NewScanner(tmpDir, false, nil).UseCache(true).Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if err := os.Remove(filepath.Join(tmpDir, "002.md")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewScanner(tmpDir, false, nil).UseCache(true).Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	c := loadProjectCache(t, tmpDir)
+	if len(c.entries) != 1 {
+		t.Errorf("Expected 1 cache entry, got %d", len(c.entries))
+	}
+}
+
+func TestScanner_CacheLocation(t *testing.T) {
+	tmpDir := newProject(t)
+	tasksDir := filepath.Join(tmpDir, "tasks")
+	createTaskFile(t, tasksDir, "001")
+
+	if got, ok := cacheDir(tasksDir); !ok || got != filepath.Join(tmpDir, cacheSubDir) {
+		t.Errorf("cacheDir = %q, %v; want %q", got, ok, filepath.Join(tmpDir, cacheSubDir))
+	}
+
+	if _, err := NewScanner(tasksDir, false, nil).Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, ".taskmd")); !os.IsNotExist(err) {
+		t.Error("expected no cache to be written unless the cache is enabled")
+	}
+}
+
+func TestScanner_CacheSkippedOutsideProject(t *testing.T) {
+	tmpDir := t.TempDir()
+	createTaskFile(t, tmpDir, "001")
+
+	if got := scanTitle(t, tmpDir); got != "Task 001" {
+		t.Fatalf("title = %q, want %q", got, "Task 001")
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, ".taskmd")); !os.IsNotExist(err) {
+		t.Error("expected scanning a directory outside a project to leave it untouched")
+	}
+}
+
+func TestScanner_CacheDiscardsOtherKey(t *testing.T) {
+	tmpDir := newProject(t)
+	createTaskFile(t, tmpDir, "001")
+	path := filepath.Join(tmpDir, "001.md")
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	scanTitle(t, tmpDir)
+	rewriteCachedTitle(t, tmpDir, path, "Cached")
+
+	// A cache written by another build or task model is not trusted.
+	cachePath := filepath.Join(tmpDir, cacheSubDir, cacheFileName)
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = []byte(strings.Replace(string(data), cacheKey(), "other", 1))
+	if err := os.WriteFile(cachePath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := scanTitle(t, tmpDir); got != "Task 001" {
+		t.Errorf("title = %q, want the file to be parsed again", got)
+	}
+}
@@ -5,10 +5,13 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/driangle/taskmd/apps/cli/internal/model"
 	"github.com/driangle/taskmd/apps/cli/internal/parser"
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
 )
 
 // defaultSkipDirs are directories always skipped during scanning.
@@ -22,14 +25,16 @@ var defaultSkipDirs = []string{
 	"out",
 	"target",
 	"__pycache__",
-	"archive",
 }
 
 // Scanner scans directories for markdown task files
 type Scanner struct {
-	rootDir    string
-	verbose    bool
-	ignoreDirs map[string]bool
+	rootDir         string
+	verbose         bool
+	ignoreDirs      map[string]bool
+	includeArchived bool
+	useCache        bool
+	workers         int
 }
 
 // NewScanner creates a new directory scanner.
@@ -46,13 +51,35 @@ func NewScanner(rootDir string, verbose bool, ignoreDirs []string) *Scanner {
 		rootDir:    rootDir,
 		verbose:    verbose,
 		ignoreDirs: ignoreMap,
+		workers:    runtime.GOMAXPROCS(0),
 	}
 }
 
+// IncludeArchived makes Scan return the tasks in archive directories as
+// well, marked as archived. Either way their IDs are reported in
+// ScanResult.ArchivedIDs.
+func (s *Scanner) IncludeArchived(include bool) *Scanner {
+	s.includeArchived = include
+	return s
+}
+
+// UseCache turns the parse cache in .taskmd/cache on or off. It is off by
+// default. When on, files whose path, mtime, size and content hash are
+// unchanged since the last scan reuse the cached result instead of being
+// parsed again. The cache lives in the project directory, next to
+// .taskmd.yaml or in an existing .taskmd/; outside a project it is not used.
+func (s *Scanner) UseCache(use bool) *Scanner {
+	s.useCache = use
+	return s
+}
+
 // ScanResult contains the results of a directory scan
 type ScanResult struct {
 	Tasks  []*model.Task
 	Errors []ScanError
+	// ArchivedIDs holds the IDs of the tasks in archive directories, so that
+	// dependencies on them can be resolved and their IDs are not reused.
+	ArchivedIDs []string
 }
 
 // ScanError represents an error encountered during scanning
@@ -62,26 +89,79 @@ type ScanError struct {
 }
 
 // Scan walks the directory tree and finds all markdown files with task frontmatter
-//
-//nolint:gocognit,funlen // TODO: refactor to reduce complexity
 func (s *Scanner) Scan() (*ScanResult, error) {
+	// Resolve absolute path
+	absRoot, err := filepath.Abs(s.rootDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path %s: %w", s.rootDir, err)
+	}
+
+	if s.verbose {
+		fmt.Fprintf(os.Stderr, "Scanning directory: %s\n", absRoot)
+	}
+
 	result := &ScanResult{
 		Tasks:  make([]*model.Task, 0),
 		Errors: make([]ScanError, 0),
 	}
 
-	// Resolve absolute path
-	absRoot, err := filepath.Abs(s.rootDir)
+	paths, err := s.findMarkdownFiles(absRoot, result)
 	if err != nil {
-		return nil, fmt.Errorf("failed to resolve path %s: %w", s.rootDir, err)
+		return nil, err
+	}
+
+	cache := s.openCache(absRoot)
+
+	for i, parsed := range s.parseFiles(paths, cache) {
+		if parsed.err != nil {
+			// Not all .md files are tasks, so we silently skip parse errors
+			// unless verbose mode is enabled
+			if s.verbose {
+				fmt.Fprintf(os.Stderr, "Skipping %s: %v\n", paths[i], parsed.err)
+			}
+			continue
+		}
+		archived := isArchivedPath(absRoot, paths[i])
+		if archived {
+			result.ArchivedIDs = append(result.ArchivedIDs, parsed.task.ID)
+		}
+		if archived && !s.includeArchived {
+			continue
+		}
+		result.Tasks = append(result.Tasks, s.placeTask(absRoot, parsed.task, archived))
+	}
+
+	if cache != nil {
+		if err := cache.save(pathSet(paths)); err != nil && s.verbose {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
 	}
 
 	if s.verbose {
-		fmt.Fprintf(os.Stderr, "Scanning directory: %s\n", absRoot)
+		fmt.Fprintf(os.Stderr, "Scan complete. Found %d tasks\n", len(result.Tasks))
+	}
+
+	return result, nil
+}
+
+// openCache loads the parse cache for absRoot, or returns nil when the
+// cache is off or absRoot is not in a project.
+func (s *Scanner) openCache(absRoot string) *parseCache {
+	if !s.useCache {
+		return nil
+	}
+	dir, ok := cacheDir(absRoot)
+	if !ok {
+		return nil
 	}
+	return loadCache(dir)
+}
 
-	// Walk the directory tree
-	err = filepath.WalkDir(absRoot, func(path string, d fs.DirEntry, err error) error {
+// findMarkdownFiles walks absRoot and returns the markdown files to parse,
+// in walk order. Access errors are recorded in result.
+func (s *Scanner) findMarkdownFiles(absRoot string, result *ScanResult) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(absRoot, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			result.Errors = append(result.Errors, ScanError{
 				FilePath: path,
@@ -90,56 +170,86 @@ func (s *Scanner) Scan() (*ScanResult, error) {
 			return nil // Continue walking despite errors
 		}
 
-		// Skip directories
+		// Skip hidden directories and configured/default ignore patterns
 		if d.IsDir() {
-			// Skip hidden directories and configured/default ignore patterns
-			name := d.Name()
-			if s.shouldSkipDirectory(name) {
+			if s.shouldSkipDirectory(d.Name()) {
 				return filepath.SkipDir
 			}
 			return nil
 		}
 
 		// Only process .md files
-		if !strings.HasSuffix(strings.ToLower(d.Name()), ".md") {
-			return nil
-		}
-
-		// Try to parse as a task file
-		task, err := parser.ParseTaskFile(path)
-		if err != nil {
-			// Not all .md files are tasks, so we silently skip parse errors
-			// unless verbose mode is enabled
-			if s.verbose {
-				fmt.Fprintf(os.Stderr, "Skipping %s: %v\n", path, err)
-			}
-			return nil
+		if strings.HasSuffix(strings.ToLower(d.Name()), ".md") {
+			paths = append(paths, path)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("directory walk failed: %w", err)
+	}
+	return paths, nil
+}
 
-		// If task has a group field in frontmatter, use it
-		// Otherwise, derive group from directory structure
-		if task.Group == "" {
-			task.Group = deriveGroupFromPath(absRoot, path)
-		}
+type parsedFile struct {
+	task *model.Task
+	err  error
+}
 
-		result.Tasks = append(result.Tasks, task)
+// parseFiles parses paths on a bounded pool of workers, using cache when it
+// is not nil. Results are returned in the order of paths.
+func (s *Scanner) parseFiles(paths []string, cache *parseCache) []parsedFile {
+	results := make([]parsedFile, len(paths))
+	jobs := make(chan int)
 
-		if s.verbose {
-			fmt.Fprintf(os.Stderr, "Found task: %s - %s\n", task.ID, task.Title)
-		}
+	var wg sync.WaitGroup
+	for range min(max(s.workers, 1), len(paths)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if cache != nil {
+					results[i].task, results[i].err = cache.parse(paths[i])
+				} else {
+					results[i].task, results[i].err = parser.ParseTaskFile(paths[i])
+				}
+			}
+		}()
+	}
+	for i := range paths {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
 
-		return nil
-	})
+	return results
+}
 
-	if err != nil {
-		return nil, fmt.Errorf("directory walk failed: %w", err)
+// placeTask fills in the fields that depend on where the task file lives.
+func (s *Scanner) placeTask(absRoot string, task *model.Task, archived bool) *model.Task {
+	// If task has a group field in frontmatter, use it
+	// Otherwise, derive group from directory structure
+	if task.Group == "" {
+		task.Group = deriveGroupFromPath(absRoot, task.FilePath)
+	}
+	if archived {
+		task.Archived = true
+		if task.Group == taskfile.ArchiveDirName {
+			task.Group = ""
+		}
 	}
 
 	if s.verbose {
-		fmt.Fprintf(os.Stderr, "Scan complete. Found %d tasks\n", len(result.Tasks))
+		fmt.Fprintf(os.Stderr, "Found task: %s - %s\n", task.ID, task.Title)
 	}
+	return task
+}
 
-	return result, nil
+func pathSet(paths []string) map[string]bool {
+	set := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		set[path] = true
+	}
+	return set
 }
 
 // shouldSkipDirectory determines if a directory should be skipped during scanning.
@@ -147,9 +257,28 @@ func (s *Scanner) shouldSkipDirectory(name string) bool {
 	if strings.HasPrefix(name, ".") {
 		return true
 	}
+	if name == taskfile.ArchiveDirName {
+		// Archived tasks are always read so their IDs are known.
+		return false
+	}
 	return s.ignoreDirs[name]
 }
 
+// isArchivedPath reports whether filePath lies inside an archive directory
+// below rootDir.
+func isArchivedPath(rootDir, filePath string) bool {
+	relPath, err := filepath.Rel(rootDir, filepath.Dir(filePath))
+	if err != nil {
+		return false
+	}
+	for _, part := range strings.Split(relPath, string(filepath.Separator)) {
+		if part == taskfile.ArchiveDirName {
+			return true
+		}
+	}
+	return false
+}
+
 // deriveGroupFromPath derives a group name from the file's directory path
 // relative to the root scan directory
 func deriveGroupFromPath(rootDir, filePath string) string {
@@ -1,9 +1,13 @@
 package scanner
 
 import (
+	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestScanner_Scan(t *testing.T) {
@@ -243,6 +247,51 @@ func TestScanner_DefaultSkipDirsStillApply(t *testing.T) {
 	}
 }
 
+func TestScanner_IncludeArchived(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	createTaskFile(t, tmpDir, "001")
+	createTaskFile(t, filepath.Join(tmpDir, "archive"), "002")
+	createTaskFile(t, filepath.Join(tmpDir, "archive", "web"), "003")
+
+	result, err := NewScanner(tmpDir, false, nil).Scan()
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(result.Tasks) != 1 {
+		t.Fatalf("Expected archived tasks to be skipped by default, got %d tasks", len(result.Tasks))
+	}
+	if len(result.ArchivedIDs) != 2 {
+		t.Errorf("Expected the archived IDs from the same scan, got %v", result.ArchivedIDs)
+	}
+
+	result, err = NewScanner(tmpDir, false, nil).IncludeArchived(true).Scan()
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(result.Tasks) != 3 {
+		t.Fatalf("Expected 3 tasks, got %d", len(result.Tasks))
+	}
+	for _, task := range result.Tasks {
+		wantArchived := task.ID != "001"
+		if task.Archived != wantArchived {
+			t.Errorf("task %s: Archived = %v, want %v", task.ID, task.Archived, wantArchived)
+		}
+	}
+	groups := map[string]string{}
+	for _, task := range result.Tasks {
+		groups[task.ID] = task.Group
+	}
+	if groups["002"] != "" || groups["003"] != "web" {
+		t.Errorf("expected groups from the path inside the archive, got %v", groups)
+	}
+
+	if len(result.ArchivedIDs) != 2 {
+		t.Errorf("Expected 2 archived IDs, got %v", result.ArchivedIDs)
+	}
+
+}
+
 func TestDeriveGroupFromPath(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -279,3 +328,59 @@ func TestDeriveGroupFromPath(t *testing.T) {
 		})
 	}
 }
+
+// BenchmarkScan compares parsing every file on one worker, parsing on the
+// worker pool, and scanning with a warm cache.
+func BenchmarkScan(b *testing.B) {
+	dir := b.TempDir()
+	body := strings.Repeat("Some description of the work to be done.\n", 20)
+	for i := range 1000 {
+		id := fmt.Sprintf("%04d", i)
+		content := fmt.Sprintf("---\nid: %q\ntitle: \"Task %s\"\nstatus: pending\npriority: high\n"+
+			"tags: [backend, api]\ndependencies: []\ncreated: 2024-01-01\n---\n# Task %s\n\n%s", id, id, id, body)
+		sub := filepath.Join(dir, fmt.Sprintf("group%d", i%10))
+		if err := os.MkdirAll(sub, 0755); err != nil {
+			b.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(sub, id+"-task.md"), []byte(content), 0644); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	// Age the files so the cached run trusts their stat data.
+	old := time.Now().Add(-time.Hour)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		return os.Chtimes(path, old, old)
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	run := func(b *testing.B, s *Scanner) {
+		b.Helper()
+		for b.Loop() {
+			if _, err := s.Scan(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+
+	b.Run("sequential", func(b *testing.B) {
+		s := NewScanner(dir, false, nil)
+		s.workers = 1
+		run(b, s)
+	})
+	b.Run("parallel", func(b *testing.B) {
+		run(b, NewScanner(dir, false, nil))
+	})
+	b.Run("cached", func(b *testing.B) {
+		s := NewScanner(dir, false, nil).UseCache(true)
+		if _, err := s.Scan(); err != nil {
+			b.Fatal(err)
+		}
+		run(b, s)
+	})
+}
@@ -31,6 +31,15 @@ type SourceConfig struct {
 	FieldMap  FieldMap          `yaml:"field_map"`
 	Filters   map[string]any    `yaml:"filters"`
 	Extra     map[string]string `yaml:"extra"`
+	// OnRemoteMissing is the policy for synced tasks the source no longer
+	// returns: keep (default), cancel, archive or delete.
+	OnRemoteMissing string `yaml:"on_remote_missing"`
+	// Path, Format and Columns configure the file source: the local file to
+	// read, its format (csv, json or todotxt) and a map from task fields to
+	// CSV headers or JSON keys.
+	Path    string            `yaml:"path"`
+	Format  string            `yaml:"format"`
+	Columns map[string]string `yaml:"columns"`
 }
 
 // FieldMap configures how external fields are mapped to taskmd frontmatter.
@@ -41,6 +50,28 @@ type FieldMap struct {
 	AssigneeToOwner bool              `yaml:"assignee_to_owner"`
 }
 
+// WithDefaults returns a copy of fm with status and priority entries from
+// defaults added wherever fm has no mapping for the same value.
+func (fm FieldMap) WithDefaults(defaults FieldMap) FieldMap {
+	fm.Status = mergeMapping(fm.Status, defaults.Status)
+	fm.Priority = mergeMapping(fm.Priority, defaults.Priority)
+	return fm
+}
+
+func mergeMapping(overrides, defaults map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return overrides
+	}
+	merged := make(map[string]string, len(defaults)+len(overrides))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
+
 // LoadConfig reads the project config from dir/.taskmd.yaml and returns the sync section.
 func LoadConfig(dir string) (*SyncConfig, error) {
 	path := filepath.Join(dir, configFileName)
@@ -67,6 +98,9 @@ func LoadConfig(dir string) (*SyncConfig, error) {
 		if s.OutputDir == "" {
 			return nil, fmt.Errorf("source %q has no output_dir", s.Name)
 		}
+		if !ValidMissingPolicy(s.OnRemoteMissing) {
+			return nil, fmt.Errorf("source %q has invalid on_remote_missing %q: must be keep, cancel, archive, or delete", s.Name, s.OnRemoteMissing)
+		}
 	}
 
 	return syncCfg, nil
@@ -3,6 +3,7 @@ package sync
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -135,9 +136,51 @@ func TestLoadConfig_MissingOutputDir(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_InvalidOnRemoteMissing(t *testing.T) {
+	dir := t.TempDir()
+	content := `sync:
+  sources:
+    - name: github
+      output_dir: tasks
+      on_remote_missing: purge
+`
+	writeFile(t, filepath.Join(dir, ".taskmd.yaml"), content)
+
+	_, err := LoadConfig(dir)
+	if err == nil || !strings.Contains(err.Error(), "on_remote_missing") {
+		t.Fatalf("expected on_remote_missing error, got %v", err)
+	}
+}
+
 func writeFile(t *testing.T, path, content string) {
 	t.Helper()
 	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
 		t.Fatalf("failed to write test file: %v", err)
 	}
 }
+
+func TestFieldMap_WithDefaults(t *testing.T) {
+	fm := FieldMap{
+		Status:       map[string]string{"started": "blocked"},
+		LabelsToTags: true,
+	}
+	defaults := FieldMap{
+		Status:   map[string]string{"started": "in-progress", "completed": "completed"},
+		Priority: map[string]string{"1": "critical"},
+	}
+
+	got := fm.WithDefaults(defaults)
+
+	if got.Status["started"] != "blocked" {
+		t.Errorf("expected config override to win, got %q", got.Status["started"])
+	}
+	if got.Status["completed"] != "completed" || got.Priority["1"] != "critical" {
+		t.Errorf("expected defaults to fill gaps, got %v %v", got.Status, got.Priority)
+	}
+	if !got.LabelsToTags {
+		t.Error("expected other settings to be preserved")
+	}
+	if len(fm.Status) != 1 {
+		t.Errorf("expected original map to be unchanged, got %v", fm.Status)
+	}
+}
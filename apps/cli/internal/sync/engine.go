@@ -4,10 +4,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/driangle/taskmd/apps/cli/internal/model"
 	"github.com/driangle/taskmd/apps/cli/internal/nextid"
+	"github.com/driangle/taskmd/apps/cli/internal/parser"
 	"github.com/driangle/taskmd/apps/cli/internal/scanner"
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
 )
 
 // ConflictStrategy controls how conflicts are resolved during sync.
@@ -15,6 +20,8 @@ const (
 	ConflictSkip   = "skip"   // default: skip conflicting tasks
 	ConflictRemote = "remote" // overwrite local with remote
 	ConflictLocal  = "local"  // keep local, update state hashes
+	// ConflictInteractive asks Engine.Resolve for each conflicting field.
+	ConflictInteractive = "interactive"
 )
 
 // Engine orchestrates syncing tasks from external sources.
@@ -23,6 +30,15 @@ type Engine struct {
 	Verbose          bool
 	DryRun           bool
 	ConflictStrategy string
+	// Push sends local edits back to sources that implement Pusher when
+	// only the local side changed since the last sync.
+	Push bool
+	// Resolve decides a single field conflict under ConflictInteractive and
+	// returns ConflictLocal, ConflictRemote or ConflictSkip. Nil skips.
+	Resolve func(action SyncAction, conflict FieldConflict) string
+	// Timestamps selects the status timestamps recorded when a sync changes
+	// a local task's status. Nil records none.
+	Timestamps *taskfile.TimestampConfig
 }
 
 // SyncAction describes a single sync operation.
@@ -32,6 +48,9 @@ type SyncAction struct {
 	FilePath   string
 	Title      string
 	Reason     string
+	// Conflicts lists the fields edited on both sides since the last sync,
+	// with how each was resolved.
+	Conflicts []FieldConflict
 }
 
 // SyncError describes an error during sync.
@@ -45,6 +64,8 @@ type SyncError struct {
 type SyncResult struct {
 	Created   []SyncAction
 	Updated   []SyncAction
+	Pushed    []SyncAction
+	Removed   []SyncAction
 	Skipped   []SyncAction
 	Conflicts []SyncAction
 	Errors    []SyncError
@@ -52,15 +73,11 @@ type SyncResult struct {
 
 // RunSync syncs tasks for a single source configuration.
 func (e *Engine) RunSync(srcCfg SourceConfig) (*SyncResult, error) {
-	src, err := GetSource(srcCfg.Name)
+	src, srcCfg, err := e.prepareSource(srcCfg)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := src.ValidateConfig(srcCfg); err != nil {
-		return nil, fmt.Errorf("invalid config for source %q: %w", srcCfg.Name, err)
-	}
-
 	externalTasks, err := src.FetchTasks(srcCfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch tasks from %q: %w", srcCfg.Name, err)
@@ -78,9 +95,10 @@ func (e *Engine) RunSync(srcCfg SourceConfig) (*SyncResult, error) {
 
 	result := &SyncResult{}
 	now := time.Now()
+	localIDs := assignLocalIDs(externalTasks, state, existingIDs)
 
 	for _, ext := range externalTasks {
-		action, syncErr := e.syncTask(ext, srcCfg, state, existingIDs, now)
+		action, syncErr := e.syncTask(ext, srcCfg, state, localIDs, now)
 		if syncErr != nil {
 			result.Errors = append(result.Errors, SyncError{
 				ExternalID: ext.ExternalID,
@@ -92,9 +110,10 @@ func (e *Engine) RunSync(srcCfg SourceConfig) (*SyncResult, error) {
 		switch action.Reason {
 		case "created":
 			result.Created = append(result.Created, action)
-			existingIDs = append(existingIDs, action.LocalID)
 		case "updated":
 			result.Updated = append(result.Updated, action)
+		case "pushed":
+			result.Pushed = append(result.Pushed, action)
 		case "conflict":
 			result.Conflicts = append(result.Conflicts, action)
 		default:
@@ -102,6 +121,8 @@ func (e *Engine) RunSync(srcCfg SourceConfig) (*SyncResult, error) {
 		}
 	}
 
+	e.handleMissing(externalTasks, srcCfg, state, result, now)
+
 	if !e.DryRun {
 		state.LastSync = now
 		if err := SaveState(e.ConfigDir, srcCfg.Name, state); err != nil {
@@ -112,24 +133,50 @@ func (e *Engine) RunSync(srcCfg SourceConfig) (*SyncResult, error) {
 	return result, nil
 }
 
+// prepareSource looks up and validates the source for srcCfg, merging any
+// source default field mappings under the configured ones.
+func (e *Engine) prepareSource(srcCfg SourceConfig) (Source, SourceConfig, error) {
+	src, err := GetSource(srcCfg.Name)
+	if err != nil {
+		return nil, srcCfg, err
+	}
+
+	if err := src.ValidateConfig(srcCfg); err != nil {
+		return nil, srcCfg, fmt.Errorf("invalid config for source %q: %w", srcCfg.Name, err)
+	}
+
+	if _, ok := src.(Pusher); e.Push && !ok {
+		return nil, srcCfg, fmt.Errorf("source %q does not support pushing changes", srcCfg.Name)
+	}
+
+	if dm, ok := src.(DefaultMapper); ok {
+		srcCfg.FieldMap = srcCfg.FieldMap.WithDefaults(dm.DefaultFieldMap())
+	}
+	return src, srcCfg, nil
+}
+
 func (e *Engine) syncTask(
 	ext ExternalTask,
 	srcCfg SourceConfig,
 	state *SyncState,
-	existingIDs []string,
+	localIDs map[string]string,
 	now time.Time,
 ) (SyncAction, error) {
 	mapped := MapExternalTask(ext, srcCfg.FieldMap)
+	mapped.Dependencies = resolveDependencies(externalDependencies(ext), localIDs)
+	mapped.Parent = localIDs[ext.Extra[ExtraParent]]
+	mapped.Group = ext.Extra[ExtraGroup]
+	mapped.Effort = validEffort(ext.Extra[ExtraEffort])
 	extHash := HashExternalTask(ext)
 	outputDir := srcCfg.OutputDir
 
 	ts, exists := state.Tasks[ext.ExternalID]
 
 	if !exists {
-		return e.createTask(ext, mapped, extHash, outputDir, srcCfg.Name, state, existingIDs, now)
+		return e.createTask(ext, mapped, extHash, outputDir, srcCfg.Name, state, localIDs[ext.ExternalID], now)
 	}
 
-	return e.updateTask(ext, mapped, extHash, ts, state, now)
+	return e.updateTask(ext, mapped, extHash, srcCfg, ts, state, now)
 }
 
 func (e *Engine) createTask(
@@ -137,12 +184,9 @@ func (e *Engine) createTask(
 	mapped MappedTask,
 	extHash, outputDir, sourceName string,
 	state *SyncState,
-	existingIDs []string,
+	newID string,
 	now time.Time,
 ) (SyncAction, error) {
-	nextResult := nextid.Calculate(existingIDs)
-	newID := nextResult.NextID
-
 	action := SyncAction{
 		ExternalID: ext.ExternalID,
 		LocalID:    newID,
@@ -165,6 +209,7 @@ func (e *Engine) createTask(
 	}
 
 	action.FilePath = filePath
+	fields := fieldValuesFromMapped(mapped)
 
 	state.Tasks[ext.ExternalID] = TaskState{
 		ExternalID:   ext.ExternalID,
@@ -172,16 +217,80 @@ func (e *Engine) createTask(
 		FilePath:     filePath,
 		ExternalHash: extHash,
 		LocalHash:    localHash,
+		Fields:       &fields,
 		LastSynced:   now,
 	}
 
 	return action, nil
 }
 
+// assignLocalIDs maps every external ID to its local task ID. Tasks not yet
+// tracked in state are allocated new IDs up front so that dependencies can be
+// resolved before any file is written.
+func assignLocalIDs(externalTasks []ExternalTask, state *SyncState, existingIDs []string) map[string]string {
+	localIDs := make(map[string]string, len(state.Tasks)+len(externalTasks))
+	for extID, ts := range state.Tasks {
+		localIDs[extID] = ts.LocalID
+	}
+
+	taken := append([]string{}, existingIDs...)
+	for _, ext := range externalTasks {
+		if _, ok := localIDs[ext.ExternalID]; ok {
+			continue
+		}
+		id := nextid.Calculate(taken).NextID
+		localIDs[ext.ExternalID] = id
+		taken = append(taken, id)
+	}
+	return localIDs
+}
+
+// externalDependencies combines ext.Dependencies with the IDs listed under
+// ExtraDependsOn. It returns nil when the source reports neither.
+func externalDependencies(ext ExternalTask) []string {
+	listed, ok := ext.Extra[ExtraDependsOn]
+	if !ok {
+		return ext.Dependencies
+	}
+	deps := append([]string{}, ext.Dependencies...)
+	for _, id := range strings.Split(listed, ",") {
+		if id = strings.TrimSpace(id); id != "" && !slices.Contains(deps, id) {
+			deps = append(deps, id)
+		}
+	}
+	return deps
+}
+
+// validEffort returns effort if it is a taskmd effort value, otherwise "".
+func validEffort(effort string) string {
+	switch model.Effort(effort) {
+	case model.EffortSmall, model.EffortMedium, model.EffortLarge:
+		return effort
+	}
+	return ""
+}
+
+// resolveDependencies translates external dependency IDs into local task IDs.
+// Dependencies on tasks this source has never synced are dropped. A nil input
+// (source does not report dependencies) stays nil.
+func resolveDependencies(extDeps []string, localIDs map[string]string) []string {
+	if extDeps == nil {
+		return nil
+	}
+	deps := []string{}
+	for _, extID := range extDeps {
+		if id, ok := localIDs[extID]; ok {
+			deps = append(deps, id)
+		}
+	}
+	return deps
+}
+
 func (e *Engine) updateTask(
 	ext ExternalTask,
 	mapped MappedTask,
 	extHash string,
+	srcCfg SourceConfig,
 	ts TaskState,
 	state *SyncState,
 	now time.Time,
@@ -208,11 +317,25 @@ func (e *Engine) updateTask(
 		return action, nil
 	}
 
-	if localChanged {
-		return e.resolveConflict(action, ext, mapped, extHash, ts, state, now)
+	remote := fieldValuesFromMapped(mapped)
+	if ts.Fields == nil {
+		// State written before field-level merging: fall back to whole-task
+		// handling unless one side is known to be unchanged.
+		if !localChanged {
+			return e.applyExternalUpdate(action, ext, mapped, extHash, ts, state, now)
+		}
+		if extChanged {
+			return e.resolveConflict(action, ext, mapped, extHash, ts, state, now)
+		}
+		ts.Fields = &remote
 	}
 
-	return e.applyExternalUpdate(action, ext, mapped, extHash, ts, state, now)
+	local, err := parser.ParseTaskFile(ts.FilePath)
+	if err != nil {
+		return SyncAction{}, fmt.Errorf("failed to parse local file: %w", err)
+	}
+
+	return e.mergeTask(action, ext, extHash, srcCfg, ts, *ts.Fields, fieldValuesFromTask(local), remote, state, now)
 }
 
 func (e *Engine) resolveConflict(
@@ -228,7 +351,7 @@ func (e *Engine) resolveConflict(
 	case ConflictRemote:
 		return e.applyExternalUpdate(action, ext, mapped, extHash, ts, state, now)
 	case ConflictLocal:
-		return e.acceptLocal(action, extHash, ts, state, now)
+		return e.acceptLocal(action, mapped, extHash, ts, state, now)
 	default:
 		action.Reason = "conflict"
 		return action, nil
@@ -237,6 +360,7 @@ func (e *Engine) resolveConflict(
 
 func (e *Engine) acceptLocal(
 	action SyncAction,
+	mapped MappedTask,
 	extHash string,
 	ts TaskState,
 	state *SyncState,
@@ -251,9 +375,11 @@ func (e *Engine) acceptLocal(
 	if err != nil {
 		return SyncAction{}, fmt.Errorf("failed to hash local file: %w", err)
 	}
+	fields := fieldValuesFromMapped(mapped)
 
 	ts.ExternalHash = extHash
 	ts.LocalHash = localHash
+	ts.Fields = &fields
 	ts.LastSynced = now
 	state.Tasks[action.ExternalID] = ts
 
@@ -283,10 +409,12 @@ func (e *Engine) recreateMissingFile(
 	if err != nil {
 		return SyncAction{}, err
 	}
+	fields := fieldValuesFromMapped(mapped)
 
 	ts.FilePath = filePath
 	ts.ExternalHash = extHash
 	ts.LocalHash = localHash
+	ts.Fields = &fields
 	ts.LastSynced = now
 	state.Tasks[ext.ExternalID] = ts
 
@@ -307,7 +435,7 @@ func (e *Engine) applyExternalUpdate(
 		return action, nil
 	}
 
-	if err := UpdateSyncedTaskFile(ts.FilePath, mapped); err != nil {
+	if err := UpdateSyncedTaskFile(ts.FilePath, mapped, e.Timestamps); err != nil {
 		return SyncAction{}, fmt.Errorf("failed to update task file: %w", err)
 	}
 
@@ -315,9 +443,11 @@ func (e *Engine) applyExternalUpdate(
 	if err != nil {
 		return SyncAction{}, fmt.Errorf("failed to hash updated file: %w", err)
 	}
+	fields := fieldValuesFromMapped(mapped)
 
 	ts.ExternalHash = extHash
 	ts.LocalHash = localHash
+	ts.Fields = &fields
 	ts.LastSynced = now
 	state.Tasks[ext.ExternalID] = ts
 
@@ -343,9 +473,10 @@ func (e *Engine) scanExistingIDs(outputDir string) ([]string, error) {
 		return nil, err
 	}
 
-	ids := make([]string, len(result.Tasks))
-	for i, t := range result.Tasks {
-		ids[i] = t.ID
+	// Archived IDs stay taken so an unarchived task never clashes.
+	ids := make([]string, 0, len(result.Tasks)+len(result.ArchivedIDs))
+	for _, t := range result.Tasks {
+		ids = append(ids, t.ID)
 	}
-	return ids, nil
+	return append(ids, result.ArchivedIDs...), nil
 }
@@ -181,6 +181,46 @@ func TestEngine_UpdateOnExternalChange(t *testing.T) {
 	}
 }
 
+func TestEngine_UpdateOnExternalEffortChange(t *testing.T) {
+	sourceName := "test-update-effort"
+	defer cleanupRegistry(sourceName)
+
+	mock := &mockSource{
+		name: sourceName,
+		tasks: []ExternalTask{
+			{ExternalID: "EXT-1", Title: "Task", Status: "open", Extra: map[string]string{ExtraEffort: "small"}},
+		},
+	}
+	Register(mock)
+
+	dir := t.TempDir()
+	engine := &Engine{ConfigDir: dir}
+	srcCfg := SourceConfig{Name: sourceName, OutputDir: filepath.Join(dir, "tasks")}
+
+	first, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("first sync error: %v", err)
+	}
+
+	// Only the effort (e.g. a GitLab weight) changes remotely.
+	mock.tasks[0].Extra = map[string]string{ExtraEffort: "large"}
+	result, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("second sync error: %v", err)
+	}
+	if len(result.Updated) != 1 {
+		t.Fatalf("expected 1 updated, got %d", len(result.Updated))
+	}
+
+	content, err := os.ReadFile(first.Created[0].FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "effort: large") {
+		t.Errorf("expected the new effort to be written, got:\n%s", content)
+	}
+}
+
 func TestEngine_ConflictOnLocalChange(t *testing.T) {
 	sourceName := "test-conflict"
 	defer cleanupRegistry(sourceName)
@@ -196,7 +236,7 @@ func TestEngine_ConflictOnLocalChange(t *testing.T) {
 	srcCfg := SourceConfig{
 		Name:      sourceName,
 		OutputDir: outputDir,
-		FieldMap:  FieldMap{Status: map[string]string{"open": "pending"}},
+		FieldMap:  FieldMap{Status: map[string]string{"open": "pending", "closed": "completed"}},
 	}
 
 	// First sync creates the file
@@ -208,7 +248,10 @@ func TestEngine_ConflictOnLocalChange(t *testing.T) {
 		t.Fatalf("expected 1 created, got %d", len(result1.Created))
 	}
 
-	// Modify the local file
+	// Change the status on both sides
+	setupMockSource(sourceName, []ExternalTask{
+		{ExternalID: "EXT-1", Title: "A task", Status: "closed"},
+	})
 	filePath := result1.Created[0].FilePath
 	data, err := os.ReadFile(filePath)
 	if err != nil {
@@ -245,7 +288,7 @@ func TestEngine_ConflictRemote(t *testing.T) {
 	srcCfg := SourceConfig{
 		Name:      sourceName,
 		OutputDir: outputDir,
-		FieldMap:  FieldMap{Status: map[string]string{"open": "pending"}},
+		FieldMap:  FieldMap{Status: map[string]string{"open": "pending", "closed": "completed"}},
 	}
 
 	// First sync creates the file
@@ -255,7 +298,10 @@ func TestEngine_ConflictRemote(t *testing.T) {
 	}
 	filePath := result1.Created[0].FilePath
 
-	// Modify the local file
+	// Change the status on both sides
+	setupMockSource(sourceName, []ExternalTask{
+		{ExternalID: "EXT-1", Title: "A task", Status: "closed"},
+	})
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		t.Fatalf("failed to read file: %v", err)
@@ -302,7 +348,7 @@ func TestEngine_ConflictLocal(t *testing.T) {
 	srcCfg := SourceConfig{
 		Name:      sourceName,
 		OutputDir: outputDir,
-		FieldMap:  FieldMap{Status: map[string]string{"open": "pending"}},
+		FieldMap:  FieldMap{Status: map[string]string{"open": "pending", "closed": "completed"}},
 	}
 
 	// First sync creates the file
@@ -312,7 +358,10 @@ func TestEngine_ConflictLocal(t *testing.T) {
 	}
 	filePath := result1.Created[0].FilePath
 
-	// Modify the local file
+	// Change the status on both sides
+	setupMockSource(sourceName, []ExternalTask{
+		{ExternalID: "EXT-1", Title: "A task", Status: "closed"},
+	})
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		t.Fatalf("failed to read file: %v", err)
@@ -455,6 +504,247 @@ func TestEngine_MultipleTasksGetSequentialIDs(t *testing.T) {
 	}
 }
 
+func TestEngine_SkipsArchivedIDs(t *testing.T) {
+	sourceName := "test-archived-ids"
+	defer cleanupRegistry(sourceName)
+
+	setupMockSource(sourceName, []ExternalTask{{ExternalID: "EXT-1", Title: "First", Status: "open"}})
+
+	dir := t.TempDir()
+	outputDir := filepath.Join(dir, "tasks")
+	archived := "---\nid: \"007\"\ntitle: Old\nstatus: completed\n---\n"
+	if err := os.MkdirAll(filepath.Join(outputDir, "archive"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "archive", "007-old.md"), []byte(archived), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := &Engine{ConfigDir: dir}
+	result, err := engine.RunSync(SourceConfig{Name: sourceName, OutputDir: outputDir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Created) != 1 || result.Created[0].LocalID != "008" {
+		t.Errorf("expected the new task to get 008 after archived 007, got %+v", result.Created)
+	}
+}
+
+func TestEngine_ResolvesDependencies(t *testing.T) {
+	sourceName := "test-deps"
+	defer cleanupRegistry(sourceName)
+
+	// The dependent task comes first, so its blocker's ID must be allocated up front.
+	src := &mockSource{name: sourceName, tasks: []ExternalTask{
+		{ExternalID: "EXT-2", Title: "Deploy", Status: "open", Dependencies: []string{"EXT-1", "UNKNOWN-9"}},
+		{ExternalID: "EXT-1", Title: "Build", Status: "open", Dependencies: []string{}},
+	}}
+	Register(src)
+
+	dir := t.TempDir()
+	engine := &Engine{ConfigDir: dir}
+	srcCfg := SourceConfig{Name: sourceName, OutputDir: filepath.Join(dir, "tasks")}
+
+	result, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Created) != 2 {
+		t.Fatalf("expected 2 created, got %d", len(result.Created))
+	}
+
+	deploy, build := result.Created[0], result.Created[1]
+	content, err := os.ReadFile(deploy.FilePath)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	want := `dependencies: ["` + build.LocalID + `"]`
+	if !strings.Contains(string(content), want) {
+		t.Errorf("expected %q in file, got:\n%s", want, content)
+	}
+
+	// Removing the link remotely clears the local dependency.
+	src.tasks[0].Dependencies = []string{}
+	if _, err := engine.RunSync(srcCfg); err != nil {
+		t.Fatalf("unexpected error on second sync: %v", err)
+	}
+	content, _ = os.ReadFile(deploy.FilePath)
+	if strings.Contains(string(content), build.LocalID+`"]`) {
+		t.Errorf("expected dependency to be cleared, got:\n%s", content)
+	}
+}
+
+func TestEngine_MapsRelationsFromExtra(t *testing.T) {
+	sourceName := "test-relations"
+	defer cleanupRegistry(sourceName)
+
+	setupMockSource(sourceName, []ExternalTask{
+		{ExternalID: "12", Title: "Child", Status: "open", Extra: map[string]string{
+			ExtraParent:    "10",
+			ExtraDependsOn: "11, 99",
+			ExtraGroup:     "v1.0",
+		}},
+		{ExternalID: "10", Title: "Epic", Status: "open"},
+		{ExternalID: "11", Title: "Blocker", Status: "open"},
+	})
+
+	dir := t.TempDir()
+	engine := &Engine{ConfigDir: dir}
+	result, err := engine.RunSync(SourceConfig{Name: sourceName, OutputDir: filepath.Join(dir, "tasks")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Created) != 3 {
+		t.Fatalf("expected 3 created, got %d", len(result.Created))
+	}
+
+	child, epic, blocker := result.Created[0], result.Created[1], result.Created[2]
+	content, err := os.ReadFile(child.FilePath)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	for _, want := range []string{
+		`dependencies: ["` + blocker.LocalID + `"]`,
+		`parent: "` + epic.LocalID + `"`,
+		`group: "v1.0"`,
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("expected %q in file, got:\n%s", want, content)
+		}
+	}
+}
+
+// pushMockSource is a mockSource that records pushed tasks.
+type pushMockSource struct {
+	mockSource
+	pushed []ExternalTask
+}
+
+func (m *pushMockSource) PushTask(_ SourceConfig, task ExternalTask) error {
+	m.pushed = append(m.pushed, task)
+	return nil
+}
+
+// editLocalFile replaces old with new in the file at path.
+func editLocalFile(t *testing.T, path, old, new string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(strings.Replace(string(data), old, new, 1)), 0644); err != nil {
+		t.Fatalf("failed to modify file: %v", err)
+	}
+}
+
+func TestEngine_PushLocalChanges(t *testing.T) {
+	sourceName := "test-push"
+	defer cleanupRegistry(sourceName)
+
+	src := &pushMockSource{mockSource: mockSource{name: sourceName, tasks: []ExternalTask{
+		{ExternalID: "EXT-1", Title: "A task", Status: "open", Labels: []string{"bug"}, Assignee: "alice"},
+	}}}
+	Register(src)
+
+	dir := t.TempDir()
+	engine := &Engine{ConfigDir: dir, Push: true}
+	srcCfg := SourceConfig{
+		Name:      sourceName,
+		OutputDir: filepath.Join(dir, "tasks"),
+		FieldMap: FieldMap{
+			Status:          map[string]string{"open": "pending", "closed": "completed"},
+			LabelsToTags:    true,
+			AssigneeToOwner: true,
+		},
+	}
+
+	result1, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("first sync error: %v", err)
+	}
+	filePath := result1.Created[0].FilePath
+
+	editLocalFile(t, filePath, "status: pending", "status: completed")
+	editLocalFile(t, filePath, `"A task"`, `"A renamed task"`)
+	editLocalFile(t, filePath, `["bug"]`, `["bug", "ui"]`)
+	editLocalFile(t, filePath, "owner: alice", "owner: bob")
+
+	result2, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("push sync error: %v", err)
+	}
+	if len(result2.Pushed) != 1 || len(result2.Conflicts) != 0 {
+		t.Fatalf("expected 1 pushed and no conflicts, got pushed=%d conflicts=%d", len(result2.Pushed), len(result2.Conflicts))
+	}
+	if len(src.pushed) != 1 {
+		t.Fatalf("expected 1 push, got %d", len(src.pushed))
+	}
+
+	got := src.pushed[0]
+	if got.ExternalID != "EXT-1" || got.Title != "A renamed task" || got.Status != "closed" || got.Assignee != "bob" {
+		t.Errorf("unexpected pushed task: %+v", got)
+	}
+	if strings.Join(got.Labels, ",") != "bug,ui" {
+		t.Errorf("expected labels [bug ui], got %v", got.Labels)
+	}
+
+	// The remote now reflects the push; the next sync has nothing to do.
+	src.tasks = []ExternalTask{got}
+	result3, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("third sync error: %v", err)
+	}
+	if len(result3.Skipped) != 1 || len(src.pushed) != 1 {
+		t.Errorf("expected 1 skipped and no new push, got skipped=%d pushes=%d", len(result3.Skipped), len(src.pushed))
+	}
+}
+
+func TestEngine_PushTrueConflict(t *testing.T) {
+	sourceName := "test-push-conflict"
+	defer cleanupRegistry(sourceName)
+
+	src := &pushMockSource{mockSource: mockSource{name: sourceName, tasks: []ExternalTask{
+		{ExternalID: "EXT-1", Title: "A task", Status: "open"},
+	}}}
+	Register(src)
+
+	dir := t.TempDir()
+	engine := &Engine{ConfigDir: dir, Push: true}
+	srcCfg := SourceConfig{
+		Name:      sourceName,
+		OutputDir: filepath.Join(dir, "tasks"),
+		FieldMap:  FieldMap{Status: map[string]string{"open": "pending", "closed": "completed"}},
+	}
+
+	result1, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("first sync error: %v", err)
+	}
+
+	editLocalFile(t, result1.Created[0].FilePath, "status: pending", "status: in-progress")
+	src.tasks[0].Status = "closed"
+
+	result2, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("second sync error: %v", err)
+	}
+	if len(result2.Conflicts) != 1 || len(src.pushed) != 0 {
+		t.Errorf("expected a conflict and no push, got conflicts=%d pushes=%d", len(result2.Conflicts), len(src.pushed))
+	}
+}
+
+func TestEngine_PushUnsupportedSource(t *testing.T) {
+	sourceName := "test-push-unsupported"
+	defer cleanupRegistry(sourceName)
+	setupMockSource(sourceName, nil)
+
+	engine := &Engine{ConfigDir: t.TempDir(), Push: true}
+	_, err := engine.RunSync(SourceConfig{Name: sourceName, OutputDir: "tasks"})
+	if err == nil || !strings.Contains(err.Error(), "does not support pushing") {
+		t.Fatalf("expected unsupported push error, got %v", err)
+	}
+}
+
 func TestHashExternalTask_Deterministic(t *testing.T) {
 	ext := ExternalTask{
 		ExternalID:  "1",
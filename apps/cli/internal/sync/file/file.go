@@ -0,0 +1,232 @@
+package file
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/driangle/taskmd/apps/cli/internal/sync"
+)
+
+// Supported file formats.
+const (
+	FormatCSV     = "csv"
+	FormatJSON    = "json"
+	FormatTodoTxt = "todotxt"
+)
+
+// FileSource imports tasks from a local CSV, JSON or todo.txt file. Each
+// record needs a stable id so that re-importing an edited file updates the
+// tasks it created instead of duplicating them.
+type FileSource struct{}
+
+func init() {
+	sync.Register(&FileSource{})
+}
+
+func (f *FileSource) Name() string { return "file" }
+
+func (f *FileSource) ValidateConfig(cfg sync.SourceConfig) error {
+	if cfg.Path == "" {
+		return fmt.Errorf("path is required")
+	}
+	if _, err := resolveFormat(cfg); err != nil {
+		return err
+	}
+	for field := range cfg.Columns {
+		if !isField(field) {
+			return fmt.Errorf("unknown column field %q (valid: %s)", field, strings.Join(fieldNames(), ", "))
+		}
+	}
+	return nil
+}
+
+// DefaultFieldMap accepts taskmd's own statuses and priorities as-is, plus
+// common alternatives and todo.txt priority letters.
+func (f *FileSource) DefaultFieldMap() sync.FieldMap {
+	return sync.FieldMap{
+		Status: map[string]string{
+			"pending":     "pending",
+			"in-progress": "in-progress",
+			"completed":   "completed",
+			"blocked":     "blocked",
+			"cancelled":   "cancelled",
+			"todo":        "pending",
+			"doing":       "in-progress",
+			"done":        "completed",
+		},
+		Priority: map[string]string{
+			"critical": "critical",
+			"high":     "high",
+			"medium":   "medium",
+			"low":      "low",
+			"A":        "critical",
+			"B":        "high",
+			"C":        "medium",
+			"D":        "low",
+		},
+	}
+}
+
+func (f *FileSource) FetchTasks(cfg sync.SourceConfig) ([]sync.ExternalTask, error) {
+	format, err := resolveFormat(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(cfg.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", cfg.Path, err)
+	}
+
+	var records []record
+	switch format {
+	case FormatCSV:
+		records, err = parseCSV(data, cfg.Columns)
+	case FormatJSON:
+		records, err = parseJSON(data, cfg.Columns)
+	case FormatTodoTxt:
+		records = parseTodoTxt(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", cfg.Path, err)
+	}
+
+	tasks := make([]sync.ExternalTask, 0, len(records))
+	seen := make(map[string]bool, len(records))
+	for i, rec := range records {
+		task := rec.toExternalTask()
+		if task.ExternalID == "" {
+			// Falling back to the title would turn a renamed record into a
+			// new task, so every record needs a stable id.
+			return nil, fmt.Errorf("record %d in %s has no id", i+1, cfg.Path)
+		}
+		if seen[task.ExternalID] {
+			return nil, fmt.Errorf("duplicate id %q in %s", task.ExternalID, cfg.Path)
+		}
+		seen[task.ExternalID] = true
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// resolveFormat returns the configured format, or infers it from the file
+// extension.
+func resolveFormat(cfg sync.SourceConfig) (string, error) {
+	if cfg.Format != "" {
+		switch cfg.Format {
+		case FormatCSV, FormatJSON, FormatTodoTxt:
+			return cfg.Format, nil
+		}
+		return "", fmt.Errorf("unsupported format %q (valid: csv, json, todotxt)", cfg.Format)
+	}
+
+	switch strings.ToLower(filepath.Ext(cfg.Path)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".json":
+		return FormatJSON, nil
+	case ".txt":
+		return FormatTodoTxt, nil
+	}
+	return "", fmt.Errorf("cannot infer format from %q; set format to csv, json or todotxt", cfg.Path)
+}
+
+// record holds one imported task keyed by field name (see fields).
+type record map[string]string
+
+// fields lists the task fields a record can carry, with the header or key
+// aliases recognized when no column mapping is configured.
+var fields = map[string][]string{
+	"id":           {"id", "key"},
+	"title":        {"title", "name", "summary"},
+	"description":  {"description", "body"},
+	"status":       {"status", "state"},
+	"priority":     {"priority"},
+	"assignee":     {"assignee", "owner"},
+	"labels":       {"labels", "tags"},
+	"dependencies": {"dependencies", "depends_on"},
+	"effort":       {"effort"},
+	"group":        {"group"},
+	"parent":       {"parent"},
+	"url":          {"url"},
+}
+
+func isField(name string) bool {
+	_, ok := fields[name]
+	return ok
+}
+
+func fieldNames() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// keyFor returns the field that a header or key feeds. Configured columns
+// win; other headers fall back to the default aliases (case-insensitive) for
+// fields the mapping leaves out.
+func keyFor(header string, columns map[string]string) string {
+	for field, col := range columns {
+		if col == header {
+			return field
+		}
+	}
+	h := strings.ToLower(strings.TrimSpace(header))
+	for field, aliases := range fields {
+		if _, mapped := columns[field]; mapped {
+			continue
+		}
+		for _, a := range aliases {
+			if h == a {
+				return field
+			}
+		}
+	}
+	return ""
+}
+
+func (r record) toExternalTask() sync.ExternalTask {
+	task := sync.ExternalTask{
+		ExternalID:  r["id"],
+		Title:       r["title"],
+		Description: r["description"],
+		Status:      r["status"],
+		Priority:    r["priority"],
+		Assignee:    r["assignee"],
+		Labels:      splitList(r["labels"]),
+		URL:         r["url"],
+		Extra:       map[string]string{},
+	}
+
+	if deps, ok := r["dependencies"]; ok {
+		task.Dependencies = splitList(deps)
+		if task.Dependencies == nil {
+			task.Dependencies = []string{}
+		}
+	}
+
+	for field, key := range map[string]string{"effort": sync.ExtraEffort, "group": sync.ExtraGroup, "parent": sync.ExtraParent} {
+		if v := r[field]; v != "" {
+			task.Extra[key] = v
+		}
+	}
+
+	return task
+}
+
+// splitList splits a comma-, semicolon- or pipe-separated cell.
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ';' || r == '|' }) {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
@@ -0,0 +1,252 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/driangle/taskmd/apps/cli/internal/sync"
+)
+
+func writeInput(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write input: %v", err)
+	}
+	return path
+}
+
+func TestFileSource_Name(t *testing.T) {
+	src := &FileSource{}
+	if src.Name() != "file" {
+		t.Fatalf("expected name %q, got %q", "file", src.Name())
+	}
+}
+
+func TestFileSource_ValidateConfig(t *testing.T) {
+	src := &FileSource{}
+
+	if err := src.ValidateConfig(sync.SourceConfig{Path: "tasks.csv"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := src.ValidateConfig(sync.SourceConfig{Path: "tasks.dat", Format: "json"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := src.ValidateConfig(sync.SourceConfig{}); err == nil {
+		t.Error("expected error for missing path")
+	}
+	if err := src.ValidateConfig(sync.SourceConfig{Path: "tasks.dat"}); err == nil {
+		t.Error("expected error for unknown extension")
+	}
+	if err := src.ValidateConfig(sync.SourceConfig{Path: "tasks.csv", Format: "xml"}); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+	if err := src.ValidateConfig(sync.SourceConfig{Path: "tasks.csv", Columns: map[string]string{"colour": "Colour"}}); err == nil {
+		t.Error("expected error for unknown column field")
+	}
+}
+
+func TestFileSource_FetchTasks_CSV(t *testing.T) {
+	path := writeInput(t, "tasks.csv", "id,title,status,priority,owner,tags,depends_on,effort,notes\n"+
+		"1,Set up CI,done,high,alice,\"infra, ci\",,small,ignored\n"+
+		"2,Write docs,todo,,,,1,,\n"+
+		",,,,,,,,\n")
+
+	tasks, err := (&FileSource{}).FetchTasks(sync.SourceConfig{Path: path})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(tasks))
+	}
+
+	first := tasks[0]
+	if first.ExternalID != "1" || first.Title != "Set up CI" || first.Status != "done" || first.Priority != "high" {
+		t.Errorf("unexpected task: %+v", first)
+	}
+	if first.Assignee != "alice" || strings.Join(first.Labels, "|") != "infra|ci" {
+		t.Errorf("unexpected assignee/labels: %q %v", first.Assignee, first.Labels)
+	}
+	if first.Extra[sync.ExtraEffort] != "small" {
+		t.Errorf("expected effort small, got %q", first.Extra[sync.ExtraEffort])
+	}
+	if first.Dependencies == nil || len(first.Dependencies) != 0 {
+		t.Errorf("expected empty non-nil dependencies, got %#v", first.Dependencies)
+	}
+	if strings.Join(tasks[1].Dependencies, ",") != "1" {
+		t.Errorf("expected dependency on 1, got %v", tasks[1].Dependencies)
+	}
+}
+
+func TestFileSource_FetchTasks_CSVColumns(t *testing.T) {
+	path := writeInput(t, "export.csv", "Key,Summary,State,Title\nABC-1,Real title,todo,Other\n")
+
+	tasks, err := (&FileSource{}).FetchTasks(sync.SourceConfig{
+		Path:    path,
+		Columns: map[string]string{"id": "Key", "title": "Summary"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("expected 1 task, got %d", len(tasks))
+	}
+	if tasks[0].ExternalID != "ABC-1" || tasks[0].Title != "Real title" || tasks[0].Status != "todo" {
+		t.Errorf("unexpected task: %+v", tasks[0])
+	}
+}
+
+func TestFileSource_FetchTasks_JSON(t *testing.T) {
+	path := writeInput(t, "tasks.json", `[
+  {"id": 7, "title": "Ship it", "status": "in-progress", "tags": ["release", "v2"], "group": "Launch", "parent": 3},
+  {"id": "8", "title": "Bare"}
+]`)
+
+	tasks, err := (&FileSource{}).FetchTasks(sync.SourceConfig{Path: path})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(tasks))
+	}
+	if tasks[0].ExternalID != "7" || strings.Join(tasks[0].Labels, ",") != "release,v2" {
+		t.Errorf("unexpected task: %+v", tasks[0])
+	}
+	if tasks[0].Extra[sync.ExtraGroup] != "Launch" || tasks[0].Extra[sync.ExtraParent] != "3" {
+		t.Errorf("unexpected extra: %v", tasks[0].Extra)
+	}
+	if tasks[1].Dependencies != nil {
+		t.Errorf("expected nil dependencies when the key is absent, got %v", tasks[1].Dependencies)
+	}
+}
+
+func TestFileSource_FetchTasks_DuplicateID(t *testing.T) {
+	path := writeInput(t, "tasks.json", `[{"id": "a", "title": "One"}, {"id": "a", "title": "Two"}]`)
+
+	if _, err := (&FileSource{}).FetchTasks(sync.SourceConfig{Path: path}); err == nil {
+		t.Fatal("expected error for duplicate id")
+	}
+}
+
+func TestFileSource_FetchTasks_MissingID(t *testing.T) {
+	path := writeInput(t, "tasks.csv", "id,title\n1,Has id\n,No id\n")
+
+	_, err := (&FileSource{}).FetchTasks(sync.SourceConfig{Path: path})
+	if err == nil || !strings.Contains(err.Error(), "record 2") || !strings.Contains(err.Error(), "no id") {
+		t.Fatalf("expected error for the record without an id, got %v", err)
+	}
+}
+
+func TestParseTodoLine(t *testing.T) {
+	rec := parseTodoLine("x 2026-01-02 2026-01-01 (A) Call mom +Family @phone id:call dep:a,b due:2026-02-01")
+
+	want := record{
+		"status":       "done",
+		"priority":     "A",
+		"title":        "Call mom due:2026-02-01",
+		"labels":       "Family,phone",
+		"group":        "Family",
+		"id":           "call",
+		"dependencies": "a,b",
+	}
+	for k, v := range want {
+		if rec[k] != v {
+			t.Errorf("%s = %q, want %q", k, rec[k], v)
+		}
+	}
+
+	rec = parseTodoLine("(B) 2026-01-01 Plan trip")
+	if rec["status"] != "todo" || rec["priority"] != "B" || rec["title"] != "Plan trip" {
+		t.Errorf("unexpected record: %v", rec)
+	}
+}
+
+func TestFileSource_ReimportUpdatesTasks(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "todo.txt")
+	write := func(content string) {
+		if err := os.WriteFile(input, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write input: %v", err)
+		}
+	}
+	write("(A) Pay rent id:rent\nBuy milk id:milk dep:rent\n")
+
+	engine := &sync.Engine{ConfigDir: dir}
+	srcCfg := sync.SourceConfig{Name: "file", Path: input, OutputDir: filepath.Join(dir, "tasks")}
+
+	result, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("first import error: %v", err)
+	}
+	if len(result.Created) != 2 {
+		t.Fatalf("expected 2 created, got %d", len(result.Created))
+	}
+
+	content, err := os.ReadFile(result.Created[0].FilePath)
+	if err != nil {
+		t.Fatalf("failed to read task: %v", err)
+	}
+	if !strings.Contains(string(content), "priority: critical") {
+		t.Errorf("expected mapped priority, got:\n%s", content)
+	}
+
+	write("x (A) Pay rent id:rent\nBuy milk id:milk dep:rent\n")
+	result, err = engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("re-import error: %v", err)
+	}
+	if len(result.Created) != 0 || len(result.Updated) != 1 || len(result.Skipped) != 1 {
+		t.Fatalf("expected 1 updated and 1 skipped, got created=%d updated=%d skipped=%d",
+			len(result.Created), len(result.Updated), len(result.Skipped))
+	}
+
+	entries, err := os.ReadDir(srcCfg.OutputDir)
+	if err != nil {
+		t.Fatalf("failed to read output dir: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("expected 2 task files, got %d", len(entries))
+	}
+}
+
+func TestFileSource_ReimportUpdatesEffort(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "tasks.csv")
+	write := func(content string) {
+		if err := os.WriteFile(input, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write input: %v", err)
+		}
+	}
+	write("id,title,effort\n1,Set up CI,small\n")
+
+	engine := &sync.Engine{ConfigDir: dir}
+	srcCfg := sync.SourceConfig{Name: "file", Path: input, OutputDir: filepath.Join(dir, "tasks")}
+
+	result, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("first import error: %v", err)
+	}
+	if len(result.Created) != 1 {
+		t.Fatalf("expected 1 created, got %d", len(result.Created))
+	}
+	path := result.Created[0].FilePath
+
+	write("id,title,effort\n1,Set up CI,large\n")
+	result, err = engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("re-import error: %v", err)
+	}
+	if len(result.Updated) != 1 {
+		t.Fatalf("expected the effort change to update the task, got updated=%d", len(result.Updated))
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read task: %v", err)
+	}
+	if !strings.Contains(string(content), "effort: large") {
+		t.Errorf("expected the new effort, got:\n%s", content)
+	}
+}
@@ -0,0 +1,147 @@
+package file
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// parseCSV reads a CSV file with a header row. Columns not mapped to a task
+// field are ignored.
+func parseCSV(data []byte, columns map[string]string) ([]record, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	r.FieldsPerRecord = -1
+
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	header := rows[0]
+	keys := make([]string, len(header))
+	for i, h := range header {
+		keys[i] = keyFor(h, columns)
+	}
+
+	var records []record
+	for _, row := range rows[1:] {
+		rec := record{}
+		for i, cell := range row {
+			if i < len(keys) && keys[i] != "" {
+				rec[keys[i]] = strings.TrimSpace(cell)
+			}
+		}
+		if rec["id"] == "" && rec["title"] == "" {
+			continue // blank row
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// parseJSON reads a JSON array of objects. String, number and boolean values
+// are taken as text; arrays are joined with commas.
+func parseJSON(data []byte, columns map[string]string) ([]record, error) {
+	var items []map[string]any
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+
+	records := make([]record, 0, len(items))
+	for _, item := range items {
+		rec := record{}
+		for k, v := range item {
+			if key := keyFor(k, columns); key != "" {
+				rec[key] = jsonText(v)
+			}
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+func jsonText(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case []any:
+		parts := make([]string, len(val))
+		for i, item := range val {
+			parts[i] = jsonText(item)
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+var (
+	todoPriority = regexp.MustCompile(`^\(([A-Z])\)\s+`)
+	todoDate     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}\s+`)
+)
+
+// parseTodoTxt reads todo.txt lines. A leading "x" marks the task done and
+// "(A)" sets its priority. +project and @context words become labels, the
+// first project also the group. The id: tag identifies the task (the title is
+// used otherwise), and dep: lists ids of blocking tasks.
+func parseTodoTxt(data []byte) []record {
+	var records []record
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		records = append(records, parseTodoLine(line))
+	}
+	return records
+}
+
+func parseTodoLine(line string) record {
+	rec := record{"status": "todo"}
+
+	if strings.HasPrefix(line, "x ") {
+		rec["status"] = "done"
+		line = strings.TrimSpace(line[2:])
+	}
+	// Priority and the completion and creation dates lead the line, in
+	// whichever order the writing tool used.
+	for {
+		if m := todoPriority.FindStringSubmatch(line); m != nil {
+			rec["priority"] = m[1]
+			line = line[len(m[0]):]
+		} else if loc := todoDate.FindStringIndex(line); loc != nil {
+			line = line[loc[1]:]
+		} else {
+			break
+		}
+	}
+
+	var words, labels []string
+	for _, w := range strings.Fields(line) {
+		switch {
+		case len(w) > 1 && (w[0] == '+' || w[0] == '@'):
+			labels = append(labels, w[1:])
+			if w[0] == '+' && rec["group"] == "" {
+				rec["group"] = w[1:]
+			}
+		case strings.HasPrefix(w, "id:") && len(w) > 3:
+			rec["id"] = w[3:]
+		case strings.HasPrefix(w, "dep:") && len(w) > 4:
+			rec["dependencies"] = w[4:]
+		default:
+			words = append(words, w)
+		}
+	}
+
+	rec["title"] = strings.Join(words, " ")
+	rec["labels"] = strings.Join(labels, ",")
+	return rec
+}
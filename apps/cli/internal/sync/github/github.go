@@ -1,12 +1,15 @@
 package github
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -21,6 +24,12 @@ const (
 )
 
 // GitHubSource fetches tasks from GitHub Issues.
+//
+// Issue relations are reported through ExternalTask.Extra: "Depends on #12"
+// or "Blocked by #12" lines in the body become depends_on, a sub-issue's
+// parent issue becomes parent, and the milestone becomes group. Pull requests
+// in the fetch that close an issue ("Fixes #12") are listed under
+// pull_requests.
 type GitHubSource struct {
 	// HTTPClient allows injecting a custom client for testing. Nil uses http.DefaultClient.
 	HTTPClient *http.Client
@@ -69,29 +78,59 @@ func (g *GitHubSource) FetchTasks(cfg sync.SourceConfig) ([]sync.ExternalTask, e
 		client = http.DefaultClient
 	}
 
-	var tasks []sync.ExternalTask
+	issues, pulls, err := fetchAll(client, apiURL, params, token)
+	if err != nil {
+		return nil, err
+	}
 
-	for page := 1; page <= maxPages; page++ {
-		issues, hasMore, err := fetchPage(client, apiURL, params, token, page)
-		if err != nil {
-			return nil, err
-		}
+	parents, err := fetchParents(client, apiURL, token, issues)
+	if err != nil {
+		return nil, err
+	}
+	closedBy := closingPullRequests(pulls)
 
-		for _, issue := range issues {
-			if issue.PullRequest != nil {
-				continue
-			}
-			tasks = append(tasks, issueToExternalTask(issue))
+	tasks := make([]sync.ExternalTask, 0, len(issues))
+	for _, issue := range issues {
+		task := issueToExternalTask(issue)
+		if parent, ok := parents[issue.Number]; ok {
+			task.Extra[sync.ExtraParent] = strconv.Itoa(parent)
 		}
-
-		if !hasMore {
-			break
+		if prs := closedBy[issue.Number]; len(prs) > 0 {
+			task.Extra["pull_requests"] = strings.Join(prs, ",")
 		}
+		tasks = append(tasks, task)
 	}
 
 	return tasks, nil
 }
 
+// PushTask updates the issue's title and state, and its labels and assignee
+// when the field_map maps them to tags and owner.
+func (g *GitHubSource) PushTask(cfg sync.SourceConfig, task sync.ExternalTask) error {
+	owner, repo, err := splitProject(cfg.Project)
+	if err != nil {
+		return err
+	}
+
+	token, err := resolveToken(cfg.TokenEnv)
+	if err != nil {
+		return err
+	}
+
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	client := g.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	apiURL := fmt.Sprintf("%s/repos/%s/%s/issues/%s", baseURL, owner, repo, task.ExternalID)
+	return patchIssue(client, apiURL, token, buildIssuePatch(task, cfg.FieldMap))
+}
+
 // JSON response types
 
 type ghIssue struct {
@@ -106,6 +145,11 @@ type ghIssue struct {
 	Assignee    *ghAssignee  `json:"assignee"`
 	Milestone   *ghMilestone `json:"milestone"`
 	PullRequest *struct{}    `json:"pull_request"`
+	SubIssues   *ghSubIssues `json:"sub_issues_summary"`
+}
+
+type ghSubIssues struct {
+	Total int `json:"total"`
 }
 
 type ghLabel struct {
@@ -183,6 +227,30 @@ func toCommaSeparated(val any) string {
 	}
 }
 
+// fetchAll fetches every page of issues, separating out pull requests.
+func fetchAll(client *http.Client, apiURL string, params url.Values, token string) ([]ghIssue, []ghIssue, error) {
+	var issues, pulls []ghIssue
+	for page := 1; page <= maxPages; page++ {
+		batch, hasMore, err := fetchPage(client, apiURL, params, token, page)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, issue := range batch {
+			if issue.PullRequest != nil {
+				pulls = append(pulls, issue)
+				continue
+			}
+			issues = append(issues, issue)
+		}
+
+		if !hasMore {
+			break
+		}
+	}
+	return issues, pulls, nil
+}
+
 func fetchPage(client *http.Client, apiURL string, params url.Values, token string, page int) ([]ghIssue, bool, error) {
 	params.Set("page", strconv.Itoa(page))
 
@@ -224,6 +292,7 @@ func issueToExternalTask(issue ghIssue) sync.ExternalTask {
 		URL:         issue.HTMLURL,
 		CreatedAt:   issue.CreatedAt,
 		UpdatedAt:   issue.UpdatedAt,
+		Extra:       map[string]string{},
 	}
 
 	for _, l := range issue.Labels {
@@ -236,7 +305,129 @@ func issueToExternalTask(issue ghIssue) sync.ExternalTask {
 
 	if issue.Milestone != nil {
 		task.Priority = issue.Milestone.Title
+		task.Extra[sync.ExtraGroup] = issue.Milestone.Title
+	}
+
+	if deps := dependencyRefs(issue.Body); len(deps) > 0 {
+		task.Extra[sync.ExtraDependsOn] = strings.Join(deps, ",")
 	}
 
 	return task
 }
+
+var (
+	// dependsOnPattern matches "Depends on #1, #2" and "Blocked by #3 and #4".
+	dependsOnPattern = regexp.MustCompile(`(?i)\b(?:depends\s+on|blocked\s+by):?((?:\s*(?:,|and)?\s*#\d+\b)+)`)
+	// closesPattern matches GitHub's closing keywords, e.g. "Fixes #12".
+	closesPattern   = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+#(\d+)\b`)
+	issueRefPattern = regexp.MustCompile(`#(\d+)`)
+)
+
+// dependencyRefs returns the issue numbers referenced by dependency lines in
+// body, in order of appearance. References to other repositories are ignored.
+func dependencyRefs(body string) []string {
+	var refs []string
+	for _, m := range dependsOnPattern.FindAllStringSubmatch(body, -1) {
+		for _, ref := range issueRefPattern.FindAllStringSubmatch(m[1], -1) {
+			if !slices.Contains(refs, ref[1]) {
+				refs = append(refs, ref[1])
+			}
+		}
+	}
+	return refs
+}
+
+// closingPullRequests maps issue numbers to the URLs of pull requests whose
+// body closes them.
+func closingPullRequests(pulls []ghIssue) map[int][]string {
+	closedBy := make(map[int][]string)
+	for _, pr := range pulls {
+		for _, m := range closesPattern.FindAllStringSubmatch(pr.Body, -1) {
+			n, err := strconv.Atoi(m[1])
+			if err != nil || slices.Contains(closedBy[n], pr.HTMLURL) {
+				continue
+			}
+			closedBy[n] = append(closedBy[n], pr.HTMLURL)
+		}
+	}
+	return closedBy
+}
+
+// fetchParents maps sub-issue numbers to their parent issue number. Only
+// issues reporting sub-issues are queried.
+func fetchParents(client *http.Client, issuesURL, token string, issues []ghIssue) (map[int]int, error) {
+	parents := make(map[int]int)
+	for _, issue := range issues {
+		if issue.SubIssues == nil || issue.SubIssues.Total == 0 {
+			continue
+		}
+
+		params := url.Values{}
+		params.Set("per_page", strconv.Itoa(perPage))
+		subURL := fmt.Sprintf("%s/%d/sub_issues", issuesURL, issue.Number)
+
+		children, _, err := fetchPage(client, subURL, params, token, 1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch sub-issues of #%d: %w", issue.Number, err)
+		}
+		for _, child := range children {
+			parents[child.Number] = issue.Number
+		}
+	}
+	return parents, nil
+}
+
+func buildIssuePatch(task sync.ExternalTask, fm sync.FieldMap) map[string]any {
+	patch := map[string]any{"title": task.Title}
+
+	if task.Status == "open" || task.Status == "closed" {
+		patch["state"] = task.Status
+	}
+
+	if fm.LabelsToTags {
+		labels := task.Labels
+		if labels == nil {
+			labels = []string{}
+		}
+		patch["labels"] = labels
+	}
+
+	if fm.AssigneeToOwner {
+		assignees := []string{}
+		if task.Assignee != "" {
+			assignees = append(assignees, task.Assignee)
+		}
+		patch["assignees"] = assignees
+	}
+
+	return patch
+}
+
+func patchIssue(client *http.Client, apiURL, token string, patch map[string]any) error {
+	body, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequest("PATCH", apiURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("GitHub API returned %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
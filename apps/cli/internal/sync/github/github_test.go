@@ -226,6 +226,67 @@ func TestGitHubSource_FetchTasks_SkipsPullRequests(t *testing.T) {
 	}
 }
 
+func TestGitHubSource_FetchTasks_Relations(t *testing.T) {
+	issues := []ghIssue{
+		{Number: 10, Title: "Epic", State: "open", SubIssues: &ghSubIssues{Total: 2}},
+		{
+			Number:    11,
+			Title:     "Child",
+			State:     "open",
+			Body:      "Some context.\n\nDepends on #12, #13 and #14\nBlocked by: #12\nSee also other/repo#5",
+			Milestone: &ghMilestone{Title: "v1.0"},
+		},
+		{Number: 12, Title: "Other child", State: "open"},
+		{Number: 20, Title: "Fix child", State: "open", Body: "Fixes #11", PullRequest: &struct{}{},
+			HTMLURL: "https://github.com/owner/repo/pull/20"},
+	}
+
+	var subIssuePaths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/sub_issues") {
+			subIssuePaths = append(subIssuePaths, r.URL.Path)
+			json.NewEncoder(w).Encode([]ghIssue{{Number: 11}, {Number: 12}})
+			return
+		}
+		json.NewEncoder(w).Encode(issues)
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_REL_TOKEN", "tok")
+
+	src := &GitHubSource{HTTPClient: srv.Client()}
+	tasks, err := src.FetchTasks(sync.SourceConfig{Project: "owner/repo", BaseURL: srv.URL, TokenEnv: "TEST_REL_TOKEN"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tasks) != 3 {
+		t.Fatalf("expected 3 tasks, got %d", len(tasks))
+	}
+
+	if len(subIssuePaths) != 1 || subIssuePaths[0] != "/repos/owner/repo/issues/10/sub_issues" {
+		t.Errorf("expected one sub-issue request for #10, got %v", subIssuePaths)
+	}
+
+	child := tasks[1]
+	want := map[string]string{
+		sync.ExtraDependsOn: "12,13,14",
+		sync.ExtraParent:    "10",
+		sync.ExtraGroup:     "v1.0",
+		"pull_requests":     "https://github.com/owner/repo/pull/20",
+	}
+	for key, val := range want {
+		if child.Extra[key] != val {
+			t.Errorf("Extra[%q] = %q, want %q", key, child.Extra[key], val)
+		}
+	}
+	if tasks[2].Extra[sync.ExtraParent] != "10" {
+		t.Errorf("expected #12 parent 10, got %q", tasks[2].Extra[sync.ExtraParent])
+	}
+	if _, ok := tasks[0].Extra[sync.ExtraParent]; ok {
+		t.Error("expected no parent for the epic")
+	}
+}
+
 func TestGitHubSource_FetchTasks_Pagination(t *testing.T) {
 	callCount := 0
 
@@ -467,3 +528,89 @@ func TestGitHubSource_FetchTasks_UnsetToken(t *testing.T) {
 		t.Errorf("expected error to mention env var name, got: %v", err)
 	}
 }
+
+func TestGitHubSource_PushTask(t *testing.T) {
+	var gotMethod, gotPath string
+	var gotBody map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		gotPath = r.URL.Path
+		if r.Header.Get("Authorization") != "Bearer push-token" {
+			t.Errorf("unexpected auth header: %s", r.Header.Get("Authorization"))
+		}
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_PUSH_TOKEN", "push-token")
+
+	src := &GitHubSource{HTTPClient: srv.Client()}
+	cfg := sync.SourceConfig{
+		Project:  "owner/repo",
+		BaseURL:  srv.URL,
+		TokenEnv: "TEST_PUSH_TOKEN",
+		FieldMap: sync.FieldMap{LabelsToTags: true, AssigneeToOwner: true},
+	}
+	task := sync.ExternalTask{ExternalID: "42", Title: "Renamed", Status: "closed", Labels: []string{"bug"}, Assignee: "bob"}
+
+	if err := src.PushTask(cfg, task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotMethod != http.MethodPatch || gotPath != "/repos/owner/repo/issues/42" {
+		t.Errorf("unexpected request: %s %s", gotMethod, gotPath)
+	}
+	if gotBody["title"] != "Renamed" || gotBody["state"] != "closed" {
+		t.Errorf("unexpected body: %v", gotBody)
+	}
+	if labels, _ := gotBody["labels"].([]any); len(labels) != 1 || labels[0] != "bug" {
+		t.Errorf("expected labels [bug], got %v", gotBody["labels"])
+	}
+	if assignees, _ := gotBody["assignees"].([]any); len(assignees) != 1 || assignees[0] != "bob" {
+		t.Errorf("expected assignees [bob], got %v", gotBody["assignees"])
+	}
+}
+
+func TestGitHubSource_PushTask_OnlyMappedFields(t *testing.T) {
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_PUSH_TOKEN", "tok")
+
+	src := &GitHubSource{HTTPClient: srv.Client()}
+	cfg := sync.SourceConfig{Project: "owner/repo", BaseURL: srv.URL, TokenEnv: "TEST_PUSH_TOKEN"}
+	task := sync.ExternalTask{ExternalID: "1", Title: "T", Status: "in-progress", Labels: []string{"bug"}}
+
+	if err := src.PushTask(cfg, task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, key := range []string{"state", "labels", "assignees"} {
+		if _, ok := gotBody[key]; ok {
+			t.Errorf("expected %q to be omitted, got %v", key, gotBody)
+		}
+	}
+}
+
+func TestGitHubSource_PushTask_APIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_PUSH_TOKEN", "tok")
+
+	src := &GitHubSource{HTTPClient: srv.Client()}
+	cfg := sync.SourceConfig{Project: "owner/repo", BaseURL: srv.URL, TokenEnv: "TEST_PUSH_TOKEN"}
+	err := src.PushTask(cfg, sync.ExternalTask{ExternalID: "9", Title: "T"})
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("expected 404 error, got %v", err)
+	}
+}
@@ -0,0 +1,308 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/sync"
+)
+
+const (
+	defaultBaseURL = "https://gitlab.com"
+	perPage        = 100
+	maxPages       = 50
+)
+
+// GitLabSource fetches tasks from GitLab issues via the REST API (v4).
+//
+// project is a numeric project ID or a full path such as "group/project".
+// base_url points at a self-hosted instance. Status and priority come from
+// the first label (typically a scoped label such as "workflow::doing") that
+// field_map maps, falling back to the issue state for status. Weights become
+// effort, the milestone becomes group, and "is blocked by" links become
+// dependencies.
+type GitLabSource struct {
+	// HTTPClient allows injecting a custom client for testing. Nil uses http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+func init() {
+	sync.Register(&GitLabSource{})
+}
+
+func (g *GitLabSource) Name() string { return "gitlab" }
+
+func (g *GitLabSource) ValidateConfig(cfg sync.SourceConfig) error {
+	if cfg.Project == "" {
+		return fmt.Errorf("project is required")
+	}
+	if cfg.TokenEnv == "" {
+		return fmt.Errorf("token_env is required")
+	}
+	return nil
+}
+
+// DefaultFieldMap maps GitLab's issue states onto taskmd statuses.
+func (g *GitLabSource) DefaultFieldMap() sync.FieldMap {
+	return sync.FieldMap{
+		Status: map[string]string{
+			"opened": "pending",
+			"closed": "completed",
+		},
+	}
+}
+
+func (g *GitLabSource) FetchTasks(cfg sync.SourceConfig) ([]sync.ExternalTask, error) {
+	token := os.Getenv(cfg.TokenEnv)
+	if token == "" {
+		return nil, fmt.Errorf("environment variable %q is not set", cfg.TokenEnv)
+	}
+
+	client := g.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	projectURL := fmt.Sprintf("%s/projects/%s", apiBase(cfg.BaseURL), url.PathEscape(cfg.Project))
+	params := buildQueryParams(cfg.Filters)
+
+	var tasks []sync.ExternalTask
+
+	for page := 1; page <= maxPages; page++ {
+		params.Set("page", strconv.Itoa(page))
+
+		var issues []glIssue
+		next, err := getJSON(client, projectURL+"/issues?"+params.Encode(), token, &issues)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, issue := range issues {
+			deps, err := fetchBlockers(client, projectURL, token, issue)
+			if err != nil {
+				return nil, err
+			}
+			task := issueToExternalTask(issue, cfg.FieldMap)
+			task.Dependencies = deps
+			tasks = append(tasks, task)
+		}
+
+		if next == "" {
+			break
+		}
+	}
+
+	return tasks, nil
+}
+
+// JSON response types
+
+type glIssue struct {
+	IID         int          `json:"iid"`
+	ProjectID   int          `json:"project_id"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	State       string       `json:"state"`
+	WebURL      string       `json:"web_url"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	Labels      []string     `json:"labels"`
+	Assignees   []glUser     `json:"assignees"`
+	Weight      *int         `json:"weight"`
+	Milestone   *glMilestone `json:"milestone"`
+}
+
+type glUser struct {
+	Username string `json:"username"`
+}
+
+type glMilestone struct {
+	Title string `json:"title"`
+}
+
+type glLink struct {
+	IID       int    `json:"iid"`
+	ProjectID int    `json:"project_id"`
+	LinkType  string `json:"link_type"`
+}
+
+// helpers
+
+// apiBase returns the v4 API root for baseURL, accepting instance URLs with
+// or without the /api/v4 suffix.
+func apiBase(baseURL string) string {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	baseURL = strings.TrimRight(baseURL, "/")
+	if strings.HasSuffix(baseURL, "/api/v4") {
+		return baseURL
+	}
+	return baseURL + "/api/v4"
+}
+
+func buildQueryParams(filters map[string]any) url.Values {
+	params := url.Values{}
+	params.Set("per_page", strconv.Itoa(perPage))
+	params.Set("order_by", "created_at")
+	params.Set("sort", "asc")
+
+	hasState := false
+	for key, val := range filters {
+		switch key {
+		case "labels":
+			params.Set("labels", toCommaSeparated(val))
+		case "state":
+			params.Set("state", fmt.Sprint(val))
+			hasState = true
+		default:
+			params.Set(key, fmt.Sprint(val))
+		}
+	}
+
+	if !hasState {
+		params.Set("state", "all")
+	}
+
+	return params
+}
+
+func toCommaSeparated(val any) string {
+	switch v := val.(type) {
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = fmt.Sprint(item)
+		}
+		return strings.Join(parts, ",")
+	case []string:
+		return strings.Join(v, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// getJSON decodes the response of a GET request into out and returns the
+// X-Next-Page header, which is empty on the last page.
+func getJSON(client *http.Client, reqURL, token string, out any) (string, error) {
+	req, err := http.NewRequest("GET", reqURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("PRIVATE-TOKEN", token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("GitLab API returned %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return resp.Header.Get("X-Next-Page"), nil
+}
+
+// fetchBlockers returns the IIDs of issues in the same project linked to
+// issue as blocking it. It never returns nil, so removed links clear local
+// dependencies.
+func fetchBlockers(client *http.Client, projectURL, token string, issue glIssue) ([]string, error) {
+	var links []glLink
+	linksURL := fmt.Sprintf("%s/issues/%d/links", projectURL, issue.IID)
+	if _, err := getJSON(client, linksURL, token, &links); err != nil {
+		return nil, fmt.Errorf("failed to fetch links of #%d: %w", issue.IID, err)
+	}
+
+	deps := []string{}
+	for _, l := range links {
+		if l.LinkType == "is_blocked_by" && l.ProjectID == issue.ProjectID {
+			deps = append(deps, strconv.Itoa(l.IID))
+		}
+	}
+	return deps, nil
+}
+
+func issueToExternalTask(issue glIssue, fm sync.FieldMap) sync.ExternalTask {
+	task := sync.ExternalTask{
+		ExternalID:  strconv.Itoa(issue.IID),
+		Title:       issue.Title,
+		Description: issue.Description,
+		Status:      issue.State,
+		URL:         issue.WebURL,
+		CreatedAt:   issue.CreatedAt,
+		UpdatedAt:   issue.UpdatedAt,
+		Extra:       map[string]string{},
+	}
+
+	// Labels that field_map maps to a status or priority are consumed there
+	// rather than becoming tags. A closed issue stays closed whatever its
+	// workflow label says.
+	var statusLabel string
+	for _, label := range issue.Labels {
+		switch {
+		case hasKey(fm.Status, label):
+			if statusLabel == "" {
+				statusLabel = label
+			}
+		case hasKey(fm.Priority, label):
+			if task.Priority == "" {
+				task.Priority = label
+			}
+		default:
+			task.Labels = append(task.Labels, label)
+		}
+	}
+	if statusLabel != "" && issue.State != "closed" {
+		task.Status = statusLabel
+	}
+
+	if len(issue.Assignees) > 0 {
+		task.Assignee = issue.Assignees[0].Username
+	}
+
+	if issue.Weight != nil {
+		task.Extra["weight"] = strconv.Itoa(*issue.Weight)
+		task.Extra[sync.ExtraEffort] = weightToEffort(*issue.Weight)
+	}
+
+	if issue.Milestone != nil {
+		task.Extra[sync.ExtraGroup] = issue.Milestone.Title
+	}
+
+	return task
+}
+
+func hasKey(m map[string]string, key string) bool {
+	_, ok := m[key]
+	return ok
+}
+
+// weightToEffort buckets GitLab issue weights: 1-2 small, 3-5 medium,
+// 6 and above large. A weight of 0 has no effort.
+func weightToEffort(weight int) string {
+	switch {
+	case weight <= 0:
+		return ""
+	case weight <= 2:
+		return "small"
+	case weight <= 5:
+		return "medium"
+	default:
+		return "large"
+	}
+}
@@ -0,0 +1,269 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/driangle/taskmd/apps/cli/internal/sync"
+)
+
+func intPtr(n int) *int { return &n }
+
+func TestGitLabSource_Name(t *testing.T) {
+	src := &GitLabSource{}
+	if src.Name() != "gitlab" {
+		t.Fatalf("expected name %q, got %q", "gitlab", src.Name())
+	}
+}
+
+func TestGitLabSource_ValidateConfig(t *testing.T) {
+	src := &GitLabSource{}
+
+	if err := src.ValidateConfig(sync.SourceConfig{Project: "group/project", TokenEnv: "GITLAB_TOKEN"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := src.ValidateConfig(sync.SourceConfig{TokenEnv: "GITLAB_TOKEN"}); err == nil {
+		t.Error("expected error for missing project")
+	}
+	if err := src.ValidateConfig(sync.SourceConfig{Project: "42"}); err == nil {
+		t.Error("expected error for missing token_env")
+	}
+}
+
+func TestAPIBase(t *testing.T) {
+	tests := map[string]string{
+		"":                                   "https://gitlab.com/api/v4",
+		"https://gitlab.example.com":         "https://gitlab.example.com/api/v4",
+		"https://gitlab.example.com/":        "https://gitlab.example.com/api/v4",
+		"https://gitlab.example.com/api/v4":  "https://gitlab.example.com/api/v4",
+		"https://example.com/gitlab/api/v4/": "https://example.com/gitlab/api/v4",
+	}
+	for in, want := range tests {
+		if got := apiBase(in); got != want {
+			t.Errorf("apiBase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGitLabSource_FetchTasks_Basic(t *testing.T) {
+	issues := []glIssue{
+		{
+			IID:         1,
+			ProjectID:   7,
+			Title:       "Fix login",
+			Description: "Steps to reproduce",
+			State:       "opened",
+			WebURL:      "https://gitlab.example.com/group/project/-/issues/1",
+			Labels:      []string{"bug", "workflow::doing", "priority::high"},
+			Assignees:   []glUser{{Username: "alice"}, {Username: "bob"}},
+			Weight:      intPtr(3),
+			Milestone:   &glMilestone{Title: "16.0"},
+		},
+		{IID: 2, ProjectID: 7, Title: "Done already", State: "closed", Labels: []string{"workflow::doing"}},
+	}
+	links := map[string][]glLink{
+		"1": {
+			{IID: 2, ProjectID: 7, LinkType: "is_blocked_by"},
+			{IID: 3, ProjectID: 7, LinkType: "relates_to"},
+			{IID: 9, ProjectID: 8, LinkType: "is_blocked_by"},
+		},
+	}
+
+	var gotPaths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "glpat-123" {
+			t.Errorf("unexpected token header: %q", r.Header.Get("PRIVATE-TOKEN"))
+		}
+		gotPaths = append(gotPaths, r.URL.EscapedPath())
+		if strings.HasSuffix(r.URL.Path, "/links") {
+			parts := strings.Split(r.URL.Path, "/")
+			iid := parts[len(parts)-2]
+			json.NewEncoder(w).Encode(links[iid])
+			return
+		}
+		if r.URL.Query().Get("state") != "all" {
+			t.Errorf("expected state=all, got %q", r.URL.Query().Get("state"))
+		}
+		json.NewEncoder(w).Encode(issues)
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_GITLAB_TOKEN", "glpat-123")
+
+	src := &GitLabSource{HTTPClient: srv.Client()}
+	fm := sync.FieldMap{
+		Status:   map[string]string{"workflow::doing": "in-progress"},
+		Priority: map[string]string{"priority::high": "high"},
+	}.WithDefaults(src.DefaultFieldMap())
+	cfg := sync.SourceConfig{Project: "group/project", BaseURL: srv.URL, TokenEnv: "TEST_GITLAB_TOKEN", FieldMap: fm}
+
+	tasks, err := src.FetchTasks(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotPaths[0] != "/api/v4/projects/group%2Fproject/issues" {
+		t.Errorf("unexpected issues path: %s", gotPaths[0])
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(tasks))
+	}
+
+	task := tasks[0]
+	if task.ExternalID != "1" || task.Title != "Fix login" || task.Description != "Steps to reproduce" {
+		t.Errorf("unexpected task: %+v", task)
+	}
+	if task.Status != "workflow::doing" || task.Priority != "priority::high" || task.Assignee != "alice" {
+		t.Errorf("unexpected status/priority/assignee: %q %q %q", task.Status, task.Priority, task.Assignee)
+	}
+	if strings.Join(task.Labels, ",") != "bug" {
+		t.Errorf("expected only unmapped labels, got %v", task.Labels)
+	}
+	if task.Extra[sync.ExtraEffort] != "medium" || task.Extra["weight"] != "3" || task.Extra[sync.ExtraGroup] != "16.0" {
+		t.Errorf("unexpected extra: %v", task.Extra)
+	}
+	if strings.Join(task.Dependencies, ",") != "2" {
+		t.Errorf("expected dependencies [2], got %v", task.Dependencies)
+	}
+
+	closed := tasks[1]
+	if closed.Status != "closed" {
+		t.Errorf("expected closed issue to keep closed status, got %q", closed.Status)
+	}
+	if closed.Dependencies == nil || len(closed.Dependencies) != 0 {
+		t.Errorf("expected empty non-nil dependencies, got %#v", closed.Dependencies)
+	}
+}
+
+func TestGitLabSource_FetchTasks_Pagination(t *testing.T) {
+	var pages []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/links") {
+			fmt.Fprint(w, "[]")
+			return
+		}
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		switch page {
+		case "1":
+			w.Header().Set("X-Next-Page", "2")
+			json.NewEncoder(w).Encode([]glIssue{{IID: 1}, {IID: 2}})
+		case "2":
+			w.Header().Set("X-Next-Page", "")
+			json.NewEncoder(w).Encode([]glIssue{{IID: 3}})
+		default:
+			t.Errorf("unexpected page %q", page)
+		}
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_GITLAB_TOKEN", "tok")
+
+	src := &GitLabSource{HTTPClient: srv.Client()}
+	tasks, err := src.FetchTasks(sync.SourceConfig{Project: "42", BaseURL: srv.URL, TokenEnv: "TEST_GITLAB_TOKEN"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tasks) != 3 {
+		t.Fatalf("expected 3 tasks, got %d", len(tasks))
+	}
+	if strings.Join(pages, ",") != "1,2" {
+		t.Errorf("expected pages 1,2, got %v", pages)
+	}
+}
+
+func TestGitLabSource_FetchTasks_AuthError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"message":"401 Unauthorized"}`)
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_GITLAB_TOKEN", "bad")
+
+	src := &GitLabSource{HTTPClient: srv.Client()}
+	_, err := src.FetchTasks(sync.SourceConfig{Project: "42", BaseURL: srv.URL, TokenEnv: "TEST_GITLAB_TOKEN"})
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("expected 401 error, got %v", err)
+	}
+}
+
+func TestGitLabSource_FetchTasks_UnsetToken(t *testing.T) {
+	src := &GitLabSource{}
+	_, err := src.FetchTasks(sync.SourceConfig{Project: "42", TokenEnv: "TEST_GITLAB_UNSET_TOKEN"})
+	if err == nil || !strings.Contains(err.Error(), "TEST_GITLAB_UNSET_TOKEN") {
+		t.Fatalf("expected unset token error, got %v", err)
+	}
+}
+
+func TestWeightToEffort(t *testing.T) {
+	tests := map[int]string{0: "", 1: "small", 2: "small", 3: "medium", 5: "medium", 6: "large", 13: "large"}
+	for weight, want := range tests {
+		if got := weightToEffort(weight); got != want {
+			t.Errorf("weightToEffort(%d) = %q, want %q", weight, got, want)
+		}
+	}
+}
+
+func TestGitLabSource_EngineWritesEffortAndDependencies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/issues/2/links") {
+			json.NewEncoder(w).Encode([]glLink{{IID: 1, ProjectID: 7, LinkType: "is_blocked_by"}})
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/links") {
+			fmt.Fprint(w, "[]")
+			return
+		}
+		json.NewEncoder(w).Encode([]glIssue{
+			{IID: 1, ProjectID: 7, Title: "Build", State: "opened", Weight: intPtr(8)},
+			{IID: 2, ProjectID: 7, Title: "Ship", State: "closed"},
+		})
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_GITLAB_TOKEN", "tok")
+
+	// Swap in a source using the test server's client.
+	sync.Register(&GitLabSource{HTTPClient: srv.Client()})
+	defer sync.Register(&GitLabSource{})
+
+	dir := t.TempDir()
+	engine := &sync.Engine{ConfigDir: dir}
+	result, err := engine.RunSync(sync.SourceConfig{
+		Name:      "gitlab",
+		Project:   "7",
+		BaseURL:   srv.URL,
+		TokenEnv:  "TEST_GITLAB_TOKEN",
+		OutputDir: filepath.Join(dir, "tasks"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Created) != 2 {
+		t.Fatalf("expected 2 created, got %d", len(result.Created))
+	}
+
+	build, err := os.ReadFile(result.Created[0].FilePath)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if !strings.Contains(string(build), "effort: large") || !strings.Contains(string(build), "status: pending") {
+		t.Errorf("expected effort and default status, got:\n%s", build)
+	}
+
+	ship, err := os.ReadFile(result.Created[1].FilePath)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	want := `dependencies: ["` + result.Created[0].LocalID + `"]`
+	if !strings.Contains(string(ship), want) || !strings.Contains(string(ship), "status: completed") {
+		t.Errorf("expected %q and completed status, got:\n%s", want, ship)
+	}
+}
@@ -18,6 +18,14 @@ func HashExternalTask(ext ExternalTask) string {
 	fmt.Fprintf(h, "assignee:%s\n", ext.Assignee)
 	fmt.Fprintf(h, "labels:%s\n", strings.Join(ext.Labels, ","))
 	fmt.Fprintf(h, "url:%s\n", ext.URL)
+	if len(ext.Dependencies) > 0 {
+		fmt.Fprintf(h, "deps:%s\n", strings.Join(ext.Dependencies, ","))
+	}
+	for _, key := range []string{ExtraDependsOn, ExtraParent, ExtraGroup, ExtraEffort} {
+		if v := ext.Extra[key]; v != "" {
+			fmt.Fprintf(h, "%s:%s\n", key, v)
+		}
+	}
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
 
@@ -27,6 +35,11 @@ func HashLocalFile(path string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	return HashContent(data), nil
+}
+
+// HashContent returns the SHA-256 hash of file content, matching HashLocalFile.
+func HashContent(data []byte) string {
 	h := sha256.Sum256(data)
-	return fmt.Sprintf("%x", h[:]), nil
+	return fmt.Sprintf("%x", h[:])
 }
@@ -0,0 +1,142 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// adfNode is a node in an Atlassian Document Format tree, the rich text
+// representation REST API v3 uses for descriptions.
+type adfNode struct {
+	Type    string         `json:"type"`
+	Text    string         `json:"text"`
+	Attrs   map[string]any `json:"attrs"`
+	Marks   []adfMark      `json:"marks"`
+	Content []adfNode      `json:"content"`
+}
+
+type adfMark struct {
+	Type  string         `json:"type"`
+	Attrs map[string]any `json:"attrs"`
+}
+
+// descriptionText returns the issue description as markdown. API v2 returns
+// a plain string; API v3 returns an ADF document.
+func descriptionText(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+
+	var doc adfNode
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return ""
+	}
+
+	var b strings.Builder
+	renderBlocks(&b, doc.Content, "")
+	return strings.TrimSpace(b.String())
+}
+
+// renderBlocks writes block nodes separated by blank lines, prefixing each
+// line with indent (used for nested lists).
+func renderBlocks(b *strings.Builder, nodes []adfNode, indent string) {
+	for _, n := range nodes {
+		switch n.Type {
+		case "paragraph":
+			b.WriteString(indent + renderInline(n.Content) + "\n\n")
+		case "heading":
+			level := 1
+			if l, ok := n.Attrs["level"].(float64); ok && l >= 1 && l <= 6 {
+				level = int(l)
+			}
+			b.WriteString(strings.Repeat("#", level) + " " + renderInline(n.Content) + "\n\n")
+		case "bulletList", "orderedList":
+			renderList(b, n, indent)
+			if indent == "" {
+				b.WriteString("\n")
+			}
+		case "codeBlock":
+			lang, _ := n.Attrs["language"].(string)
+			b.WriteString("```" + lang + "\n" + renderInline(n.Content) + "\n```\n\n")
+		case "blockquote":
+			var inner strings.Builder
+			renderBlocks(&inner, n.Content, "")
+			for _, line := range strings.Split(strings.TrimSpace(inner.String()), "\n") {
+				b.WriteString(strings.TrimRight("> "+line, " ") + "\n")
+			}
+			b.WriteString("\n")
+		case "rule":
+			b.WriteString("---\n\n")
+		default:
+			renderBlocks(b, n.Content, indent)
+		}
+	}
+}
+
+func renderList(b *strings.Builder, list adfNode, indent string) {
+	for i, item := range list.Content {
+		marker := "- "
+		if list.Type == "orderedList" {
+			marker = fmt.Sprintf("%d. ", i+1)
+		}
+		for j, child := range item.Content {
+			switch {
+			case child.Type == "bulletList" || child.Type == "orderedList":
+				renderList(b, child, indent+"  ")
+			case j == 0:
+				b.WriteString(indent + marker + renderInline(child.Content) + "\n")
+			default:
+				b.WriteString(indent + "  " + renderInline(child.Content) + "\n")
+			}
+		}
+	}
+}
+
+func renderInline(nodes []adfNode) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		switch n.Type {
+		case "text":
+			b.WriteString(applyMarks(n.Text, n.Marks))
+		case "hardBreak":
+			b.WriteString("\n")
+		case "mention", "emoji", "status":
+			if text, ok := n.Attrs["text"].(string); ok {
+				b.WriteString(text)
+			}
+		case "inlineCard":
+			if u, ok := n.Attrs["url"].(string); ok {
+				b.WriteString(u)
+			}
+		default:
+			b.WriteString(renderInline(n.Content))
+		}
+	}
+	return b.String()
+}
+
+func applyMarks(text string, marks []adfMark) string {
+	for _, m := range marks {
+		switch m.Type {
+		case "strong":
+			text = "**" + text + "**"
+		case "em":
+			text = "*" + text + "*"
+		case "strike":
+			text = "~~" + text + "~~"
+		case "code":
+			text = "`" + text + "`"
+		case "link":
+			if href, ok := m.Attrs["href"].(string); ok {
+				text = "[" + text + "](" + href + ")"
+			}
+		}
+	}
+	return text
+}
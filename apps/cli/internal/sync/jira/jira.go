@@ -0,0 +1,403 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/sync"
+)
+
+const (
+	defaultAPIVersion = "2"
+	maxResults        = 100
+	maxPages          = 50
+	searchFields      = "summary,description,status,priority,assignee,labels,issuetype,created,updated,issuelinks"
+
+	deploymentCloud  = "cloud"
+	deploymentServer = "server"
+
+	// blocksLinkType is the name of Jira's built-in "blocks / is blocked by" link type.
+	blocksLinkType = "blocks"
+)
+
+// JiraSource fetches tasks from Jira Cloud or Jira Server/Data Center.
+//
+// Cloud searches with /rest/api/N/search/jql, which pages with
+// nextPageToken; Server/Data Center uses /rest/api/N/search with
+// startAt/total. A base_url on atlassian.net is Cloud unless
+// filters.deployment says otherwise.
+//
+// Authentication uses the token from token_env. When user_env is set the
+// request uses basic auth (Jira Cloud: email + API token); otherwise the
+// token is sent as a bearer personal access token (Jira Server/Data Center).
+type JiraSource struct {
+	// HTTPClient allows injecting a custom client for testing. Nil uses http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+func init() {
+	sync.Register(&JiraSource{})
+}
+
+func (j *JiraSource) Name() string { return "jira" }
+
+func (j *JiraSource) ValidateConfig(cfg sync.SourceConfig) error {
+	if cfg.BaseURL == "" {
+		return fmt.Errorf("base_url is required")
+	}
+	if _, err := url.ParseRequestURI(cfg.BaseURL); err != nil {
+		return fmt.Errorf("invalid base_url %q: %w", cfg.BaseURL, err)
+	}
+	if cfg.Project == "" && filterString(cfg.Filters, "jql") == "" {
+		return fmt.Errorf("project or filters.jql is required")
+	}
+	if cfg.TokenEnv == "" {
+		return fmt.Errorf("token_env is required")
+	}
+	if v := apiVersion(cfg); v != "2" && v != "3" {
+		return fmt.Errorf("filters.api_version must be 2 or 3, got %q", v)
+	}
+	if d := filterString(cfg.Filters, "deployment"); d != "" && d != deploymentCloud && d != deploymentServer {
+		return fmt.Errorf("filters.deployment must be %s or %s, got %q", deploymentCloud, deploymentServer, d)
+	}
+	return nil
+}
+
+func (j *JiraSource) FetchTasks(cfg sync.SourceConfig) ([]sync.ExternalTask, error) {
+	auth, err := resolveAuth(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := strings.TrimRight(cfg.BaseURL, "/")
+	cursor := &pageCursor{cloud: isCloud(cfg)}
+	apiURL := fmt.Sprintf("%s/rest/api/%s/search", baseURL, apiVersion(cfg))
+	if cursor.cloud {
+		apiURL += "/jql"
+	}
+	jql := buildJQL(cfg.Project, cfg.Filters)
+
+	client := j.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var tasks []sync.ExternalTask
+
+	for page := 0; page < maxPages; page++ {
+		resp, err := fetchPage(client, apiURL, jql, auth, cursor.params())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, issue := range resp.Issues {
+			tasks = append(tasks, issueToExternalTask(issue, baseURL))
+		}
+
+		if !cursor.advance(resp) {
+			break
+		}
+	}
+
+	return tasks, nil
+}
+
+// pageCursor tracks the position in the search results: a nextPageToken on
+// Cloud, an offset on Server/Data Center.
+type pageCursor struct {
+	cloud   bool
+	startAt int
+	token   string
+}
+
+func (c *pageCursor) params() url.Values {
+	params := url.Values{}
+	if !c.cloud {
+		params.Set("startAt", strconv.Itoa(c.startAt))
+	} else if c.token != "" {
+		params.Set("nextPageToken", c.token)
+	}
+	return params
+}
+
+// advance moves past resp and reports whether another page follows.
+func (c *pageCursor) advance(resp *searchResponse) bool {
+	if len(resp.Issues) == 0 {
+		return false
+	}
+	if c.cloud {
+		c.token = resp.NextPageToken
+		return !resp.IsLast && c.token != ""
+	}
+	c.startAt += len(resp.Issues)
+	return c.startAt < resp.Total
+}
+
+// JSON response types
+
+type searchResponse struct {
+	StartAt    int         `json:"startAt"`
+	MaxResults int         `json:"maxResults"`
+	Total      int         `json:"total"`
+	Issues     []jiraIssue `json:"issues"`
+
+	// Cloud's search/jql endpoint pages with a token instead of startAt.
+	NextPageToken string `json:"nextPageToken"`
+	IsLast        bool   `json:"isLast"`
+}
+
+type jiraIssue struct {
+	Key    string     `json:"key"`
+	Fields jiraFields `json:"fields"`
+}
+
+type jiraFields struct {
+	Summary     string          `json:"summary"`
+	Description json.RawMessage `json:"description"`
+	Status      *jiraNamed      `json:"status"`
+	Priority    *jiraNamed      `json:"priority"`
+	IssueType   *jiraNamed      `json:"issuetype"`
+	Assignee    *jiraUser       `json:"assignee"`
+	Labels      []string        `json:"labels"`
+	Created     string          `json:"created"`
+	Updated     string          `json:"updated"`
+	IssueLinks  []jiraIssueLink `json:"issuelinks"`
+}
+
+type jiraNamed struct {
+	Name string `json:"name"`
+}
+
+type jiraUser struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type jiraIssueLink struct {
+	Type         jiraLinkType `json:"type"`
+	InwardIssue  *jiraIssue   `json:"inwardIssue"`
+	OutwardIssue *jiraIssue   `json:"outwardIssue"`
+}
+
+type jiraLinkType struct {
+	Name    string `json:"name"`
+	Inward  string `json:"inward"`
+	Outward string `json:"outward"`
+}
+
+// helpers
+
+func apiVersion(cfg sync.SourceConfig) string {
+	if v := filterString(cfg.Filters, "api_version"); v != "" {
+		return v
+	}
+	return defaultAPIVersion
+}
+
+// isCloud reports whether cfg points at Jira Cloud: filters.deployment when
+// set, otherwise whether base_url is on atlassian.net.
+func isCloud(cfg sync.SourceConfig) bool {
+	if d := filterString(cfg.Filters, "deployment"); d != "" {
+		return d == deploymentCloud
+	}
+	u, err := url.Parse(cfg.BaseURL)
+	if err != nil {
+		return false
+	}
+	return strings.HasSuffix(strings.ToLower(u.Hostname()), ".atlassian.net")
+}
+
+func filterString(filters map[string]any, key string) string {
+	val, ok := filters[key]
+	if !ok || val == nil {
+		return ""
+	}
+	return fmt.Sprint(val)
+}
+
+func resolveAuth(cfg sync.SourceConfig) (string, error) {
+	token := os.Getenv(cfg.TokenEnv)
+	if token == "" {
+		return "", fmt.Errorf("environment variable %q is not set", cfg.TokenEnv)
+	}
+	if cfg.UserEnv == "" {
+		return "Bearer " + token, nil
+	}
+	user := os.Getenv(cfg.UserEnv)
+	if user == "" {
+		return "", fmt.Errorf("environment variable %q is not set", cfg.UserEnv)
+	}
+	req := http.Request{Header: http.Header{}}
+	req.SetBasicAuth(user, token)
+	return req.Header.Get("Authorization"), nil
+}
+
+// buildJQL returns the JQL query for the source. An explicit filters.jql is
+// used as-is; otherwise the query is built from project and the simple
+// status, assignee, labels and type filters.
+func buildJQL(project string, filters map[string]any) string {
+	if jql := filterString(filters, "jql"); jql != "" {
+		return jql
+	}
+
+	var clauses []string
+	if project != "" {
+		clauses = append(clauses, "project = "+quoteJQL(project))
+	}
+
+	keys := make([]string, 0, len(filters))
+	for k := range filters {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		field, ok := jqlFilterFields[key]
+		if !ok {
+			continue
+		}
+		values := toStrings(filters[key])
+		if len(values) == 1 {
+			clauses = append(clauses, field+" = "+quoteJQL(values[0]))
+			continue
+		}
+		quoted := make([]string, len(values))
+		for i, v := range values {
+			quoted[i] = quoteJQL(v)
+		}
+		clauses = append(clauses, field+" in ("+strings.Join(quoted, ", ")+")")
+	}
+
+	return strings.Join(clauses, " AND ") + " ORDER BY key ASC"
+}
+
+// jqlFilterFields maps simple filter keys to JQL field names.
+var jqlFilterFields = map[string]string{
+	"status":   "status",
+	"assignee": "assignee",
+	"labels":   "labels",
+	"type":     "issuetype",
+}
+
+func quoteJQL(s string) string {
+	return strconv.Quote(s)
+}
+
+func toStrings(val any) []string {
+	switch v := val.(type) {
+	case []any:
+		out := make([]string, len(v))
+		for i, item := range v {
+			out[i] = fmt.Sprint(item)
+		}
+		return out
+	case []string:
+		return v
+	case string:
+		parts := strings.Split(v, ",")
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		return parts
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
+
+func fetchPage(client *http.Client, apiURL, jql, auth string, params url.Values) (*searchResponse, error) {
+	params.Set("jql", jql)
+	params.Set("maxResults", strconv.Itoa(maxResults))
+	params.Set("fields", searchFields)
+
+	req, err := http.NewRequest("GET", apiURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", auth)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Jira API returned %d: %s", resp.StatusCode, string(body))
+	}
+
+	var result searchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
+
+func issueToExternalTask(issue jiraIssue, baseURL string) sync.ExternalTask {
+	f := issue.Fields
+	task := sync.ExternalTask{
+		ExternalID:   issue.Key,
+		Title:        f.Summary,
+		Description:  descriptionText(f.Description),
+		Labels:       f.Labels,
+		URL:          baseURL + "/browse/" + issue.Key,
+		CreatedAt:    parseTime(f.Created),
+		UpdatedAt:    parseTime(f.Updated),
+		Dependencies: blockingKeys(f.IssueLinks),
+	}
+
+	if f.Status != nil {
+		task.Status = f.Status.Name
+	}
+	if f.Priority != nil {
+		task.Priority = f.Priority.Name
+	}
+	if f.Assignee != nil {
+		task.Assignee = f.Assignee.Name
+		if task.Assignee == "" {
+			task.Assignee = f.Assignee.DisplayName
+		}
+	}
+	if f.IssueType != nil {
+		task.Extra = map[string]string{"issue_type": f.IssueType.Name}
+	}
+
+	return task
+}
+
+// blockingKeys returns the keys of issues that block this one, i.e. the
+// inward side of "blocks" links. The result is never nil so that removed
+// links clear dependencies on the next sync.
+func blockingKeys(links []jiraIssueLink) []string {
+	keys := []string{}
+	for _, link := range links {
+		if !strings.EqualFold(link.Type.Name, blocksLinkType) || link.InwardIssue == nil {
+			continue
+		}
+		keys = append(keys, link.InwardIssue.Key)
+	}
+	return keys
+}
+
+// Jira timestamps use a numeric zone offset without a colon, e.g.
+// 2024-01-15T10:30:00.000+0000.
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+func parseTime(s string) time.Time {
+	if t, err := time.Parse(jiraTimeLayout, s); err == nil {
+		return t
+	}
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
@@ -0,0 +1,481 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/driangle/taskmd/apps/cli/internal/sync"
+)
+
+func TestJiraSource_Name(t *testing.T) {
+	src := &JiraSource{}
+	if src.Name() != "jira" {
+		t.Fatalf("expected name %q, got %q", "jira", src.Name())
+	}
+}
+
+func TestJiraSource_ValidateConfig(t *testing.T) {
+	src := &JiraSource{}
+	tests := []struct {
+		name    string
+		cfg     sync.SourceConfig
+		wantErr string
+	}{
+		{
+			name: "valid with project",
+			cfg:  sync.SourceConfig{BaseURL: "https://example.atlassian.net", Project: "PROJ", TokenEnv: "JIRA_TOKEN"},
+		},
+		{
+			name: "valid with jql only",
+			cfg: sync.SourceConfig{BaseURL: "https://jira.example.com", TokenEnv: "JIRA_TOKEN",
+				Filters: map[string]any{"jql": "assignee = currentUser()"}},
+		},
+		{
+			name:    "missing base_url",
+			cfg:     sync.SourceConfig{Project: "PROJ", TokenEnv: "JIRA_TOKEN"},
+			wantErr: "base_url is required",
+		},
+		{
+			name:    "missing project and jql",
+			cfg:     sync.SourceConfig{BaseURL: "https://example.atlassian.net", TokenEnv: "JIRA_TOKEN"},
+			wantErr: "project or filters.jql is required",
+		},
+		{
+			name:    "missing token_env",
+			cfg:     sync.SourceConfig{BaseURL: "https://example.atlassian.net", Project: "PROJ"},
+			wantErr: "token_env is required",
+		},
+		{
+			name: "bad api_version",
+			cfg: sync.SourceConfig{BaseURL: "https://example.atlassian.net", Project: "PROJ", TokenEnv: "JIRA_TOKEN",
+				Filters: map[string]any{"api_version": 4}},
+			wantErr: "api_version must be 2 or 3",
+		},
+		{
+			name: "bad deployment",
+			cfg: sync.SourceConfig{BaseURL: "https://jira.example.com", Project: "PROJ", TokenEnv: "JIRA_TOKEN",
+				Filters: map[string]any{"deployment": "datacenter"}},
+			wantErr: "deployment must be cloud or server",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := src.ValidateConfig(tt.cfg)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestJiraSource_FetchTasks_Basic(t *testing.T) {
+	resp := searchResponse{
+		Total: 2,
+		Issues: []jiraIssue{
+			{
+				Key: "PROJ-1",
+				Fields: jiraFields{
+					Summary:     "Fix login",
+					Description: json.RawMessage(`"Users cannot log in"`),
+					Status:      &jiraNamed{Name: "In Progress"},
+					Priority:    &jiraNamed{Name: "High"},
+					IssueType:   &jiraNamed{Name: "Bug"},
+					Assignee:    &jiraUser{Name: "alice", DisplayName: "Alice"},
+					Labels:      []string{"auth"},
+					Created:     "2024-01-15T10:30:00.000+0000",
+					Updated:     "2024-01-16T08:00:00.000+0100",
+				},
+			},
+			{
+				Key: "PROJ-2",
+				Fields: jiraFields{
+					Summary:  "Add SSO",
+					Status:   &jiraNamed{Name: "To Do"},
+					Assignee: &jiraUser{DisplayName: "Bob Smith"},
+				},
+			},
+		},
+	}
+
+	var gotPath string
+	var gotQuery map[string][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer pat-token" {
+			t.Errorf("unexpected auth header: %s", r.Header.Get("Authorization"))
+		}
+		gotPath = r.URL.Path
+		gotQuery = r.URL.Query()
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_JIRA_TOKEN", "pat-token")
+
+	src := &JiraSource{HTTPClient: srv.Client()}
+	cfg := sync.SourceConfig{
+		BaseURL:  srv.URL + "/",
+		Project:  "PROJ",
+		TokenEnv: "TEST_JIRA_TOKEN",
+	}
+
+	tasks, err := src.FetchTasks(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotPath != "/rest/api/2/search" {
+		t.Errorf("expected v2 search path, got %q", gotPath)
+	}
+	if jql := gotQuery["jql"][0]; jql != `project = "PROJ" ORDER BY key ASC` {
+		t.Errorf("unexpected jql: %q", jql)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(tasks))
+	}
+
+	task := tasks[0]
+	if task.ExternalID != "PROJ-1" || task.Title != "Fix login" || task.Description != "Users cannot log in" {
+		t.Errorf("unexpected task: %+v", task)
+	}
+	if task.Status != "In Progress" || task.Priority != "High" || task.Assignee != "alice" {
+		t.Errorf("unexpected status/priority/assignee: %+v", task)
+	}
+	if task.URL != srv.URL+"/browse/PROJ-1" {
+		t.Errorf("unexpected URL: %q", task.URL)
+	}
+	if task.CreatedAt.IsZero() || task.UpdatedAt.IsZero() {
+		t.Errorf("expected parsed timestamps, got %v / %v", task.CreatedAt, task.UpdatedAt)
+	}
+	if task.Extra["issue_type"] != "Bug" {
+		t.Errorf("expected issue_type Bug, got %v", task.Extra)
+	}
+	if tasks[1].Assignee != "Bob Smith" {
+		t.Errorf("expected display name fallback, got %q", tasks[1].Assignee)
+	}
+}
+
+func TestJiraSource_FetchTasks_BasicAuth(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "me@example.com" || pass != "api-token" {
+			t.Errorf("unexpected basic auth: %q %q %v", user, pass, ok)
+		}
+		json.NewEncoder(w).Encode(searchResponse{})
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_JIRA_TOKEN", "api-token")
+	t.Setenv("TEST_JIRA_USER", "me@example.com")
+
+	src := &JiraSource{HTTPClient: srv.Client()}
+	cfg := sync.SourceConfig{
+		BaseURL:  srv.URL,
+		Project:  "PROJ",
+		TokenEnv: "TEST_JIRA_TOKEN",
+		UserEnv:  "TEST_JIRA_USER",
+	}
+
+	if _, err := src.FetchTasks(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestJiraSource_FetchTasks_MissingUserEnv(t *testing.T) {
+	t.Setenv("TEST_JIRA_TOKEN", "api-token")
+
+	src := &JiraSource{}
+	cfg := sync.SourceConfig{
+		BaseURL:  "https://example.atlassian.net",
+		Project:  "PROJ",
+		TokenEnv: "TEST_JIRA_TOKEN",
+		UserEnv:  "UNSET_JIRA_USER_12345",
+	}
+
+	_, err := src.FetchTasks(cfg)
+	if err == nil || !strings.Contains(err.Error(), "UNSET_JIRA_USER_12345") {
+		t.Fatalf("expected unset user_env error, got %v", err)
+	}
+}
+
+func TestJiraSource_FetchTasks_ServerPagination(t *testing.T) {
+	const total = maxResults + 5
+	var startAts []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/search" {
+			t.Errorf("expected the Server search path, got %q", r.URL.Path)
+		}
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		startAts = append(startAts, r.URL.Query().Get("startAt"))
+
+		end := min(startAt+maxResults, total)
+		resp := searchResponse{StartAt: startAt, MaxResults: maxResults, Total: total}
+		for i := startAt; i < end; i++ {
+			resp.Issues = append(resp.Issues, jiraIssue{
+				Key:    fmt.Sprintf("PROJ-%d", i+1),
+				Fields: jiraFields{Summary: fmt.Sprintf("Issue %d", i+1)},
+			})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_JIRA_TOKEN", "tok")
+
+	src := &JiraSource{HTTPClient: srv.Client()}
+	cfg := sync.SourceConfig{BaseURL: srv.URL, Project: "PROJ", TokenEnv: "TEST_JIRA_TOKEN"}
+
+	tasks, err := src.FetchTasks(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tasks) != total {
+		t.Fatalf("expected %d tasks, got %d", total, len(tasks))
+	}
+	if strings.Join(startAts, ",") != "0,100" {
+		t.Errorf("expected requests at startAt 0,100, got %v", startAts)
+	}
+}
+
+func TestJiraSource_FetchTasks_CloudPagination(t *testing.T) {
+	const total = maxResults + 5
+	var tokens []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/search/jql" {
+			t.Errorf("expected the Cloud search path, got %q", r.URL.Path)
+		}
+		if r.URL.Query().Has("startAt") {
+			t.Errorf("expected no startAt on Cloud, got %q", r.URL.RawQuery)
+		}
+		token := r.URL.Query().Get("nextPageToken")
+		tokens = append(tokens, token)
+
+		start := 0
+		if token != "" {
+			start, _ = strconv.Atoi(strings.TrimPrefix(token, "page-"))
+		}
+		end := min(start+maxResults, total)
+		// Cloud reports no total; only the token says whether more follow.
+		resp := searchResponse{IsLast: end == total}
+		if !resp.IsLast {
+			resp.NextPageToken = fmt.Sprintf("page-%d", end)
+		}
+		for i := start; i < end; i++ {
+			resp.Issues = append(resp.Issues, jiraIssue{Key: fmt.Sprintf("PROJ-%d", i+1)})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_JIRA_TOKEN", "tok")
+
+	src := &JiraSource{HTTPClient: srv.Client()}
+	cfg := sync.SourceConfig{
+		BaseURL:  srv.URL,
+		Project:  "PROJ",
+		TokenEnv: "TEST_JIRA_TOKEN",
+		Filters:  map[string]any{"deployment": "cloud", "api_version": 3},
+	}
+
+	tasks, err := src.FetchTasks(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tasks) != total {
+		t.Fatalf("expected %d tasks, got %d", total, len(tasks))
+	}
+	if strings.Join(tokens, ",") != ",page-100" {
+		t.Errorf("expected requests with tokens \"\", page-100, got %q", tokens)
+	}
+}
+
+func TestIsCloud(t *testing.T) {
+	tests := []struct {
+		baseURL    string
+		deployment string
+		want       bool
+	}{
+		{"https://myorg.atlassian.net", "", true},
+		{"https://jira.example.com", "", false},
+		{"https://jira.example.com", "cloud", true},
+		{"https://myorg.atlassian.net", "server", false},
+	}
+	for _, tt := range tests {
+		cfg := sync.SourceConfig{BaseURL: tt.baseURL}
+		if tt.deployment != "" {
+			cfg.Filters = map[string]any{"deployment": tt.deployment}
+		}
+		if got := isCloud(cfg); got != tt.want {
+			t.Errorf("isCloud(%s, %q) = %v, want %v", tt.baseURL, tt.deployment, got, tt.want)
+		}
+	}
+}
+
+func TestJiraSource_FetchTasks_JQLAndVersion(t *testing.T) {
+	var gotPath, gotJQL string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotJQL = r.URL.Query().Get("jql")
+		json.NewEncoder(w).Encode(searchResponse{})
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_JIRA_TOKEN", "tok")
+
+	src := &JiraSource{HTTPClient: srv.Client()}
+	cfg := sync.SourceConfig{
+		BaseURL:  srv.URL,
+		Project:  "PROJ",
+		TokenEnv: "TEST_JIRA_TOKEN",
+		Filters: map[string]any{
+			"jql":         "project = PROJ AND sprint in openSprints()",
+			"api_version": 3,
+		},
+	}
+
+	if _, err := src.FetchTasks(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotPath != "/rest/api/3/search" {
+		t.Errorf("expected v3 search path, got %q", gotPath)
+	}
+	if gotJQL != "project = PROJ AND sprint in openSprints()" {
+		t.Errorf("expected jql to be passed through, got %q", gotJQL)
+	}
+}
+
+func TestBuildJQL_SimpleFilters(t *testing.T) {
+	got := buildJQL("PROJ", map[string]any{
+		"status":   []any{"To Do", "In Progress"},
+		"assignee": "alice",
+		"type":     "Bug",
+		"ignored":  "x",
+	})
+	want := `project = "PROJ" AND assignee = "alice" AND status in ("To Do", "In Progress") AND issuetype = "Bug" ORDER BY key ASC`
+	if got != want {
+		t.Errorf("buildJQL() =\n  %s\nwant\n  %s", got, want)
+	}
+}
+
+func TestJiraSource_FetchTasks_BlocksLinks(t *testing.T) {
+	resp := searchResponse{
+		Total: 1,
+		Issues: []jiraIssue{{
+			Key: "PROJ-3",
+			Fields: jiraFields{
+				Summary: "Deploy",
+				IssueLinks: []jiraIssueLink{
+					{Type: jiraLinkType{Name: "Blocks", Inward: "is blocked by", Outward: "blocks"}, InwardIssue: &jiraIssue{Key: "PROJ-1"}},
+					{Type: jiraLinkType{Name: "Blocks"}, OutwardIssue: &jiraIssue{Key: "PROJ-9"}},
+					{Type: jiraLinkType{Name: "Relates"}, InwardIssue: &jiraIssue{Key: "PROJ-5"}},
+					{Type: jiraLinkType{Name: "Blocks"}, InwardIssue: &jiraIssue{Key: "OTHER-2"}},
+				},
+			},
+		}},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_JIRA_TOKEN", "tok")
+
+	src := &JiraSource{HTTPClient: srv.Client()}
+	tasks, err := src.FetchTasks(sync.SourceConfig{BaseURL: srv.URL, Project: "PROJ", TokenEnv: "TEST_JIRA_TOKEN"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	deps := tasks[0].Dependencies
+	if len(deps) != 2 || deps[0] != "PROJ-1" || deps[1] != "OTHER-2" {
+		t.Errorf("expected dependencies [PROJ-1 OTHER-2], got %v", deps)
+	}
+}
+
+func TestJiraSource_FetchTasks_NoLinksGivesEmptyDependencies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(searchResponse{Total: 1, Issues: []jiraIssue{{Key: "PROJ-1"}}})
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_JIRA_TOKEN", "tok")
+
+	src := &JiraSource{HTTPClient: srv.Client()}
+	tasks, err := src.FetchTasks(sync.SourceConfig{BaseURL: srv.URL, Project: "PROJ", TokenEnv: "TEST_JIRA_TOKEN"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tasks[0].Dependencies == nil {
+		t.Error("expected non-nil dependencies so removed links are cleared")
+	}
+}
+
+func TestJiraSource_FetchTasks_APIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"errorMessages":["Error in the JQL Query"]}`)
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_JIRA_TOKEN", "tok")
+
+	src := &JiraSource{HTTPClient: srv.Client()}
+	_, err := src.FetchTasks(sync.SourceConfig{BaseURL: srv.URL, Project: "PROJ", TokenEnv: "TEST_JIRA_TOKEN"})
+	if err == nil {
+		t.Fatal("expected error for API error response")
+	}
+	if !strings.Contains(err.Error(), "400") || !strings.Contains(err.Error(), "JQL") {
+		t.Errorf("expected error with status and message, got: %v", err)
+	}
+}
+
+func TestDescriptionText_ADF(t *testing.T) {
+	raw := json.RawMessage(`{
+		"type": "doc", "version": 1,
+		"content": [
+			{"type": "heading", "attrs": {"level": 2}, "content": [{"type": "text", "text": "Steps"}]},
+			{"type": "paragraph", "content": [
+				{"type": "text", "text": "Run "},
+				{"type": "text", "text": "make", "marks": [{"type": "code"}]},
+				{"type": "text", "text": " then see "},
+				{"type": "text", "text": "docs", "marks": [{"type": "link", "attrs": {"href": "https://example.com"}}]}
+			]},
+			{"type": "bulletList", "content": [
+				{"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "one", "marks": [{"type": "strong"}]}]}]},
+				{"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "two"}]}]}
+			]},
+			{"type": "codeBlock", "attrs": {"language": "go"}, "content": [{"type": "text", "text": "fmt.Println()"}]}
+		]
+	}`)
+
+	want := "## Steps\n\nRun `make` then see [docs](https://example.com)\n\n- **one**\n- two\n\n```go\nfmt.Println()\n```"
+	if got := descriptionText(raw); got != want {
+		t.Errorf("descriptionText() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestDescriptionText_PlainAndNull(t *testing.T) {
+	if got := descriptionText(json.RawMessage(`"h1. Title"`)); got != "h1. Title" {
+		t.Errorf("expected plain string passthrough, got %q", got)
+	}
+	if got := descriptionText(json.RawMessage(`null`)); got != "" {
+		t.Errorf("expected empty for null, got %q", got)
+	}
+}
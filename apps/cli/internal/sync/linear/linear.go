@@ -0,0 +1,349 @@
+package linear
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+	"github.com/driangle/taskmd/apps/cli/internal/sync"
+)
+
+const (
+	defaultBaseURL = "https://api.linear.app/graphql"
+	perPage        = 100
+	maxPages       = 50
+)
+
+const issuesQuery = `query Issues($filter: IssueFilter, $first: Int!, $after: String) {
+  issues(filter: $filter, first: $first, after: $after, orderBy: createdAt) {
+    nodes {
+      identifier
+      title
+      description
+      url
+      priority
+      priorityLabel
+      createdAt
+      updatedAt
+      state { name type }
+      assignee { name displayName }
+      labels { nodes { name } }
+      project { name }
+      cycle { number }
+    }
+    pageInfo { hasNextPage endCursor }
+  }
+}`
+
+// LinearSource fetches tasks from Linear issues via the GraphQL API.
+//
+// Issues are selected by team key (project in config, or filters.team) and
+// optionally narrowed by filters.project, filters.state, filters.labels and
+// filters.assignee.
+type LinearSource struct {
+	// HTTPClient allows injecting a custom client for testing. Nil uses http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+func init() {
+	sync.Register(&LinearSource{})
+}
+
+func (l *LinearSource) Name() string { return "linear" }
+
+func (l *LinearSource) ValidateConfig(cfg sync.SourceConfig) error {
+	if cfg.Project == "" && cfg.Filters["team"] == nil && cfg.Filters["project"] == nil {
+		return fmt.Errorf("project (team key), filters.team or filters.project is required")
+	}
+	if cfg.TokenEnv == "" {
+		return fmt.Errorf("token_env is required")
+	}
+	return nil
+}
+
+// DefaultFieldMap maps Linear workflow state types and numeric priorities
+// onto taskmd values. field_map entries keyed by state name, state type,
+// priority label or priority number override these.
+func (l *LinearSource) DefaultFieldMap() sync.FieldMap {
+	return sync.FieldMap{
+		Status: map[string]string{
+			"triage":    string(model.StatusPending),
+			"backlog":   string(model.StatusPending),
+			"unstarted": string(model.StatusPending),
+			"started":   string(model.StatusInProgress),
+			"completed": string(model.StatusCompleted),
+			"canceled":  string(model.StatusCancelled),
+		},
+		Priority: map[string]string{
+			"1": string(model.PriorityCritical),
+			"2": string(model.PriorityHigh),
+			"3": string(model.PriorityMedium),
+			"4": string(model.PriorityLow),
+		},
+	}
+}
+
+func (l *LinearSource) FetchTasks(cfg sync.SourceConfig) ([]sync.ExternalTask, error) {
+	token := os.Getenv(cfg.TokenEnv)
+	if token == "" {
+		return nil, fmt.Errorf("environment variable %q is not set", cfg.TokenEnv)
+	}
+
+	apiURL := cfg.BaseURL
+	if apiURL == "" {
+		apiURL = defaultBaseURL
+	}
+
+	client := l.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	vars := map[string]any{
+		"filter": buildFilter(cfg.Project, cfg.Filters),
+		"first":  perPage,
+	}
+
+	var tasks []sync.ExternalTask
+
+	for page := 0; page < maxPages; page++ {
+		conn, err := fetchPage(client, apiURL, token, vars)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, issue := range conn.Nodes {
+			tasks = append(tasks, issueToExternalTask(issue, cfg.FieldMap))
+		}
+
+		if !conn.PageInfo.HasNextPage || conn.PageInfo.EndCursor == "" {
+			break
+		}
+		vars["after"] = conn.PageInfo.EndCursor
+	}
+
+	return tasks, nil
+}
+
+// JSON request/response types
+
+type graphqlRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+type graphqlResponse struct {
+	Data struct {
+		Issues issueConnection `json:"issues"`
+	} `json:"data"`
+	Errors []graphqlError `json:"errors"`
+}
+
+type graphqlError struct {
+	Message string `json:"message"`
+}
+
+type issueConnection struct {
+	Nodes    []linearIssue `json:"nodes"`
+	PageInfo pageInfo      `json:"pageInfo"`
+}
+
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+type linearIssue struct {
+	Identifier    string       `json:"identifier"`
+	Title         string       `json:"title"`
+	Description   string       `json:"description"`
+	URL           string       `json:"url"`
+	Priority      int          `json:"priority"`
+	PriorityLabel string       `json:"priorityLabel"`
+	CreatedAt     time.Time    `json:"createdAt"`
+	UpdatedAt     time.Time    `json:"updatedAt"`
+	State         *linearState `json:"state"`
+	Assignee      *linearUser  `json:"assignee"`
+	Labels        labelConn    `json:"labels"`
+	Project       *linearNamed `json:"project"`
+	Cycle         *linearCycle `json:"cycle"`
+}
+
+type linearState struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type linearUser struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type labelConn struct {
+	Nodes []linearNamed `json:"nodes"`
+}
+
+type linearNamed struct {
+	Name string `json:"name"`
+}
+
+type linearCycle struct {
+	Number int `json:"number"`
+}
+
+// helpers
+
+// buildFilter builds the IssueFilter variable from the team key and filters.
+func buildFilter(team string, filters map[string]any) map[string]any {
+	filter := map[string]any{}
+
+	if v, ok := filters["team"]; ok {
+		team = fmt.Sprint(v)
+	}
+	if team != "" {
+		filter["team"] = map[string]any{"key": map[string]any{"eq": team}}
+	}
+
+	for key, field := range namedFilterFields {
+		if v, ok := filters[key]; ok {
+			filter[field] = map[string]any{"name": nameComparator(v)}
+		}
+	}
+
+	if v, ok := filters["assignee"]; ok {
+		filter["assignee"] = map[string]any{"email": map[string]any{"eq": fmt.Sprint(v)}}
+	}
+
+	return filter
+}
+
+// namedFilterFields maps filter keys to IssueFilter fields matched by name.
+var namedFilterFields = map[string]string{
+	"project": "project",
+	"state":   "state",
+	"labels":  "labels",
+}
+
+// nameComparator matches a single name with eq and a list with in.
+func nameComparator(val any) map[string]any {
+	switch v := val.(type) {
+	case []any:
+		names := make([]string, len(v))
+		for i, item := range v {
+			names[i] = fmt.Sprint(item)
+		}
+		return map[string]any{"in": names}
+	case []string:
+		return map[string]any{"in": v}
+	default:
+		return map[string]any{"eq": fmt.Sprint(v)}
+	}
+}
+
+func fetchPage(client *http.Client, apiURL, token string, vars map[string]any) (*issueConnection, error) {
+	body, err := json.Marshal(graphqlRequest{Query: issuesQuery, Variables: vars})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", apiURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Personal API keys are sent as-is; OAuth tokens need a Bearer prefix,
+	// which users include in the environment variable themselves.
+	req.Header.Set("Authorization", token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Linear API returned %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var result graphqlResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(result.Errors) > 0 {
+		msgs := make([]string, len(result.Errors))
+		for i, e := range result.Errors {
+			msgs[i] = e.Message
+		}
+		return nil, fmt.Errorf("Linear API error: %s", strings.Join(msgs, "; "))
+	}
+
+	return &result.Data.Issues, nil
+}
+
+func issueToExternalTask(issue linearIssue, fm sync.FieldMap) sync.ExternalTask {
+	task := sync.ExternalTask{
+		ExternalID:  issue.Identifier,
+		Title:       issue.Title,
+		Description: issue.Description,
+		Priority:    priorityKey(issue, fm),
+		URL:         issue.URL,
+		CreatedAt:   issue.CreatedAt,
+		UpdatedAt:   issue.UpdatedAt,
+		Extra:       map[string]string{},
+	}
+
+	if issue.State != nil {
+		task.Status = stateKey(*issue.State, fm)
+	}
+
+	if issue.Assignee != nil {
+		task.Assignee = issue.Assignee.DisplayName
+		if task.Assignee == "" {
+			task.Assignee = issue.Assignee.Name
+		}
+	}
+
+	for _, l := range issue.Labels.Nodes {
+		task.Labels = append(task.Labels, l.Name)
+	}
+
+	if issue.Project != nil {
+		task.Extra["project"] = issue.Project.Name
+	}
+	if issue.Cycle != nil {
+		task.Extra["cycle"] = strconv.Itoa(issue.Cycle.Number)
+	}
+
+	return task
+}
+
+// stateKey returns the workflow state name when field_map maps it, so
+// per-state overrides win; otherwise the state type.
+func stateKey(state linearState, fm sync.FieldMap) string {
+	if _, ok := fm.Status[state.Name]; ok {
+		return state.Name
+	}
+	return state.Type
+}
+
+// priorityKey returns the priority label when field_map maps it; otherwise
+// the numeric priority. Linear uses 0 for "No priority", which maps to none.
+func priorityKey(issue linearIssue, fm sync.FieldMap) string {
+	if _, ok := fm.Priority[issue.PriorityLabel]; ok {
+		return issue.PriorityLabel
+	}
+	if issue.Priority == 0 {
+		return ""
+	}
+	return strconv.Itoa(issue.Priority)
+}
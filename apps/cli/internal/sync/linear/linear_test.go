@@ -0,0 +1,311 @@
+package linear
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/driangle/taskmd/apps/cli/internal/sync"
+)
+
+// graphqlHandler decodes each GraphQL request and answers with respond.
+func graphqlHandler(t *testing.T, respond func(r *http.Request, req graphqlRequest) any) http.HandlerFunc {
+	t.Helper()
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		var req graphqlRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		json.NewEncoder(w).Encode(respond(r, req))
+	}
+}
+
+func issuesPayload(nodes []linearIssue, hasNext bool, cursor string) map[string]any {
+	return map[string]any{
+		"data": map[string]any{
+			"issues": issueConnection{
+				Nodes:    nodes,
+				PageInfo: pageInfo{HasNextPage: hasNext, EndCursor: cursor},
+			},
+		},
+	}
+}
+
+func TestLinearSource_Name(t *testing.T) {
+	src := &LinearSource{}
+	if src.Name() != "linear" {
+		t.Fatalf("expected name %q, got %q", "linear", src.Name())
+	}
+}
+
+func TestLinearSource_ValidateConfig(t *testing.T) {
+	src := &LinearSource{}
+
+	if err := src.ValidateConfig(sync.SourceConfig{Project: "ENG", TokenEnv: "LINEAR_API_KEY"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := src.ValidateConfig(sync.SourceConfig{TokenEnv: "LINEAR_API_KEY", Filters: map[string]any{"project": "Q1"}}); err != nil {
+		t.Errorf("unexpected error for project filter: %v", err)
+	}
+	if err := src.ValidateConfig(sync.SourceConfig{TokenEnv: "LINEAR_API_KEY"}); err == nil {
+		t.Error("expected error when no team or project is set")
+	}
+	if err := src.ValidateConfig(sync.SourceConfig{Project: "ENG"}); err == nil {
+		t.Error("expected error for missing token_env")
+	}
+}
+
+func TestLinearSource_FetchTasks_Basic(t *testing.T) {
+	nodes := []linearIssue{
+		{
+			Identifier:    "ENG-1",
+			Title:         "Fix crash",
+			Description:   "Crashes on start",
+			URL:           "https://linear.app/acme/issue/ENG-1",
+			Priority:      1,
+			PriorityLabel: "Urgent",
+			State:         &linearState{Name: "In Progress", Type: "started"},
+			Assignee:      &linearUser{Name: "Alice Smith", DisplayName: "alice"},
+			Labels:        labelConn{Nodes: []linearNamed{{Name: "bug"}}},
+			Project:       &linearNamed{Name: "Q1 Roadmap"},
+			Cycle:         &linearCycle{Number: 7},
+		},
+		{
+			Identifier:    "ENG-2",
+			Title:         "Polish",
+			PriorityLabel: "No priority",
+			State:         &linearState{Name: "Backlog", Type: "backlog"},
+		},
+	}
+
+	var gotAuth string
+	var gotVars map[string]any
+	srv := httptest.NewServer(graphqlHandler(t, func(r *http.Request, req graphqlRequest) any {
+		gotAuth = r.Header.Get("Authorization")
+		gotVars = req.Variables
+		return issuesPayload(nodes, false, "")
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_LINEAR_KEY", "lin_api_123")
+
+	src := &LinearSource{HTTPClient: srv.Client()}
+	cfg := sync.SourceConfig{Project: "ENG", BaseURL: srv.URL, TokenEnv: "TEST_LINEAR_KEY"}
+
+	tasks, err := src.FetchTasks(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if gotAuth != "lin_api_123" {
+		t.Errorf("expected raw API key in Authorization, got %q", gotAuth)
+	}
+	filter, _ := json.Marshal(gotVars["filter"])
+	if string(filter) != `{"team":{"key":{"eq":"ENG"}}}` {
+		t.Errorf("unexpected filter: %s", filter)
+	}
+
+	if len(tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(tasks))
+	}
+
+	task := tasks[0]
+	if task.ExternalID != "ENG-1" || task.Title != "Fix crash" || task.Description != "Crashes on start" {
+		t.Errorf("unexpected task: %+v", task)
+	}
+	if task.Status != "started" || task.Priority != "1" || task.Assignee != "alice" {
+		t.Errorf("unexpected status/priority/assignee: %q %q %q", task.Status, task.Priority, task.Assignee)
+	}
+	if len(task.Labels) != 1 || task.Labels[0] != "bug" {
+		t.Errorf("unexpected labels: %v", task.Labels)
+	}
+	if task.Extra["project"] != "Q1 Roadmap" || task.Extra["cycle"] != "7" {
+		t.Errorf("unexpected extra: %v", task.Extra)
+	}
+	if tasks[1].Priority != "" {
+		t.Errorf("expected no priority for 0, got %q", tasks[1].Priority)
+	}
+}
+
+func TestLinearSource_FetchTasks_Pagination(t *testing.T) {
+	var afters []any
+	srv := httptest.NewServer(graphqlHandler(t, func(_ *http.Request, req graphqlRequest) any {
+		after := req.Variables["after"]
+		afters = append(afters, after)
+		if after == nil {
+			return issuesPayload([]linearIssue{{Identifier: "ENG-1"}, {Identifier: "ENG-2"}}, true, "cursor-1")
+		}
+		return issuesPayload([]linearIssue{{Identifier: "ENG-3"}}, false, "cursor-2")
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_LINEAR_KEY", "key")
+
+	src := &LinearSource{HTTPClient: srv.Client()}
+	tasks, err := src.FetchTasks(sync.SourceConfig{Project: "ENG", BaseURL: srv.URL, TokenEnv: "TEST_LINEAR_KEY"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tasks) != 3 {
+		t.Fatalf("expected 3 tasks, got %d", len(tasks))
+	}
+	if len(afters) != 2 || afters[0] != nil || afters[1] != "cursor-1" {
+		t.Errorf("expected cursors [nil cursor-1], got %v", afters)
+	}
+}
+
+func TestLinearSource_FetchTasks_Filters(t *testing.T) {
+	var gotFilter string
+	srv := httptest.NewServer(graphqlHandler(t, func(_ *http.Request, req graphqlRequest) any {
+		b, _ := json.Marshal(req.Variables["filter"])
+		gotFilter = string(b)
+		return issuesPayload(nil, false, "")
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_LINEAR_KEY", "key")
+
+	src := &LinearSource{HTTPClient: srv.Client()}
+	cfg := sync.SourceConfig{
+		BaseURL:  srv.URL,
+		TokenEnv: "TEST_LINEAR_KEY",
+		Filters: map[string]any{
+			"team":     "ENG",
+			"project":  "Q1 Roadmap",
+			"state":    []any{"Todo", "In Progress"},
+			"assignee": "alice@example.com",
+		},
+	}
+
+	if _, err := src.FetchTasks(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		`"team":{"key":{"eq":"ENG"}}`,
+		`"project":{"name":{"eq":"Q1 Roadmap"}}`,
+		`"state":{"name":{"in":["Todo","In Progress"]}}`,
+		`"assignee":{"email":{"eq":"alice@example.com"}}`,
+	} {
+		if !strings.Contains(gotFilter, want) {
+			t.Errorf("expected filter to contain %s, got %s", want, gotFilter)
+		}
+	}
+}
+
+func TestLinearSource_FetchTasks_GraphQLError(t *testing.T) {
+	srv := httptest.NewServer(graphqlHandler(t, func(*http.Request, graphqlRequest) any {
+		return map[string]any{"errors": []graphqlError{{Message: "Authentication required"}}}
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_LINEAR_KEY", "key")
+
+	src := &LinearSource{HTTPClient: srv.Client()}
+	_, err := src.FetchTasks(sync.SourceConfig{Project: "ENG", BaseURL: srv.URL, TokenEnv: "TEST_LINEAR_KEY"})
+	if err == nil || !strings.Contains(err.Error(), "Authentication required") {
+		t.Fatalf("expected GraphQL error, got %v", err)
+	}
+}
+
+func TestLinearSource_FetchTasks_HTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "bad request")
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_LINEAR_KEY", "key")
+
+	src := &LinearSource{HTTPClient: srv.Client()}
+	_, err := src.FetchTasks(sync.SourceConfig{Project: "ENG", BaseURL: srv.URL, TokenEnv: "TEST_LINEAR_KEY"})
+	if err == nil || !strings.Contains(err.Error(), "400") {
+		t.Fatalf("expected status code error, got %v", err)
+	}
+}
+
+func TestLinearSource_MappingWithOverrides(t *testing.T) {
+	src := &LinearSource{}
+	fm := sync.FieldMap{
+		Status:   map[string]string{"In Review": "blocked", "backlog": "blocked"},
+		Priority: map[string]string{"Low": "medium"},
+	}.WithDefaults(src.DefaultFieldMap())
+
+	tests := []struct {
+		name         string
+		issue        linearIssue
+		wantStatus   string
+		wantPriority string
+	}{
+		{"started type", linearIssue{State: &linearState{Name: "In Progress", Type: "started"}, Priority: 2, PriorityLabel: "High"}, "in-progress", "high"},
+		{"state name override", linearIssue{State: &linearState{Name: "In Review", Type: "started"}, Priority: 1, PriorityLabel: "Urgent"}, "blocked", "critical"},
+		{"state type override", linearIssue{State: &linearState{Name: "Icebox", Type: "backlog"}, Priority: 3, PriorityLabel: "Medium"}, "blocked", "medium"},
+		{"canceled", linearIssue{State: &linearState{Name: "Canceled", Type: "canceled"}, Priority: 4, PriorityLabel: "Low"}, "cancelled", "medium"},
+		{"completed no priority", linearIssue{State: &linearState{Name: "Done", Type: "completed"}, PriorityLabel: "No priority"}, "completed", ""},
+		{"unstarted", linearIssue{State: &linearState{Name: "Todo", Type: "unstarted"}}, "pending", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapped := sync.MapExternalTask(issueToExternalTask(tt.issue, fm), fm)
+			if mapped.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", mapped.Status, tt.wantStatus)
+			}
+			if mapped.Priority != tt.wantPriority {
+				t.Errorf("priority = %q, want %q", mapped.Priority, tt.wantPriority)
+			}
+		})
+	}
+}
+
+func TestLinearSource_EngineAppliesDefaults(t *testing.T) {
+	srv := httptest.NewServer(graphqlHandler(t, func(*http.Request, graphqlRequest) any {
+		return issuesPayload([]linearIssue{{
+			Identifier: "ENG-1",
+			Title:      "Ship it",
+			Priority:   2,
+			State:      &linearState{Name: "In Progress", Type: "started"},
+		}}, false, "")
+	}))
+	defer srv.Close()
+
+	t.Setenv("TEST_LINEAR_KEY", "key")
+
+	// Swap in a source using the test server's client.
+	sync.Register(&LinearSource{HTTPClient: srv.Client()})
+	defer sync.Register(&LinearSource{})
+
+	dir := t.TempDir()
+	engine := &sync.Engine{ConfigDir: dir}
+	result, err := engine.RunSync(sync.SourceConfig{
+		Name:      "linear",
+		Project:   "ENG",
+		BaseURL:   srv.URL,
+		TokenEnv:  "TEST_LINEAR_KEY",
+		OutputDir: filepath.Join(dir, "tasks"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Created) != 1 {
+		t.Fatalf("expected 1 created, got %d", len(result.Created))
+	}
+
+	data, err := os.ReadFile(result.Created[0].FilePath)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	content := string(data)
+	if !strings.Contains(content, "status: in-progress") || !strings.Contains(content, "priority: high") {
+		t.Errorf("expected default mappings in file, got:\n%s", content)
+	}
+}
@@ -1,5 +1,11 @@
 package sync
 
+import (
+	"sort"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
 // MappedTask holds the result of mapping an ExternalTask to taskmd fields.
 type MappedTask struct {
 	Title       string
@@ -9,6 +15,13 @@ type MappedTask struct {
 	Owner       string
 	Tags        []string
 	URL         string
+	// Dependencies holds local task IDs resolved by the engine; nil leaves
+	// the dependencies of an existing file untouched.
+	Dependencies []string
+	// Parent is the local ID of the parent task, resolved by the engine.
+	Parent string
+	Group  string
+	Effort string
 }
 
 // MapExternalTask converts an ExternalTask to taskmd fields using the FieldMap.
@@ -44,3 +57,40 @@ func mapField(value string, mapping map[string]string, fallback string) string {
 	}
 	return fallback
 }
+
+// ReverseMapTask applies a local task's title, status, tags and owner to ext,
+// translating taskmd values back into the source's vocabulary with reverse
+// field_map lookups. Tags and owner are only carried over when the field_map
+// maps labels and assignee in the first place.
+func ReverseMapTask(ext ExternalTask, local *model.Task, fm FieldMap) ExternalTask {
+	out := ext
+	out.Title = local.Title
+	out.Status = reverseField(string(local.Status), ext.Status, fm.Status)
+	if fm.LabelsToTags {
+		out.Labels = append([]string{}, local.Tags...)
+	}
+	if fm.AssigneeToOwner {
+		out.Assignee = local.Owner
+	}
+	return out
+}
+
+// reverseField returns the external value that maps to local. The current
+// external value is kept when it already maps to local or when no key does;
+// otherwise the alphabetically first matching key wins.
+func reverseField(local, current string, mapping map[string]string) string {
+	if mapping[current] == local {
+		return current
+	}
+	keys := make([]string, 0, len(mapping))
+	for k, v := range mapping {
+		if v == local {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return current
+	}
+	sort.Strings(keys)
+	return keys[0]
+}
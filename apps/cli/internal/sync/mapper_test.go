@@ -2,6 +2,8 @@ package sync
 
 import (
 	"testing"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
 )
 
 func TestMapExternalTask_StatusMapping(t *testing.T) {
@@ -175,3 +177,41 @@ func TestMapExternalTask_EmptyFieldMap(t *testing.T) {
 		t.Errorf("expected no tags, got %v", mapped.Tags)
 	}
 }
+
+func TestReverseMapTask(t *testing.T) {
+	ext := ExternalTask{ExternalID: "7", Title: "Old", Status: "open", Labels: []string{"bug"}, Assignee: "alice"}
+	local := &model.Task{Title: "New", Status: model.StatusCompleted, Tags: []string{"ui"}, Owner: "bob"}
+
+	fm := FieldMap{Status: map[string]string{"open": "pending", "closed": "completed", "done": "completed"}}
+	got := ReverseMapTask(ext, local, fm)
+
+	if got.Title != "New" || got.Status != "closed" {
+		t.Errorf("expected title New and first matching status key closed, got %q %q", got.Title, got.Status)
+	}
+	if got.Assignee != "alice" || len(got.Labels) != 1 || got.Labels[0] != "bug" {
+		t.Errorf("expected labels and assignee untouched without mapping, got %v %q", got.Labels, got.Assignee)
+	}
+
+	fm.LabelsToTags = true
+	fm.AssigneeToOwner = true
+	got = ReverseMapTask(ext, local, fm)
+	if got.Assignee != "bob" || len(got.Labels) != 1 || got.Labels[0] != "ui" {
+		t.Errorf("expected labels and assignee from local, got %v %q", got.Labels, got.Assignee)
+	}
+}
+
+func TestReverseMapTask_StatusKeepsCurrent(t *testing.T) {
+	fm := FieldMap{Status: map[string]string{"backlog": "pending", "todo": "pending"}}
+
+	// Current value already maps to the local status.
+	got := ReverseMapTask(ExternalTask{Status: "todo"}, &model.Task{Status: model.StatusPending}, fm)
+	if got.Status != "todo" {
+		t.Errorf("expected current status kept, got %q", got.Status)
+	}
+
+	// No key maps to the local status.
+	got = ReverseMapTask(ExternalTask{Status: "todo"}, &model.Task{Status: model.StatusBlocked}, fm)
+	if got.Status != "todo" {
+		t.Errorf("expected current status kept when unmapped, got %q", got.Status)
+	}
+}
@@ -0,0 +1,306 @@
+package sync
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
+)
+
+// FieldValues holds the taskmd values of the synced fields as of the last
+// sync. It is the common ancestor for three-way merges.
+type FieldValues struct {
+	Title        string   `yaml:"title"`
+	Status       string   `yaml:"status"`
+	Priority     string   `yaml:"priority,omitempty"`
+	Effort       string   `yaml:"effort,omitempty"`
+	Owner        string   `yaml:"owner,omitempty"`
+	Tags         []string `yaml:"tags,omitempty"`
+	Dependencies []string `yaml:"dependencies,omitempty"`
+	Parent       string   `yaml:"parent,omitempty"`
+	Group        string   `yaml:"group,omitempty"`
+	Description  string   `yaml:"description,omitempty"`
+}
+
+// FieldConflict describes a field that changed differently on both sides
+// since the last sync.
+type FieldConflict struct {
+	Field  string `json:"field"`
+	Base   string `json:"base"`
+	Local  string `json:"local"`
+	Remote string `json:"remote"`
+	// Resolution is ConflictLocal, ConflictRemote or ConflictSkip (unresolved).
+	Resolution string `json:"resolution"`
+}
+
+func fieldValuesFromMapped(m MappedTask) FieldValues {
+	return FieldValues{
+		Title:        m.Title,
+		Status:       m.Status,
+		Priority:     m.Priority,
+		Effort:       m.Effort,
+		Owner:        m.Owner,
+		Tags:         m.Tags,
+		Dependencies: m.Dependencies,
+		Parent:       m.Parent,
+		Group:        m.Group,
+		Description:  strings.TrimSpace(m.Description),
+	}
+}
+
+func fieldValuesFromTask(t *model.Task) FieldValues {
+	return FieldValues{
+		Title:        t.Title,
+		Status:       string(t.Status),
+		Priority:     string(t.Priority),
+		Effort:       string(t.Effort),
+		Owner:        t.Owner,
+		Tags:         t.Tags,
+		Dependencies: t.Dependencies,
+		Parent:       t.Parent,
+		Group:        t.Group,
+		Description:  strings.TrimSpace(t.Body),
+	}
+}
+
+// syncedField describes one field taking part in the merge.
+type syncedField struct {
+	name string
+	get  func(v *FieldValues) string
+	set  func(dst, src *FieldValues)
+}
+
+var syncedFields = []syncedField{
+	{"title", func(v *FieldValues) string { return v.Title }, func(d, s *FieldValues) { d.Title = s.Title }},
+	{"status", func(v *FieldValues) string { return v.Status }, func(d, s *FieldValues) { d.Status = s.Status }},
+	{"priority", func(v *FieldValues) string { return v.Priority }, func(d, s *FieldValues) { d.Priority = s.Priority }},
+	{"effort", func(v *FieldValues) string { return v.Effort }, func(d, s *FieldValues) { d.Effort = s.Effort }},
+	{"owner", func(v *FieldValues) string { return v.Owner }, func(d, s *FieldValues) { d.Owner = s.Owner }},
+	{"tags", func(v *FieldValues) string { return strings.Join(v.Tags, ", ") }, func(d, s *FieldValues) { d.Tags = s.Tags }},
+	{"dependencies", func(v *FieldValues) string { return strings.Join(v.Dependencies, ", ") }, func(d, s *FieldValues) { d.Dependencies = s.Dependencies }},
+	{"parent", func(v *FieldValues) string { return v.Parent }, func(d, s *FieldValues) { d.Parent = s.Parent }},
+	{"group", func(v *FieldValues) string { return v.Group }, func(d, s *FieldValues) { d.Group = s.Group }},
+	{"description", func(v *FieldValues) string { return v.Description }, func(d, s *FieldValues) { d.Description = s.Description }},
+}
+
+// mergeResult is the outcome of a field-level three-way merge.
+type mergeResult struct {
+	merged     FieldValues // values the local file ends up with
+	base       FieldValues // new common ancestor to store in state
+	toLocal    []string    // fields to write into the local file
+	localOnly  []string    // fields that differ from remote in favour of local
+	conflicts  []FieldConflict
+	unresolved int
+}
+
+// mergeFields merges each field separately. A field changed on one side only
+// takes that side's value; a field changed identically on both sides is
+// settled; a field changed differently on both sides is passed to resolve.
+func mergeFields(base, local, remote FieldValues, resolve func(FieldConflict) string) mergeResult {
+	res := mergeResult{merged: local, base: base}
+
+	for _, f := range syncedFields {
+		b, l, r := f.get(&base), f.get(&local), f.get(&remote)
+		switch {
+		case l == r:
+			f.set(&res.base, &remote)
+		case l == b:
+			f.set(&res.merged, &remote)
+			f.set(&res.base, &remote)
+			res.toLocal = append(res.toLocal, f.name)
+		case r == b:
+			res.localOnly = append(res.localOnly, f.name)
+		default:
+			c := FieldConflict{Field: f.name, Base: b, Local: l, Remote: r}
+			c.Resolution = resolve(c)
+			switch c.Resolution {
+			case ConflictRemote:
+				f.set(&res.merged, &remote)
+				f.set(&res.base, &remote)
+				res.toLocal = append(res.toLocal, f.name)
+			case ConflictLocal:
+				f.set(&res.base, &remote)
+				res.localOnly = append(res.localOnly, f.name)
+			default:
+				c.Resolution = ConflictSkip
+				res.unresolved++
+			}
+			res.conflicts = append(res.conflicts, c)
+		}
+	}
+
+	return res
+}
+
+// pushableFields returns the fields a Pusher can send back for this field map.
+func pushableFields(fm FieldMap) []string {
+	fields := []string{"title", "status"}
+	if fm.LabelsToTags {
+		fields = append(fields, "tags")
+	}
+	if fm.AssigneeToOwner {
+		fields = append(fields, "owner")
+	}
+	return fields
+}
+
+// mergeTask reconciles a task that changed locally, remotely or both since
+// the last sync, field by field.
+func (e *Engine) mergeTask(
+	action SyncAction,
+	ext ExternalTask,
+	extHash string,
+	srcCfg SourceConfig,
+	ts TaskState,
+	base, local, remote FieldValues,
+	state *SyncState,
+	now time.Time,
+) (SyncAction, error) {
+	res := mergeFields(base, local, remote, func(c FieldConflict) string {
+		return e.resolveField(action, c)
+	})
+	action.Conflicts = res.conflicts
+
+	pushable := pushableFields(srcCfg.FieldMap)
+	var pending []string
+	for _, f := range res.localOnly {
+		if slices.Contains(pushable, f) {
+			pending = append(pending, f)
+		}
+	}
+	push := e.Push && len(pending) > 0 && res.unresolved == 0
+
+	action.Reason = mergeReason(res, push)
+	if e.DryRun {
+		return action, nil
+	}
+
+	if len(res.toLocal) > 0 {
+		req := mergeUpdateRequest(res.merged, res.toLocal)
+		req.Timestamps = e.Timestamps
+		if err := taskfile.UpdateTaskFile(ts.FilePath, req); err != nil {
+			return SyncAction{}, fmt.Errorf("failed to update task file: %w", err)
+		}
+	}
+
+	if push {
+		pushedHash, err := e.pushMerged(ext, srcCfg, res)
+		if err != nil {
+			return SyncAction{}, err
+		}
+		extHash = pushedHash
+		for _, f := range syncedFields {
+			if slices.Contains(pending, f.name) {
+				f.set(&res.base, &res.merged)
+			}
+		}
+		pending = nil
+	}
+
+	// Local hash stays stale while local edits await a push or conflicts are
+	// unresolved, so the next sync merges this task again.
+	if len(pending) == 0 && res.unresolved == 0 {
+		localHash, err := HashLocalFile(ts.FilePath)
+		if err != nil {
+			return SyncAction{}, fmt.Errorf("failed to hash local file: %w", err)
+		}
+		ts.LocalHash = localHash
+	}
+
+	ts.ExternalHash = extHash
+	ts.Fields = &res.base
+	ts.LastSynced = now
+	state.Tasks[action.ExternalID] = ts
+
+	return action, nil
+}
+
+func mergeReason(res mergeResult, push bool) string {
+	switch {
+	case res.unresolved > 0:
+		return "conflict"
+	case push:
+		return "pushed"
+	case len(res.toLocal) > 0 || len(res.conflicts) > 0:
+		return "updated"
+	default:
+		return "skipped"
+	}
+}
+
+// pushMerged sends the merged values to the source and returns the hash of
+// the remote task as it now stands.
+func (e *Engine) pushMerged(ext ExternalTask, srcCfg SourceConfig, res mergeResult) (string, error) {
+	src, err := GetSource(srcCfg.Name)
+	if err != nil {
+		return "", err
+	}
+
+	local := &model.Task{
+		Title:  res.merged.Title,
+		Status: model.Status(res.merged.Status),
+		Tags:   res.merged.Tags,
+		Owner:  res.merged.Owner,
+	}
+	pushed := ReverseMapTask(ext, local, srcCfg.FieldMap)
+	if err := src.(Pusher).PushTask(srcCfg, pushed); err != nil {
+		return "", fmt.Errorf("failed to push task: %w", err)
+	}
+	return HashExternalTask(pushed), nil
+}
+
+// mergeUpdateRequest builds a file update for the given fields. Empty scalar
+// values are left alone, matching UpdateSyncedTaskFile.
+func mergeUpdateRequest(v FieldValues, fields []string) taskfile.UpdateRequest {
+	var req taskfile.UpdateRequest
+	for _, f := range fields {
+		switch f {
+		case "title":
+			req.Title = &v.Title
+		case "status":
+			req.Status = &v.Status
+		case "priority":
+			req.Priority = nonEmpty(&v.Priority)
+		case "effort":
+			req.Effort = nonEmpty(&v.Effort)
+		case "owner":
+			req.Owner = nonEmpty(&v.Owner)
+		case "tags":
+			tags := append([]string{}, v.Tags...)
+			req.Tags = &tags
+		case "dependencies":
+			deps := append([]string{}, v.Dependencies...)
+			req.Dependencies = &deps
+		case "parent":
+			req.Parent = nonEmpty(&v.Parent)
+		case "group":
+			req.Group = nonEmpty(&v.Group)
+		case "description":
+			req.Body = &v.Description
+		}
+	}
+	return req
+}
+
+func nonEmpty(s *string) *string {
+	if *s == "" {
+		return nil
+	}
+	return s
+}
+
+// resolveField decides a single field conflict according to the strategy.
+func (e *Engine) resolveField(action SyncAction, c FieldConflict) string {
+	switch e.ConflictStrategy {
+	case ConflictRemote, ConflictLocal:
+		return e.ConflictStrategy
+	case ConflictInteractive:
+		if e.Resolve != nil && !e.DryRun {
+			return e.Resolve(action, c)
+		}
+	}
+	return ConflictSkip
+}
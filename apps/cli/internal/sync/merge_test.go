@@ -0,0 +1,290 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMergeFields(t *testing.T) {
+	base := FieldValues{Title: "A", Status: "pending", Owner: "alice", Tags: []string{"bug"}}
+	local := FieldValues{Title: "A", Status: "in-progress", Owner: "bob", Tags: []string{"bug"}}
+	remote := FieldValues{Title: "B", Status: "pending", Owner: "carol", Tags: []string{"bug"}}
+
+	res := mergeFields(base, local, remote, func(FieldConflict) string { return ConflictSkip })
+
+	if res.merged.Title != "B" || res.merged.Status != "in-progress" || res.merged.Owner != "bob" {
+		t.Errorf("unexpected merged values: %+v", res.merged)
+	}
+	if strings.Join(res.toLocal, ",") != "title" {
+		t.Errorf("expected title written locally, got %v", res.toLocal)
+	}
+	if strings.Join(res.localOnly, ",") != "status" {
+		t.Errorf("expected status as local-only change, got %v", res.localOnly)
+	}
+	if len(res.conflicts) != 1 || res.unresolved != 1 {
+		t.Fatalf("expected 1 unresolved conflict, got %+v", res.conflicts)
+	}
+	c := res.conflicts[0]
+	if c.Field != "owner" || c.Base != "alice" || c.Local != "bob" || c.Remote != "carol" || c.Resolution != ConflictSkip {
+		t.Errorf("unexpected conflict: %+v", c)
+	}
+	if res.base.Title != "B" || res.base.Owner != "alice" {
+		t.Errorf("expected base to advance only for settled fields, got %+v", res.base)
+	}
+}
+
+func TestMergeFields_SameChangeOnBothSides(t *testing.T) {
+	base := FieldValues{Title: "A", Status: "pending"}
+	both := FieldValues{Title: "A", Status: "completed"}
+
+	res := mergeFields(base, both, both, func(FieldConflict) string {
+		t.Fatal("resolver should not be called")
+		return ""
+	})
+	if len(res.conflicts) != 0 || len(res.toLocal) != 0 || len(res.localOnly) != 0 {
+		t.Errorf("expected nothing to do, got %+v", res)
+	}
+	if res.base.Status != "completed" {
+		t.Errorf("expected base status completed, got %q", res.base.Status)
+	}
+}
+
+// mergeFixture creates a synced task, then edits the local file and swaps the
+// remote task before returning the engine config for the next sync.
+func mergeFixture(t *testing.T, sourceName string, engine *Engine, localEdit [2]string, remote ExternalTask) (SourceConfig, string) {
+	t.Helper()
+	setupMockSource(sourceName, []ExternalTask{
+		{ExternalID: "EXT-1", Title: "A task", Status: "open", Assignee: "alice"},
+	})
+
+	dir := t.TempDir()
+	engine.ConfigDir = dir
+	srcCfg := SourceConfig{
+		Name:      sourceName,
+		OutputDir: filepath.Join(dir, "tasks"),
+		FieldMap: FieldMap{
+			Status:          map[string]string{"open": "pending", "closed": "completed"},
+			AssigneeToOwner: true,
+		},
+	}
+
+	result, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("first sync error: %v", err)
+	}
+	filePath := result.Created[0].FilePath
+
+	editLocalFile(t, filePath, localEdit[0], localEdit[1])
+	setupMockSource(sourceName, []ExternalTask{remote})
+	return srcCfg, filePath
+}
+
+func TestEngine_MergeNonOverlappingEdits(t *testing.T) {
+	sourceName := "test-merge"
+	defer cleanupRegistry(sourceName)
+
+	engine := &Engine{}
+	srcCfg, filePath := mergeFixture(t, sourceName, engine,
+		[2]string{"status: pending", "status: in-progress"},
+		ExternalTask{ExternalID: "EXT-1", Title: "Renamed remotely", Status: "open", Assignee: "alice"})
+
+	result, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("second sync error: %v", err)
+	}
+	if len(result.Conflicts) != 0 || len(result.Updated) != 1 {
+		t.Fatalf("expected 1 updated and no conflicts, got updated=%d conflicts=%d", len(result.Updated), len(result.Conflicts))
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if !strings.Contains(string(content), `title: "Renamed remotely"`) || !strings.Contains(string(content), "status: in-progress") {
+		t.Errorf("expected both edits in file, got:\n%s", content)
+	}
+
+	// Nothing changed since; the next sync is a no-op.
+	result, err = engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("third sync error: %v", err)
+	}
+	if len(result.Skipped) != 1 {
+		t.Errorf("expected 1 skipped, got %d", len(result.Skipped))
+	}
+}
+
+func TestEngine_MergeConflictReport(t *testing.T) {
+	sourceName := "test-merge-conflict"
+	defer cleanupRegistry(sourceName)
+
+	engine := &Engine{}
+	srcCfg, filePath := mergeFixture(t, sourceName, engine,
+		[2]string{"owner: alice", "owner: bob"},
+		ExternalTask{ExternalID: "EXT-1", Title: "Renamed remotely", Status: "open", Assignee: "carol"})
+
+	result, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("second sync error: %v", err)
+	}
+	if len(result.Conflicts) != 1 {
+		t.Fatalf("expected 1 conflict, got %d", len(result.Conflicts))
+	}
+
+	conflicts := result.Conflicts[0].Conflicts
+	if len(conflicts) != 1 {
+		t.Fatalf("expected 1 conflicting field, got %+v", conflicts)
+	}
+	want := FieldConflict{Field: "owner", Base: "alice", Local: "bob", Remote: "carol", Resolution: ConflictSkip}
+	if conflicts[0] != want {
+		t.Errorf("conflict = %+v, want %+v", conflicts[0], want)
+	}
+
+	// The non-conflicting title change is still applied.
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if !strings.Contains(string(content), `title: "Renamed remotely"`) || !strings.Contains(string(content), "owner: bob") {
+		t.Errorf("expected remote title and local owner, got:\n%s", content)
+	}
+
+	// The conflict stays until it is resolved.
+	result, err = engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("third sync error: %v", err)
+	}
+	if len(result.Conflicts) != 1 {
+		t.Errorf("expected the conflict to be reported again, got %d", len(result.Conflicts))
+	}
+}
+
+func TestEngine_MergeInteractive(t *testing.T) {
+	sourceName := "test-merge-interactive"
+	defer cleanupRegistry(sourceName)
+
+	var asked []FieldConflict
+	engine := &Engine{
+		ConflictStrategy: ConflictInteractive,
+		Resolve: func(_ SyncAction, c FieldConflict) string {
+			asked = append(asked, c)
+			return ConflictRemote
+		},
+	}
+	srcCfg, filePath := mergeFixture(t, sourceName, engine,
+		[2]string{"owner: alice", "owner: bob"},
+		ExternalTask{ExternalID: "EXT-1", Title: "A task", Status: "open", Assignee: "carol"})
+
+	result, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("second sync error: %v", err)
+	}
+	if len(asked) != 1 || asked[0].Field != "owner" {
+		t.Fatalf("expected to be asked about owner, got %+v", asked)
+	}
+	if len(result.Updated) != 1 || result.Updated[0].Conflicts[0].Resolution != ConflictRemote {
+		t.Fatalf("expected 1 updated with remote resolution, got %+v", result.Updated)
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if !strings.Contains(string(content), "owner: carol") {
+		t.Errorf("expected remote owner, got:\n%s", content)
+	}
+}
+
+func TestEngine_MergeLegacyState(t *testing.T) {
+	sourceName := "test-merge-legacy"
+	defer cleanupRegistry(sourceName)
+
+	engine := &Engine{}
+	srcCfg, filePath := mergeFixture(t, sourceName, engine,
+		[2]string{"status: pending", "status: in-progress"},
+		ExternalTask{ExternalID: "EXT-1", Title: "A task", Status: "open", Assignee: "alice"})
+
+	// Drop the stored field values, as in state written by older versions.
+	state, err := LoadState(engine.ConfigDir, sourceName)
+	if err != nil {
+		t.Fatalf("failed to load state: %v", err)
+	}
+	ts := state.Tasks["EXT-1"]
+	ts.Fields = nil
+	state.Tasks["EXT-1"] = ts
+	if err := SaveState(engine.ConfigDir, sourceName, state); err != nil {
+		t.Fatalf("failed to save state: %v", err)
+	}
+
+	result, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("second sync error: %v", err)
+	}
+	if len(result.Conflicts) != 0 || len(result.Skipped) != 1 {
+		t.Fatalf("expected local-only edit to be skipped, got conflicts=%d skipped=%d", len(result.Conflicts), len(result.Skipped))
+	}
+
+	state, err = LoadState(engine.ConfigDir, sourceName)
+	if err != nil {
+		t.Fatalf("failed to load state: %v", err)
+	}
+	if state.Tasks["EXT-1"].Fields == nil {
+		t.Error("expected field values to be recorded")
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if !strings.Contains(string(content), "status: in-progress") {
+		t.Errorf("expected local edit to be kept, got:\n%s", content)
+	}
+}
+
+func TestEngine_MergePushesLocalSideOfMerge(t *testing.T) {
+	sourceName := "test-merge-push"
+	defer cleanupRegistry(sourceName)
+
+	src := &pushMockSource{mockSource: mockSource{name: sourceName, tasks: []ExternalTask{
+		{ExternalID: "EXT-1", Title: "A task", Status: "open"},
+	}}}
+	Register(src)
+
+	dir := t.TempDir()
+	engine := &Engine{ConfigDir: dir, Push: true}
+	srcCfg := SourceConfig{
+		Name:      sourceName,
+		OutputDir: filepath.Join(dir, "tasks"),
+		FieldMap:  FieldMap{Status: map[string]string{"open": "pending", "closed": "completed"}},
+	}
+
+	result, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("first sync error: %v", err)
+	}
+
+	editLocalFile(t, result.Created[0].FilePath, "status: pending", "status: completed")
+	src.tasks[0].Title = "Renamed remotely"
+
+	result, err = engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("second sync error: %v", err)
+	}
+	if len(result.Pushed) != 1 || len(src.pushed) != 1 {
+		t.Fatalf("expected 1 push, got pushed=%d pushes=%d", len(result.Pushed), len(src.pushed))
+	}
+	if got := src.pushed[0]; got.Title != "Renamed remotely" || got.Status != "closed" {
+		t.Errorf("expected merged task to be pushed, got %+v", got)
+	}
+
+	src.tasks = []ExternalTask{src.pushed[0]}
+	result, err = engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("third sync error: %v", err)
+	}
+	if len(result.Skipped) != 1 || len(src.pushed) != 1 {
+		t.Errorf("expected 1 skipped and no new push, got skipped=%d pushes=%d", len(result.Skipped), len(src.pushed))
+	}
+}
@@ -0,0 +1,178 @@
+package sync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+	"github.com/driangle/taskmd/apps/cli/internal/parser"
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
+)
+
+// Policies for tasks in sync state that the source no longer returns
+// (deleted, transferred, or excluded by filters).
+const (
+	MissingKeep    = "keep"    // default: leave the task file and state alone
+	MissingCancel  = "cancel"  // set the task status to cancelled
+	MissingArchive = "archive" // move the task file to the archive directory
+	MissingDelete  = "delete"  // delete the task file
+)
+
+// ValidMissingPolicy reports whether p is a valid on_remote_missing value.
+// An empty value means MissingKeep.
+func ValidMissingPolicy(p string) bool {
+	switch p {
+	case "", MissingKeep, MissingCancel, MissingArchive, MissingDelete:
+		return true
+	}
+	return false
+}
+
+// handleMissing applies the source's on_remote_missing policy to every task
+// in state that is absent from the fetched tasks. Tasks that the archive and
+// delete policies would remove but that changed locally since the last sync
+// are kept and reported as conflicts.
+func (e *Engine) handleMissing(
+	externalTasks []ExternalTask,
+	srcCfg SourceConfig,
+	state *SyncState,
+	result *SyncResult,
+	now time.Time,
+) {
+	if srcCfg.OnRemoteMissing == "" || srcCfg.OnRemoteMissing == MissingKeep {
+		return
+	}
+
+	fetched := make(map[string]bool, len(externalTasks))
+	for _, ext := range externalTasks {
+		fetched[ext.ExternalID] = true
+	}
+
+	var missing []string
+	for extID := range state.Tasks {
+		if !fetched[extID] {
+			missing = append(missing, extID)
+		}
+	}
+	sort.Strings(missing)
+
+	for _, extID := range missing {
+		ts := state.Tasks[extID]
+		action, changed, err := e.removeTask(ts, srcCfg, state, now)
+		switch {
+		case err != nil:
+			result.Errors = append(result.Errors, SyncError{ExternalID: extID, Title: action.Title, Err: err})
+		case changed && action.Reason == "conflict":
+			result.Conflicts = append(result.Conflicts, action)
+		case changed:
+			result.Removed = append(result.Removed, action)
+		}
+	}
+}
+
+// removeTask applies the policy to a single task. It reports false when there
+// is nothing to do, e.g. the task is already cancelled. A task with local
+// changes is left alone under the archive and delete policies, and its action
+// is marked as a conflict.
+func (e *Engine) removeTask(ts TaskState, srcCfg SourceConfig, state *SyncState, now time.Time) (SyncAction, bool, error) {
+	action := SyncAction{
+		ExternalID: ts.ExternalID,
+		LocalID:    ts.LocalID,
+		FilePath:   ts.FilePath,
+		Reason:     "removed",
+	}
+
+	if _, err := os.Stat(ts.FilePath); os.IsNotExist(err) {
+		// The task file is already gone; forget the task.
+		if !e.DryRun {
+			delete(state.Tasks, ts.ExternalID)
+		}
+		return action, true, nil
+	}
+
+	local, err := parser.ParseTaskFile(ts.FilePath)
+	if err != nil {
+		return action, false, fmt.Errorf("failed to parse local file: %w", err)
+	}
+	action.Title = local.Title
+
+	switch srcCfg.OnRemoteMissing {
+	case MissingCancel:
+		if local.Status == model.StatusCancelled || local.Status == model.StatusCompleted {
+			return action, false, nil
+		}
+		if e.DryRun {
+			return action, true, nil
+		}
+		return action, true, e.cancelMissing(ts, state, now)
+	case MissingArchive, MissingDelete:
+		return e.dropTask(action, local, ts, srcCfg, state)
+	default:
+		return action, false, fmt.Errorf("invalid on_remote_missing policy %q", srcCfg.OnRemoteMissing)
+	}
+}
+
+// dropTask archives or deletes the task file, with its worklog, and forgets
+// the task. A file that changed since the last sync is kept and the action
+// is marked as a conflict, so local edits are not thrown away.
+func (e *Engine) dropTask(
+	action SyncAction,
+	local *model.Task,
+	ts TaskState,
+	srcCfg SourceConfig,
+	state *SyncState,
+) (SyncAction, bool, error) {
+	localChanged, err := e.localFileChanged(ts)
+	if err != nil {
+		return action, false, fmt.Errorf("failed to check local file: %w", err)
+	}
+	if localChanged {
+		action.Reason = "conflict"
+		return action, true, nil
+	}
+	if e.DryRun {
+		return action, true, nil
+	}
+
+	if srcCfg.OnRemoteMissing == MissingArchive {
+		dest, err := taskfile.ArchiveTask(local, filepath.Clean(srcCfg.OutputDir))
+		if err != nil {
+			return action, false, err
+		}
+		action.FilePath = dest
+	} else if err := os.Remove(ts.FilePath); err != nil {
+		return action, false, fmt.Errorf("failed to delete %s: %w", ts.FilePath, err)
+	}
+
+	delete(state.Tasks, ts.ExternalID)
+	return action, true, nil
+}
+
+// cancelMissing marks the task cancelled but keeps it in state, so that if
+// the issue reappears the next sync merges it back in as usual.
+func (e *Engine) cancelMissing(ts TaskState, state *SyncState, now time.Time) error {
+	status := string(model.StatusCancelled)
+	if err := taskfile.UpdateTaskFile(ts.FilePath, taskfile.UpdateRequest{Status: &status, Timestamps: e.Timestamps}); err != nil {
+		return fmt.Errorf("failed to update task file: %w", err)
+	}
+
+	localHash, err := HashLocalFile(ts.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to hash local file: %w", err)
+	}
+
+	// Clearing the external hash makes a reappearing issue count as changed.
+	ts.ExternalHash = ""
+	ts.LocalHash = localHash
+	if ts.Fields != nil {
+		fields := *ts.Fields
+		fields.Status = status
+		ts.Fields = &fields
+	}
+	ts.LastSynced = now
+	state.Tasks[ts.ExternalID] = ts
+	return nil
+}
@@ -0,0 +1,254 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/driangle/taskmd/apps/cli/internal/parser"
+	"github.com/driangle/taskmd/apps/cli/internal/worklog"
+)
+
+// syncThenDrop creates two synced tasks, then removes EXT-2 from the source.
+func syncThenDrop(t *testing.T, sourceName, policy string, engine *Engine) (SourceConfig, string) {
+	t.Helper()
+	setupMockSource(sourceName, []ExternalTask{
+		{ExternalID: "EXT-1", Title: "Stays", Status: "open"},
+		{ExternalID: "EXT-2", Title: "Goes away", Status: "open"},
+	})
+
+	dir := t.TempDir()
+	engine.ConfigDir = dir
+	srcCfg := SourceConfig{
+		Name:            sourceName,
+		OutputDir:       filepath.Join(dir, "tasks"),
+		FieldMap:        FieldMap{Status: map[string]string{"open": "pending"}},
+		OnRemoteMissing: policy,
+	}
+
+	result, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("first sync error: %v", err)
+	}
+	if len(result.Created) != 2 {
+		t.Fatalf("expected 2 created, got %d", len(result.Created))
+	}
+
+	setupMockSource(sourceName, []ExternalTask{
+		{ExternalID: "EXT-1", Title: "Stays", Status: "open"},
+	})
+	return srcCfg, result.Created[1].FilePath
+}
+
+func TestEngine_MissingKeep(t *testing.T) {
+	sourceName := "test-missing-keep"
+	defer cleanupRegistry(sourceName)
+
+	engine := &Engine{}
+	srcCfg, filePath := syncThenDrop(t, sourceName, "", engine)
+
+	result, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("second sync error: %v", err)
+	}
+	if len(result.Removed) != 0 {
+		t.Errorf("expected nothing removed, got %d", len(result.Removed))
+	}
+	if _, err := os.Stat(filePath); err != nil {
+		t.Errorf("expected task file to remain: %v", err)
+	}
+
+	state, _ := LoadState(engine.ConfigDir, sourceName)
+	if _, ok := state.Tasks["EXT-2"]; !ok {
+		t.Error("expected state entry to remain")
+	}
+}
+
+func TestEngine_MissingCancel(t *testing.T) {
+	sourceName := "test-missing-cancel"
+	defer cleanupRegistry(sourceName)
+
+	engine := &Engine{}
+	srcCfg, filePath := syncThenDrop(t, sourceName, MissingCancel, engine)
+
+	result, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("second sync error: %v", err)
+	}
+	if len(result.Removed) != 1 || result.Removed[0].ExternalID != "EXT-2" || result.Removed[0].Title != "Goes away" {
+		t.Fatalf("expected EXT-2 removed, got %+v", result.Removed)
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+	if !strings.Contains(string(content), "status: cancelled") {
+		t.Errorf("expected cancelled status, got:\n%s", content)
+	}
+
+	// Already cancelled: nothing more to report.
+	result, err = engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("third sync error: %v", err)
+	}
+	if len(result.Removed) != 0 {
+		t.Errorf("expected no further removals, got %d", len(result.Removed))
+	}
+
+	// The issue comes back: the task is reopened rather than duplicated.
+	setupMockSource(sourceName, []ExternalTask{
+		{ExternalID: "EXT-1", Title: "Stays", Status: "open"},
+		{ExternalID: "EXT-2", Title: "Goes away", Status: "open"},
+	})
+	result, err = engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("fourth sync error: %v", err)
+	}
+	if len(result.Created) != 0 || len(result.Updated) != 1 {
+		t.Fatalf("expected 1 updated and none created, got created=%d updated=%d", len(result.Created), len(result.Updated))
+	}
+	content, _ = os.ReadFile(filePath)
+	if !strings.Contains(string(content), "status: pending") {
+		t.Errorf("expected task to be reopened, got:\n%s", content)
+	}
+}
+
+func TestEngine_MissingArchive(t *testing.T) {
+	sourceName := "test-missing-archive"
+	defer cleanupRegistry(sourceName)
+
+	engine := &Engine{}
+	srcCfg, filePath := syncThenDrop(t, sourceName, MissingArchive, engine)
+
+	result, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("second sync error: %v", err)
+	}
+	if len(result.Removed) != 1 {
+		t.Fatalf("expected 1 removed, got %d", len(result.Removed))
+	}
+
+	want := filepath.Join(srcCfg.OutputDir, "archive", filepath.Base(filePath))
+	if result.Removed[0].FilePath != want {
+		t.Errorf("archived path = %q, want %q", result.Removed[0].FilePath, want)
+	}
+	if _, err := os.Stat(want); err != nil {
+		t.Errorf("expected archived file: %v", err)
+	}
+	if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+		t.Error("expected original file to be moved")
+	}
+
+	state, _ := LoadState(engine.ConfigDir, sourceName)
+	if _, ok := state.Tasks["EXT-2"]; ok {
+		t.Error("expected state entry to be dropped")
+	}
+}
+
+func TestEngine_MissingArchiveMovesWorklog(t *testing.T) {
+	sourceName := "test-missing-archive-worklog"
+	defer cleanupRegistry(sourceName)
+
+	engine := &Engine{}
+	srcCfg, filePath := syncThenDrop(t, sourceName, MissingArchive, engine)
+	local, err := parser.ParseTaskFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := worklog.AppendEntry(worklog.WorklogPath(filePath, local.ID), "notes"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := engine.RunSync(srcCfg); err != nil {
+		t.Fatalf("second sync error: %v", err)
+	}
+
+	archived := filepath.Join(srcCfg.OutputDir, "archive", filepath.Base(filePath))
+	if _, err := os.Stat(worklog.WorklogPath(archived, local.ID)); err != nil {
+		t.Errorf("expected worklog to move with the task: %v", err)
+	}
+	if _, err := os.Stat(worklog.WorklogPath(filePath, local.ID)); !os.IsNotExist(err) {
+		t.Error("expected no worklog left behind")
+	}
+}
+
+func TestEngine_MissingLocalChangesKept(t *testing.T) {
+	for _, policy := range []string{MissingArchive, MissingDelete} {
+		t.Run(policy, func(t *testing.T) {
+			sourceName := "test-missing-changed-" + policy
+			defer cleanupRegistry(sourceName)
+
+			engine := &Engine{}
+			srcCfg, filePath := syncThenDrop(t, sourceName, policy, engine)
+			content, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filePath, append(content, "Local notes.\n"...), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := engine.RunSync(srcCfg)
+			if err != nil {
+				t.Fatalf("second sync error: %v", err)
+			}
+			if len(result.Removed) != 0 || len(result.Conflicts) != 1 {
+				t.Fatalf("expected 1 conflict and nothing removed, got %d removed, %d conflicts", len(result.Removed), len(result.Conflicts))
+			}
+			if _, err := os.Stat(filePath); err != nil {
+				t.Errorf("expected changed task file to remain: %v", err)
+			}
+
+			state, _ := LoadState(engine.ConfigDir, sourceName)
+			if _, ok := state.Tasks["EXT-2"]; !ok {
+				t.Error("expected state entry to remain")
+			}
+		})
+	}
+}
+
+func TestEngine_MissingDelete(t *testing.T) {
+	sourceName := "test-missing-delete"
+	defer cleanupRegistry(sourceName)
+
+	engine := &Engine{}
+	srcCfg, filePath := syncThenDrop(t, sourceName, MissingDelete, engine)
+
+	result, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("second sync error: %v", err)
+	}
+	if len(result.Removed) != 1 {
+		t.Fatalf("expected 1 removed, got %d", len(result.Removed))
+	}
+	if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+		t.Error("expected task file to be deleted")
+	}
+
+	state, _ := LoadState(engine.ConfigDir, sourceName)
+	if len(state.Tasks) != 1 {
+		t.Errorf("expected 1 task left in state, got %d", len(state.Tasks))
+	}
+}
+
+func TestEngine_MissingDryRun(t *testing.T) {
+	sourceName := "test-missing-dryrun"
+	defer cleanupRegistry(sourceName)
+
+	engine := &Engine{}
+	srcCfg, filePath := syncThenDrop(t, sourceName, MissingDelete, engine)
+
+	engine.DryRun = true
+	result, err := engine.RunSync(srcCfg)
+	if err != nil {
+		t.Fatalf("dry-run sync error: %v", err)
+	}
+	if len(result.Removed) != 1 {
+		t.Fatalf("expected 1 removed in preview, got %d", len(result.Removed))
+	}
+	if _, err := os.Stat(filePath); err != nil {
+		t.Errorf("expected file to remain in dry-run: %v", err)
+	}
+}
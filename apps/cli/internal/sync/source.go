@@ -14,12 +14,45 @@ type ExternalTask struct {
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	URL         string
-	Extra       map[string]string
+	// Dependencies lists the external IDs of tasks that block this one.
+	// Nil means the source does not report dependencies.
+	Dependencies []string
+	// Extra holds source-specific values. The keys below are understood by
+	// the engine; any others are informational.
+	Extra map[string]string
 }
 
+// Extra keys the engine maps onto task relations.
+const (
+	// ExtraDependsOn lists external IDs of blocking tasks, comma-separated.
+	// They are added to Dependencies.
+	ExtraDependsOn = "depends_on"
+	// ExtraParent is the external ID of the parent task.
+	ExtraParent = "parent"
+	// ExtraGroup is written as the task's group.
+	ExtraGroup = "group"
+	// ExtraEffort is written as the task's effort when it is a valid taskmd
+	// effort (small, medium, large).
+	ExtraEffort = "effort"
+)
+
 // Source defines the interface that external task providers must implement.
 type Source interface {
 	Name() string
 	ValidateConfig(cfg SourceConfig) error
 	FetchTasks(cfg SourceConfig) ([]ExternalTask, error)
 }
+
+// DefaultMapper is implemented by sources whose values have a natural taskmd
+// mapping. The engine merges DefaultFieldMap under the configured field_map,
+// so config entries always take precedence.
+type DefaultMapper interface {
+	DefaultFieldMap() FieldMap
+}
+
+// Pusher is implemented by sources that can write local edits back to the
+// external tracker. The task carries the source's own vocabulary (status,
+// labels, assignee) after reverse field_map lookups.
+type Pusher interface {
+	PushTask(cfg SourceConfig, task ExternalTask) error
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -20,12 +21,15 @@ type SyncState struct {
 
 // TaskState tracks the sync state of a single task.
 type TaskState struct {
-	ExternalID   string    `yaml:"external_id"`
-	LocalID      string    `yaml:"local_id"`
-	FilePath     string    `yaml:"file_path"`
-	ExternalHash string    `yaml:"external_hash"`
-	LocalHash    string    `yaml:"local_hash"`
-	LastSynced   time.Time `yaml:"last_synced"`
+	ExternalID   string `yaml:"external_id"`
+	LocalID      string `yaml:"local_id"`
+	FilePath     string `yaml:"file_path"`
+	ExternalHash string `yaml:"external_hash"`
+	LocalHash    string `yaml:"local_hash"`
+	// Fields holds the synced field values as of the last sync, the base for
+	// field-level merges. Nil in state written by older versions.
+	Fields     *FieldValues `yaml:"fields,omitempty"`
+	LastSynced time.Time    `yaml:"last_synced"`
 }
 
 // LoadState reads the state file for a source. Returns empty state if file doesn't exist.
@@ -61,9 +65,9 @@ func SaveState(dir, sourceName string, state *SyncState) error {
 		return fmt.Errorf("failed to create state directory: %w", err)
 	}
 
-	data, err := yaml.Marshal(state)
+	data, err := MarshalState(state)
 	if err != nil {
-		return fmt.Errorf("failed to marshal state: %w", err)
+		return err
 	}
 
 	path := stateFilePath(dir, sourceName)
@@ -74,6 +78,40 @@ func SaveState(dir, sourceName string, state *SyncState) error {
 	return nil
 }
 
+// MarshalState encodes a sync state in the on-disk YAML format.
+func MarshalState(state *SyncState) ([]byte, error) {
+	data, err := yaml.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal state: %w", err)
+	}
+	return data, nil
+}
+
+// StateFilePath returns the path of the state file for a source.
+func StateFilePath(dir, sourceName string) string {
+	return stateFilePath(dir, sourceName)
+}
+
+// StateSources returns the sorted names of all sources with a state file under dir.
+func StateSources(dir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(dir, stateSubDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state directory: %w", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".yaml" {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(e.Name(), ".yaml"))
+	}
+	return names, nil
+}
+
 func stateFilePath(dir, sourceName string) string {
 	return filepath.Join(dir, stateSubDir, sourceName+".yaml")
 }
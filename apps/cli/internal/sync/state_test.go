@@ -106,3 +106,29 @@ func TestState_SaveCreatesDirectory(t *testing.T) {
 		t.Errorf("expected source=jira, got %q", loaded.Source)
 	}
 }
+
+func TestStateSources(t *testing.T) {
+	dir := t.TempDir()
+
+	names, err := StateSources(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(names) != 0 {
+		t.Fatalf("expected no sources, got %v", names)
+	}
+
+	for _, source := range []string{"jira", "github"} {
+		if err := SaveState(dir, source, &SyncState{Source: source, Tasks: map[string]TaskState{}}); err != nil {
+			t.Fatalf("SaveState: %v", err)
+		}
+	}
+
+	names, err = StateSources(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(names) != 2 || names[0] != "github" || names[1] != "jira" {
+		t.Errorf("expected [github jira], got %v", names)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
 
 	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
@@ -16,9 +15,7 @@ func WriteTaskFile(dir, id string, mapped MappedTask, externalID, sourceName str
 		return "", fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	slug := slugify(mapped.Title)
-	filename := fmt.Sprintf("%s-%s.md", id, slug)
-	path := filepath.Join(dir, filename)
+	path := filepath.Join(dir, taskfile.FileName(id, mapped.Title))
 
 	content := renderTaskFile(id, mapped, externalID, sourceName)
 
@@ -29,11 +26,13 @@ func WriteTaskFile(dir, id string, mapped MappedTask, externalID, sourceName str
 	return path, nil
 }
 
-// UpdateSyncedTaskFile updates an existing synced task file.
-func UpdateSyncedTaskFile(filePath string, mapped MappedTask) error {
+// UpdateSyncedTaskFile updates an existing synced task file, recording the
+// status timestamps selected by stamps.
+func UpdateSyncedTaskFile(filePath string, mapped MappedTask, stamps *taskfile.TimestampConfig) error {
 	req := taskfile.UpdateRequest{
-		Status: &mapped.Status,
-		Title:  &mapped.Title,
+		Status:     &mapped.Status,
+		Title:      &mapped.Title,
+		Timestamps: stamps,
 	}
 	if mapped.Priority != "" {
 		req.Priority = &mapped.Priority
@@ -41,9 +40,21 @@ func UpdateSyncedTaskFile(filePath string, mapped MappedTask) error {
 	if mapped.Owner != "" {
 		req.Owner = &mapped.Owner
 	}
+	if mapped.Effort != "" {
+		req.Effort = &mapped.Effort
+	}
 	if len(mapped.Tags) > 0 {
 		req.Tags = &mapped.Tags
 	}
+	if mapped.Dependencies != nil {
+		req.Dependencies = &mapped.Dependencies
+	}
+	if mapped.Parent != "" {
+		req.Parent = &mapped.Parent
+	}
+	if mapped.Group != "" {
+		req.Group = &mapped.Group
+	}
 	if mapped.Description != "" {
 		req.Body = &mapped.Description
 	}
@@ -59,11 +70,20 @@ func renderTaskFile(id string, mapped MappedTask, externalID, sourceName string)
 	if mapped.Priority != "" {
 		fmt.Fprintf(&b, "priority: %s\n", mapped.Priority)
 	}
+	if mapped.Effort != "" {
+		fmt.Fprintf(&b, "effort: %s\n", mapped.Effort)
+	}
 	if mapped.Owner != "" {
 		fmt.Fprintf(&b, "owner: %s\n", mapped.Owner)
 	}
-	b.WriteString("dependencies: []\n")
+	b.WriteString(taskfile.FormatInlineList("dependencies", mapped.Dependencies) + "\n")
 	b.WriteString(taskfile.FormatInlineTags(mapped.Tags) + "\n")
+	if mapped.Parent != "" {
+		fmt.Fprintf(&b, "parent: %q\n", mapped.Parent)
+	}
+	if mapped.Group != "" {
+		fmt.Fprintf(&b, "group: %q\n", mapped.Group)
+	}
 	fmt.Fprintf(&b, "sync_source: %s\n", sourceName)
 	fmt.Fprintf(&b, "sync_id: %q\n", externalID)
 	b.WriteString("---\n")
@@ -76,16 +96,3 @@ func renderTaskFile(id string, mapped MappedTask, externalID, sourceName string)
 
 	return b.String()
 }
-
-var nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
-
-func slugify(s string) string {
-	s = strings.ToLower(s)
-	s = nonAlphanumeric.ReplaceAllString(s, "-")
-	s = strings.Trim(s, "-")
-	if len(s) > 50 {
-		s = s[:50]
-		s = strings.TrimRight(s, "-")
-	}
-	return s
-}
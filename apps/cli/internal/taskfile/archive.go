@@ -0,0 +1,107 @@
+package taskfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+	"github.com/driangle/taskmd/apps/cli/internal/worklog"
+)
+
+// ArchiveDirName is the subdirectory archived task files are moved into.
+// The scanner skips directories with this name unless asked to include them.
+const ArchiveDirName = "archive"
+
+// ArchiveTaskFile moves filePath into the archive directory under rootDir,
+// keeping its path relative to rootDir, and returns the new path. It refuses
+// to overwrite an existing archived file.
+func ArchiveTaskFile(filePath, rootDir string) (string, error) {
+	relPath, err := filepath.Rel(rootDir, filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute relative path for %s: %w", filePath, err)
+	}
+
+	destPath := filepath.Join(rootDir, ArchiveDirName, relPath)
+	if err := moveFile(filePath, destPath, "archive"); err != nil {
+		return "", err
+	}
+	return destPath, nil
+}
+
+// UnarchiveTaskFile moves filePath out of the archive directory it is in,
+// back to where it was archived from, and returns the new path. The
+// archive directory may be nested, e.g. <group>/archive/ as created by sync.
+// It refuses to overwrite an existing file.
+func UnarchiveTaskFile(filePath string) (string, error) {
+	destPath, ok := unarchivedPath(filePath)
+	if !ok {
+		return "", fmt.Errorf("%s is not in an archive directory", filePath)
+	}
+	if err := moveFile(filePath, destPath, "restore"); err != nil {
+		return "", err
+	}
+	return destPath, nil
+}
+
+// unarchivedPath returns filePath with its innermost archive directory
+// segment removed. It reports false if filePath is not in an archive
+// directory.
+func unarchivedPath(filePath string) (string, bool) {
+	rest := filepath.Base(filePath)
+	for dir := filepath.Dir(filePath); ; dir = filepath.Dir(dir) {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		if filepath.Base(dir) == ArchiveDirName {
+			return filepath.Join(parent, rest), true
+		}
+		rest = filepath.Join(filepath.Base(dir), rest)
+	}
+}
+
+// ArchiveTask moves a task file and its worklog into the archive directory
+// under rootDir, and returns the new task file path.
+func ArchiveTask(task *model.Task, rootDir string) (string, error) {
+	destPath, err := ArchiveTaskFile(task.FilePath, rootDir)
+	if err != nil {
+		return "", err
+	}
+	return destPath, moveWorklog(task, destPath, "archive")
+}
+
+// UnarchiveTask moves an archived task file and its worklog back out of its
+// archive directory, and returns the new task file path.
+func UnarchiveTask(task *model.Task) (string, error) {
+	destPath, err := UnarchiveTaskFile(task.FilePath)
+	if err != nil {
+		return "", err
+	}
+	return destPath, moveWorklog(task, destPath, "restore")
+}
+
+// moveWorklog moves the task's worklog, if it has one, next to the task file
+// at its new path.
+func moveWorklog(task *model.Task, newFilePath, action string) error {
+	src := worklog.WorklogPath(task.FilePath, task.ID)
+	if _, err := os.Stat(src); os.IsNotExist(err) {
+		return nil
+	}
+	return moveFile(src, worklog.WorklogPath(newFilePath, task.ID), action)
+}
+
+// moveFile renames src to dest, creating dest's directory. It refuses to
+// overwrite an existing file.
+func moveFile(src, dest, action string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", dest, err)
+	}
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("%s destination already exists: %s", action, dest)
+	}
+	if err := os.Rename(src, dest); err != nil {
+		return fmt.Errorf("failed to move %s: %w", src, err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package taskfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+func TestArchiveTaskFile(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "cli", "042-task.md")
+	if err := os.MkdirAll(filepath.Dir(src), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(src, []byte("---\nid: \"042\"\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dest, err := ArchiveTaskFile(src, root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := filepath.Join(root, "archive", "cli", "042-task.md")
+	if dest != want {
+		t.Errorf("dest = %q, want %q", dest, want)
+	}
+	if _, err := os.Stat(src); !os.IsNotExist(err) {
+		t.Error("expected source file to be moved")
+	}
+	if _, err := os.Stat(want); err != nil {
+		t.Errorf("expected archived file: %v", err)
+	}
+}
+
+func TestArchiveTaskFile_DestinationExists(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "042-task.md")
+	existing := filepath.Join(root, "archive", "042-task.md")
+	if err := os.MkdirAll(filepath.Dir(existing), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{src, existing} {
+		if err := os.WriteFile(p, []byte("---\n---\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := ArchiveTaskFile(src, root); err == nil {
+		t.Fatal("expected error when archive destination exists")
+	}
+	if _, err := os.Stat(src); err != nil {
+		t.Errorf("expected source file to stay in place: %v", err)
+	}
+}
+
+func TestArchiveAndUnarchiveTask(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "cli", "042-task.md")
+	wl := filepath.Join(root, "cli", ".worklogs", "042.md")
+	for _, p := range []string{src, wl} {
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("---\nid: \"042\"\n---\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	task := &model.Task{ID: "042", FilePath: src}
+	archived, err := ArchiveTask(task, root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "archive", "cli", ".worklogs", "042.md")); err != nil {
+		t.Errorf("expected archived worklog: %v", err)
+	}
+
+	task.FilePath = archived
+	restored, err := UnarchiveTask(task)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if restored != src {
+		t.Errorf("restored = %q, want %q", restored, src)
+	}
+	if _, err := os.Stat(wl); err != nil {
+		t.Errorf("expected restored worklog: %v", err)
+	}
+}
+
+func TestUnarchiveTaskFile_NotArchived(t *testing.T) {
+	root := t.TempDir()
+	if _, err := UnarchiveTaskFile(filepath.Join(root, "042-task.md")); err == nil {
+		t.Fatal("expected error for a file outside the archive")
+	}
+}
+
+func TestUnarchiveTaskFile_NestedArchive(t *testing.T) {
+	root := t.TempDir()
+	// Sync archives into <output dir>/archive/, below the task root.
+	src := filepath.Join(root, "github", "archive", "sub", "042-task.md")
+	if err := os.MkdirAll(filepath.Dir(src), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(src, []byte("---\nid: \"042\"\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := UnarchiveTaskFile(src)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := filepath.Join(root, "github", "sub", "042-task.md"); restored != want {
+		t.Errorf("restored = %q, want %q", restored, want)
+	}
+	if _, err := os.Stat(restored); err != nil {
+		t.Errorf("expected restored file: %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package taskfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// CreateRequest describes the frontmatter and body of a new task file.
+type CreateRequest struct {
+	ID           string
+	Title        string
+	Status       string
+	Priority     string
+	Effort       string
+	Owner        string
+	Parent       string
+	Group        string
+	Dependencies []string
+	Tags         []string
+	Created      time.Time
+	Body         string
+}
+
+// CreateTaskFile writes a new task file named "<id>-<slug>.md" into dir and
+// returns its path. It refuses to overwrite an existing file.
+func CreateTaskFile(dir string, req CreateRequest) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create task directory: %w", err)
+	}
+
+	path := filepath.Join(dir, FileName(req.ID, req.Title))
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("task file already exists: %s", path)
+	}
+
+	if err := os.WriteFile(path, []byte(RenderNewTask(req)), 0644); err != nil {
+		return "", fmt.Errorf("failed to write task file: %w", err)
+	}
+
+	return path, nil
+}
+
+// RenderNewTask renders the full markdown content (frontmatter and body) for a new task.
+func RenderNewTask(req CreateRequest) string {
+	status := req.Status
+	if status == "" {
+		status = "pending"
+	}
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "id: %q\n", req.ID)
+	fmt.Fprintf(&b, "title: %q\n", req.Title)
+	fmt.Fprintf(&b, "status: %s\n", status)
+	if req.Priority != "" {
+		fmt.Fprintf(&b, "priority: %s\n", req.Priority)
+	}
+	if req.Effort != "" {
+		fmt.Fprintf(&b, "effort: %s\n", req.Effort)
+	}
+	b.WriteString(FormatInlineList("dependencies", req.Dependencies) + "\n")
+	b.WriteString(FormatInlineTags(req.Tags) + "\n")
+	if req.Owner != "" {
+		fmt.Fprintf(&b, "owner: %q\n", req.Owner)
+	}
+	if req.Parent != "" {
+		fmt.Fprintf(&b, "parent: %q\n", req.Parent)
+	}
+	if req.Group != "" {
+		fmt.Fprintf(&b, "group: %q\n", req.Group)
+	}
+	if !req.Created.IsZero() {
+		fmt.Fprintf(&b, "created: %s\n", req.Created.Format("2006-01-02"))
+	}
+	b.WriteString("---\n")
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		body = "# " + req.Title
+	}
+	b.WriteString("\n")
+	b.WriteString(body)
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// FileName returns the conventional task filename "<id>-<slug>.md".
+func FileName(id, title string) string {
+	slug := Slugify(title)
+	if slug == "" {
+		return id + ".md"
+	}
+	return fmt.Sprintf("%s-%s.md", id, slug)
+}
+
+var nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slugify converts a title into a lowercase, hyphen-separated filename slug
+// of at most 50 characters.
+func Slugify(s string) string {
+	s = strings.ToLower(s)
+	s = nonAlphanumeric.ReplaceAllString(s, "-")
+	s = strings.Trim(s, "-")
+	if len(s) > 50 {
+		s = s[:50]
+		s = strings.TrimRight(s, "-")
+	}
+	return s
+}
@@ -0,0 +1,108 @@
+package taskfile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/parser"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Add login page", "add-login-page"},
+		{"  Fix: crash on (empty) input!  ", "fix-crash-on-empty-input"},
+		{"!!!", ""},
+		{strings.Repeat("word ", 20), "word-word-word-word-word-word-word-word-word-word"},
+	}
+	for _, tt := range tests {
+		if got := Slugify(tt.in); got != tt.want {
+			t.Errorf("Slugify(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFileName(t *testing.T) {
+	if got := FileName("042", "Add login"); got != "042-add-login.md" {
+		t.Errorf("unexpected filename: %q", got)
+	}
+	if got := FileName("042", "???"); got != "042.md" {
+		t.Errorf("expected bare id filename for empty slug, got %q", got)
+	}
+}
+
+func TestRenderNewTask(t *testing.T) {
+	out := RenderNewTask(CreateRequest{
+		ID:           "043",
+		Title:        "Add export",
+		Priority:     "high",
+		Dependencies: []string{"042"},
+		Tags:         []string{"cli"},
+		Parent:       "040",
+		Created:      time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	for _, want := range []string{
+		`id: "043"`,
+		`title: "Add export"`,
+		"status: pending",
+		"priority: high",
+		`dependencies: ["042"]`,
+		`tags: ["cli"]`,
+		`parent: "040"`,
+		"created: 2026-03-01",
+		"# Add export",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "effort:") || strings.Contains(out, "owner:") {
+		t.Errorf("expected empty optional fields to be omitted:\n%s", out)
+	}
+}
+
+func TestRenderNewTask_QuotesOwnerAndGroup(t *testing.T) {
+	content := RenderNewTask(CreateRequest{
+		ID:    "044",
+		Title: "Route mail",
+		Owner: "@alice",
+		Group: "ops: #infra",
+	})
+
+	task, err := parser.ParseTaskContent("044-route-mail.md", []byte(content))
+	if err != nil {
+		t.Fatalf("rendered task does not parse: %v\n%s", err, content)
+	}
+	if task.Owner != "@alice" {
+		t.Errorf("owner = %q, want %q", task.Owner, "@alice")
+	}
+	if task.Group != "ops: #infra" {
+		t.Errorf("group = %q, want %q", task.Group, "ops: #infra")
+	}
+}
+
+func TestCreateTaskFile_RefusesOverwrite(t *testing.T) {
+	dir := t.TempDir()
+	req := CreateRequest{ID: "001", Title: "First"}
+
+	path, err := CreateTaskFile(dir, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if filepath.Base(path) != "001-first.md" {
+		t.Errorf("unexpected path: %s", path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected file to exist: %v", err)
+	}
+
+	if _, err := CreateTaskFile(dir, req); err == nil {
+		t.Fatal("expected error when file already exists")
+	}
+}
@@ -0,0 +1,298 @@
+package taskfile
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+// listUpdate describes a replace or add/remove change to a single list field.
+type listUpdate struct {
+	key     string
+	replace *[]string
+	add     []string
+	remove  []string
+}
+
+func (u listUpdate) changed() bool {
+	return u.replace != nil || len(u.add) > 0 || len(u.remove) > 0
+}
+
+func (u listUpdate) compute(current []string) []string {
+	if u.replace != nil {
+		return *u.replace
+	}
+	return ComputeNewList(current, u.add, u.remove)
+}
+
+func buildListUpdates(req UpdateRequest) []listUpdate {
+	return []listUpdate{
+		{key: "tags", replace: req.Tags, add: req.AddTags, remove: req.RemTags},
+		{key: "dependencies", replace: req.Dependencies, add: req.AddDeps, remove: req.RemDeps},
+		{key: "touches", replace: req.Touches, add: req.AddTouches, remove: req.RemTouches},
+		{key: "context", replace: req.Context, add: req.AddContext, remove: req.RemContext},
+	}
+}
+
+// ComputeNewTags computes the resulting tag list after additions and removals.
+func ComputeNewTags(current, addTags, removeTags []string) []string {
+	return ComputeNewList(current, addTags, removeTags)
+}
+
+// ComputeNewList computes the resulting list after additions and removals.
+// Existing order is preserved, removals win over additions of the same value
+// already present, and duplicates are dropped.
+func ComputeNewList(current, add, remove []string) []string {
+	removeSet := make(map[string]bool, len(remove))
+	for _, v := range remove {
+		removeSet[v] = true
+	}
+
+	var result []string
+	seen := make(map[string]bool)
+	for _, v := range current {
+		if !removeSet[v] && !seen[v] {
+			result = append(result, v)
+			seen[v] = true
+		}
+	}
+
+	for _, v := range add {
+		if !seen[v] {
+			result = append(result, v)
+			seen[v] = true
+		}
+	}
+
+	return result
+}
+
+// findKeyLine returns the index of the "key:" line within the frontmatter, or -1.
+func findKeyLine(lines []string, openIdx, closeIdx int, key string) int {
+	for i := openIdx + 1; i < closeIdx; i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), key+":") {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseCurrentList reads the existing values of a list field from frontmatter lines.
+func parseCurrentList(lines []string, openIdx, closeIdx int, key string) []string {
+	i := findKeyLine(lines, openIdx, closeIdx, key)
+	if i < 0 {
+		return nil
+	}
+	if strings.Contains(lines[i], "[") {
+		return parseInlineList(strings.TrimSpace(lines[i]))
+	}
+	end := multilineItemsEnd(lines, i+1, closeIdx)
+	var values []string
+	for j := i + 1; j < end; j++ {
+		values = append(values, unquote(strings.TrimPrefix(strings.TrimSpace(lines[j]), "- ")))
+	}
+	return values
+}
+
+func parseInlineList(line string) []string {
+	start := strings.Index(line, "[")
+	end := strings.LastIndex(line, "]")
+	if end <= start {
+		return nil
+	}
+	inner := line[start+1 : end]
+	if strings.TrimSpace(inner) == "" {
+		return nil
+	}
+	var values []string
+	for _, p := range strings.Split(inner, ",") {
+		p = unquote(strings.TrimSpace(p))
+		if p != "" {
+			values = append(values, p)
+		}
+	}
+	return values
+}
+
+func unquote(s string) string {
+	return strings.Trim(strings.TrimSpace(s), `"'`)
+}
+
+// multilineItemsEnd returns the index just past the "- item" lines starting at start.
+func multilineItemsEnd(lines []string, start, closeIdx int) int {
+	end := start
+	for end < closeIdx && strings.HasPrefix(strings.TrimSpace(lines[end]), "- ") {
+		end++
+	}
+	return end
+}
+
+// applyListUpdate rewrites a list field to hold newValues, preserving inline
+// or multiline formatting. A missing field is inserted in inline form.
+func applyListUpdate(lines []string, openIdx, closeIdx int, key string, newValues []string) ([]string, int) {
+	keyIdx := findKeyLine(lines, openIdx, closeIdx, key)
+
+	if keyIdx < 0 {
+		lines = insertLine(lines, closeIdx, FormatInlineList(key, newValues))
+		return lines, closeIdx + 1
+	}
+
+	if strings.Contains(lines[keyIdx], "[") {
+		lines[keyIdx] = FormatInlineList(key, newValues)
+		return lines, closeIdx
+	}
+
+	removeStart := keyIdx + 1
+	removeEnd := multilineItemsEnd(lines, removeStart, closeIdx)
+
+	// An empty block has no item style to preserve; fall back to inline.
+	if removeEnd == removeStart || len(newValues) == 0 {
+		lines[keyIdx] = FormatInlineList(key, newValues)
+		return replaceLines(lines, removeStart, removeEnd, nil), closeIdx - (removeEnd - removeStart)
+	}
+
+	first := lines[removeStart]
+	indent := first[:len(first)-len(strings.TrimLeft(first, " "))]
+	quoted := strings.HasPrefix(strings.TrimPrefix(strings.TrimSpace(first), "- "), `"`)
+
+	newLines := make([]string, len(newValues))
+	for i, v := range newValues {
+		if quoted {
+			v = strconv.Quote(v)
+		}
+		newLines[i] = indent + "- " + v
+	}
+
+	lines = replaceLines(lines, removeStart, removeEnd, newLines)
+	return lines, closeIdx + len(newLines) - (removeEnd - removeStart)
+}
+
+// replaceLines replaces lines[start:end] with repl.
+func replaceLines(lines []string, start, end int, repl []string) []string {
+	result := make([]string, 0, len(lines)-(end-start)+len(repl))
+	result = append(result, lines[:start]...)
+	result = append(result, repl...)
+	result = append(result, lines[end:]...)
+	return result
+}
+
+// blockEnd returns the index just past a nested YAML block that starts on keyIdx.
+// The block continues while lines are indented, list items, or blank.
+func blockEnd(lines []string, keyIdx, closeIdx int) int {
+	end := keyIdx + 1
+	for end < closeIdx {
+		line := lines[end]
+		if strings.TrimSpace(line) != "" && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") && !strings.HasPrefix(line, "-") {
+			break
+		}
+		end++
+	}
+	for end > keyIdx+1 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	return end
+}
+
+// ComputeNewVerify computes the resulting verify steps after additions and removals.
+// A step is removed when its run or check field equals one of the remove values.
+func ComputeNewVerify(current, add []model.VerifyStep, remove []string) []model.VerifyStep {
+	removeSet := make(map[string]bool, len(remove))
+	for _, v := range remove {
+		removeSet[v] = true
+	}
+
+	var result []model.VerifyStep
+	for _, s := range current {
+		if (s.Run != "" && removeSet[s.Run]) || (s.Check != "" && removeSet[s.Check]) {
+			continue
+		}
+		result = append(result, s)
+	}
+	return append(result, add...)
+}
+
+// parseCurrentVerify decodes the existing verify block from frontmatter lines.
+func parseCurrentVerify(lines []string, keyIdx, end int) ([]model.VerifyStep, error) {
+	var doc struct {
+		Verify []model.VerifyStep `yaml:"verify"`
+	}
+	block := strings.Join(lines[keyIdx:end], "\n")
+	if err := yaml.Unmarshal([]byte(block), &doc); err != nil {
+		return nil, err
+	}
+	return doc.Verify, nil
+}
+
+// applyVerifyUpdate rewrites the verify block according to the request.
+func applyVerifyUpdate(lines []string, openIdx, closeIdx int, req UpdateRequest) ([]string, int, error) {
+	keyIdx := findKeyLine(lines, openIdx, closeIdx, "verify")
+
+	var current []model.VerifyStep
+	end := closeIdx
+	if keyIdx >= 0 {
+		end = blockEnd(lines, keyIdx, closeIdx)
+		var err error
+		current, err = parseCurrentVerify(lines, keyIdx, end)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	newSteps := ComputeNewVerify(current, req.AddVerify, req.RemVerify)
+	if req.Verify != nil {
+		newSteps = *req.Verify
+	}
+	rendered := FormatVerifySteps(newSteps)
+
+	if keyIdx < 0 {
+		lines = replaceLines(lines, closeIdx, closeIdx, rendered)
+		return lines, closeIdx + len(rendered), nil
+	}
+
+	lines = replaceLines(lines, keyIdx, end, rendered)
+	return lines, closeIdx + len(rendered) - (end - keyIdx), nil
+}
+
+// FormatVerifySteps renders verify steps as a multiline YAML block.
+func FormatVerifySteps(steps []model.VerifyStep) []string {
+	if len(steps) == 0 {
+		return []string{"verify: []"}
+	}
+	out := []string{"verify:"}
+	for _, s := range steps {
+		out = append(out, "  - type: "+s.Type)
+		if s.Run != "" {
+			out = append(out, "    run: "+strconv.Quote(s.Run))
+		}
+		if s.Dir != "" {
+			out = append(out, "    dir: "+strconv.Quote(s.Dir))
+		}
+		if s.Check != "" {
+			out = append(out, "    check: "+strconv.Quote(s.Check))
+		}
+	}
+	return out
+}
+
+// ParseVerifyFlag parses a "type:value" shorthand into a verify step.
+// "bash:<command>" sets run and "assert:<text>" sets check.
+func ParseVerifyFlag(spec string) (model.VerifyStep, error) {
+	typ, value, ok := strings.Cut(spec, ":")
+	typ = strings.TrimSpace(typ)
+	value = strings.TrimSpace(value)
+	if !ok || value == "" {
+		return model.VerifyStep{}, fmt.Errorf("invalid verify step %q (expected bash:<command> or assert:<check>)", spec)
+	}
+	switch typ {
+	case "bash":
+		return model.VerifyStep{Type: typ, Run: value}, nil
+	case "assert":
+		return model.VerifyStep{Type: typ, Check: value}, nil
+	default:
+		return model.VerifyStep{}, fmt.Errorf("invalid verify step type %q (expected bash or assert)", typ)
+	}
+}
@@ -0,0 +1,171 @@
+package taskfile
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+	"github.com/driangle/taskmd/apps/cli/internal/parser"
+)
+
+const listFieldsTask = `---
+id: "005"
+title: "List fields"
+status: pending
+dependencies:
+  - "001"
+  - "002"
+touches: ["cli/graph"]
+verify:
+  - type: bash
+    run: "go test ./..."
+    dir: apps/cli
+  - type: assert
+    check: "Graph renders"
+created: 2026-02-08
+---
+
+# List fields
+`
+
+func TestUpdateTaskFile_AddRemoveDependenciesMultiline(t *testing.T) {
+	path := createTestFile(t, listFieldsTask)
+
+	err := UpdateTaskFile(path, UpdateRequest{
+		AddDeps: []string{"003"},
+		RemDeps: []string{"001"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, _ := os.ReadFile(path)
+	s := string(content)
+	if !strings.Contains(s, "dependencies:\n  - \"002\"\n  - \"003\"\ntouches:") {
+		t.Errorf("expected quoted multiline dependencies preserved, got:\n%s", s)
+	}
+}
+
+func TestUpdateTaskFile_ReplaceDependenciesInline(t *testing.T) {
+	path := createTestFile(t, inlineTagsTask)
+
+	deps := []string{"010", "011"}
+	if err := UpdateTaskFile(path, UpdateRequest{Dependencies: &deps}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, _ := os.ReadFile(path)
+	if !strings.Contains(string(content), `dependencies: ["010", "011"]`) {
+		t.Errorf("expected inserted inline dependencies, got:\n%s", content)
+	}
+}
+
+func TestUpdateTaskFile_RemoveAllMultilineItems(t *testing.T) {
+	path := createTestFile(t, listFieldsTask)
+
+	if err := UpdateTaskFile(path, UpdateRequest{RemDeps: []string{"001", "002"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, _ := os.ReadFile(path)
+	if !strings.Contains(string(content), "dependencies: []\ntouches:") {
+		t.Errorf("expected empty inline list, got:\n%s", content)
+	}
+}
+
+func TestUpdateTaskFile_TouchesAndContext(t *testing.T) {
+	path := createTestFile(t, listFieldsTask)
+
+	err := UpdateTaskFile(path, UpdateRequest{
+		AddTouches: []string{"cli/list"},
+		RemTouches: []string{"cli/graph"},
+		AddContext: []string{"docs/graph.md"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, _ := os.ReadFile(path)
+	s := string(content)
+	if !strings.Contains(s, `touches: ["cli/list"]`) {
+		t.Errorf("expected touches update, got:\n%s", s)
+	}
+	if !strings.Contains(s, `context: ["docs/graph.md"]`) {
+		t.Errorf("expected context insertion, got:\n%s", s)
+	}
+}
+
+func TestUpdateTaskFile_AddRemoveVerify(t *testing.T) {
+	path := createTestFile(t, listFieldsTask)
+
+	err := UpdateTaskFile(path, UpdateRequest{
+		AddVerify: []model.VerifyStep{{Type: "bash", Run: "make lint"}},
+		RemVerify: []string{"Graph renders"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	task, err := parser.ParseTaskFile(path)
+	if err != nil {
+		t.Fatalf("updated file no longer parses: %v", err)
+	}
+	if len(task.Verify) != 2 {
+		t.Fatalf("expected 2 verify steps, got %+v", task.Verify)
+	}
+	if task.Verify[0].Run != "go test ./..." || task.Verify[0].Dir != "apps/cli" {
+		t.Errorf("expected first step preserved, got %+v", task.Verify[0])
+	}
+	if task.Verify[1].Run != "make lint" {
+		t.Errorf("expected appended step, got %+v", task.Verify[1])
+	}
+	if task.Created.IsZero() {
+		t.Error("expected fields after verify block to be preserved")
+	}
+}
+
+func TestUpdateTaskFile_ReplaceVerifyInsertsBlock(t *testing.T) {
+	path := createTestFile(t, noTagsTask)
+
+	steps := []model.VerifyStep{{Type: "assert", Check: `Output says "ok"`}}
+	if err := UpdateTaskFile(path, UpdateRequest{Verify: &steps}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	task, err := parser.ParseTaskFile(path)
+	if err != nil {
+		t.Fatalf("updated file no longer parses: %v", err)
+	}
+	if len(task.Verify) != 1 || task.Verify[0].Check != `Output says "ok"` {
+		t.Errorf("unexpected verify steps: %+v", task.Verify)
+	}
+	if !strings.Contains(task.Body, "Body content here.") {
+		t.Error("expected body to be preserved")
+	}
+}
+
+func TestValidateUpdateRequest_InvalidVerify(t *testing.T) {
+	errs := ValidateUpdateRequest(UpdateRequest{AddVerify: []model.VerifyStep{{Type: "bash"}}})
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %v", errs)
+	}
+}
+
+func TestParseVerifyFlag(t *testing.T) {
+	step, err := ParseVerifyFlag("bash: go test ./...")
+	if err != nil || step.Type != "bash" || step.Run != "go test ./..." {
+		t.Errorf("unexpected bash step: %+v, %v", step, err)
+	}
+
+	step, err = ParseVerifyFlag("assert:Docs updated")
+	if err != nil || step.Type != "assert" || step.Check != "Docs updated" {
+		t.Errorf("unexpected assert step: %+v, %v", step, err)
+	}
+
+	for _, bad := range []string{"go test", "bash:", "shell:ls"} {
+		if _, err := ParseVerifyFlag(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
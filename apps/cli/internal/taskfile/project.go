@@ -0,0 +1,44 @@
+package taskfile
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// ConfigFileName is the project config file that marks a taskmd project.
+const ConfigFileName = ".taskmd.yaml"
+
+// stateDirName is the directory that holds taskmd's local state.
+const stateDirName = ".taskmd"
+
+// ProjectDir returns the nearest directory at or above dir that contains
+// .taskmd.yaml or .taskmd/. The search stops at the repository root (the
+// first directory containing .git) and never reaches the home directory,
+// whose .taskmd.yaml is the user's global config. ok is false when there is
+// no such directory.
+func ProjectDir(dir string) (root string, ok bool) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	home, _ := os.UserHomeDir()
+
+	for dir := abs; dir != home; {
+		for _, marker := range []string{ConfigFileName, stateDirName} {
+			if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+				return dir, true
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir || isRepoRoot(dir) {
+			return "", false
+		}
+		dir = parent
+	}
+	return "", false
+}
+
+func isRepoRoot(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
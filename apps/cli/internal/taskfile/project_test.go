@@ -0,0 +1,52 @@
+package taskfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func mkdirs(t *testing.T, paths ...string) {
+	t.Helper()
+	for _, p := range paths {
+		if err := os.MkdirAll(p, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestProjectDir(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, ConfigFileName), []byte("dir: tasks\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tasks := filepath.Join(root, "tasks", "backend")
+	mkdirs(t, tasks)
+
+	if got, ok := ProjectDir(tasks); !ok || got != root {
+		t.Errorf("ProjectDir = %q, %v; want %q", got, ok, root)
+	}
+}
+
+func TestProjectDir_StateDir(t *testing.T) {
+	root := t.TempDir()
+	mkdirs(t, filepath.Join(root, ".taskmd"))
+
+	if got, ok := ProjectDir(root); !ok || got != root {
+		t.Errorf("ProjectDir = %q, %v; want %q", got, ok, root)
+	}
+}
+
+func TestProjectDir_StopsAtRepoRoot(t *testing.T) {
+	outer := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outer, ConfigFileName), []byte("dir: .\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	repo := filepath.Join(outer, "repo")
+	tasks := filepath.Join(repo, "tasks")
+	mkdirs(t, filepath.Join(repo, ".git"), tasks)
+
+	if got, ok := ProjectDir(tasks); ok {
+		t.Errorf("expected no project dir outside the repository, got %q", got)
+	}
+}
@@ -1,25 +1,69 @@
 package taskfile
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/driangle/taskmd/apps/cli/internal/model"
 )
 
 // UpdateRequest describes which fields to update. Nil pointer means "no change".
 type UpdateRequest struct {
-	Title    *string
-	Status   *string
-	Priority *string
-	Effort   *string
-	Owner    *string
-	Parent   *string
-	Tags     *[]string // replace tags entirely
-	AddTags  []string  // add to existing tags
-	RemTags  []string  // remove from existing tags
-	Body     *string
+	ID        *string
+	Title     *string
+	Status    *string
+	Priority  *string
+	Effort    *string
+	Owner     *string
+	Parent    *string
+	Group     *string
+	Due       *string   // YYYY-MM-DD; empty clears
+	Start     *string   // YYYY-MM-DD; empty clears
+	Scheduled *string   // YYYY-MM-DD; empty clears
+	Started   *string   // RFC 3339 timestamp; empty removes the field
+	Completed *string   // RFC 3339 timestamp; empty removes the field
+	Tags      *[]string // replace tags entirely
+	AddTags   []string  // add to existing tags
+	RemTags   []string  // remove from existing tags
+	Body      *string
+
+	Dependencies *[]string // replace dependencies entirely
+	AddDeps      []string  // add to existing dependencies
+	RemDeps      []string  // remove from existing dependencies
+	Touches      *[]string // replace touches entirely
+	AddTouches   []string  // add to existing touches
+	RemTouches   []string  // remove from existing touches
+	Context      *[]string // replace context entirely
+	AddContext   []string  // add to existing context
+	RemContext   []string  // remove from existing context
+
+	Verify    *[]model.VerifyStep // replace verify steps entirely
+	AddVerify []model.VerifyStep  // append verify steps
+	RemVerify []string            // remove verify steps whose run or check matches
+
+	Timestamps *TimestampConfig // timestamps to maintain on a status change; nil maintains none
+}
+
+// HasListEdits reports whether the request adds or removes any list items.
+func (r UpdateRequest) HasListEdits() bool {
+	lengths := []int{
+		len(r.AddTags), len(r.RemTags),
+		len(r.AddDeps), len(r.RemDeps),
+		len(r.AddTouches), len(r.RemTouches),
+		len(r.AddContext), len(r.RemContext),
+		len(r.AddVerify), len(r.RemVerify),
+	}
+	for _, n := range lengths {
+		if n > 0 {
+			return true
+		}
+	}
+	return false
 }
 
 var validStatuses = map[string]bool{
@@ -46,33 +90,114 @@ var validEfforts = map[string]bool{
 // ValidateUpdateRequest checks enum fields and returns a list of error strings.
 func ValidateUpdateRequest(req UpdateRequest) []string {
 	var errs []string
-	if req.Status != nil && !validStatuses[*req.Status] {
-		errs = append(errs, fmt.Sprintf("invalid status: %q", *req.Status))
+	for _, e := range []struct {
+		name  string
+		value *string
+		valid map[string]bool
+	}{
+		{"status", req.Status, validStatuses},
+		{"priority", req.Priority, validPriorities},
+		{"effort", req.Effort, validEfforts},
+	} {
+		if e.value != nil && !e.valid[*e.value] {
+			errs = append(errs, fmt.Sprintf("invalid %s: %q", e.name, *e.value))
+		}
 	}
-	if req.Priority != nil && !validPriorities[*req.Priority] {
-		errs = append(errs, fmt.Sprintf("invalid priority: %q", *req.Priority))
+	for _, d := range []struct {
+		name, kind, layout, expected string
+		value                        *string
+	}{
+		{"due", "date", DateLayout, "YYYY-MM-DD", req.Due},
+		{"start", "date", DateLayout, "YYYY-MM-DD", req.Start},
+		{"scheduled", "date", DateLayout, "YYYY-MM-DD", req.Scheduled},
+		{"started", "timestamp", TimestampLayout, "RFC 3339", req.Started},
+		{"completed", "timestamp", TimestampLayout, "RFC 3339", req.Completed},
+	} {
+		if d.value != nil && *d.value != "" {
+			if _, err := time.Parse(d.layout, *d.value); err != nil {
+				errs = append(errs, fmt.Sprintf("invalid %s %s: %q (expected %s)", d.name, d.kind, *d.value, d.expected))
+			}
+		}
 	}
-	if req.Effort != nil && !validEfforts[*req.Effort] {
-		errs = append(errs, fmt.Sprintf("invalid effort: %q", *req.Effort))
+	if req.Verify != nil {
+		errs = append(errs, model.ValidateVerifySteps(*req.Verify)...)
 	}
+	errs = append(errs, model.ValidateVerifySteps(req.AddVerify)...)
 	return errs
 }
 
+// DateLayout is the format of date fields (created, due, start, scheduled).
+const DateLayout = "2006-01-02"
+
+// ErrNoFrontmatter is returned when content has no "---" delimited frontmatter block.
+var ErrNoFrontmatter = errors.New("no valid frontmatter")
+
 // UpdateTaskFile reads a task markdown file, applies the requested changes, and writes it back.
+// A status change also maintains the started and completed timestamps
+// selected by req.Timestamps.
 func UpdateTaskFile(filePath string, req UpdateRequest) error {
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to read task file: %w", err)
 	}
 
+	if req.Status != nil && req.Timestamps != nil {
+		stampTransition(&req, content, time.Now(), *req.Timestamps)
+	}
+
+	updated, err := ApplyUpdate(content, req)
+	if errors.Is(err, ErrNoFrontmatter) {
+		return fmt.Errorf("task file has no valid frontmatter: %s", filePath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", filePath, err)
+	}
+
+	return os.WriteFile(filePath, updated, 0644)
+}
+
+// ApplyUpdate applies the requested changes to task file content in memory
+// and returns the rewritten content. Lines not touched by the request are preserved.
+func ApplyUpdate(content []byte, req UpdateRequest) ([]byte, error) {
 	lines := strings.Split(string(content), "\n")
 
 	openIdx, closeIdx := FindFrontmatterBounds(lines)
 	if openIdx < 0 || closeIdx < 0 {
-		return fmt.Errorf("task file has no valid frontmatter: %s", filePath)
+		return nil, ErrNoFrontmatter
+	}
+
+	lines, closeIdx = applyScalarUpdates(lines, openIdx, closeIdx, req)
+
+	// Apply list field updates (tags, dependencies, touches, context).
+	for _, u := range buildListUpdates(req) {
+		if !u.changed() {
+			continue
+		}
+		current := parseCurrentList(lines, openIdx, closeIdx, u.key)
+		lines, closeIdx = applyListUpdate(lines, openIdx, closeIdx, u.key, u.compute(current))
+	}
+
+	// Apply verify step updates.
+	if req.Verify != nil || len(req.AddVerify) > 0 || len(req.RemVerify) > 0 {
+		var err error
+		lines, closeIdx, err = applyVerifyUpdate(lines, openIdx, closeIdx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update verify steps: %w", err)
+		}
 	}
 
-	// Apply scalar field updates within frontmatter.
+	// Apply body update — replace everything after closing ---.
+	if req.Body != nil {
+		lines = replaceBody(lines, closeIdx, *req.Body)
+	}
+
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+// applyScalarUpdates rewrites scalar fields within frontmatter, drops
+// removed ones and inserts those that weren't found. It returns the updated
+// lines and closing delimiter index.
+func applyScalarUpdates(lines []string, openIdx, closeIdx int, req UpdateRequest) ([]string, int) {
 	scalarUpdates := buildScalarUpdates(req)
 	found := make([]bool, len(scalarUpdates))
 	for i := openIdx + 1; i < closeIdx; i++ {
@@ -86,39 +211,28 @@ func UpdateTaskFile(filePath string, req UpdateRequest) error {
 		}
 	}
 
-	// Insert any scalar fields that weren't found in existing frontmatter.
+	lines, closeIdx = removeScalars(lines, openIdx, closeIdx, req)
 	for j := len(scalarUpdates) - 1; j >= 0; j-- {
-		if !found[j] {
+		if !found[j] && !scalarUpdates[j].remove {
 			u := scalarUpdates[j]
 			lines = insertLine(lines, closeIdx, u.key+": "+u.value)
 			closeIdx++
 		}
 	}
-
-	// Apply tag updates.
-	if req.Tags != nil {
-		lines, closeIdx = setTags(lines, openIdx, closeIdx, *req.Tags)
-	} else if len(req.AddTags) > 0 || len(req.RemTags) > 0 {
-		currentTags := parseCurrentTags(lines, openIdx, closeIdx)
-		newTags := ComputeNewTags(currentTags, req.AddTags, req.RemTags)
-		lines, closeIdx = applyTagUpdates(lines, openIdx, closeIdx, currentTags, newTags)
-	}
-
-	// Apply body update — replace everything after closing ---.
-	if req.Body != nil {
-		lines = replaceBody(lines, closeIdx, *req.Body)
-	}
-
-	return os.WriteFile(filePath, []byte(strings.Join(lines, "\n")), 0644)
+	return lines, closeIdx
 }
 
 type scalarUpdate struct {
-	key   string
-	value string
+	key    string
+	value  string
+	remove bool // drop the line instead of writing an empty value
 }
 
 func buildScalarUpdates(req UpdateRequest) []scalarUpdate {
 	var updates []scalarUpdate
+	if req.ID != nil {
+		updates = append(updates, scalarUpdate{key: "id", value: strconv.Quote(*req.ID)})
+	}
 	if req.Title != nil {
 		updates = append(updates, scalarUpdate{key: "title", value: fmt.Sprintf("%q", *req.Title)})
 	}
@@ -137,9 +251,48 @@ func buildScalarUpdates(req UpdateRequest) []scalarUpdate {
 	if req.Parent != nil {
 		updates = append(updates, scalarUpdate{key: "parent", value: *req.Parent})
 	}
+	if req.Group != nil {
+		updates = append(updates, scalarUpdate{key: "group", value: strconv.Quote(*req.Group)})
+	}
+	if req.Due != nil {
+		updates = append(updates, scalarUpdate{key: "due", value: *req.Due})
+	}
+	if req.Start != nil {
+		updates = append(updates, scalarUpdate{key: "start", value: *req.Start})
+	}
+	if req.Scheduled != nil {
+		updates = append(updates, scalarUpdate{key: "scheduled", value: *req.Scheduled})
+	}
+	if req.Started != nil {
+		updates = append(updates, scalarUpdate{key: "started", value: *req.Started, remove: *req.Started == ""})
+	}
+	if req.Completed != nil {
+		updates = append(updates, scalarUpdate{key: "completed", value: *req.Completed, remove: *req.Completed == ""})
+	}
 	return updates
 }
 
+// removeScalars deletes the frontmatter lines of fields the request removes
+// and returns the new closing delimiter index.
+func removeScalars(lines []string, openIdx, closeIdx int, req UpdateRequest) ([]string, int) {
+	var keys []string
+	for _, u := range buildScalarUpdates(req) {
+		if u.remove {
+			keys = append(keys, u.key+":")
+		}
+	}
+	if len(keys) == 0 {
+		return lines, closeIdx
+	}
+	for i := closeIdx - 1; i > openIdx; i-- {
+		if slices.ContainsFunc(keys, func(prefix string) bool { return strings.HasPrefix(lines[i], prefix) }) {
+			lines = slices.Delete(lines, i, i+1)
+			closeIdx--
+		}
+	}
+	return lines, closeIdx
+}
+
 // replaceBody replaces all content after the closing frontmatter delimiter.
 func replaceBody(lines []string, closeIdx int, newBody string) []string {
 	// Keep frontmatter lines including closing ---
@@ -160,137 +313,21 @@ func replaceBody(lines []string, closeIdx int, newBody string) []string {
 	return result
 }
 
-// parseCurrentTags reads the existing tags from frontmatter lines.
-func parseCurrentTags(lines []string, openIdx, closeIdx int) []string {
-	for i := openIdx + 1; i < closeIdx; i++ {
-		if !strings.HasPrefix(strings.TrimSpace(lines[i]), "tags:") {
-			continue
-		}
-		if strings.Contains(lines[i], "[") {
-			return parseInlineTags(strings.TrimSpace(lines[i]))
-		}
-		return parseMultilineTags(lines, i+1, closeIdx)
-	}
-	return nil
-}
-
-func parseInlineTags(line string) []string {
-	inner := line[strings.Index(line, "[")+1 : strings.LastIndex(line, "]")]
-	if strings.TrimSpace(inner) == "" {
-		return nil
-	}
-	parts := strings.Split(inner, ",")
-	var tags []string
-	for _, p := range parts {
-		p = strings.TrimSpace(p)
-		p = strings.Trim(p, `"'`)
-		if p != "" {
-			tags = append(tags, p)
-		}
-	}
-	return tags
-}
-
-func parseMultilineTags(lines []string, start, closeIdx int) []string {
-	var tags []string
-	for j := start; j < closeIdx; j++ {
-		lt := strings.TrimSpace(lines[j])
-		if strings.HasPrefix(lt, "- ") {
-			tags = append(tags, strings.TrimPrefix(lt, "- "))
-		} else {
-			break
-		}
-	}
-	return tags
-}
-
-// setTags replaces tags entirely with the given list.
-func setTags(lines []string, openIdx, closeIdx int, tags []string) ([]string, int) {
-	return applyTagUpdates(lines, openIdx, closeIdx, nil, tags)
-}
-
-// ComputeNewTags computes the resulting tag list after additions and removals.
-func ComputeNewTags(current, addTags, removeTags []string) []string {
-	removeSet := make(map[string]bool, len(removeTags))
-	for _, t := range removeTags {
-		removeSet[t] = true
-	}
-
-	var result []string
-	seen := make(map[string]bool)
-	for _, t := range current {
-		if !removeSet[t] {
-			result = append(result, t)
-			seen[t] = true
-		}
-	}
-
-	for _, t := range addTags {
-		if !seen[t] {
-			result = append(result, t)
-			seen[t] = true
-		}
-	}
-
-	return result
-}
-
-// applyTagUpdates modifies the lines slice to reflect the new tags.
-func applyTagUpdates(lines []string, openIdx, closeIdx int, _ []string, newTags []string) ([]string, int) {
-	tagsLineIdx := -1
-	for i := openIdx + 1; i < closeIdx; i++ {
-		if strings.HasPrefix(strings.TrimSpace(lines[i]), "tags:") {
-			tagsLineIdx = i
-			break
-		}
-	}
-
-	if tagsLineIdx < 0 {
-		tagLine := FormatInlineTags(newTags)
-		lines = insertLine(lines, closeIdx, tagLine)
-		closeIdx++
-		return lines, closeIdx
-	}
-
-	// Detect inline vs multiline format.
-	if strings.Contains(lines[tagsLineIdx], "[") {
-		lines[tagsLineIdx] = FormatInlineTags(newTags)
-		return lines, closeIdx
-	}
-
-	// Multiline format
-	removeStart := tagsLineIdx + 1
-	removeEnd := removeStart
-	for removeEnd < closeIdx && strings.HasPrefix(strings.TrimSpace(lines[removeEnd]), "- ") {
-		removeEnd++
-	}
-
-	var newTagLines []string
-	for _, t := range newTags {
-		newTagLines = append(newTagLines, "  - "+t)
-	}
-
-	before := lines[:removeStart]
-	after := lines[removeEnd:]
-	result := make([]string, 0, len(before)+len(newTagLines)+len(after))
-	result = append(result, before...)
-	result = append(result, newTagLines...)
-	result = append(result, after...)
-
-	closeIdx += len(newTagLines) - (removeEnd - removeStart)
-	return result, closeIdx
-}
-
 // FormatInlineTags formats tags as inline YAML: tags: ["a", "b"]
 func FormatInlineTags(tags []string) string {
-	if len(tags) == 0 {
-		return "tags: []"
+	return FormatInlineList("tags", tags)
+}
+
+// FormatInlineList formats a list field as inline YAML: key: ["a", "b"]
+func FormatInlineList(key string, values []string) string {
+	if len(values) == 0 {
+		return key + ": []"
 	}
-	quoted := make([]string, len(tags))
-	for i, t := range tags {
-		quoted[i] = `"` + t + `"`
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
 	}
-	return "tags: [" + strings.Join(quoted, ", ") + "]"
+	return key + ": [" + strings.Join(quoted, ", ") + "]"
 }
 
 func insertLine(lines []string, idx int, line string) []string {
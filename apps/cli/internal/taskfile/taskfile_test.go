@@ -127,6 +127,28 @@ func TestUpdateTaskFile_Title(t *testing.T) {
 	}
 }
 
+func TestUpdateTaskFile_Dates(t *testing.T) {
+	path := createTestFile(t, inlineTagsTask)
+
+	err := UpdateTaskFile(path, UpdateRequest{Due: strPtr("2026-03-01"), Start: strPtr("2026-02-15")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = UpdateTaskFile(path, UpdateRequest{Due: strPtr("2026-03-08")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, _ := os.ReadFile(path)
+	s := string(content)
+	if !strings.Contains(s, "due: 2026-03-08\n") || strings.Contains(s, "2026-03-01") {
+		t.Errorf("expected due date to be replaced, got:\n%s", s)
+	}
+	if !strings.Contains(s, "start: 2026-02-15\n") {
+		t.Errorf("expected start date, got:\n%s", s)
+	}
+}
+
 func TestUpdateTaskFile_ReplaceTags(t *testing.T) {
 	path := createTestFile(t, inlineTagsTask)
 
@@ -297,6 +319,16 @@ func TestValidateUpdateRequest_InvalidEffort(t *testing.T) {
 	}
 }
 
+func TestValidateUpdateRequest_InvalidDate(t *testing.T) {
+	errs := ValidateUpdateRequest(UpdateRequest{Due: strPtr("next friday"), Start: strPtr("")})
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
+	}
+	if !strings.Contains(errs[0], "invalid due date") {
+		t.Errorf("expected 'invalid due date' error, got: %s", errs[0])
+	}
+}
+
 func TestValidateUpdateRequest_MultipleErrors(t *testing.T) {
 	errs := ValidateUpdateRequest(UpdateRequest{
 		Status: strPtr("bad"),
@@ -0,0 +1,115 @@
+package taskfile
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/driangle/taskmd/apps/cli/internal/model"
+)
+
+// TimestampLayout is the format of the started and completed fields.
+const TimestampLayout = time.RFC3339
+
+// TimestampConfig controls which status-transition timestamps UpdateTaskFile
+// records in frontmatter. Callers load it from the timestamps section of
+// .taskmd.yaml; both are recorded unless that section turns them off.
+type TimestampConfig struct {
+	Started   bool `json:"started" yaml:"started"`     // set on the first move to in-progress
+	Completed bool `json:"completed" yaml:"completed"` // set on completion, removed on reopen
+}
+
+// DefaultTimestampConfig returns the built-in timestamp settings.
+func DefaultTimestampConfig() TimestampConfig {
+	return TimestampConfig{Started: true, Completed: true}
+}
+
+// ParseTimestampConfig converts the raw timestamps section (as decoded from
+// YAML) into a TimestampConfig. The section is either a boolean that turns
+// both fields on or off, or a mapping of field names to booleans. Problems
+// are returned as messages rather than errors.
+func ParseTimestampConfig(raw any) (TimestampConfig, []string) {
+	cfg := DefaultTimestampConfig()
+	switch v := raw.(type) {
+	case nil:
+		return cfg, nil
+	case bool:
+		return TimestampConfig{Started: v, Completed: v}, nil
+	case map[string]any:
+		fields := map[string]*bool{
+			"started":   &cfg.Started,
+			"completed": &cfg.Completed,
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		var problems []string
+		for _, key := range keys {
+			field, ok := fields[key]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("timestamps has unknown field: '%s' (valid: completed, started)", key))
+				continue
+			}
+			on, ok := v[key].(bool)
+			if !ok {
+				problems = append(problems, fmt.Sprintf("timestamps.%s must be true or false", key))
+				continue
+			}
+			*field = on
+		}
+		return cfg, problems
+	default:
+		return cfg, []string{"timestamps must be true, false or a mapping of started/completed to booleans"}
+	}
+}
+
+// stampTransition adds the timestamp changes implied by the request's status
+// change to req. Moving to in-progress records started unless it is already
+// set, completing records completed, and moving away from completed removes
+// it. Timestamps the request sets explicitly are left alone, as is a status
+// that does not change.
+func stampTransition(req *UpdateRequest, content []byte, now time.Time, cfg TimestampConfig) {
+	if req.Status == nil {
+		return
+	}
+	current := currentFrontmatter(content)
+	if current["status"] == *req.Status {
+		return
+	}
+	stamp := now.Truncate(time.Second).Format(TimestampLayout)
+
+	switch model.Status(*req.Status) {
+	case model.StatusInProgress:
+		if cfg.Started && req.Started == nil && current["started"] == "" {
+			req.Started = &stamp
+		}
+	case model.StatusCompleted:
+		if cfg.Completed && req.Completed == nil {
+			req.Completed = &stamp
+		}
+	}
+	if *req.Status != string(model.StatusCompleted) && cfg.Completed && req.Completed == nil && current["completed"] != "" {
+		remove := ""
+		req.Completed = &remove
+	}
+}
+
+// currentFrontmatter returns the top-level scalar values in content's
+// frontmatter, unquoted. Nested and list values are ignored.
+func currentFrontmatter(content []byte) map[string]string {
+	values := make(map[string]string)
+	lines := strings.Split(string(content), "\n")
+	openIdx, closeIdx := FindFrontmatterBounds(lines)
+	for i := openIdx + 1; i < closeIdx; i++ {
+		key, value, ok := strings.Cut(lines[i], ":")
+		if !ok || key == "" || strings.HasPrefix(key, " ") || strings.HasPrefix(key, "\t") {
+			continue
+		}
+		values[key] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+	return values
+}
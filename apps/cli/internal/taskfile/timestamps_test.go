@@ -0,0 +1,128 @@
+package taskfile
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+const pendingTask = `---
+id: "001"
+title: "Setup project"
+status: pending
+---
+
+# Setup project
+`
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestUpdateTaskFile_StampsTransitions(t *testing.T) {
+	defaults := DefaultTimestampConfig()
+	path := createTestFile(t, pendingTask)
+
+	if err := UpdateTaskFile(path, UpdateRequest{Timestamps: &defaults, Status: strPtr("in-progress")}); err != nil {
+		t.Fatal(err)
+	}
+	s := readTestFile(t, path)
+	started := frontmatterValue(t, s, "started")
+	if _, err := time.Parse(TimestampLayout, started); err != nil {
+		t.Fatalf("expected an RFC 3339 started timestamp, got %q", started)
+	}
+
+	if err := UpdateTaskFile(path, UpdateRequest{Timestamps: &defaults, Status: strPtr("completed")}); err != nil {
+		t.Fatal(err)
+	}
+	s = readTestFile(t, path)
+	if frontmatterValue(t, s, "completed") == "" {
+		t.Errorf("expected completed timestamp, got:\n%s", s)
+	}
+	if frontmatterValue(t, s, "started") != started {
+		t.Errorf("expected started to be kept, got:\n%s", s)
+	}
+
+	// Reopening removes completed but keeps started.
+	if err := UpdateTaskFile(path, UpdateRequest{Timestamps: &defaults, Status: strPtr("in-progress")}); err != nil {
+		t.Fatal(err)
+	}
+	s = readTestFile(t, path)
+	if strings.Contains(s, "completed:") {
+		t.Errorf("expected completed to be removed on reopen, got:\n%s", s)
+	}
+	if frontmatterValue(t, s, "started") != started {
+		t.Errorf("expected the original started timestamp, got:\n%s", s)
+	}
+	if !strings.HasSuffix(s, "---\n\n# Setup project\n") {
+		t.Errorf("expected the body to be untouched, got:\n%s", s)
+	}
+}
+
+func TestUpdateTaskFile_UnchangedStatusKeepsTimestamps(t *testing.T) {
+	defaults := DefaultTimestampConfig()
+	path := createTestFile(t, strings.Replace(pendingTask, "status: pending", "status: completed\ncompleted: 2026-01-02T09:00:00Z", 1))
+
+	if err := UpdateTaskFile(path, UpdateRequest{Timestamps: &defaults, Status: strPtr("completed")}); err != nil {
+		t.Fatal(err)
+	}
+	if got := frontmatterValue(t, readTestFile(t, path), "completed"); got != "2026-01-02T09:00:00Z" {
+		t.Errorf("expected completed to be unchanged, got %q", got)
+	}
+}
+
+func TestUpdateTaskFile_TimestampsDisabled(t *testing.T) {
+	path := createTestFile(t, pendingTask)
+	cfg := TimestampConfig{Started: false, Completed: true}
+
+	if err := UpdateTaskFile(path, UpdateRequest{Timestamps: &cfg, Status: strPtr("in-progress")}); err != nil {
+		t.Fatal(err)
+	}
+	if s := readTestFile(t, path); strings.Contains(s, "started:") {
+		t.Errorf("expected no started timestamp when disabled, got:\n%s", s)
+	}
+
+	if err := UpdateTaskFile(path, UpdateRequest{Timestamps: &cfg, Status: strPtr("completed")}); err != nil {
+		t.Fatal(err)
+	}
+	if s := readTestFile(t, path); !strings.Contains(s, "completed:") {
+		t.Errorf("expected completed timestamp, got:\n%s", s)
+	}
+}
+
+func TestUpdateTaskFile_NoTimestampConfig(t *testing.T) {
+	path := createTestFile(t, pendingTask)
+
+	if err := UpdateTaskFile(path, UpdateRequest{Status: strPtr("completed")}); err != nil {
+		t.Fatal(err)
+	}
+	if s := readTestFile(t, path); strings.Contains(s, "completed:") {
+		t.Errorf("expected no timestamps without a config, got:\n%s", s)
+	}
+}
+
+func TestParseTimestampConfig(t *testing.T) {
+	cfg, problems := ParseTimestampConfig(false)
+	if cfg.Started || cfg.Completed || len(problems) != 0 {
+		t.Errorf("expected both disabled, got %+v %v", cfg, problems)
+	}
+
+	cfg, problems = ParseTimestampConfig(map[string]any{"completed": false, "done": true})
+	if !cfg.Started || cfg.Completed {
+		t.Errorf("expected only completed disabled, got %+v", cfg)
+	}
+	if len(problems) != 1 || !strings.Contains(problems[0], "unknown field: 'done'") {
+		t.Errorf("expected an unknown field problem, got %v", problems)
+	}
+}
+
+func frontmatterValue(t *testing.T, content, key string) string {
+	t.Helper()
+	return currentFrontmatter([]byte(content))[key]
+}
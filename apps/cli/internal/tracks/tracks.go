@@ -41,6 +41,8 @@ type Result struct {
 type Options struct {
 	Filters     []string
 	KnownScopes map[string]bool
+	Scoring     *next.Config // nil uses next.DefaultConfig
+	Archived    []string     // IDs of archived tasks; dependencies on them count as met
 }
 
 type scored struct {
@@ -50,7 +52,11 @@ type scored struct {
 
 // Assign groups actionable tasks into parallel tracks based on scope overlap.
 func Assign(tasks []*model.Task, opts Options) (*Result, error) {
-	items, err := scoreActionable(tasks, opts.Filters)
+	scoring := next.DefaultConfig()
+	if opts.Scoring != nil {
+		scoring = *opts.Scoring
+	}
+	items, err := scoreActionable(tasks, opts, scoring)
 	if err != nil {
 		return nil, err
 	}
@@ -66,15 +72,16 @@ func Assign(tasks []*model.Task, opts Options) (*Result, error) {
 	}, nil
 }
 
-func scoreActionable(tasks []*model.Task, filters []string) ([]scored, error) {
+func scoreActionable(tasks []*model.Task, opts Options, scoring next.Config) ([]scored, error) {
 	taskMap := next.BuildTaskMap(tasks)
-	criticalPath := next.CalculateCriticalPathTasks(tasks, taskMap)
+	model.AddArchived(taskMap, opts.Archived)
+	criticalPath := next.CalculateCriticalPathTasks(tasks, scoring.Effort)
 	downstreamCounts := computeDownstreamCounts(tasks)
 
 	candidates := tasks
-	if len(filters) > 0 {
+	if len(opts.Filters) > 0 {
 		var err error
-		candidates, err = filter.Apply(candidates, filters)
+		candidates, err = filter.ApplyEnv(candidates, opts.Filters, filter.NewEnv(tasks).WithArchived(opts.Archived))
 		if err != nil {
 			return nil, err
 		}
@@ -83,7 +90,7 @@ func scoreActionable(tasks []*model.Task, filters []string) ([]scored, error) {
 	var items []scored
 	for _, t := range candidates {
 		if next.IsActionable(t, taskMap) {
-			s, _ := next.ScoreTask(t, criticalPath, downstreamCounts)
+			s, _ := scoring.Score(t, criticalPath, downstreamCounts)
 			items = append(items, scored{task: t, score: s})
 		}
 	}
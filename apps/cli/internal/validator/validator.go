@@ -2,9 +2,16 @@ package validator
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/driangle/taskmd/apps/cli/internal/filter"
+	"github.com/driangle/taskmd/apps/cli/internal/graph"
 	"github.com/driangle/taskmd/apps/cli/internal/model"
+	"github.com/driangle/taskmd/apps/cli/internal/next"
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
+	"github.com/driangle/taskmd/apps/cli/internal/views"
 )
 
 // ValidationLevel represents the severity of a validation issue
@@ -61,6 +68,10 @@ func (vr *ValidationResult) AddIssue(level ValidationLevel, taskID, filePath, me
 // Extracted in the CLI layer so the validator stays viper-free.
 type ConfigData struct {
 	Scopes     map[string]ScopeConfig
+	Views      any // raw views section, nil if absent
+	Next       any // raw next section, nil if absent
+	Effort     any // raw effort section, nil if absent
+	Timestamps any // raw timestamps section, nil if absent
 	TopKeys    []string
 	ConfigPath string
 }
@@ -73,12 +84,24 @@ type ScopeConfig struct {
 
 // Validator validates task collections
 type Validator struct {
-	strict bool
+	strict   bool
+	now      func() time.Time // clock for date checks; time.Now unless overridden in tests
+	archived map[string]bool  // IDs of archived tasks, which count as completed dependencies
 }
 
 // NewValidator creates a new validator
 func NewValidator(strict bool) *Validator {
-	return &Validator{strict: strict}
+	return &Validator{strict: strict, now: time.Now}
+}
+
+// WithArchived makes dependencies on the given archived task IDs resolve as
+// completed instead of being reported as missing.
+func (v *Validator) WithArchived(ids []string) *Validator {
+	v.archived = make(map[string]bool, len(ids))
+	for _, id := range ids {
+		v.archived[id] = true
+	}
+	return v
 }
 
 // Validate performs all validation checks on a set of tasks
@@ -103,6 +126,8 @@ func (v *Validator) Validate(tasks []*model.Task) *ValidationResult {
 	v.checkMissingParent(tasks, taskMap, result)
 	v.checkParentSelfReference(tasks, result)
 	v.checkParentCycles(tasks, taskMap, result)
+	v.checkDates(tasks, result)
+	v.checkTimestamps(tasks, result)
 
 	// Strict mode additional checks
 	if v.strict {
@@ -165,6 +190,11 @@ func (v *Validator) checkInvalidFieldValues(tasks []*model.Task, result *Validat
 			result.AddIssue(LevelError, task.ID, task.FilePath,
 				fmt.Sprintf("invalid effort: '%s' (valid values: small, medium, large)", task.Effort))
 		}
+
+		if task.Estimate < 0 {
+			result.AddIssue(LevelError, task.ID, task.FilePath,
+				fmt.Sprintf("invalid estimate: %g (must be a non-negative number of hours)", task.Estimate))
+		}
 	}
 }
 
@@ -191,7 +221,7 @@ func (v *Validator) checkDuplicateIDs(tasks []*model.Task, result *ValidationRes
 func (v *Validator) checkMissingDependencies(tasks []*model.Task, taskMap map[string]*model.Task, result *ValidationResult) {
 	for _, task := range tasks {
 		for _, depID := range task.Dependencies {
-			if _, exists := taskMap[depID]; !exists {
+			if _, exists := taskMap[depID]; !exists && !v.archived[depID] {
 				result.AddIssue(LevelError, task.ID, task.FilePath,
 					fmt.Sprintf("dependency references non-existent task: '%s'", depID))
 			}
@@ -310,6 +340,46 @@ func (v *Validator) checkParentCycles(tasks []*model.Task, taskMap map[string]*m
 	}
 }
 
+// checkDates validates that start and scheduled dates do not fall after the due date
+func (v *Validator) checkDates(tasks []*model.Task, result *ValidationResult) {
+	for _, task := range tasks {
+		if task.Due.IsZero() {
+			continue
+		}
+		if !task.Start.IsZero() && task.Start.After(task.Due) {
+			result.AddIssue(LevelError, task.ID, task.FilePath,
+				fmt.Sprintf("start date %s is after due date %s", formatDate(task.Start), formatDate(task.Due)))
+		}
+		if !task.Scheduled.IsZero() && task.Scheduled.After(task.Due) {
+			result.AddIssue(LevelWarning, task.ID, task.FilePath,
+				fmt.Sprintf("scheduled date %s is after due date %s", formatDate(task.Scheduled), formatDate(task.Due)))
+		}
+	}
+}
+
+// checkTimestamps validates that completed is only set on completed tasks and
+// does not come before started
+func (v *Validator) checkTimestamps(tasks []*model.Task, result *ValidationResult) {
+	for _, task := range tasks {
+		if task.Completed.IsZero() {
+			continue
+		}
+		if task.Status != model.StatusCompleted {
+			result.AddIssue(LevelError, task.ID, task.FilePath,
+				fmt.Sprintf("completed timestamp is set but status is '%s'", task.Status))
+		}
+		if !task.Started.IsZero() && task.Completed.Before(task.Started) {
+			result.AddIssue(LevelWarning, task.ID, task.FilePath,
+				fmt.Sprintf("completed timestamp %s is before started timestamp %s",
+					task.Completed.Format(time.RFC3339), task.Started.Format(time.RFC3339)))
+		}
+	}
+}
+
+func formatDate(d time.Time) string {
+	return d.Format("2006-01-02")
+}
+
 // ValidateConfig checks the .taskmd.yaml config file for issues.
 // Returns an empty result if config is nil.
 func (v *Validator) ValidateConfig(config *ConfigData) *ValidationResult {
@@ -321,6 +391,10 @@ func (v *Validator) ValidateConfig(config *ConfigData) *ValidationResult {
 	}
 
 	v.checkConfigScopes(config, result)
+	v.checkConfigViews(config, result)
+	v.checkConfigNext(config, result)
+	v.checkConfigEffort(config, result)
+	v.checkConfigTimestamps(config, result)
 	v.checkUnknownConfigKeys(config, result)
 
 	return result
@@ -348,13 +422,77 @@ func scopeLabel(name, description string) string {
 	return fmt.Sprintf("scope '%s'", name)
 }
 
+// checkConfigViews reports malformed views: unknown or mistyped fields are
+// warnings, invalid filter expressions and sort fields are errors.
+func (v *Validator) checkConfigViews(config *ConfigData, result *ValidationResult) {
+	if config.Views == nil {
+		return
+	}
+
+	parsed, problems := views.Parse(config.Views)
+	for _, p := range problems {
+		result.AddIssue(LevelWarning, "", config.ConfigPath, p)
+	}
+
+	for _, view := range parsed.List() {
+		if _, err := filter.Compile(view.Filters); err != nil {
+			result.AddIssue(LevelError, "", config.ConfigPath,
+				fmt.Sprintf("view '%s' has an invalid filter: %v", view.Name, err))
+		}
+		if view.Sort != "" && !slices.Contains(model.SortFields, view.Sort) {
+			result.AddIssue(LevelError, "", config.ConfigPath,
+				fmt.Sprintf("view '%s' has invalid sort field '%s' (valid: %s)", view.Name, view.Sort, strings.Join(model.SortFields, ", ")))
+		}
+	}
+}
+
+// checkConfigNext reports unknown keys and non-integer values in the next
+// scoring section as warnings.
+func (v *Validator) checkConfigNext(config *ConfigData, result *ValidationResult) {
+	if config.Next == nil {
+		return
+	}
+	_, problems := next.ParseConfig(config.Next)
+	for _, p := range problems {
+		result.AddIssue(LevelWarning, "", config.ConfigPath, p)
+	}
+}
+
+// checkConfigEffort reports unknown levels and invalid hours in the effort
+// section as warnings.
+func (v *Validator) checkConfigEffort(config *ConfigData, result *ValidationResult) {
+	if config.Effort == nil {
+		return
+	}
+	_, problems := graph.ParseEffortWeights(config.Effort)
+	for _, p := range problems {
+		result.AddIssue(LevelWarning, "", config.ConfigPath, p)
+	}
+}
+
+// checkConfigTimestamps reports unknown fields and non-boolean values in the
+// timestamps section as warnings.
+func (v *Validator) checkConfigTimestamps(config *ConfigData, result *ValidationResult) {
+	if config.Timestamps == nil {
+		return
+	}
+	_, problems := taskfile.ParseTimestampConfig(config.Timestamps)
+	for _, p := range problems {
+		result.AddIssue(LevelWarning, "", config.ConfigPath, p)
+	}
+}
+
 var knownConfigKeys = map[string]bool{
-	"dir":      true,
-	"task-dir": true,
-	"web":      true,
-	"scopes":   true,
-	"sync":     true,
-	"ignore":   true,
+	"dir":        true,
+	"task-dir":   true,
+	"web":        true,
+	"scopes":     true,
+	"sync":       true,
+	"ignore":     true,
+	"views":      true,
+	"next":       true,
+	"effort":     true,
+	"timestamps": true,
 }
 
 // checkUnknownConfigKeys warns about unrecognized top-level config keys.
@@ -429,5 +567,11 @@ func (v *Validator) checkStrictWarnings(tasks []*model.Task, result *ValidationR
 			result.AddIssue(LevelWarning, task.ID, task.FilePath,
 				"task has no description/body content")
 		}
+
+		// Warn about open tasks past their due date
+		if task.IsOverdue(v.now()) {
+			result.AddIssue(LevelWarning, task.ID, task.FilePath,
+				fmt.Sprintf("task is overdue (due %s)", formatDate(task.Due)))
+		}
 	}
 }
@@ -1,7 +1,9 @@
 package validator
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/driangle/taskmd/apps/cli/internal/model"
 )
@@ -113,6 +115,17 @@ func TestValidate_InvalidFieldValues(t *testing.T) {
 			},
 			wantErrs: 1,
 		},
+		{
+			name: "negative estimate",
+			tasks: []*model.Task{
+				{
+					ID:       "001",
+					Title:    "Test",
+					Estimate: -3,
+				},
+			},
+			wantErrs: 1,
+		},
 		{
 			name: "multiple invalid values",
 			tasks: []*model.Task{
@@ -260,6 +273,21 @@ func TestValidate_MissingDependencies(t *testing.T) {
 	}
 }
 
+func TestValidate_ArchivedDependencies(t *testing.T) {
+	tasks := []*model.Task{
+		{ID: "001", Title: "Task 1", Dependencies: []string{"042", "999"}},
+	}
+
+	result := NewValidator(false).WithArchived([]string{"042"}).Validate(tasks)
+
+	if result.Errors != 1 {
+		t.Fatalf("Expected only the unknown dependency to be reported, got %+v", result.Issues)
+	}
+	if !strings.Contains(result.Issues[0].Message, "'999'") {
+		t.Errorf("Expected the error to name 999, got %q", result.Issues[0].Message)
+	}
+}
+
 func TestValidate_CircularDependencies(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -412,6 +440,95 @@ func TestValidate_StrictMode(t *testing.T) {
 	}
 }
 
+func TestValidate_Dates(t *testing.T) {
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+	tasks := []*model.Task{
+		{ID: "001", Title: "Start after due", Start: day("2026-03-05"), Due: day("2026-03-01")},
+		{ID: "002", Title: "Scheduled after due", Scheduled: day("2026-03-05"), Due: day("2026-03-01")},
+		{ID: "003", Title: "Fine", Start: day("2026-02-20"), Scheduled: day("2026-02-25"), Due: day("2026-03-01")},
+	}
+
+	result := NewValidator(false).Validate(tasks)
+
+	if result.Errors != 1 || result.Warnings != 1 {
+		t.Fatalf("expected 1 error and 1 warning, got %+v", result.Issues)
+	}
+	for _, issue := range result.Issues {
+		switch issue.TaskID {
+		case "001":
+			if issue.Level != LevelError || !strings.Contains(issue.Message, "start date 2026-03-05 is after due date 2026-03-01") {
+				t.Errorf("unexpected issue: %+v", issue)
+			}
+		case "002":
+			if issue.Level != LevelWarning || !strings.Contains(issue.Message, "scheduled date") {
+				t.Errorf("unexpected issue: %+v", issue)
+			}
+		default:
+			t.Errorf("unexpected issue: %+v", issue)
+		}
+	}
+}
+
+func TestValidate_Timestamps(t *testing.T) {
+	at := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	tasks := []*model.Task{
+		{ID: "001", Title: "Reopened", Status: model.StatusInProgress, Started: at, Completed: at.Add(time.Hour)},
+		{ID: "002", Title: "Backwards", Status: model.StatusCompleted, Started: at, Completed: at.Add(-time.Hour)},
+		{ID: "003", Title: "Fine", Status: model.StatusCompleted, Started: at, Completed: at.Add(time.Hour)},
+		{ID: "004", Title: "Completed without timestamp", Status: model.StatusCompleted},
+	}
+
+	result := NewValidator(false).Validate(tasks)
+
+	if result.Errors != 1 || result.Warnings != 1 {
+		t.Fatalf("expected 1 error and 1 warning, got %+v", result.Issues)
+	}
+	for _, issue := range result.Issues {
+		switch issue.TaskID {
+		case "001":
+			if issue.Level != LevelError || !strings.Contains(issue.Message, "completed timestamp is set but status is 'in-progress'") {
+				t.Errorf("unexpected issue: %+v", issue)
+			}
+		case "002":
+			if issue.Level != LevelWarning || !strings.Contains(issue.Message, "is before started timestamp") {
+				t.Errorf("unexpected issue: %+v", issue)
+			}
+		default:
+			t.Errorf("unexpected issue: %+v", issue)
+		}
+	}
+}
+
+func TestValidate_StrictOverdue(t *testing.T) {
+	due := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	tasks := []*model.Task{
+		{ID: "001", Title: "Open", Status: model.StatusPending, Due: due},
+		{ID: "002", Title: "Done", Status: model.StatusCompleted, Due: due},
+		{ID: "003", Title: "Due today", Status: model.StatusPending, Due: due.AddDate(0, 0, 1)},
+	}
+
+	v := NewValidator(true)
+	v.now = func() time.Time { return time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC) }
+	result := v.Validate(tasks)
+
+	var overdue []string
+	for _, issue := range result.Issues {
+		if strings.Contains(issue.Message, "overdue") {
+			overdue = append(overdue, issue.TaskID+": "+issue.Message)
+		}
+	}
+	if len(overdue) != 1 || overdue[0] != "001: task is overdue (due 2026-03-01)" {
+		t.Errorf("expected only 001 to be overdue, got %v", overdue)
+	}
+
+	if NewValidator(false).Validate(tasks).Warnings != 0 {
+		t.Error("expected no overdue warnings outside strict mode")
+	}
+}
+
 func TestValidationResult_IsValid(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -711,6 +828,135 @@ func TestValidateConfig_UnknownKeys(t *testing.T) {
 	}
 }
 
+func TestValidateConfig_Views(t *testing.T) {
+	v := NewValidator(false)
+	config := &ConfigData{
+		TopKeys:    []string{"views"},
+		ConfigPath: ".taskmd.yaml",
+		Views: map[string]any{
+			"backlog": map[string]any{
+				"filter":  "status!=completed",
+				"sort":    "priority",
+				"columns": []any{"id", "title"},
+			},
+			"sprint": map[string]any{
+				"filter":   []any{"tag=sprint", "priority>=urgent"},
+				"group-by": "status",
+				"sort":     "deadline",
+			},
+		},
+	}
+
+	result := v.ValidateConfig(config)
+
+	var messages []string
+	for _, issue := range result.Issues {
+		messages = append(messages, string(issue.Level)+": "+issue.Message)
+	}
+	joined := strings.Join(messages, "\n")
+
+	if result.Warnings != 1 || !strings.Contains(joined, "view 'sprint' has unknown field: 'group-by'") {
+		t.Errorf("expected a warning for the unknown view field, got:\n%s", joined)
+	}
+	if result.Errors != 2 {
+		t.Errorf("expected 2 errors (invalid filter and sort), got:\n%s", joined)
+	}
+	if strings.Contains(joined, "backlog") {
+		t.Errorf("expected no issues for a valid view, got:\n%s", joined)
+	}
+}
+
+func TestValidateConfig_Next(t *testing.T) {
+	v := NewValidator(false)
+	config := &ConfigData{
+		TopKeys:    []string{"next"},
+		ConfigPath: ".taskmd.yaml",
+		Next: map[string]any{
+			"weights": map[string]any{
+				"per_downstream": 10,
+				"critical_path":  "high",
+				"urgency":        5,
+			},
+			"boosts": map[string]any{
+				"tags": map[string]any{"security": 20},
+			},
+		},
+	}
+
+	result := v.ValidateConfig(config)
+
+	var messages []string
+	for _, issue := range result.Issues {
+		messages = append(messages, issue.Message)
+	}
+	joined := strings.Join(messages, "\n")
+
+	if result.Warnings != 2 || result.Errors != 0 {
+		t.Errorf("expected 2 warnings and no errors, got:\n%s", joined)
+	}
+	if !strings.Contains(joined, "next.weights.critical_path must be an integer") ||
+		!strings.Contains(joined, "unknown weight: 'urgency'") {
+		t.Errorf("expected warnings for the bad weight value and unknown weight, got:\n%s", joined)
+	}
+}
+
+func TestValidateConfig_Effort(t *testing.T) {
+	v := NewValidator(false)
+	config := &ConfigData{
+		TopKeys:    []string{"effort"},
+		ConfigPath: ".taskmd.yaml",
+		Effort: map[string]any{
+			"small":  1,
+			"medium": -4,
+			"huge":   40,
+		},
+	}
+
+	result := v.ValidateConfig(config)
+
+	var messages []string
+	for _, issue := range result.Issues {
+		messages = append(messages, issue.Message)
+	}
+	joined := strings.Join(messages, "\n")
+
+	if result.Warnings != 2 || result.Errors != 0 {
+		t.Errorf("expected 2 warnings and no errors, got:\n%s", joined)
+	}
+	if !strings.Contains(joined, "effort.medium must be a non-negative number of hours") ||
+		!strings.Contains(joined, "unknown level: 'huge'") {
+		t.Errorf("expected warnings for the negative hours and unknown level, got:\n%s", joined)
+	}
+}
+
+func TestValidateConfig_Timestamps(t *testing.T) {
+	v := NewValidator(false)
+	config := &ConfigData{
+		TopKeys:    []string{"timestamps"},
+		ConfigPath: ".taskmd.yaml",
+		Timestamps: map[string]any{
+			"started":  "yes",
+			"finished": true,
+		},
+	}
+
+	result := v.ValidateConfig(config)
+
+	var messages []string
+	for _, issue := range result.Issues {
+		messages = append(messages, issue.Message)
+	}
+	joined := strings.Join(messages, "\n")
+
+	if result.Warnings != 2 || result.Errors != 0 {
+		t.Errorf("expected 2 warnings and no errors, got:\n%s", joined)
+	}
+	if !strings.Contains(joined, "timestamps.started must be true or false") ||
+		!strings.Contains(joined, "unknown field: 'finished'") {
+		t.Errorf("expected warnings for the non-boolean value and unknown field, got:\n%s", joined)
+	}
+}
+
 func TestValidateConfig_NilConfig(t *testing.T) {
 	v := NewValidator(false)
 	result := v.ValidateConfig(nil)
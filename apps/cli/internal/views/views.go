@@ -0,0 +1,168 @@
+// Package views parses saved views (named queries) from the views section of
+// .taskmd.yaml. A view bundles filter expressions with presentation defaults
+// such as sort order, table columns and board grouping.
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const configFileName = ".taskmd.yaml"
+
+// View is a saved query.
+type View struct {
+	Name        string   `json:"name" yaml:"-"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Filters     []string `json:"filters,omitempty" yaml:"filter,omitempty"`
+	Sort        string   `json:"sort,omitempty" yaml:"sort,omitempty"`
+	Columns     []string `json:"columns,omitempty" yaml:"columns,omitempty"`
+	GroupBy     string   `json:"group_by,omitempty" yaml:"group_by,omitempty"`
+}
+
+// Views maps view names to their definitions.
+type Views map[string]View
+
+// KnownFields lists the keys accepted inside a view definition.
+var KnownFields = []string{"description", "filter", "sort", "columns", "group_by"}
+
+// Parse converts the raw views section (as decoded from YAML) into Views.
+// Problems such as unknown fields or values of the wrong type are returned
+// as messages rather than errors so callers can decide how strict to be.
+func Parse(raw any) (Views, []string) {
+	if raw == nil {
+		return Views{}, nil
+	}
+	entries, ok := raw.(map[string]any)
+	if !ok {
+		return Views{}, []string{"views must be a mapping of view names to definitions"}
+	}
+
+	views := make(Views, len(entries))
+	var problems []string
+	for _, name := range sortedKeys(entries) {
+		view, viewProblems := parseView(name, entries[name])
+		views[name] = view
+		problems = append(problems, viewProblems...)
+	}
+	return views, problems
+}
+
+func parseView(name string, raw any) (View, []string) {
+	view := View{Name: name}
+	fields, ok := raw.(map[string]any)
+	if !ok {
+		return view, []string{fmt.Sprintf("view '%s' must be a mapping", name)}
+	}
+
+	var problems []string
+	for _, key := range sortedKeys(fields) {
+		val := fields[key]
+		var err error
+		switch key {
+		case "description":
+			view.Description, err = asString(val)
+		case "filter":
+			view.Filters, err = asList(val)
+		case "sort":
+			view.Sort, err = asString(val)
+		case "columns":
+			view.Columns, err = asList(val)
+		case "group_by":
+			view.GroupBy, err = asString(val)
+		default:
+			problems = append(problems, fmt.Sprintf("view '%s' has unknown field: '%s' (valid: %s)", name, key, strings.Join(KnownFields, ", ")))
+			continue
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("view '%s' field '%s' %v", name, key, err))
+		}
+	}
+	return view, problems
+}
+
+func asString(val any) (string, error) {
+	s, ok := val.(string)
+	if !ok {
+		return "", fmt.Errorf("must be a string")
+	}
+	return s, nil
+}
+
+// asList accepts a list of strings or a single string.
+func asList(val any) ([]string, error) {
+	switch v := val.(type) {
+	case string:
+		return []string{v}, nil
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("must be a string or list of strings")
+			}
+			out = append(out, s)
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("must be a string or list of strings")
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Get returns the named view, or an error listing the available views.
+func (v Views) Get(name string) (View, error) {
+	if view, ok := v[name]; ok {
+		return view, nil
+	}
+	// Viper lower-cases map keys, so accept names case-insensitively.
+	if view, ok := v[strings.ToLower(name)]; ok {
+		return view, nil
+	}
+	if len(v) == 0 {
+		return View{}, fmt.Errorf("unknown view %q: no views defined in %s", name, configFileName)
+	}
+	return View{}, fmt.Errorf("unknown view %q (available: %s)", name, strings.Join(v.Names(), ", "))
+}
+
+// Names returns the sorted view names.
+func (v Views) Names() []string {
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// List returns all views sorted by name.
+func (v Views) List() []View {
+	list := make([]View, 0, len(v))
+	for _, name := range v.Names() {
+		list = append(list, v[name])
+	}
+	return list
+}
+
+// ColumnsString joins the view's columns into the comma-separated form used
+// by --columns, splitting any comma-separated entries.
+func (v View) ColumnsString() string {
+	var cols []string
+	for _, c := range v.Columns {
+		for _, part := range strings.Split(c, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				cols = append(cols, part)
+			}
+		}
+	}
+	return strings.Join(cols, ",")
+}
@@ -0,0 +1,104 @@
+package views
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	raw := map[string]any{
+		"mine": map[string]any{
+			"description": "My open work",
+			"filter":      "owner=alice",
+			"sort":        "priority",
+			"columns":     []any{"id", "title"},
+		},
+		"triage": map[string]any{
+			"filter":   []any{"status=pending", "priority>=high"},
+			"group_by": "priority",
+			"colour":   "red",
+		},
+	}
+
+	got, problems := Parse(raw)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 views, got %d", len(got))
+	}
+
+	mine := got["mine"]
+	if mine.Name != "mine" || mine.Description != "My open work" || mine.Sort != "priority" {
+		t.Errorf("unexpected view: %+v", mine)
+	}
+	if len(mine.Filters) != 1 || mine.Filters[0] != "owner=alice" {
+		t.Errorf("expected single string filter to become a list, got %v", mine.Filters)
+	}
+
+	triage := got["triage"]
+	if len(triage.Filters) != 2 || triage.GroupBy != "priority" {
+		t.Errorf("unexpected view: %+v", triage)
+	}
+
+	if len(problems) != 1 || !strings.Contains(problems[0], "unknown field: 'colour'") {
+		t.Errorf("expected unknown field problem, got %v", problems)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  any
+		want string
+	}{
+		{"not a mapping", []any{"a"}, "must be a mapping of view names"},
+		{"view not a mapping", map[string]any{"v": "status=pending"}, "view 'v' must be a mapping"},
+		{"wrong type", map[string]any{"v": map[string]any{"sort": 3}}, "field 'sort' must be a string"},
+		{"bad list", map[string]any{"v": map[string]any{"filter": []any{1}}}, "field 'filter' must be a string or list"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, problems := Parse(tt.raw)
+			if len(problems) != 1 || !strings.Contains(problems[0], tt.want) {
+				t.Errorf("expected problem containing %q, got %v", tt.want, problems)
+			}
+		})
+	}
+}
+
+func TestParse_Nil(t *testing.T) {
+	got, problems := Parse(nil)
+	if len(got) != 0 || len(problems) != 0 {
+		t.Errorf("expected empty result, got %v %v", got, problems)
+	}
+}
+
+func TestGet(t *testing.T) {
+	v := Views{
+		"mine":   {Name: "mine"},
+		"triage": {Name: "triage"},
+	}
+
+	if _, err := v.Get("mine"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := v.Get("Mine"); err != nil {
+		t.Errorf("expected case-insensitive lookup, got %v", err)
+	}
+
+	_, err := v.Get("missing")
+	if err == nil || !strings.Contains(err.Error(), "available: mine, triage") {
+		t.Errorf("expected error listing views, got %v", err)
+	}
+
+	_, err = Views{}.Get("missing")
+	if err == nil || !strings.Contains(err.Error(), "no views defined") {
+		t.Errorf("expected no views error, got %v", err)
+	}
+}
+
+func TestColumnsString(t *testing.T) {
+	v := View{Columns: []string{"id", "title, status", " "}}
+	if got := v.ColumnsString(); got != "id,title,status" {
+		t.Errorf("ColumnsString() = %q", got)
+	}
+}
@@ -9,12 +9,14 @@ import (
 
 // DataProvider caches scan results and invalidates on file changes.
 type DataProvider struct {
-	scanDir string
-	verbose bool
+	scanDir         string
+	verbose         bool
+	includeArchived bool // also serve tasks from archive/ directories
 
-	mu    sync.RWMutex
-	tasks []*model.Task
-	dirty bool
+	mu       sync.RWMutex
+	tasks    []*model.Task
+	archived []string
+	dirty    bool
 }
 
 // NewDataProvider creates a DataProvider for the given directory.
@@ -43,17 +45,25 @@ func (dp *DataProvider) GetTasks() ([]*model.Task, error) {
 		return dp.tasks, nil
 	}
 
-	s := scanner.NewScanner(dp.scanDir, dp.verbose, nil)
-	result, err := s.Scan()
+	result, err := scanner.NewScanner(dp.scanDir, dp.verbose, nil).IncludeArchived(dp.includeArchived).Scan()
 	if err != nil {
 		return nil, err
 	}
 
 	dp.tasks = result.Tasks
+	dp.archived = result.ArchivedIDs
 	dp.dirty = false
 	return dp.tasks, nil
 }
 
+// ArchivedIDs returns the IDs of the tasks in archive directories, as found
+// by the last GetTasks scan.
+func (dp *DataProvider) ArchivedIDs() []string {
+	dp.mu.RLock()
+	defer dp.mu.RUnlock()
+	return dp.archived
+}
+
 // Invalidate marks cached data as stale.
 func (dp *DataProvider) Invalidate() {
 	dp.mu.Lock()
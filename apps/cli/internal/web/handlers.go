@@ -2,13 +2,19 @@ package web
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/driangle/taskmd/apps/cli/internal/board"
+	"github.com/driangle/taskmd/apps/cli/internal/claim"
+	"github.com/driangle/taskmd/apps/cli/internal/filter"
+	"github.com/driangle/taskmd/apps/cli/internal/forecast"
 	"github.com/driangle/taskmd/apps/cli/internal/graph"
+	"github.com/driangle/taskmd/apps/cli/internal/history"
 	"github.com/driangle/taskmd/apps/cli/internal/metrics"
 	"github.com/driangle/taskmd/apps/cli/internal/model"
 	"github.com/driangle/taskmd/apps/cli/internal/next"
@@ -16,6 +22,7 @@ import (
 	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
 	"github.com/driangle/taskmd/apps/cli/internal/tracks"
 	"github.com/driangle/taskmd/apps/cli/internal/validator"
+	"github.com/driangle/taskmd/apps/cli/internal/views"
 	"github.com/driangle/taskmd/apps/cli/internal/worklog"
 )
 
@@ -74,17 +81,67 @@ func handleSearch(dp *DataProvider) http.HandlerFunc {
 	}
 }
 
-func handleTasks(dp *DataProvider) http.HandlerFunc {
+func handleTasks(dp *DataProvider, savedViews views.Views) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		tasks, err := dp.GetTasks()
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
+
+		filters := r.URL.Query()["filter"]
+		sortField := r.URL.Query().Get("sort")
+		if name := r.URL.Query().Get("view"); name != "" {
+			view, err := savedViews.Get(name)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			filters = append(append([]string{}, view.Filters...), filters...)
+			if sortField == "" {
+				sortField = view.Sort
+			}
+		}
+
+		tasks, err = filterAndSortTasks(tasks, filters, sortField, dp.ArchivedIDs())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
 		writeJSON(w, tasks)
 	}
 }
 
+// filterAndSortTasks applies filter expressions and an optional sort field.
+// The cached task slice is copied before sorting so it is never reordered.
+// Dependencies on archived tasks count as met.
+func filterAndSortTasks(tasks []*model.Task, filters []string, sortField string, archived []string) ([]*model.Task, error) {
+	if len(filters) > 0 {
+		filtered, err := filter.ApplyEnv(tasks, filters, filter.NewEnv(tasks).WithArchived(archived))
+		if err != nil {
+			return nil, err
+		}
+		tasks = filtered
+		if tasks == nil {
+			tasks = []*model.Task{}
+		}
+	}
+	if sortField != "" {
+		tasks = append([]*model.Task{}, tasks...)
+		if err := model.SortTasks(tasks, sortField); err != nil {
+			return nil, err
+		}
+	}
+	return tasks, nil
+}
+
+func handleViews(savedViews views.Views) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, savedViews.List())
+	}
+}
+
 func handleTaskByID(dp *DataProvider) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		taskID := r.PathValue("id")
@@ -155,7 +212,7 @@ func handleBoard(dp *DataProvider) http.HandlerFunc {
 	}
 }
 
-func handleGraph(dp *DataProvider) http.HandlerFunc {
+func handleGraph(dp *DataProvider, effort graph.EffortWeights) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		tasks, err := dp.GetTasks()
 		if err != nil {
@@ -163,7 +220,8 @@ func handleGraph(dp *DataProvider) http.HandlerFunc {
 			return
 		}
 
-		g := graph.NewGraph(tasks)
+		g := graph.NewGraph(tasks).WithArchived(dp.ArchivedIDs())
+		g.Effort = effort
 		writeJSON(w, g.ToJSON())
 	}
 }
@@ -176,13 +234,13 @@ func handleGraphMermaid(dp *DataProvider) http.HandlerFunc {
 			return
 		}
 
-		g := graph.NewGraph(tasks)
+		g := graph.NewGraph(tasks).WithArchived(dp.ArchivedIDs())
 		w.Header().Set("Content-Type", "text/plain")
 		w.Write([]byte(g.ToMermaid(""))) //nolint:errcheck
 	}
 }
 
-func handleStats(dp *DataProvider) http.HandlerFunc {
+func handleStats(dp *DataProvider, effort graph.EffortWeights) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		tasks, err := dp.GetTasks()
 		if err != nil {
@@ -190,12 +248,52 @@ func handleStats(dp *DataProvider) http.HandlerFunc {
 			return
 		}
 
-		m := metrics.Calculate(tasks)
+		m := metrics.Calculate(tasks, effort)
+		dates, _ := history.TaskDates(tasks, dp.scanDir) // outside git, frontmatter only
+		m.Flow = metrics.CalculateFlow(tasks, dates, time.Now())
 		writeJSON(w, m)
 	}
 }
 
-func handleNext(dp *DataProvider) http.HandlerFunc {
+func handleForecast(dp *DataProvider, effort graph.EffortWeights) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tasks, err := dp.GetTasks()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		q := r.URL.Query()
+		scope, err := forecast.Scope(tasks, q.Get("parent"), q["filter"])
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		completions, err := forecast.CompletionDates(tasks, dp.scanDir, q.Get("source"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		opts := forecast.Options{Effort: effort}
+		if n, err := strconv.Atoi(q.Get("weeks")); err == nil && n > 0 {
+			opts.Weeks = n
+		}
+		res, err := forecast.Run(scope, completions, opts)
+		if errors.Is(err, forecast.ErrNoHistory) {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, res)
+	}
+}
+
+func handleNext(dp *DataProvider, scoring next.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		tasks, err := dp.GetTasks()
 		if err != nil {
@@ -213,8 +311,12 @@ func handleNext(dp *DataProvider) http.HandlerFunc {
 		filters := r.URL.Query()["filter"]
 
 		recs, err := next.Recommend(tasks, next.Options{
-			Limit:   limit,
-			Filters: filters,
+			Limit:    limit,
+			Filters:  filters,
+			For:      r.URL.Query().Get("for"),
+			Scoring:  &scoring,
+			Explain:  r.URL.Query().Get("explain") == "true",
+			Archived: dp.ArchivedIDs(),
 		})
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
@@ -225,7 +327,7 @@ func handleNext(dp *DataProvider) http.HandlerFunc {
 	}
 }
 
-func handleTracks(dp *DataProvider) http.HandlerFunc {
+func handleTracks(dp *DataProvider, scoring next.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		tasks, err := dp.GetTasks()
 		if err != nil {
@@ -236,7 +338,9 @@ func handleTracks(dp *DataProvider) http.HandlerFunc {
 		filters := r.URL.Query()["filter"]
 
 		result, err := tracks.Assign(tasks, tracks.Options{
-			Filters: filters,
+			Filters:  filters,
+			Scoring:  &scoring,
+			Archived: dp.ArchivedIDs(),
 		})
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
@@ -261,7 +365,7 @@ func handleValidate(dp *DataProvider) http.HandlerFunc {
 			return
 		}
 
-		v := validator.NewValidator(false)
+		v := validator.NewValidator(false).WithArchived(dp.ArchivedIDs())
 		result := v.Validate(tasks)
 		writeJSON(w, result)
 	}
@@ -277,6 +381,11 @@ type TaskUpdateRequest struct {
 	Parent   *string   `json:"parent"`
 	Tags     *[]string `json:"tags"`
 	Body     *string   `json:"body"`
+
+	Dependencies *[]string           `json:"dependencies"`
+	Touches      *[]string           `json:"touches"`
+	Context      *[]string           `json:"context"`
+	Verify       *[]model.VerifyStep `json:"verify"`
 }
 
 // ErrorResponse is a structured JSON error response.
@@ -300,7 +409,7 @@ func findTaskByID(tasks []*model.Task, id string) *model.Task {
 	return nil
 }
 
-func handleUpdateTask(dp *DataProvider, readonly bool) http.HandlerFunc {
+func handleUpdateTask(dp *DataProvider, readonly bool, stamps *taskfile.TimestampConfig) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if readonly {
 			writeError(w, http.StatusForbidden, "server is in read-only mode", nil)
@@ -338,6 +447,7 @@ func handleUpdateTask(dp *DataProvider, readonly bool) http.HandlerFunc {
 			return
 		}
 
+		req.Timestamps = stamps
 		if err := taskfile.UpdateTaskFile(found.FilePath, req); err != nil {
 			handleFileUpdateError(w, err)
 			return
@@ -355,6 +465,92 @@ func handleUpdateTask(dp *DataProvider, readonly bool) http.HandlerFunc {
 	}
 }
 
+// ClaimRequest is the JSON request body for POST /api/tasks/{id}/claim.
+type ClaimRequest struct {
+	Owner  string `json:"owner"`
+	Lease  string `json:"lease,omitempty"`  // Go duration, e.g. "30m"; defaults to claim.DefaultLease
+	Action string `json:"action,omitempty"` // claim (default), renew or release
+}
+
+func handleClaimTask(dp *DataProvider, readonly bool, stamps *taskfile.TimestampConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if readonly {
+			writeError(w, http.StatusForbidden, "server is in read-only mode", nil)
+			return
+		}
+
+		taskID := r.PathValue("id")
+		var body ClaimRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON body", []string{err.Error()})
+			return
+		}
+		if body.Owner == "" {
+			writeError(w, http.StatusBadRequest, "owner is required", nil)
+			return
+		}
+		lease := claim.DefaultLease
+		if body.Lease != "" {
+			d, err := time.ParseDuration(body.Lease)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "invalid lease", []string{err.Error()})
+				return
+			}
+			lease = d
+		}
+
+		claimer := &claim.Claimer{
+			StateDir: claim.StateDir(dp.scanDir),
+			Load: func() ([]*model.Task, []string, error) {
+				dp.Invalidate()
+				tasks, err := dp.GetTasks()
+				return tasks, dp.ArchivedIDs(), err
+			},
+			Timestamps: stamps,
+		}
+
+		result, err := runClaimAction(claimer, body.Action, taskID, body.Owner, lease)
+		if err != nil {
+			writeClaimError(w, err)
+			return
+		}
+
+		dp.Invalidate()
+		writeJSON(w, result)
+	}
+}
+
+func runClaimAction(claimer *claim.Claimer, action, taskID, owner string, lease time.Duration) (any, error) {
+	switch action {
+	case "", "claim":
+		return claimer.Claim(taskID, owner, lease)
+	case "renew":
+		return claimer.Renew(taskID, owner, lease)
+	case "release":
+		if err := claimer.Release(taskID, owner); err != nil {
+			return nil, err
+		}
+		return map[string]string{"task_id": taskID, "released": owner}, nil
+	default:
+		return nil, errInvalidClaimAction
+	}
+}
+
+var errInvalidClaimAction = errors.New("invalid action (valid: claim, renew, release)")
+
+func writeClaimError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, claim.ErrClaimed), errors.Is(err, claim.ErrNotClaimable):
+		writeError(w, http.StatusConflict, err.Error(), nil)
+	case errors.Is(err, errInvalidClaimAction):
+		writeError(w, http.StatusBadRequest, err.Error(), nil)
+	case errors.Is(err, claim.ErrNotFound):
+		writeError(w, http.StatusNotFound, err.Error(), nil)
+	default:
+		writeError(w, http.StatusInternalServerError, "claim failed", []string{err.Error()})
+	}
+}
+
 func toUpdateRequest(body TaskUpdateRequest) taskfile.UpdateRequest {
 	return taskfile.UpdateRequest{
 		Title:    body.Title,
@@ -365,6 +561,11 @@ func toUpdateRequest(body TaskUpdateRequest) taskfile.UpdateRequest {
 		Parent:   body.Parent,
 		Tags:     body.Tags,
 		Body:     body.Body,
+
+		Dependencies: body.Dependencies,
+		Touches:      body.Touches,
+		Context:      body.Context,
+		Verify:       body.Verify,
 	}
 }
 
@@ -5,17 +5,22 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/driangle/taskmd/apps/cli/internal/board"
+	"github.com/driangle/taskmd/apps/cli/internal/forecast"
+	"github.com/driangle/taskmd/apps/cli/internal/graph"
 	"github.com/driangle/taskmd/apps/cli/internal/metrics"
 	"github.com/driangle/taskmd/apps/cli/internal/next"
 	"github.com/driangle/taskmd/apps/cli/internal/search"
 	"github.com/driangle/taskmd/apps/cli/internal/tracks"
 	"github.com/driangle/taskmd/apps/cli/internal/validator"
+	"github.com/driangle/taskmd/apps/cli/internal/views"
 )
 
 func createTestTaskDir(t *testing.T) string {
@@ -58,7 +63,7 @@ func TestHandleTasks(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/api/tasks", nil)
 	rec := httptest.NewRecorder()
 
-	handleTasks(dp)(rec, req)
+	handleTasks(dp, nil)(rec, req)
 
 	if rec.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d", rec.Code)
@@ -79,6 +84,112 @@ func TestHandleTasks(t *testing.T) {
 	}
 }
 
+func TestHandleTasks_Filter(t *testing.T) {
+	dir := createTestTaskDir(t)
+	dp := NewDataProvider(dir, false)
+
+	tests := []struct {
+		filter  string
+		wantIDs []string
+	}{
+		{"priority>=high", []string{"001"}},
+		{"tag=core or tag=setup", []string{"001", "002"}},
+		{"blocked", []string{"002"}},
+		{"status=completed", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/tasks?filter="+url.QueryEscape(tt.filter), nil)
+			rec := httptest.NewRecorder()
+
+			handleTasks(dp, nil)(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+			}
+			var tasks []map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &tasks); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			if tasks == nil {
+				t.Fatal("expected a JSON array, got null")
+			}
+			if len(tasks) != len(tt.wantIDs) {
+				t.Fatalf("expected %d tasks, got %d", len(tt.wantIDs), len(tasks))
+			}
+			for i, id := range tt.wantIDs {
+				if tasks[i]["id"] != id {
+					t.Errorf("task %d: expected %s, got %v", i, id, tasks[i]["id"])
+				}
+			}
+		})
+	}
+}
+
+func TestHandleTasks_InvalidFilter(t *testing.T) {
+	dir := createTestTaskDir(t)
+	dp := NewDataProvider(dir, false)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/tasks?filter="+url.QueryEscape("priority>=urgent"), nil)
+	rec := httptest.NewRecorder()
+
+	handleTasks(dp, nil)(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestHandleTasks_View(t *testing.T) {
+	dir := createTestTaskDir(t)
+	dp := NewDataProvider(dir, false)
+	savedViews := views.Views{
+		"active": {Name: "active", Filters: []string{"status!=completed"}, Sort: "priority"},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/tasks?view=active&filter=tag%3Dcore", nil)
+	rec := httptest.NewRecorder()
+	handleTasks(dp, savedViews)(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var tasks []map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &tasks); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0]["id"] != "002" {
+		t.Fatalf("expected only task 002, got %v", tasks)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/tasks?view=missing", nil)
+	rec = httptest.NewRecorder()
+	handleTasks(dp, savedViews)(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown view, got %d", rec.Code)
+	}
+}
+
+func TestHandleViews(t *testing.T) {
+	savedViews := views.Views{
+		"b": {Name: "b", Sort: "id"},
+		"a": {Name: "a", Filters: []string{"tag=cli"}},
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/views", nil)
+	rec := httptest.NewRecorder()
+	handleViews(savedViews)(rec, req)
+
+	var list []views.View
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(list) != 2 || list[0].Name != "a" || list[1].Name != "b" {
+		t.Fatalf("expected views sorted by name, got %+v", list)
+	}
+}
+
 func TestHandleTaskByID_Success(t *testing.T) {
 	dir := createTestTaskDir(t)
 	dp := NewDataProvider(dir, false)
@@ -194,7 +305,7 @@ func TestHandleGraph(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/api/graph", nil)
 	rec := httptest.NewRecorder()
 
-	handleGraph(dp)(rec, req)
+	handleGraph(dp, graph.DefaultEffortWeights())(rec, req)
 
 	if rec.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d", rec.Code)
@@ -244,7 +355,7 @@ func TestHandleStats(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/api/stats", nil)
 	rec := httptest.NewRecorder()
 
-	handleStats(dp)(rec, req)
+	handleStats(dp, graph.DefaultEffortWeights())(rec, req)
 
 	if rec.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d", rec.Code)
@@ -302,7 +413,7 @@ func TestHandleUpdateTask_Success(t *testing.T) {
 	req.SetPathValue("id", "001")
 	rec := httptest.NewRecorder()
 
-	handleUpdateTask(dp, false)(rec, req)
+	handleUpdateTask(dp, false, nil)(rec, req)
 
 	if rec.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
@@ -323,6 +434,68 @@ func TestHandleUpdateTask_Success(t *testing.T) {
 	}
 }
 
+// POST /api/tasks/{id}/claim tests
+
+func postClaim(dp *DataProvider, readonly bool, id, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/tasks/"+id+"/claim", strings.NewReader(body))
+	req.SetPathValue("id", id)
+	rec := httptest.NewRecorder()
+	handleClaimTask(dp, readonly, nil)(rec, req)
+	return rec
+}
+
+func TestHandleClaimTask(t *testing.T) {
+	dir := createTestTaskDir(t)
+	dp := NewDataProvider(dir, false)
+
+	rec := postClaim(dp, false, "001", `{"owner":"agent-1","lease":"1h"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	content, _ := os.ReadFile(filepath.Join(dir, "001-task-one.md"))
+	if !strings.Contains(string(content), "status: in-progress") || !strings.Contains(string(content), "owner: agent-1") {
+		t.Errorf("expected task to be claimed, got:\n%s", content)
+	}
+
+	rec = postClaim(dp, false, "001", `{"owner":"agent-2"}`)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for a claim someone else holds, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = postClaim(dp, false, "001", `{"owner":"agent-1","action":"release"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 on release, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandleClaimTask_Errors(t *testing.T) {
+	dir := createTestTaskDir(t)
+	dp := NewDataProvider(dir, false)
+
+	tests := []struct {
+		name     string
+		readonly bool
+		id       string
+		body     string
+		want     int
+	}{
+		{"read-only", true, "001", `{"owner":"a"}`, http.StatusForbidden},
+		{"missing owner", false, "001", `{}`, http.StatusBadRequest},
+		{"bad lease", false, "001", `{"owner":"a","lease":"soon"}`, http.StatusBadRequest},
+		{"not found", false, "999", `{"owner":"a"}`, http.StatusNotFound},
+		{"not actionable", false, "002", `{"owner":"a"}`, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := postClaim(dp, tt.readonly, tt.id, tt.body)
+			if rec.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
 func TestHandleUpdateTask_NotFound(t *testing.T) {
 	dir := createTestTaskDir(t)
 	dp := NewDataProvider(dir, false)
@@ -332,7 +505,7 @@ func TestHandleUpdateTask_NotFound(t *testing.T) {
 	req.SetPathValue("id", "999")
 	rec := httptest.NewRecorder()
 
-	handleUpdateTask(dp, false)(rec, req)
+	handleUpdateTask(dp, false, nil)(rec, req)
 
 	if rec.Code != http.StatusNotFound {
 		t.Fatalf("expected 404, got %d", rec.Code)
@@ -348,7 +521,7 @@ func TestHandleUpdateTask_InvalidStatus(t *testing.T) {
 	req.SetPathValue("id", "001")
 	rec := httptest.NewRecorder()
 
-	handleUpdateTask(dp, false)(rec, req)
+	handleUpdateTask(dp, false, nil)(rec, req)
 
 	if rec.Code != http.StatusBadRequest {
 		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
@@ -372,7 +545,7 @@ func TestHandleUpdateTask_InvalidJSON(t *testing.T) {
 	req.SetPathValue("id", "001")
 	rec := httptest.NewRecorder()
 
-	handleUpdateTask(dp, false)(rec, req)
+	handleUpdateTask(dp, false, nil)(rec, req)
 
 	if rec.Code != http.StatusBadRequest {
 		t.Fatalf("expected 400, got %d", rec.Code)
@@ -388,7 +561,7 @@ func TestHandleUpdateTask_Title(t *testing.T) {
 	req.SetPathValue("id", "001")
 	rec := httptest.NewRecorder()
 
-	handleUpdateTask(dp, false)(rec, req)
+	handleUpdateTask(dp, false, nil)(rec, req)
 
 	if rec.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
@@ -409,7 +582,7 @@ func TestHandleUpdateTask_Body(t *testing.T) {
 	req.SetPathValue("id", "001")
 	rec := httptest.NewRecorder()
 
-	handleUpdateTask(dp, false)(rec, req)
+	handleUpdateTask(dp, false, nil)(rec, req)
 
 	if rec.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
@@ -434,7 +607,7 @@ func TestHandleUpdateTask_Tags(t *testing.T) {
 	req.SetPathValue("id", "001")
 	rec := httptest.NewRecorder()
 
-	handleUpdateTask(dp, false)(rec, req)
+	handleUpdateTask(dp, false, nil)(rec, req)
 
 	if rec.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
@@ -454,6 +627,34 @@ func TestHandleUpdateTask_Tags(t *testing.T) {
 	}
 }
 
+func TestHandleUpdateTask_Dependencies(t *testing.T) {
+	dir := createTestTaskDir(t)
+	dp := NewDataProvider(dir, false)
+
+	body := strings.NewReader(`{"dependencies":[],"touches":["web"]}`)
+	req := httptest.NewRequest(http.MethodPut, "/api/tasks/002", body)
+	req.SetPathValue("id", "002")
+	rec := httptest.NewRecorder()
+
+	handleUpdateTask(dp, false, nil)(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var task map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &task); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if deps, _ := task["dependencies"].([]any); len(deps) != 0 {
+		t.Errorf("expected dependencies to be cleared, got %v", task["dependencies"])
+	}
+	if touches, _ := task["touches"].([]any); len(touches) != 1 {
+		t.Errorf("expected 1 touches entry, got %v", task["touches"])
+	}
+}
+
 func TestHandleUpdateTask_PartialUpdate(t *testing.T) {
 	dir := createTestTaskDir(t)
 	dp := NewDataProvider(dir, false)
@@ -464,7 +665,7 @@ func TestHandleUpdateTask_PartialUpdate(t *testing.T) {
 	req.SetPathValue("id", "001")
 	rec := httptest.NewRecorder()
 
-	handleUpdateTask(dp, false)(rec, req)
+	handleUpdateTask(dp, false, nil)(rec, req)
 
 	if rec.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
@@ -539,7 +740,7 @@ func TestHandleUpdateTask_ReadOnly(t *testing.T) {
 	req.SetPathValue("id", "001")
 	rec := httptest.NewRecorder()
 
-	handleUpdateTask(dp, true)(rec, req)
+	handleUpdateTask(dp, true, nil)(rec, req)
 
 	if rec.Code != http.StatusForbidden {
 		t.Fatalf("expected 403, got %d: %s", rec.Code, rec.Body.String())
@@ -563,7 +764,7 @@ func TestHandleNext(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/api/next", nil)
 	rec := httptest.NewRecorder()
 
-	handleNext(dp)(rec, req)
+	handleNext(dp, next.DefaultConfig())(rec, req)
 
 	if rec.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d", rec.Code)
@@ -599,7 +800,7 @@ func TestHandleNext_WithLimit(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/api/next?limit=1", nil)
 	rec := httptest.NewRecorder()
 
-	handleNext(dp)(rec, req)
+	handleNext(dp, next.DefaultConfig())(rec, req)
 
 	if rec.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d", rec.Code)
@@ -633,7 +834,7 @@ priority: high
 	req := httptest.NewRequest(http.MethodGet, "/api/next", nil)
 	rec := httptest.NewRecorder()
 
-	handleNext(dp)(rec, req)
+	handleNext(dp, next.DefaultConfig())(rec, req)
 
 	if rec.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d", rec.Code)
@@ -669,7 +870,7 @@ priority: medium
 	req := httptest.NewRequest(http.MethodGet, "/api/next", nil)
 	rec := httptest.NewRecorder()
 
-	handleNext(dp)(rec, req)
+	handleNext(dp, next.DefaultConfig())(rec, req)
 
 	if rec.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d", rec.Code)
@@ -686,6 +887,43 @@ priority: medium
 	}
 }
 
+func TestHandleNext_ConfiguredScoringAndExplain(t *testing.T) {
+	dir := createTestTaskDir(t)
+	dp := NewDataProvider(dir, false)
+
+	scoring := next.DefaultConfig()
+	scoring.Weights.PriorityHigh = 70
+	scoring.TagBoosts = map[string]int{"setup": 5}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/next?explain=true", nil)
+	rec := httptest.NewRecorder()
+
+	handleNext(dp, scoring)(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	var recs []next.Recommendation
+	if err := json.Unmarshal(rec.Body.Bytes(), &recs); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(recs) != 1 {
+		t.Fatalf("expected 1 recommendation, got %d", len(recs))
+	}
+
+	want := []next.Factor{
+		{Name: "high priority", Points: 70},
+		{Name: "on critical path", Points: next.ScoreCriticalPath},
+		{Name: "unblocks 1 task", Points: next.ScorePerDownstream},
+		{Name: "small effort", Points: next.ScoreEffortSmall},
+		{Name: "tag setup", Points: 5},
+	}
+	if fmt.Sprint(recs[0].Breakdown) != fmt.Sprint(want) {
+		t.Errorf("breakdown = %v, want %v", recs[0].Breakdown, want)
+	}
+}
+
 // GET /api/tracks tests
 
 func createTracksTestDir(t *testing.T) string {
@@ -733,7 +971,7 @@ func fetchTracksResult(t *testing.T, dp *DataProvider, query string) tracks.Resu
 	req := httptest.NewRequest(http.MethodGet, "/api/tracks"+query, nil)
 	rec := httptest.NewRecorder()
 
-	handleTracks(dp)(rec, req)
+	handleTracks(dp, next.DefaultConfig())(rec, req)
 
 	if rec.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
@@ -798,7 +1036,7 @@ func TestHandleTracks_WithFilters(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/api/tracks?filter=priority%3Dhigh", nil)
 	rec := httptest.NewRecorder()
 
-	handleTracks(dp)(rec, req)
+	handleTracks(dp, next.DefaultConfig())(rec, req)
 
 	if rec.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
@@ -932,3 +1170,46 @@ func TestHandleSearch_CaseInsensitive(t *testing.T) {
 		t.Fatalf("expected 2 results for case-insensitive search, got %d", len(results))
 	}
 }
+
+func TestHandleForecast(t *testing.T) {
+	dir := createTestTaskDir(t)
+	done := "---\nid: \"003\"\ntitle: \"Task Three\"\nstatus: completed\n---\n"
+	os.WriteFile(filepath.Join(dir, "003-task-three.md"), []byte(done), 0644)
+	os.MkdirAll(filepath.Join(dir, ".worklogs"), 0755)
+	entry := "## " + time.Now().UTC().AddDate(0, 0, -2).Format(time.RFC3339) + "\n\nDone.\n"
+	os.WriteFile(filepath.Join(dir, ".worklogs", "003.md"), []byte(entry), 0644)
+	dp := NewDataProvider(dir, false)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/forecast?source=worklog&weeks=4", nil)
+	rec := httptest.NewRecorder()
+
+	handleForecast(dp, graph.DefaultEffortWeights())(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var result forecast.Result
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if result.Remaining != 2 || result.Completed != 1 || len(result.Burndown) != 4 {
+		t.Errorf("unexpected forecast: %+v", result)
+	}
+	if len(result.Distribution) == 0 || result.P50.Date == "" {
+		t.Errorf("expected a distribution and P50 date, got %+v", result)
+	}
+}
+
+func TestHandleForecast_NoHistory(t *testing.T) {
+	dp := NewDataProvider(createTestTaskDir(t), false)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/forecast?source=worklog", nil)
+	rec := httptest.NewRecorder()
+
+	handleForecast(dp, graph.DefaultEffortWeights())(rec, req)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", rec.Code)
+	}
+}
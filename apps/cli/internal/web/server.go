@@ -8,6 +8,9 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/driangle/taskmd/apps/cli/internal/next"
+	"github.com/driangle/taskmd/apps/cli/internal/taskfile"
+	"github.com/driangle/taskmd/apps/cli/internal/views"
 	"github.com/driangle/taskmd/apps/cli/internal/watcher"
 )
 
@@ -19,6 +22,11 @@ type Config struct {
 	Verbose  bool
 	ReadOnly bool
 	Version  string
+	Views    views.Views // saved views from .taskmd.yaml
+	Next     next.Config // scoring weights and boosts from .taskmd.yaml
+
+	Timestamps      taskfile.TimestampConfig // status timestamps to record, from .taskmd.yaml
+	IncludeArchived bool                     // serve tasks from archive/ directories too
 }
 
 // Server is the taskmd web server.
@@ -32,6 +40,7 @@ type Server struct {
 // NewServer creates a new web server.
 func NewServer(cfg Config) *Server {
 	dp := NewDataProvider(cfg.ScanDir, cfg.Verbose)
+	dp.includeArchived = cfg.IncludeArchived
 	broker := NewSSEBroker()
 
 	w := watcher.New(cfg.ScanDir, func() {
@@ -54,16 +63,19 @@ func (s *Server) Start(ctx context.Context) error {
 	// API routes
 	mux.HandleFunc("GET /api/config", handleConfig(s.config))
 	mux.HandleFunc("GET /api/search", handleSearch(s.dp))
-	mux.HandleFunc("GET /api/tasks", handleTasks(s.dp))
+	mux.HandleFunc("GET /api/tasks", handleTasks(s.dp, s.config.Views))
+	mux.HandleFunc("GET /api/views", handleViews(s.config.Views))
 	mux.HandleFunc("GET /api/tasks/{id}", handleTaskByID(s.dp))
 	mux.HandleFunc("GET /api/tasks/{id}/worklog", handleWorklog(s.dp))
-	mux.HandleFunc("PUT /api/tasks/{id}", handleUpdateTask(s.dp, s.config.ReadOnly))
+	mux.HandleFunc("PUT /api/tasks/{id}", handleUpdateTask(s.dp, s.config.ReadOnly, &s.config.Timestamps))
+	mux.HandleFunc("POST /api/tasks/{id}/claim", handleClaimTask(s.dp, s.config.ReadOnly, &s.config.Timestamps))
 	mux.HandleFunc("GET /api/board", handleBoard(s.dp))
-	mux.HandleFunc("GET /api/graph", handleGraph(s.dp))
+	mux.HandleFunc("GET /api/graph", handleGraph(s.dp, s.config.Next.Effort))
 	mux.HandleFunc("GET /api/graph/mermaid", handleGraphMermaid(s.dp))
-	mux.HandleFunc("GET /api/stats", handleStats(s.dp))
-	mux.HandleFunc("GET /api/next", handleNext(s.dp))
-	mux.HandleFunc("GET /api/tracks", handleTracks(s.dp))
+	mux.HandleFunc("GET /api/stats", handleStats(s.dp, s.config.Next.Effort))
+	mux.HandleFunc("GET /api/forecast", handleForecast(s.dp, s.config.Next.Effort))
+	mux.HandleFunc("GET /api/next", handleNext(s.dp, s.config.Next))
+	mux.HandleFunc("GET /api/tracks", handleTracks(s.dp, s.config.Next))
 	mux.HandleFunc("GET /api/validate", handleValidate(s.dp))
 	mux.Handle("GET /api/events", s.broker)
 
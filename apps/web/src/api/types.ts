@@ -67,6 +67,7 @@ export interface Stats {
   tasks_by_effort: Record<string, number>;
   blocked_tasks_count: number;
   critical_path_length: number;
+  critical_path_hours: number;
   max_dependency_depth: number;
   avg_dependencies_per_task: number;
   tags_by_count: TagInfo[];
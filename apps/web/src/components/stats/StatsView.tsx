@@ -7,12 +7,13 @@ interface StatsViewProps {
 export function StatsView({ stats }: StatsViewProps) {
   return (
     <div className="space-y-6">
-      <div className="grid grid-cols-2 sm:grid-cols-4 gap-4">
+      <div className="grid grid-cols-2 sm:grid-cols-3 lg:grid-cols-5 gap-4">
         <MetricCard label="Total Tasks" value={stats.total_tasks} />
         <MetricCard label="Blocked" value={stats.blocked_tasks_count} />
+        <MetricCard label="Critical Path" value={stats.critical_path_length} />
         <MetricCard
-          label="Critical Path"
-          value={stats.critical_path_length}
+          label="Critical Path Effort"
+          value={`${stats.critical_path_hours}h`}
         />
         <MetricCard
           label="Avg Deps/Task"